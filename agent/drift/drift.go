@@ -0,0 +1,239 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package drift records fingerprints of the artifacts that an agent
+// writes to its machine, such as proxy settings and ssh authorized
+// keys, so that changes made to them outside of Juju can be detected.
+package drift
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// StatusDataKey is the key in the machine agent's status data under
+// which drifted artifacts are reported, as a map of artifact name to
+// path.
+const StatusDataKey = "drift"
+
+// Fingerprinter returns a fingerprint of the current content of an
+// artifact.
+type Fingerprinter func() (string, error)
+
+// Artifact describes an artifact written by Juju.
+type Artifact struct {
+	// Name is the name of the artifact, such as "proxy-settings".
+	Name string
+
+	// Path is the location of the artifact on the machine.
+	Path string
+}
+
+type recorded struct {
+	path        string
+	fingerprint Fingerprinter
+	expected    string
+}
+
+// baseline is the persisted form of the fingerprint expected
+// for an artifact.
+type baseline struct {
+	Path        string `json:"path"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Tracker records the fingerprints of artifacts as they are written
+// by Juju, and reports those that have since changed.
+type Tracker struct {
+	mu        sync.Mutex
+	artifacts map[string]recorded
+
+	// storePath holds the path of the file the expected fingerprints
+	// are persisted to, or is empty if they are not persisted.
+	storePath string
+
+	// baselines holds the expected fingerprints of all artifacts,
+	// including those persisted by a previous run of the agent that
+	// have not yet been tracked.
+	baselines map[string]baseline
+}
+
+// NewTracker returns a new Tracker with no artifacts recorded.
+func NewTracker() *Tracker {
+	return &Tracker{
+		artifacts: make(map[string]recorded),
+		baselines: make(map[string]baseline),
+	}
+}
+
+// SetStore makes the tracker persist the fingerprints it records to
+// the file at the given path, and loads the fingerprints previously
+// persisted there, so that Track can detect artifacts that changed
+// while the agent was not running.
+func (t *Tracker) SetStore(path string) error {
+	baselines := make(map[string]baseline)
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &baselines); err != nil {
+			return errors.Annotatef(err, "reading %s", path)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.storePath = path
+	for name, artifact := range t.artifacts {
+		baselines[name] = baseline{Path: artifact.path, Fingerprint: artifact.expected}
+	}
+	t.baselines = baselines
+	return nil
+}
+
+// Record records the current fingerprint of the named artifact,
+// which Juju has just written. The fingerprinter is used to compute
+// the fingerprint now, and again whenever the tracker is checked.
+func (t *Tracker) Record(name, path string, fingerprint Fingerprinter) error {
+	expected, err := fingerprint()
+	if err != nil {
+		return errors.Annotatef(err, "fingerprinting %s", name)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.artifacts[name] = recorded{
+		path:        path,
+		fingerprint: fingerprint,
+		expected:    expected,
+	}
+	t.baselines[name] = baseline{Path: path, Fingerprint: expected}
+	return errors.Annotatef(t.save(), "recording %s", name)
+}
+
+// RecordFile records the current fingerprint of the named artifact,
+// which is the entire content of the file at the given path.
+func (t *Tracker) RecordFile(name, path string) error {
+	return t.Record(name, path, FileFingerprinter(path))
+}
+
+// Track starts checking the named artifact, which Juju has not just
+// written, against the fingerprint persisted when Juju last wrote
+// it. If there is no such fingerprint, the artifact's current
+// fingerprint is recorded.
+func (t *Tracker) Track(name, path string, fingerprint Fingerprinter) error {
+	t.mu.Lock()
+	stored, ok := t.baselines[name]
+	if ok && stored.Path == path {
+		t.artifacts[name] = recorded{
+			path:        path,
+			fingerprint: fingerprint,
+			expected:    stored.Fingerprint,
+		}
+		t.mu.Unlock()
+		return nil
+	}
+	t.mu.Unlock()
+	return t.Record(name, path, fingerprint)
+}
+
+// TrackFile starts checking the named artifact, which is the entire
+// content of the file at the given path; see Track.
+func (t *Tracker) TrackFile(name, path string) error {
+	return t.Track(name, path, FileFingerprinter(path))
+}
+
+// save persists the expected fingerprints, if the tracker has a
+// store. It must be called with t.mu held.
+func (t *Tracker) save() error {
+	if t.storePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(t.baselines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(utils.AtomicWriteFile(t.storePath, data, 0600))
+}
+
+// Check returns the artifacts, sorted by name, whose fingerprints
+// no longer match those last recorded.
+func (t *Tracker) Check() ([]Artifact, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var drifted []Artifact
+	for name, artifact := range t.artifacts {
+		current, err := artifact.fingerprint()
+		if err != nil {
+			return nil, errors.Annotatef(err, "fingerprinting %s", name)
+		}
+		if current != artifact.expected {
+			drifted = append(drifted, Artifact{Name: name, Path: artifact.path})
+		}
+	}
+	sort.Sort(byName(drifted))
+	return drifted, nil
+}
+
+// FileFingerprinter returns a Fingerprinter that computes the SHA256
+// hash of the content of the file at the given path. A missing file
+// has an empty fingerprint.
+func FileFingerprinter(path string) Fingerprinter {
+	return func() (string, error) {
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return "", nil
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		return Fingerprint(data), nil
+	}
+}
+
+// GlobFingerprinter returns a Fingerprinter that computes the SHA256
+// hash of the names and contents of the files matching the given
+// patterns, so that files being added or removed are detected as
+// well as changes to their content.
+func GlobFingerprinter(patterns ...string) Fingerprinter {
+	return func() (string, error) {
+		hash := sha256.New()
+		for _, pattern := range patterns {
+			paths, err := filepath.Glob(pattern)
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				data, err := ioutil.ReadFile(path)
+				if os.IsNotExist(err) {
+					continue
+				} else if err != nil {
+					return "", errors.Trace(err)
+				}
+				hash.Write([]byte(path + "\x00"))
+				hash.Write(data)
+				hash.Write([]byte("\x00"))
+			}
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+}
+
+// Fingerprint returns the hex encoded SHA256 hash of the given data.
+func Fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type byName []Artifact
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
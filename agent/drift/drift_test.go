@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package drift_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent/drift"
+)
+
+type TrackerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TrackerSuite{})
+
+func (s *TrackerSuite) TestCheckNoArtifacts(c *gc.C) {
+	drifted, err := drift.NewTracker().Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+}
+
+func (s *TrackerSuite) TestRecordFile(c *gc.C) {
+	dir := c.MkDir()
+	proxyFile := filepath.Join(dir, "proxy")
+	aptFile := filepath.Join(dir, "apt")
+	missingFile := filepath.Join(dir, "missing")
+	c.Assert(ioutil.WriteFile(proxyFile, []byte("http_proxy=foo"), 0644), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(aptFile, []byte("Acquire::http::Proxy"), 0644), jc.ErrorIsNil)
+
+	tracker := drift.NewTracker()
+	c.Assert(tracker.RecordFile("proxy", proxyFile), jc.ErrorIsNil)
+	c.Assert(tracker.RecordFile("apt", aptFile), jc.ErrorIsNil)
+	c.Assert(tracker.RecordFile("missing", missingFile), jc.ErrorIsNil)
+
+	drifted, err := tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	c.Assert(ioutil.WriteFile(proxyFile, []byte("http_proxy=bar"), 0644), jc.ErrorIsNil)
+	c.Assert(os.Remove(aptFile), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(missingFile, []byte("surprise"), 0644), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, jc.DeepEquals, []drift.Artifact{
+		{Name: "apt", Path: aptFile},
+		{Name: "missing", Path: missingFile},
+		{Name: "proxy", Path: proxyFile},
+	})
+
+	// Recording the artifact again accepts its current content.
+	c.Assert(tracker.RecordFile("proxy", proxyFile), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 2)
+}
+
+func (s *TrackerSuite) TestRecordError(c *gc.C) {
+	tracker := drift.NewTracker()
+	err := tracker.Record("keys", "/nowhere", func() (string, error) {
+		return "", errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "fingerprinting keys: boom")
+}
+
+func (s *TrackerSuite) TestTrackFileUsesStoredBaseline(c *gc.C) {
+	dir := c.MkDir()
+	store := filepath.Join(dir, "baseline.json")
+	configFile := filepath.Join(dir, "agent.conf")
+	c.Assert(ioutil.WriteFile(configFile, []byte("tag: machine-0"), 0644), jc.ErrorIsNil)
+
+	tracker := drift.NewTracker()
+	c.Assert(tracker.SetStore(store), jc.ErrorIsNil)
+	c.Assert(tracker.RecordFile("agent-config", configFile), jc.ErrorIsNil)
+
+	// The file changes while the agent is not running.
+	c.Assert(ioutil.WriteFile(configFile, []byte("tag: machine-1"), 0644), jc.ErrorIsNil)
+
+	tracker = drift.NewTracker()
+	c.Assert(tracker.SetStore(store), jc.ErrorIsNil)
+	c.Assert(tracker.TrackFile("agent-config", configFile), jc.ErrorIsNil)
+	drifted, err := tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, jc.DeepEquals, []drift.Artifact{
+		{Name: "agent-config", Path: configFile},
+	})
+}
+
+func (s *TrackerSuite) TestTrackFileWithoutBaseline(c *gc.C) {
+	dir := c.MkDir()
+	store := filepath.Join(dir, "baseline.json")
+	configFile := filepath.Join(dir, "agent.conf")
+	c.Assert(ioutil.WriteFile(configFile, []byte("tag: machine-0"), 0644), jc.ErrorIsNil)
+
+	tracker := drift.NewTracker()
+	c.Assert(tracker.SetStore(store), jc.ErrorIsNil)
+	c.Assert(tracker.TrackFile("agent-config", configFile), jc.ErrorIsNil)
+	drifted, err := tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// The current content is persisted as the baseline.
+	_, err = os.Stat(store)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TrackerSuite) TestGlobFingerprinter(c *gc.C) {
+	dir := c.MkDir()
+	sourcesFile := filepath.Join(dir, "sources.list")
+	sourcesDir := filepath.Join(dir, "sources.list.d")
+	c.Assert(os.Mkdir(sourcesDir, 0755), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(sourcesFile, []byte("deb http://archive"), 0644), jc.ErrorIsNil)
+
+	tracker := drift.NewTracker()
+	fingerprint := drift.GlobFingerprinter(sourcesFile, filepath.Join(sourcesDir, "*.list"))
+	c.Assert(tracker.Record("apt-sources", sourcesFile, fingerprint), jc.ErrorIsNil)
+	drifted, err := tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// Adding a file matching a pattern is drift.
+	ppaFile := filepath.Join(sourcesDir, "ppa.list")
+	c.Assert(ioutil.WriteFile(ppaFile, []byte("deb http://ppa"), 0644), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, jc.DeepEquals, []drift.Artifact{{Name: "apt-sources", Path: sourcesFile}})
+
+	// Files that don't match are ignored.
+	c.Assert(tracker.Record("apt-sources", sourcesFile, fingerprint), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(sourcesDir, "ppa.list.save"), nil, 0644), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// Changing and removing matching files are drift.
+	c.Assert(ioutil.WriteFile(sourcesFile, []byte("deb http://mirror"), 0644), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 1)
+	c.Assert(tracker.Record("apt-sources", sourcesFile, fingerprint), jc.ErrorIsNil)
+	c.Assert(os.Remove(ppaFile), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 1)
+}
+
+func (s *TrackerSuite) TestSetStoreInvalid(c *gc.C) {
+	store := filepath.Join(c.MkDir(), "baseline.json")
+	c.Assert(ioutil.WriteFile(store, []byte("}"), 0600), jc.ErrorIsNil)
+	err := drift.NewTracker().SetStore(store)
+	c.Assert(err, gc.ErrorMatches, "reading .*baseline.json: .*")
+}
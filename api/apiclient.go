@@ -189,6 +189,9 @@ func open(
 	}
 
 	client := rpc.NewConn(jsoncodec.NewWebsocket(conn), observer.None())
+	if opts.TraceID != "" {
+		client.SetTraceID(opts.TraceID)
+	}
 	client.Start()
 
 	bakeryClient := opts.BakeryClient
@@ -563,6 +566,9 @@ func startDialWebsocket(try *parallel.Try, addr, path string, opts DialOpts, tls
 		return errors.Trace(err)
 	}
 	cfg.TlsConfig = tlsConfig
+	// Tell the server that we can receive compressed messages;
+	// servers that do not support compression ignore the header.
+	cfg.Header.Set(jsoncodec.CompressionHeader, jsoncodec.DeflateCompression)
 	return try.Start(newWebsocketDialer(cfg, opts))
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -176,10 +177,22 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// BatchSize, if non-zero, is the number of units that are upgraded
+	// at a time. This field is only understood by Application facade
+	// version 4 and greater.
+	BatchSize int
+
+	// WaitFor is the workload status that each batch of units must
+	// reach before the next batch is upgraded.
+	WaitFor string
 }
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.BatchSize > 0 && c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades on this version of Juju")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -209,10 +222,75 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		BatchSize:          cfg.BatchSize,
+		WaitFor:            cfg.WaitFor,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// ResumeRollingUpgrade resumes the paused rolling charm upgrade of the
+// application.
+func (c *Client) ResumeRollingUpgrade(application string) error {
+	return c.updateRollingUpgrade("ResumeRollingUpgrade", application)
+}
+
+// AbortRollingUpgrade stops the rolling charm upgrade of the
+// application.
+func (c *Client) AbortRollingUpgrade(application string) error {
+	return c.updateRollingUpgrade("AbortRollingUpgrade", application)
+}
+
+func (c *Client) updateRollingUpgrade(method, application string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("rolling upgrades on this version of Juju")
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewApplicationTag(application).String(),
+	}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CharmHistory returns the charms that the application ran before its
+// most recent charm changes, most recent first.
+func (c *Client) CharmHistory(application string) ([]params.CharmHistoryEntry, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("charm history on this version of Juju")
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewApplicationTag(application).String(),
+	}}}
+	var results params.CharmHistoryResults
+	if err := c.facade.FacadeCall("CharmHistory", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].History, nil
+}
+
+// RollbackCharm sets the application's charm back to one recorded in
+// its charm history. If revision is nil, the application is rolled
+// back to the charm it ran before its most recent charm change.
+func (c *Client) RollbackCharm(application string, revision *int, forceUnits bool) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("charm rollback on this version of Juju")
+	}
+	args := params.ApplicationRollbackCharm{
+		ApplicationName: application,
+		Revision:        revision,
+		ForceUnits:      forceUnits,
+	}
+	return c.facade.FacadeCall("RollbackCharm", args, nil)
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -276,6 +354,34 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// ExposeEndpoints exposes the given application endpoints to the
+// spaces and CIDRs in their settings, leaving the settings of other
+// endpoints unchanged. The empty endpoint name stands for all
+// endpoints, and is currently the only one supported.
+func (c *Client) ExposeEndpoints(application string, exposed map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("exposing endpoints to spaces or CIDRs on this version of Juju")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposed,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
+// SetEndpointBindings binds the given endpoints of the application to
+// the given spaces, leaving the bindings of other endpoints unchanged.
+func (c *Client) SetEndpointBindings(application string, bindings map[string]string) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("changing endpoint bindings on this version of Juju")
+	}
+	args := params.ApplicationSetEndpointBindings{
+		ApplicationName: application,
+		Bindings:        bindings,
+	}
+	return c.facade.FacadeCall("SetEndpointBindings", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -188,6 +188,141 @@ func (s *applicationSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestServiceSetCharmRollingUpgrade(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.BatchSize, gc.Equals, 2)
+		c.Assert(args.WaitFor, gc.Equals, "active")
+		return nil
+	})
+	err := s.client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		BatchSize: 2,
+		WaitFor:   "active",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestResumeRollingUpgrade(c *gc.C) {
+	s.assertUpdateRollingUpgrade(c, "ResumeRollingUpgrade", s.client.ResumeRollingUpgrade)
+}
+
+func (s *applicationSuite) TestAbortRollingUpgrade(c *gc.C) {
+	s.assertUpdateRollingUpgrade(c, "AbortRollingUpgrade", s.client.AbortRollingUpgrade)
+}
+
+func (s *applicationSuite) assertUpdateRollingUpgrade(c *gc.C, method string, update func(string) error) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, method)
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	err := update("mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestCharmHistory(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "CharmHistory")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		result := response.(*params.CharmHistoryResults)
+		result.Results = []params.CharmHistoryResult{{
+			History: []params.CharmHistoryEntry{{CharmURL: "cs:mysql-1"}},
+		}}
+		return nil
+	})
+	history, err := s.client.CharmHistory("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(history, jc.DeepEquals, []params.CharmHistoryEntry{{CharmURL: "cs:mysql-1"}})
+}
+
+func (s *applicationSuite) TestCharmHistoryError(c *gc.C) {
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		result := response.(*params.CharmHistoryResults)
+		result.Results = []params.CharmHistoryResult{{
+			Error: &params.Error{Message: "boom"},
+		}}
+		return nil
+	})
+	_, err := s.client.CharmHistory("mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestRollbackCharm(c *gc.C) {
+	var called bool
+	revision := 3
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "RollbackCharm")
+		c.Assert(a, jc.DeepEquals, params.ApplicationRollbackCharm{
+			ApplicationName: "mysql",
+			Revision:        &revision,
+			ForceUnits:      true,
+		})
+		return nil
+	})
+	err := s.client.RollbackCharm("mysql", &revision, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	exposed := map[string]params.ExposedEndpoint{
+		"db-admin": {ExposeToSpaces: []string{"admin"}},
+	}
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName:  "mysql",
+			ExposedEndpoints: exposed,
+		})
+		return nil
+	})
+	err := s.client.ExposeEndpoints("mysql", exposed)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetEndpointBindings(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEndpointBindings")
+		c.Assert(a, jc.DeepEquals, params.ApplicationSetEndpointBindings{
+			ApplicationName: "mysql",
+			Bindings:        map[string]string{"server": "db"},
+		})
+		return nil
+	})
+	err := s.client.SetEndpointBindings("mysql", map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *applicationSuite) TestConsume(c *gc.C) {
 	var called bool
 	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
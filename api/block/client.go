@@ -4,7 +4,10 @@
 package block
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -44,9 +47,25 @@ func (c *Client) List() ([]params.Block, error) {
 // SwitchBlockOn switches desired block on for the current model.
 // Valid block types are "BlockDestroy", "BlockRemove" and "BlockChange".
 func (c *Client) SwitchBlockOn(blockType, msg string) error {
+	return c.SwitchScopedBlockOn(blockType, msg, nil, time.Time{})
+}
+
+// SwitchScopedBlockOn switches desired block on for the given
+// applications and machines, or for the whole model if there are
+// no targets. If expiry is not zero, the block no longer applies
+// after that time.
+// Valid block types are "BlockDestroy", "BlockRemove" and "BlockChange".
+func (c *Client) SwitchScopedBlockOn(blockType, msg string, targets []names.Tag, expiry time.Time) error {
+	if (len(targets) > 0 || !expiry.IsZero()) && c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("scoped or expiring blocks on this version of Juju")
+	}
 	args := params.BlockSwitchParams{
 		Type:    blockType,
 		Message: msg,
+		Targets: tagStrings(targets),
+	}
+	if !expiry.IsZero() {
+		args.Expiry = &expiry
 	}
 	var result params.ErrorResult
 	if err := c.facade.FacadeCall("SwitchBlockOn", args, &result); err != nil {
@@ -62,8 +81,20 @@ func (c *Client) SwitchBlockOn(blockType, msg string) error {
 // SwitchBlockOff switches desired block off for the current model.
 // Valid block types are "BlockDestroy", "BlockRemove" and "BlockChange".
 func (c *Client) SwitchBlockOff(blockType string) error {
+	return c.SwitchScopedBlockOff(blockType, nil)
+}
+
+// SwitchScopedBlockOff switches off the block of the desired type
+// that applies to exactly the given applications and machines, or
+// the model-wide block if there are no targets.
+// Valid block types are "BlockDestroy", "BlockRemove" and "BlockChange".
+func (c *Client) SwitchScopedBlockOff(blockType string, targets []names.Tag) error {
+	if len(targets) > 0 && c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("scoped blocks on this version of Juju")
+	}
 	args := params.BlockSwitchParams{
-		Type: blockType,
+		Type:    blockType,
+		Targets: tagStrings(targets),
 	}
 	var result params.ErrorResult
 	if err := c.facade.FacadeCall("SwitchBlockOff", args, &result); err != nil {
@@ -75,3 +106,11 @@ func (c *Client) SwitchBlockOff(blockType string) error {
 	}
 	return nil
 }
+
+func tagStrings(tags []names.Tag) []string {
+	var result []string
+	for _, tag := range tags {
+		result = append(result, tag.String())
+	}
+	return result
+}
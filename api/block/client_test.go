@@ -4,9 +4,12 @@
 package block_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/block"
@@ -168,3 +171,89 @@ func (s *blockMockSuite) TestList(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, errmsg)
 	c.Assert(found, gc.HasLen, 1)
 }
+
+// versionedAPICaller is an APICallerFunc that reports the given
+// facade version.
+type versionedAPICaller struct {
+	basetesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *blockMockSuite) TestSwitchScopedBlockOn(c *gc.C) {
+	called := false
+	expiry := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(request, gc.Equals, "SwitchBlockOn")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:    state.RemoveBlock.String(),
+				Message: "keep mysql",
+				Targets: []string{"application-mysql", "machine-0"},
+				Expiry:  &expiry,
+			})
+			return nil
+		},
+		version: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchScopedBlockOn(
+		state.RemoveBlock.String(), "keep mysql",
+		[]names.Tag{names.NewApplicationTag("mysql"), names.NewMachineTag("0")},
+		expiry,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *blockMockSuite) TestSwitchScopedBlockOnNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		version: 2,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchScopedBlockOn(
+		state.RemoveBlock.String(), "",
+		[]names.Tag{names.NewApplicationTag("mysql")},
+		time.Time{},
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *blockMockSuite) TestSwitchScopedBlockOff(c *gc.C) {
+	called := false
+	apiCaller := versionedAPICaller{
+		APICallerFunc: func(objType string,
+			version int,
+			id, request string,
+			a, response interface{},
+		) error {
+			called = true
+			c.Check(request, gc.Equals, "SwitchBlockOff")
+			c.Check(a, jc.DeepEquals, params.BlockSwitchParams{
+				Type:    state.RemoveBlock.String(),
+				Targets: []string{"application-mysql"},
+			})
+			return nil
+		},
+		version: 3,
+	}
+	blockClient := block.NewClient(apiCaller)
+	err := blockClient.SwitchScopedBlockOff(
+		state.RemoveBlock.String(),
+		[]names.Tag{names.NewApplicationTag("mysql")},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
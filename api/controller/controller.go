@@ -148,6 +148,29 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// ControllerMaintenance returns the maintenance mode of the
+// controller.
+func (c *Client) ControllerMaintenance() (params.ControllerMaintenance, error) {
+	var result params.ControllerMaintenanceResult
+	if err := c.facade.FacadeCall("ControllerMaintenance", nil, &result); err != nil {
+		return params.ControllerMaintenance{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.ControllerMaintenance{}, errors.Trace(result.Error)
+	}
+	return result.Maintenance, nil
+}
+
+// SetControllerMaintenance enables or disables maintenance mode for
+// the controller, with a message describing the maintenance.
+func (c *Client) SetControllerMaintenance(enabled bool, message string) error {
+	args := params.ControllerMaintenance{
+		Enabled: enabled,
+		Message: message,
+	}
+	return c.facade.FacadeCall("SetControllerMaintenance", args, nil)
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
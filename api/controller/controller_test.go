@@ -229,3 +229,55 @@ func makeSpec() controller.MigrationSpec {
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
+
+func (s *Suite) TestControllerMaintenance(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Controller")
+		c.Check(request, gc.Equals, "ControllerMaintenance")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ControllerMaintenanceResult)) = params.ControllerMaintenanceResult{
+			Maintenance: params.ControllerMaintenance{
+				Enabled: true,
+				Message: "upgrading mongo",
+			},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	maintenance, err := client.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance, jc.DeepEquals, params.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	})
+}
+
+func (s *Suite) TestControllerMaintenanceError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ControllerMaintenanceResult)) = params.ControllerMaintenanceResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	_, err := client.ControllerMaintenance()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestSetControllerMaintenance(c *gc.C) {
+	called := false
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Controller")
+		c.Check(request, gc.Equals, "SetControllerMaintenance")
+		c.Check(arg, jc.DeepEquals, params.ControllerMaintenance{
+			Enabled: true,
+			Message: "upgrading mongo",
+		})
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	err := client.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
 )
 
 // Client allows access to the cross model management API end points.
@@ -168,3 +169,48 @@ func convertListResultsToModel(items []params.ApplicationOffer) []crossmodel.Off
 	}
 	return result
 }
+
+// GrantOffer grants a user access to the specified offers.
+func (c *Client) GrantOffer(user, access string, offerURLs ...string) error {
+	return c.modifyOfferUser(params.GrantOfferAccess, user, access, offerURLs)
+}
+
+// RevokeOffer revokes a user's access to the specified offers.
+func (c *Client) RevokeOffer(user, access string, offerURLs ...string) error {
+	return c.modifyOfferUser(params.RevokeOfferAccess, user, access, offerURLs)
+}
+
+func (c *Client) modifyOfferUser(action params.OfferAction, user, access string, offerURLs []string) error {
+	var args params.ModifyOfferAccessRequest
+
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+
+	offerAccess := permission.Access(access)
+	if err := permission.ValidateOfferAccess(offerAccess); err != nil {
+		return errors.Trace(err)
+	}
+	for _, url := range offerURLs {
+		if _, err := crossmodel.ParseApplicationURL(url); err != nil {
+			return errors.Annotatef(err, "invalid offer url %q", url)
+		}
+		args.Changes = append(args.Changes, params.ModifyOfferAccess{
+			UserTag:  userTag.String(),
+			Action:   action,
+			Access:   params.OfferAccessPermission(offerAccess),
+			OfferURL: url,
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyOfferAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
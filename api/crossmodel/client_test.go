@@ -419,3 +419,58 @@ func (s *crossmodelMockSuite) TestFindFacadeCallError(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 	c.Assert(results, gc.IsNil)
 }
+
+func (s *crossmodelMockSuite) TestGrantOffer(c *gc.C) {
+	s.assertModifyOfferAccess(c, params.GrantOfferAccess, func(client *crossmodel.Client) error {
+		return client.GrantOffer("fred", "consume", "local:/u/mary/mysql")
+	})
+}
+
+func (s *crossmodelMockSuite) TestRevokeOffer(c *gc.C) {
+	s.assertModifyOfferAccess(c, params.RevokeOfferAccess, func(client *crossmodel.Client) error {
+		return client.RevokeOffer("fred", "consume", "local:/u/mary/mysql")
+	})
+}
+
+func (s *crossmodelMockSuite) assertModifyOfferAccess(c *gc.C, action params.OfferAction, call func(*crossmodel.Client) error) {
+	called := false
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			called = true
+			c.Check(objType, gc.Equals, "CrossModelRelations")
+			c.Check(request, gc.Equals, "ModifyOfferAccess")
+			c.Check(a, jc.DeepEquals, params.ModifyOfferAccessRequest{
+				Changes: []params.ModifyOfferAccess{{
+					UserTag:  "user-fred",
+					Action:   action,
+					Access:   params.OfferConsumeAccess,
+					OfferURL: "local:/u/mary/mysql",
+				}},
+			})
+			if results, ok := result.(*params.ErrorResults); ok {
+				results.Results = []params.ErrorResult{{
+					Error: common.ServerError(errors.New("fail")),
+				}}
+			}
+			return nil
+		})
+
+	client := crossmodel.NewClient(apiCaller)
+	err := call(client)
+	c.Assert(err, gc.ErrorMatches, "fail")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *crossmodelMockSuite) TestGrantOfferInvalidAccess(c *gc.C) {
+	client := crossmodel.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}))
+	err := client.GrantOffer("fred", "write", "local:/u/mary/mysql")
+	c.Assert(err, gc.ErrorMatches, `"write" offer access not valid`)
+}
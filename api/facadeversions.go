@@ -17,11 +17,11 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  3,
+	"Application":                  7,
 	"ApplicationScaler":            1,
 	"ApplicationOffers":            1,
 	"Backups":                      1,
-	"Block":                        2,
+	"Block":                        3,
 	"Bundle":                       1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
@@ -35,7 +35,7 @@ var facadeVersions = map[string]int{
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   3,
+	"Firewaller":                   4,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -48,9 +48,10 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               2,
+	"MachineManager":               3,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
+	"MaintenanceFlag":              1,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
 	"MetricsDebug":                 2,
@@ -67,7 +68,7 @@ var facadeVersions = map[string]int{
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  3,
+	"Provisioner":                  5,
 	"ProxyUpdater":                 1,
 	"Reboot":                       2,
 	"RelationUnitsWatcher":         1,
@@ -76,8 +77,9 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"RollingUpgrader":              1,
 	"Singular":                     1,
-	"Spaces":                       2,
+	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
 	"Storage":                      3,
@@ -75,6 +75,22 @@ func (m *Machine) Life() params.Life {
 	return m.life
 }
 
+// SetStatusData sets the given keys in the status data of the machine,
+// without changing the status itself. Keys with nil values are removed.
+func (m *Machine) SetStatusData(data map[string]interface{}) error {
+	var result params.ErrorResults
+	args := params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: m.tag.String(), Data: data},
+		},
+	}
+	err := m.st.facade.FacadeCall("SetStatusData", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // ActiveSubnets returns a list of subnet tags for which the machine has opened
 // ports.
 func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
@@ -108,9 +124,11 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 	return tags, nil
 }
 
-// OpenedPorts returns a map of network.PortRange to unit tag for all opened
-// port ranges on the machine for the subnet matching given subnetTag.
-func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+// OpenedPorts returns a map of network.PortRange to the tags of the units
+// which opened it for all opened port ranges on the machine for the subnet
+// matching given subnetTag. Only port-less protocols can be opened by more
+// than one unit.
+func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange][]names.UnitTag, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +151,14 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
+	endResult := make(map[network.PortRange][]names.UnitTag)
 	for _, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		portRange := ports.PortRange.NetworkPortRange()
+		endResult[portRange] = append(endResult[portRange], unitTag)
 	}
 	return endResult, nil
 }
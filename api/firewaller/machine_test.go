@@ -67,6 +67,22 @@ func (s *machineSuite) TestInstanceId(c *gc.C) {
 	c.Assert(instanceId, gc.Equals, instance.Id("i-manager"))
 }
 
+func (s *machineSuite) TestSetStatusData(c *gc.C) {
+	err := s.apiMachine.SetStatusData(map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.machines[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+
+	err = s.apiMachine.SetStatusData(map[string]interface{}{"foo": nil})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err = s.machines[0].Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Data, gc.HasLen, 0)
+}
+
 func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	w, err := s.apiMachine.WatchUnits()
 	c.Assert(err, jc.ErrorIsNil)
@@ -130,7 +146,7 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	ports, err = s.apiMachine.OpenedPorts(names.SubnetTag{})
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(ports, jc.DeepEquals, map[network.PortRange]names.UnitTag{
-		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
+	c.Assert(ports, jc.DeepEquals, map[network.PortRange][]names.UnitTag{
+		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: {unitTag},
 	})
 }
@@ -142,6 +142,12 @@ type DialOpts struct {
 	// performed and the communication need not be secure.
 	InsecureSkipVerify bool
 
+	// TraceID, if non-empty, holds the ID of the distributed trace
+	// that all requests made on the connection are part of. The API
+	// server records each request as a span of the trace when tracing
+	// is enabled in the controller config.
+	TraceID string
+
 	// DialWebsocket is used to make connections to API servers.
 	// It will be called with a websocket URL to connect to,
 	// and the TLS configuration to use to secure the connection.
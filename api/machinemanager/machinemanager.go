@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 )
 
 const machineManagerFacade = "MachineManager"
@@ -36,3 +37,59 @@ func (client *Client) AddMachines(machineParams []params.AddMachineParams) ([]pa
 	}
 	return results.Machines, err
 }
+
+// AddMachinePool adds a pool holding size machines of the given series
+// and constraints, and returns the id of the new pool.
+func (client *Client) AddMachinePool(size int, series string, cons constraints.Value) (string, error) {
+	if client.BestAPIVersion() < 3 {
+		return "", errors.NotSupportedf("machine pools on this version of Juju")
+	}
+	args := params.AddMachinePools{
+		Pools: []params.AddMachinePoolParams{{
+			Size:        size,
+			Series:      series,
+			Constraints: cons,
+		}},
+	}
+	var results params.AddMachinePoolResults
+	if err := client.facade.FacadeCall("AddMachinePools", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", err
+	}
+	return results.Results[0].Pool, nil
+}
+
+// RemoveMachinePool removes the machine pool with the given id and
+// destroys the unclaimed machines it holds.
+func (client *Client) RemoveMachinePool(id string) error {
+	if client.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("machine pools on this version of Juju")
+	}
+	args := params.MachinePools{Pools: []string{id}}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("RemoveMachinePools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SetMachinePoolSize changes the number of unclaimed machines held by
+// the machine pool with the given id.
+func (client *Client) SetMachinePoolSize(id string, size int) error {
+	if client.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("machine pools on this version of Juju")
+	}
+	args := params.MachinePoolSizes{
+		Pools: []params.MachinePoolSize{{Pool: id, Size: size}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("SetMachinePoolSizes", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -116,3 +117,121 @@ func (s *MachinemanagerSuite) TestAddMachinesResultCountInvalid(c *gc.C) {
 		c.Check(err, gc.ErrorMatches, fmt.Sprintf("expected 1 result, got %d", n))
 	}
 }
+
+// versionedAPICaller is an APICallerFunc that reports the given
+// facade version.
+type versionedAPICaller struct {
+	testing.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *MachinemanagerSuite) TestAddMachinePool(c *gc.C) {
+	var callCount int
+	apiCaller := versionedAPICaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "AddMachinePools")
+			c.Check(arg, jc.DeepEquals, params.AddMachinePools{
+				Pools: []params.AddMachinePoolParams{{
+					Size:        5,
+					Series:      "xenial",
+					Constraints: constraints.MustParse("mem=4G"),
+				}},
+			})
+			*(result.(*params.AddMachinePoolResults)) = params.AddMachinePoolResults{
+				Results: []params.AddMachinePoolResult{{Pool: "0"}},
+			}
+			callCount++
+			return nil
+		}),
+		version: 3,
+	}
+	st := machinemanager.NewClient(apiCaller)
+	id, err := st.AddMachinePool(5, "xenial", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "0")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *MachinemanagerSuite) TestAddMachinePoolServerError(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.AddMachinePoolResults)) = params.AddMachinePoolResults{
+				Results: []params.AddMachinePoolResult{{
+					Error: &params.Error{Message: "MSG", Code: "621"},
+				}},
+			}
+			return nil
+		}),
+		version: 3,
+	}
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.AddMachinePool(1, "xenial", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "MSG")
+}
+
+func (s *MachinemanagerSuite) TestAddMachinePoolNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		version: 2,
+	}
+	st := machinemanager.NewClient(apiCaller)
+	_, err := st.AddMachinePool(1, "xenial", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "machine pools on this version of Juju not supported")
+}
+
+func (s *MachinemanagerSuite) TestRemoveMachinePool(c *gc.C) {
+	var callCount int
+	apiCaller := versionedAPICaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "RemoveMachinePools")
+			c.Check(arg, jc.DeepEquals, params.MachinePools{Pools: []string{"0"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			callCount++
+			return nil
+		}),
+		version: 3,
+	}
+	st := machinemanager.NewClient(apiCaller)
+	err := st.RemoveMachinePool("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *MachinemanagerSuite) TestSetMachinePoolSize(c *gc.C) {
+	var callCount int
+	apiCaller := versionedAPICaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "SetMachinePoolSizes")
+			c.Check(arg, jc.DeepEquals, params.MachinePoolSizes{
+				Pools: []params.MachinePoolSize{{Pool: "0", Size: 3}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "MSG", Code: "621"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		version: 3,
+	}
+	st := machinemanager.NewClient(apiCaller)
+	err := st.SetMachinePoolSize("0", 3)
+	c.Assert(err, gc.ErrorMatches, "MSG")
+	c.Check(callCount, gc.Equals, 1)
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenanceflag provides access to the MaintenanceFlag
+// facade, which lets agents watch and query the maintenance mode of
+// the controller.
+package maintenanceflag
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// NewWatcherFunc exists to let us unit test Facade without patching.
+type NewWatcherFunc func(base.APICaller, params.NotifyWatchResult) watcher.NotifyWatcher
+
+// NewFacade returns a Facade backed by the supplied api caller.
+func NewFacade(apiCaller base.APICaller, newWatcher NewWatcherFunc) *Facade {
+	facadeCaller := base.NewFacadeCaller(apiCaller, "MaintenanceFlag")
+	return &Facade{
+		caller:     facadeCaller,
+		newWatcher: newWatcher,
+	}
+}
+
+// Facade lets a client watch and query the controller's maintenance
+// mode.
+type Facade struct {
+	caller     base.FacadeCaller
+	newWatcher NewWatcherFunc
+}
+
+// Maintenance returns whether the controller is in maintenance mode.
+func (facade *Facade) Maintenance() (bool, error) {
+	var result params.ControllerMaintenanceResult
+	err := facade.caller.FacadeCall("Maintenance", nil, &result)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return false, errors.Trace(result.Error)
+	}
+	return result.Maintenance.Enabled, nil
+}
+
+// Watch returns a NotifyWatcher that will inform of potential changes
+// to the result of Maintenance.
+func (facade *Facade) Watch() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := facade.caller.FacadeCall("Watch", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	apiCaller := facade.caller.RawAPICaller()
+	watcher := facade.newWatcher(apiCaller, result)
+	return watcher, nil
+}
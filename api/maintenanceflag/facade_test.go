@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenanceflag_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/maintenanceflag"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (*FacadeSuite) TestMaintenanceCallError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(interface{}) error {
+		return errors.New("bork")
+	})
+	facade := maintenanceflag.NewFacade(apiCaller, nil)
+
+	enabled, err := facade.Maintenance()
+	c.Check(err, gc.ErrorMatches, "bork")
+	c.Check(enabled, jc.IsFalse)
+	stub.CheckCallNames(c, "Maintenance")
+}
+
+func (*FacadeSuite) TestMaintenanceError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.ControllerMaintenanceResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.Error = &params.Error{Message: "mneh"}
+		return nil
+	})
+	facade := maintenanceflag.NewFacade(apiCaller, nil)
+
+	_, err := facade.Maintenance()
+	c.Check(err, gc.ErrorMatches, "mneh")
+	stub.CheckCallNames(c, "Maintenance")
+}
+
+func (*FacadeSuite) TestMaintenanceSuccess(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.ControllerMaintenanceResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.Maintenance = params.ControllerMaintenance{
+			Enabled: true,
+			Message: "upgrading mongo",
+		}
+		return nil
+	})
+	facade := maintenanceflag.NewFacade(apiCaller, nil)
+
+	enabled, err := facade.Maintenance()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(enabled, jc.IsTrue)
+	stub.CheckCallNames(c, "Maintenance")
+}
+
+func (*FacadeSuite) TestWatchError(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.NotifyWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.Error = &params.Error{Message: "snfl"}
+		return nil
+	})
+	facade := maintenanceflag.NewFacade(apiCaller, nil)
+
+	watch, err := facade.Watch()
+	c.Check(err, gc.ErrorMatches, "snfl")
+	c.Check(watch, gc.IsNil)
+	stub.CheckCallNames(c, "Watch")
+}
+
+func (*FacadeSuite) TestWatchSuccess(c *gc.C) {
+	stub := &testing.Stub{}
+	apiCaller := apiCaller(c, stub, func(response interface{}) error {
+		outPtr, ok := response.(*params.NotifyWatchResult)
+		c.Assert(ok, jc.IsTrue)
+		outPtr.NotifyWatcherId = "789"
+		return nil
+	})
+	expectWatch := &struct{ watcher.NotifyWatcher }{}
+	newWatcher := func(gotCaller base.APICaller, result params.NotifyWatchResult) watcher.NotifyWatcher {
+		c.Check(gotCaller, gc.NotNil) // uncomparable
+		c.Check(result, jc.DeepEquals, params.NotifyWatchResult{
+			NotifyWatcherId: "789",
+		})
+		return expectWatch
+	}
+	facade := maintenanceflag.NewFacade(apiCaller, newWatcher)
+
+	watch, err := facade.Watch()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(watch, gc.Equals, expectWatch)
+	stub.CheckCallNames(c, "Watch")
+}
+
+func apiCaller(c *gc.C, stub *testing.Stub, set func(interface{}) error) base.APICaller {
+	return basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "MaintenanceFlag")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(args, gc.IsNil)
+		stub.AddCall(request)
+		return set(response)
+	})
+}
@@ -86,6 +86,67 @@ func (st *State) WatchMachineErrorRetry() (watcher.NotifyWatcher, error) {
 	return w, nil
 }
 
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// the names of applications whose charms or lives change.
+func (st *State) WatchApplicationCharms() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchApplicationCharms", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchUnitAssignments returns a StringsWatcher that notifies of the
+// ids of machines whose principal units, or their subordinates,
+// change.
+func (st *State) WatchUnitAssignments() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchUnitAssignments", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchMachinePools returns a NotifyWatcher that notifies when machine
+// pools change or have machines join or leave them.
+func (st *State) WatchMachinePools() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := st.facade.FacadeCall("WatchMachinePools", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// TopUpMachinePools adds machines to the model's machine pools until
+// each holds its configured number of machines, returning the ids of
+// the machines added.
+func (st *State) TopUpMachinePools() ([]string, error) {
+	var result params.StringsResult
+	err := st.facade.FacadeCall("TopUpMachinePools", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
 // StateAddresses returns the list of addresses used to connect to the state.
 func (st *State) StateAddresses() ([]string, error) {
 	var result params.StringsResult
@@ -489,6 +489,73 @@ func (s *provisionerSuite) TestWatchContainers(c *gc.C) {
 	wc.AssertChange(container.Id())
 }
 
+func (s *provisionerSuite) TestWatchApplicationCharms(c *gc.C) {
+	w, err := s.provisioner.WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Adding an application is detected.
+	s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	wc.AssertChange("lxd-profile")
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestWatchUnitAssignments(c *gc.C) {
+	w, err := s.provisioner.WatchUnitAssignments()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine is detected.
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine.Id())
+	wc.AssertNoChange()
+}
+
+func (s *provisionerSuite) TestWatchMachinePools(c *gc.C) {
+	w, err := s.provisioner.WatchMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Adding a machine pool is detected.
+	_, err = s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *provisionerSuite) TestTopUpMachinePools(c *gc.C) {
+	_, err := s.State.AddMachinePool(2, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ids, err := s.provisioner.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ids, gc.HasLen, 2)
+	for _, id := range ids {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(m.Pool(), gc.Equals, "0")
+	}
+}
+
 func (s *provisionerSuite) TestWatchContainersAcceptsSupportedContainers(c *gc.C) {
 	apiMachine, err := s.provisioner.Machine(s.machine.Tag().(names.MachineTag))
 	c.Assert(err, jc.ErrorIsNil)
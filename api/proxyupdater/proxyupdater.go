@@ -72,21 +72,41 @@ func proxySettingsParamToProxySettings(cfg params.ProxyConfig) proxy.Settings {
 	}
 }
 
+// ProxyConfiguration holds the proxy settings for a model.
+type ProxyConfiguration struct {
+	// LegacyProxy holds the http-proxy, https-proxy, ftp-proxy and
+	// no-proxy settings, which apply to the whole machine.
+	LegacyProxy proxy.Settings
+
+	// JujuProxy holds the proxy settings that apply only to Juju
+	// agents: each juju-* proxy setting if it is set, otherwise the
+	// legacy setting, with the controller addresses in NoProxy.
+	JujuProxy proxy.Settings
+
+	// APTProxy holds the apt proxy settings.
+	APTProxy proxy.Settings
+}
+
 // ProxyConfig returns the proxy settings for the current environment
-func (api *API) ProxyConfig() (proxySettings, APTProxySettings proxy.Settings, err error) {
+func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 	var results params.ProxyConfigResults
 	args := params.Entities{
 		Entities: []params.Entity{{Tag: api.tag.String()}},
 	}
-	err = api.facade.FacadeCall("ProxyConfig", args, &results)
+	err := api.facade.FacadeCall("ProxyConfig", args, &results)
 	if err != nil {
-		return proxySettings, APTProxySettings, err
+		return ProxyConfiguration{}, err
 	}
 	if len(results.Results) != 1 {
-		return proxySettings, APTProxySettings, errors.NotFoundf("ProxyConfig for %q", api.tag)
+		return ProxyConfiguration{}, errors.NotFoundf("ProxyConfig for %q", api.tag)
 	}
 	result := results.Results[0]
-	proxySettings = proxySettingsParamToProxySettings(result.ProxySettings)
-	APTProxySettings = proxySettingsParamToProxySettings(result.APTProxySettings)
-	return proxySettings, APTProxySettings, nil
+	if result.Error != nil {
+		return ProxyConfiguration{}, result.Error
+	}
+	return ProxyConfiguration{
+		LegacyProxy: proxySettingsParamToProxySettings(result.ProxySettings),
+		JujuProxy:   proxySettingsParamToProxySettings(result.JujuProxySettings),
+		APTProxy:    proxySettingsParamToProxySettings(result.APTProxySettings),
+	}, nil
 }
@@ -89,6 +89,11 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 			FTP:     "ftp",
 			NoProxy: "NoProxy",
 		},
+		JujuProxySettings: params.ProxyConfig{
+			HTTP:    "http-juju",
+			HTTPS:   "https-juju",
+			NoProxy: "10.0.0.0/8",
+		},
 		APTProxySettings: params.ProxyConfig{
 			HTTP:    "http-apt",
 			HTTPS:   "https-apt",
@@ -107,19 +112,40 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 	}}
 	called, api := newAPI(c, args)
 
-	proxySettings, APTProxySettings, err := api.ProxyConfig()
+	config, err := api.ProxyConfig()
 	c.Assert(*called, gc.Equals, 1)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Check(proxySettings, jc.DeepEquals, proxy.Settings{
+	c.Check(config.LegacyProxy, jc.DeepEquals, proxy.Settings{
 		Http:    "http",
 		Https:   "https",
 		Ftp:     "ftp",
 		NoProxy: "NoProxy",
 	})
-	c.Check(APTProxySettings, jc.DeepEquals, proxy.Settings{
+	c.Check(config.JujuProxy, jc.DeepEquals, proxy.Settings{
+		Http:    "http-juju",
+		Https:   "https-juju",
+		NoProxy: "10.0.0.0/8",
+	})
+	c.Check(config.APTProxy, jc.DeepEquals, proxy.Settings{
 		Http:    "http-apt",
 		Https:   "https-apt",
 		Ftp:     "ftp-apt",
 		NoProxy: "NoProxy-apt",
 	})
 }
+
+func (s *ProxyUpdaterSuite) TestProxyConfigError(c *gc.C) {
+	args := []apitesting.CheckArgs{{
+		Facade: "ProxyUpdater",
+		Method: "ProxyConfig",
+		Results: params.ProxyConfigResults{
+			Results: []params.ProxyConfigResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		},
+	}}
+	_, api := newAPI(c, args)
+
+	_, err := api.ProxyConfig()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
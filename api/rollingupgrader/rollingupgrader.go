@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const rollingUpgraderFacade = "RollingUpgrader"
+
+// API provides access to the RollingUpgrader API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side RollingUpgrader facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, rollingUpgraderFacade)
+	return &API{facade: facadeCaller}
+}
+
+// AdvanceRollingUpgrades calls the server-side AdvanceRollingUpgrades
+// method.
+func (api *API) AdvanceRollingUpgrades() error {
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("AdvanceRollingUpgrades", nil, &result); err != nil {
+		return err
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RollingUpgraderSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&RollingUpgraderSuite{})
+
+func (s *RollingUpgraderSuite) TestAdvanceRollingUpgrades(c *gc.C) {
+	var callCount int
+	apiCaller := apitesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, results interface{}) error {
+			c.Check(objType, gc.Equals, "RollingUpgrader")
+			c.Check(version, gc.Equals, 0)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "AdvanceRollingUpgrades")
+			c.Check(args, gc.IsNil)
+			c.Assert(results, gc.FitsTypeOf, &params.ErrorResult{})
+			callCount++
+			return nil
+		},
+	)
+
+	api := rollingupgrader.NewAPI(apiCaller)
+	err := api.AdvanceRollingUpgrades()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *RollingUpgraderSuite) TestAdvanceRollingUpgradesResultError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, results interface{}) error {
+			*(results.(*params.ErrorResult)) = params.ErrorResult{
+				Error: &params.Error{Message: "boom!"},
+			}
+			return nil
+		},
+	)
+
+	api := rollingupgrader.NewAPI(apiCaller)
+	err := api.AdvanceRollingUpgrades()
+	c.Check(err, gc.ErrorMatches, "boom!")
+}
+
+func (s *RollingUpgraderSuite) TestAdvanceRollingUpgradesFailure(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(
+		func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("boom!")
+		},
+	)
+
+	api := rollingupgrader.NewAPI(apiCaller)
+	err := api.AdvanceRollingUpgrades()
+	c.Check(err, gc.ErrorMatches, "boom!")
+}
@@ -72,3 +72,35 @@ func (api *API) ListSpaces() ([]params.Space, error) {
 	}
 	return response.Results, err
 }
+
+// MoveSubnets moves the subnets with the given CIDRs into the named
+// space. If force is true, the move is made even if it leaves endpoint
+// bindings unsatisfiable. The names of the applications whose units
+// were told about the move are returned.
+func (api *API) MoveSubnets(name string, cidrs []string, force bool) ([]string, error) {
+	if api.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("moving subnets on this version of Juju")
+	}
+	subnetTags := make([]string, len(cidrs))
+	for i, cidr := range cidrs {
+		subnetTags[i] = names.NewSubnetTag(cidr).String()
+	}
+	args := params.MoveSubnetsParams{
+		Args: []params.MoveSubnetsParam{{
+			SpaceTag:   names.NewSpaceTag(name).String(),
+			SubnetTags: subnetTags,
+			Force:      force,
+		}},
+	}
+	var results params.MoveSubnetsResults
+	if err := api.facade.FacadeCall("MoveSubnets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Applications, nil
+}
@@ -171,3 +171,71 @@ func (s *SpacesSuite) TestListSpacesManyResults(c *gc.C) {
 func (s *SpacesSuite) TestListSpacesServerError(c *gc.C) {
 	s.testListSpaces(c, nil, errors.New("boom"), "boom")
 }
+
+type versionedAPICaller struct {
+	apitesting.APICallerFunc
+	version int
+}
+
+func (c versionedAPICaller) BestFacadeVersion(string) int {
+	return c.version
+}
+
+func (s *SpacesSuite) TestMoveSubnets(c *gc.C) {
+	var called int
+	apiCaller := versionedAPICaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Spaces")
+			c.Check(version, gc.Equals, 3)
+			c.Check(request, gc.Equals, "MoveSubnets")
+			c.Check(arg, jc.DeepEquals, params.MoveSubnetsParams{
+				Args: []params.MoveSubnetsParam{{
+					SpaceTag:   "space-dmz",
+					SubnetTags: []string{"subnet-10.0.0.0/24"},
+					Force:      true,
+				}},
+			})
+			*(result.(*params.MoveSubnetsResults)) = params.MoveSubnetsResults{
+				Results: []params.MoveSubnetsResult{{Applications: []string{"mysql"}}},
+			}
+			called++
+			return nil
+		}),
+		version: 3,
+	}
+	api := spaces.NewAPI(apiCaller)
+	applications, err := api.MoveSubnets("dmz", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, jc.DeepEquals, []string{"mysql"})
+	c.Check(called, gc.Equals, 1)
+}
+
+func (s *SpacesSuite) TestMoveSubnetsServerError(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.MoveSubnetsResults)) = params.MoveSubnetsResults{
+				Results: []params.MoveSubnetsResult{{
+					Error: &params.Error{Message: "bindings would be left unsatisfiable"},
+				}},
+			}
+			return nil
+		}),
+		version: 3,
+	}
+	api := spaces.NewAPI(apiCaller)
+	_, err := api.MoveSubnets("dmz", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, gc.ErrorMatches, "bindings would be left unsatisfiable")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsNotSupported(c *gc.C) {
+	apiCaller := versionedAPICaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+		version: 2,
+	}
+	api := spaces.NewAPI(apiCaller)
+	_, err := api.MoveSubnets("dmz", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, gc.ErrorMatches, "moving subnets on this version of Juju not supported")
+}
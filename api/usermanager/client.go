@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -180,3 +181,91 @@ func (c *Client) SetPassword(username, password string) error {
 	}
 	return results.OneError()
 }
+
+// AddGroup creates a new group in the controller.
+func (c *Client) AddGroup(name string) error {
+	if !names.IsValidUserName(name) {
+		return errors.Errorf("invalid group name %q", name)
+	}
+	args := params.AddGroups{
+		Groups: []params.AddGroup{{Name: name}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddGroup", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddToGroup adds the specified users to the named group.
+func (c *Client) AddToGroup(group string, usernames ...string) error {
+	return c.modifyGroupMembers(group, params.AddGroupMember, usernames)
+}
+
+// RemoveFromGroup removes the specified users from the named group.
+func (c *Client) RemoveFromGroup(group string, usernames ...string) error {
+	return c.modifyGroupMembers(group, params.RemoveGroupMember, usernames)
+}
+
+func (c *Client) modifyGroupMembers(group string, action params.GroupMemberAction, usernames []string) error {
+	args := params.ModifyGroupMembers{
+		Changes: make([]params.ModifyGroupMember, len(usernames)),
+	}
+	for i, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		args.Changes[i] = params.ModifyGroupMember{
+			Group:   group,
+			UserTag: names.NewUserTag(username).String(),
+			Action:  action,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ModifyGroupMembers", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// Groups returns information about the groups in the controller.
+func (c *Client) Groups() ([]params.GroupInfo, error) {
+	var results params.GroupInfoResults
+	if err := c.facade.FacadeCall("Groups", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// AddAPIToken adds an API token that allows a user to access a single
+// model with limited access until it expires or is removed. It returns
+// the ID of the token, used to remove it, and the macaroon that is
+// presented when logging in with the token.
+func (c *Client) AddAPIToken(token params.AddAPIToken) (string, *macaroon.Macaroon, error) {
+	args := params.AddAPITokens{
+		Tokens: []params.AddAPIToken{token},
+	}
+	var results params.AddAPITokenResults
+	if err := c.facade.FacadeCall("AddAPITokens", args, &results); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return "", nil, errors.Errorf("expected 1 result, got %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", nil, errors.Trace(result.Error)
+	}
+	return result.ID, result.Macaroon, nil
+}
+
+// RemoveAPITokens removes the API tokens with the specified IDs, so
+// that they may no longer be used.
+func (c *Client) RemoveAPITokens(ids ...string) error {
+	args := params.RemoveAPITokens{IDs: ids}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAPITokens", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
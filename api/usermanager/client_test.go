@@ -4,6 +4,8 @@
 package usermanager_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -215,3 +217,70 @@ func (s *usermanagerSuite) TestSetUserPasswordBadName(c *gc.C) {
 	err := s.usermanager.SetPassword("not!good", "new-password")
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
+
+func (s *usermanagerSuite) TestAddGroup(c *gc.C) {
+	err := s.usermanager.AddGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+}
+
+func (s *usermanagerSuite) TestAddGroupBadName(c *gc.C) {
+	err := s.usermanager.AddGroup("not/valid")
+	c.Assert(err, gc.ErrorMatches, `invalid group name "not/valid"`)
+}
+
+func (s *usermanagerSuite) TestAddAndRemoveGroupMembers(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := s.usermanager.AddGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.AddToGroup("devs", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	groups, err := s.usermanager.Groups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name, gc.Equals, "devs")
+	c.Assert(groups[0].Members, jc.DeepEquals, []string{"foobar"})
+
+	err = s.usermanager.RemoveFromGroup("devs", "foobar")
+	c.Assert(err, jc.ErrorIsNil)
+	groups, err = s.usermanager.Groups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups[0].Members, gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestAddToGroupBadName(c *gc.C) {
+	err := s.usermanager.AddToGroup("devs", "not!good")
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
+
+func (s *usermanagerSuite) TestAddAndRemoveAPIToken(c *gc.C) {
+	id, m, err := s.usermanager.AddAPIToken(params.AddAPIToken{
+		ModelTag: s.State.ModelTag().String(),
+		Access:   "read",
+		Expires:  time.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m, gc.NotNil)
+
+	token, err := s.State.APIToken(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.User(), gc.Equals, s.AdminUserTag(c))
+
+	err = s.usermanager.RemoveAPITokens(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.APIToken(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *usermanagerSuite) TestAddAPITokenInvalidAccess(c *gc.C) {
+	_, _, err := s.usermanager.AddAPIToken(params.AddAPIToken{
+		ModelTag: s.State.ModelTag().String(),
+		Access:   "superuser",
+		Expires:  time.Now().Add(time.Hour),
+	})
+	c.Assert(err, gc.ErrorMatches, `"superuser" model access not valid`)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/authentication"
@@ -136,6 +137,15 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		controllerMachineLogin = true
 	}
 	a.root.entity = entity
+	apiToken, err := entityAPIToken(a.root.state, entity)
+	if err != nil {
+		return fail, errors.Trace(err)
+	}
+	if apiToken != nil && controllerOnlyLogin {
+		// API tokens are limited to a single model.
+		return fail, errors.Trace(common.ErrPerm)
+	}
+	a.root.apiToken = apiToken
 	a.apiObserver.Login(entity.Tag(), a.root.state.ModelTag(), controllerMachineLogin, req.UserData)
 
 	// We have authenticated the user; enable the appropriate API
@@ -203,6 +213,28 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		loginResult.ModelTag = model.Tag().String()
 		loginResult.Facades = filterFacades(isModelFacade)
 		apiRoot = restrictRoot(apiRoot, modelFacadesOnly)
+		if apiToken != nil {
+			allowed := set.NewStrings(apiToken.Facades()...)
+			loginResult.Facades = filterFacades(func(name string) bool {
+				return isModelFacade(name) && isAPITokenFacade(allowed, name)
+			})
+			apiRoot = restrictRoot(apiRoot, apiTokenFacadesOnly(apiToken.Facades()))
+		}
+	}
+
+	// The maintenance mode of the controller may change at any
+	// time, so it is checked on every call rather than at login.
+	apiRoot = restrictRoot(apiRoot, a.srv.checkMaintenance)
+
+	if isUser {
+		// Only user requests are rate limited; agents
+		// are limited by the number of concurrent logins.
+		var modelUUID string
+		if !controllerOnlyLogin {
+			modelUUID = model.UUID()
+		}
+		userTag := entity.Tag().(names.UserTag)
+		apiRoot = restrictRoot(apiRoot, a.srv.rateLimiter.checkUser(userTag, modelUUID))
 	}
 
 	a.root.rpcConn.ServeRoot(apiRoot, serverError)
@@ -230,7 +262,7 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 	}
 
 	controllerAccess := permission.NoAccess
-	if controllerUser, err := state.ControllerAccess(a.root.state, userTag); err == nil {
+	if controllerUser, err := a.root.state.EffectiveUserAccess(userTag, a.root.state.ControllerTag()); err == nil {
 		controllerAccess = controllerUser.Access
 	} else if errors.IsNotFound(err) {
 		controllerAccess = everyoneGroupAccess
@@ -243,7 +275,7 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 		// no authorisation to access this model, unless the user is controller
 		// admin.
 
-		modelUser, err := a.root.state.UserAccess(userTag, a.root.state.ModelTag())
+		modelUser, err := a.root.state.EffectiveUserAccess(userTag, a.root.state.ModelTag())
 		if err != nil && controllerAccess != permission.SuperuserAccess {
 			return nil, errors.Wrap(err, common.ErrPerm)
 		}
@@ -267,6 +299,16 @@ func (a *admin) checkUserPermissions(userTag names.UserTag, controllerOnlyLogin
 			return nil, errors.Trace(common.ErrPerm)
 		}
 	}
+	if token := a.root.apiToken; token != nil {
+		// The user logged in with an API token, so limit their
+		// access to that granted by the token.
+		if controllerAccess.GreaterControllerAccessThan(permission.LoginAccess) {
+			controllerAccess = permission.LoginAccess
+		}
+		if modelAccess.GreaterModelAccessThan(token.Access()) {
+			modelAccess = token.Access()
+		}
+	}
 	if controllerOnlyLogin {
 		logger.Debugf("controller login: user %s has %q access", userTag.Id(), controllerAccess)
 	} else {
@@ -354,6 +396,11 @@ func checkCreds(st *state.State, req params.LoginRequest, lookForModelUser bool,
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
+	// Logins made with an API token are only valid while the token
+	// exists, and only for the model it was minted for.
+	if _, err := entityAPIToken(st, entity); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	// For user logins, update the last login time.
 	var lastLogin *time.Time
@@ -18,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	apimachiner "github.com/juju/juju/api/machiner"
@@ -595,6 +596,79 @@ func (s *loginSuite) TestControllerModelBadCreds(c *gc.C) {
 	assertInvalidEntityPassword(c, err)
 }
 
+func (s *loginSuite) addAPIToken(c *gc.C, srv *apiserver.Server, facades ...string) (*state.APIToken, *macaroon.Macaroon) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:   "fred",
+		Access: permission.WriteAccess,
+	})
+	token, err := s.State.AddAPIToken(state.AddAPITokenArgs{
+		User:      user.UserTag(),
+		Model:     s.State.ModelTag(),
+		Access:    permission.ReadAccess,
+		Facades:   facades,
+		Expires:   time.Now().Add(time.Hour),
+		CreatedBy: user.UserTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := apiserver.ServerMintAPIToken(srv, token.ID(), user.UserTag(), token.Expires())
+	c.Assert(err, jc.ErrorIsNil)
+	return token, m
+}
+
+func (s *loginSuite) TestLoginWithAPIToken(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	token, m := s.addAPIToken(c, srv, "Client")
+
+	info.ModelTag = s.State.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	err := st.Login(token.User(), "", "", []macaroon.Slice{{m}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The user's write access is limited to the token's read access.
+	c.Assert(st.ModelAccess(), gc.Equals, "read")
+	c.Assert(st.ControllerAccess(), gc.Equals, "login")
+
+	var statusResult params.FullStatus
+	err = st.APICall("Client", 1, "", "FullStatus", params.StatusParams{}, &statusResult)
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.APICall("Application", 3, "", "Deploy", params.ApplicationsDeploy{}, nil)
+	c.Assert(err, gc.ErrorMatches, `facade "Application" not supported for API token.*`)
+}
+
+func (s *loginSuite) TestLoginWithRemovedAPIToken(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	token, m := s.addAPIToken(c, srv)
+	err := s.State.RemoveAPIToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.ModelTag = s.State.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	err = st.Login(token.User(), "", "", []macaroon.Slice{{m}})
+	assertInvalidEntityPassword(c, err)
+}
+
+func (s *loginSuite) TestLoginWithAPITokenOtherModel(c *gc.C) {
+	info, srv := newServer(c, s.State)
+	defer assertStop(c, srv)
+	token, m := s.addAPIToken(c, srv)
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	_, err := otherState.AddModelUser(otherState.ModelUUID(), state.UserAccessSpec{
+		User:      token.User(),
+		CreatedBy: s.AdminUserTag(c),
+		Access:    permission.ReadAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.ModelTag = otherState.ModelTag()
+	st := s.openAPIWithoutLogin(c, info)
+	err = st.Login(token.User(), "", "", []macaroon.Slice{{m}})
+	assertPermissionDenied(c, err)
+}
+
 func (s *loginSuite) TestNonExistentModel(c *gc.C) {
 	info, srv := newServer(c, s.State)
 	defer assertStop(c, srv)
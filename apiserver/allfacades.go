@@ -45,6 +45,7 @@ import (
 	_ "github.com/juju/juju/apiserver/machineactions"
 	_ "github.com/juju/juju/apiserver/machinemanager" // ModelUser Write
 	_ "github.com/juju/juju/apiserver/machineundertaker"
+	_ "github.com/juju/juju/apiserver/maintenanceflag"
 	_ "github.com/juju/juju/apiserver/meterstatus"
 	_ "github.com/juju/juju/apiserver/metricsadder"
 	_ "github.com/juju/juju/apiserver/metricsdebug" // ModelUser Write
@@ -61,6 +62,7 @@ import (
 	_ "github.com/juju/juju/apiserver/remoterelations"
 	_ "github.com/juju/juju/apiserver/resumer"
 	_ "github.com/juju/juju/apiserver/retrystrategy"
+	_ "github.com/juju/juju/apiserver/rollingupgrader"
 	_ "github.com/juju/juju/apiserver/singular"
 	_ "github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	_ "github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 	"golang.org/x/net/websocket"
@@ -59,6 +60,8 @@ type Server struct {
 	dataDir           string
 	logDir            string
 	limiter           utils.Limiter
+	rateLimiter       *apiRateLimiter
+	codecStats        *jsoncodec.Stats
 	validator         LoginValidator
 	adminAPIFactories map[int]adminAPIFactory
 	modelUUID         string
@@ -81,6 +84,10 @@ type Server struct {
 	// certDNSNames holds the DNS names associated with cert.
 	certDNSNames []string
 
+	// maintenance holds the current maintenance mode of the
+	// controller.
+	maintenance state.ControllerMaintenance
+
 	// registerIntrospectionHandlers is a function that will
 	// call a function with (path, http.Handler) tuples. This
 	// is to support registering the handlers underneath the
@@ -134,6 +141,14 @@ type ServerConfig struct {
 	// is to support registering the handlers underneath the
 	// "/introspection" prefix.
 	RegisterIntrospectionHandlers func(func(string, http.Handler))
+
+	// RateLimits holds the limits applied to the rate of API
+	// requests made by users.
+	RateLimits RateLimitConfig
+
+	// PrometheusRegisterer, if non-nil, is used to register the
+	// metrics that count API requests rejected by RateLimits.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 func (c *ServerConfig) Validate() error {
@@ -214,6 +229,19 @@ func newServer(s *state.State, lis net.Listener, cfg ServerConfig) (_ *Server, e
 	srv.tlsConfig = srv.newTLSConfig(cfg)
 	srv.lis = tls.NewListener(lis, srv.tlsConfig)
 
+	srv.rateLimiter, err = newAPIRateLimiter(srv.clock, cfg.RateLimits, cfg.PrometheusRegisterer)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create API rate limiter")
+	}
+	srv.codecStats = &jsoncodec.Stats{}
+	if cfg.PrometheusRegisterer != nil {
+		collector := newCodecStatsCollector(srv.codecStats)
+		cfg.PrometheusRegisterer.Unregister(collector)
+		if err := cfg.PrometheusRegisterer.Register(collector); err != nil {
+			return nil, errors.Annotate(err, "cannot register API codec metrics")
+		}
+	}
+
 	srv.authCtxt, err = newAuthContext(s)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -355,6 +383,12 @@ func (srv *Server) run() {
 		srv.tomb.Kill(srv.processModelRemovals())
 	}()
 
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.tomb.Kill(srv.processMaintenanceChanges())
+	}()
+
 	// for pat based handlers, they are matched in-order of being
 	// registered, first match wins. So more specific ones have to be
 	// registered first.
@@ -501,6 +535,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/schema", &schemaHandler{
+		ctxt: httpCtxt,
+	})
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -660,7 +697,10 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 }
 
 func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserver observer.Observer, host string) error {
-	codec := jsoncodec.NewWebsocket(wsConn)
+	codec := jsoncodec.NewWebsocketWithOptions(wsConn, jsoncodec.WebsocketOptions{
+		Compress: wsConn.Request().Header.Get(jsoncodec.CompressionHeader) == jsoncodec.DeflateCompression,
+		Stats:    srv.codecStats,
+	})
 
 	conn := rpc.NewConn(codec, apiObserver)
 
@@ -763,6 +803,47 @@ func (srv *Server) processCertChanges() error {
 	}
 }
 
+// processMaintenanceChanges tracks the maintenance mode of the
+// controller, so that API calls can be checked against it without
+// consulting the database.
+func (srv *Server) processMaintenanceChanges() error {
+	w := srv.state.WatchControllerMaintenance()
+	defer w.Stop()
+	for {
+		select {
+		case <-srv.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-w.Changes():
+			if !ok {
+				return errors.New("controller maintenance watcher closed")
+			}
+			maintenance, err := srv.state.ControllerMaintenance()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if maintenance.Enabled {
+				logger.Infof("controller is in maintenance mode: %s", maintenance.Message)
+			}
+			srv.mu.Lock()
+			srv.maintenance = maintenance
+			srv.mu.Unlock()
+		}
+	}
+}
+
+// checkMaintenance is a check function for restrictRoot that blocks
+// API calls that might change the controller's models while the
+// controller is in maintenance mode.
+func (srv *Server) checkMaintenance(facadeName, methodName string) error {
+	srv.mu.Lock()
+	maintenance := srv.maintenance
+	srv.mu.Unlock()
+	if !maintenance.Enabled {
+		return nil
+	}
+	return maintenanceMethodsOnly(maintenance.Message)(facadeName, methodName)
+}
+
 // updateCertificate updates the current CA certificate and key
 // from the given cert and key.
 func (srv *Server) updateCertificate(cert, key string) error {
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// entityAPIToken returns the API token used to authenticate the
+// entity, or nil if the entity did not authenticate with an API
+// token. An error satisfying common.ErrBadCreds is returned if the
+// token has been removed or has expired, and common.ErrPerm if the
+// token may not be used with the model of the state.
+func entityAPIToken(st *state.State, entity state.Entity) (*state.APIToken, error) {
+	tokenEntity, ok := entity.(*authentication.APITokenEntity)
+	if !ok {
+		return nil, nil
+	}
+	token, err := st.APIToken(tokenEntity.TokenID)
+	if errors.IsNotFound(err) {
+		logger.Debugf("API token %q has been removed", tokenEntity.TokenID)
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if token.Expired(st.NowToTheSecond()) {
+		logger.Debugf("API token %q has expired", token.ID())
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if token.User() != entity.Tag() {
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if token.ModelTag() != st.ModelTag() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return token, nil
+}
+
+// limitAPITokenAccess limits the access the user of an API token has on
+// the target to that granted by the token: login access to the
+// controller, and no more than the token's access to its model.
+func limitAPITokenAccess(
+	token *state.APIToken, subject names.UserTag, target names.Tag, access permission.UserAccess,
+) (permission.UserAccess, error) {
+	if subject != token.User() {
+		// The access of other users is not affected by the token.
+		return access, nil
+	}
+	switch target.Kind() {
+	case names.ControllerTagKind:
+		if access.Access.GreaterControllerAccessThan(permission.LoginAccess) {
+			access.Access = permission.LoginAccess
+		}
+	case names.ModelTagKind:
+		if target != token.ModelTag() {
+			return permission.UserAccess{}, errors.NotFoundf("access to %s with API token", names.ReadableString(target))
+		}
+		if access.Access.GreaterModelAccessThan(token.Access()) {
+			access.Access = token.Access()
+		}
+	default:
+		return permission.UserAccess{}, errors.NotFoundf("access to %s with API token", names.ReadableString(target))
+	}
+	return access, nil
+}
+
+// apiTokenMinterResource makes the MintAPIToken method of the
+// authContext available to facades as a resource.
+type apiTokenMinterResource struct {
+	*authContext
+}
+
+// Stop implements facade.Resource.
+func (apiTokenMinterResource) Stop() error {
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
@@ -29,6 +30,7 @@ import (
 	jjj "github.com/juju/juju/juju"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.application")
@@ -42,6 +44,19 @@ func init() {
 
 	// Version 3 adds support for cross model relations.
 	common.RegisterStandardFacade("Application", 3, newAPI)
+
+	// Version 4 adds support for rolling charm upgrades.
+	common.RegisterStandardFacade("Application", 4, newAPI)
+
+	// Version 5 adds support for charm history and rollback.
+	common.RegisterStandardFacade("Application", 5, newAPI)
+
+	// Version 6 adds support for exposing endpoints to specific
+	// spaces and CIDRs.
+	common.RegisterStandardFacade("Application", 6, newAPI)
+
+	// Version 7 adds SetEndpointBindings.
+	common.RegisterStandardFacade("Application", 7, newAPI)
 }
 
 // API implements the application interface and is the concrete
@@ -289,7 +304,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 		return err
 	}
 	if !args.ForceCharmURL {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -313,6 +328,7 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			state.RollingUpgradeArgs{},
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -347,7 +363,7 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 	}
 	// when forced units in error, don't block
 	if !args.ForceUnits {
-		if err := api.check.ChangeAllowed(); err != nil {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -367,9 +383,228 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		state.RollingUpgradeArgs{
+			BatchSize: args.BatchSize,
+			WaitFor:   status.Status(args.WaitFor),
+		},
 	)
 }
 
+// ResumeRollingUpgrade resumes the paused rolling charm upgrades of
+// the specified applications.
+func (api *API) ResumeRollingUpgrade(args params.Entities) (params.ErrorResults, error) {
+	return api.updateRollingUpgrades(args, RollingUpgrade.Resume)
+}
+
+// AbortRollingUpgrade stops the rolling charm upgrades of the
+// specified applications. Units that have not yet been upgraded keep
+// the charm they are running.
+func (api *API) AbortRollingUpgrade(args params.Entities) (params.ErrorResults, error) {
+	return api.updateRollingUpgrades(args, RollingUpgrade.Abort)
+}
+
+func (api *API) updateRollingUpgrades(
+	args params.Entities, update func(RollingUpgrade) error,
+) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := api.updateRollingUpgrade(entity.Tag, update)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) updateRollingUpgrade(tagString string, update func(RollingUpgrade) error) error {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	upgrade, err := app.RollingUpgrade()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(update(upgrade))
+}
+
+// CharmHistory returns the charms that the specified applications ran
+// before their most recent charm changes, most recent first.
+func (api *API) CharmHistory(args params.Entities) (params.CharmHistoryResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmHistoryResults{}, err
+	}
+	results := params.CharmHistoryResults{
+		Results: make([]params.CharmHistoryResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		history, err := api.charmHistory(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].History = history
+	}
+	return results, nil
+}
+
+func (api *API) charmHistory(tagString string) ([]params.CharmHistoryEntry, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := app.CharmHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.CharmHistoryEntry, len(history))
+	for i, entry := range history {
+		resources, err := entry.Resources()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var paramsResources []params.CharmHistoryResource
+		for _, res := range resources {
+			paramsResources = append(paramsResources, params.CharmHistoryResource{
+				Name:     res.Name,
+				Origin:   res.Origin.String(),
+				Revision: res.Revision,
+			})
+		}
+		result[i] = params.CharmHistoryEntry{
+			CharmURL:  entry.CharmURL().String(),
+			Channel:   string(entry.Channel()),
+			Config:    entry.Config(),
+			Resources: paramsResources,
+			Replaced:  entry.Replaced(),
+		}
+	}
+	return result, nil
+}
+
+// RollbackCharm sets the charm of an application back to one recorded
+// in its charm history, along with the config and charm store resources
+// that were in use at the time.
+func (api *API) RollbackCharm(args params.ApplicationRollbackCharm) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	// when forced units in error, don't block
+	if !args.ForceUnits {
+		if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	history, err := app.CharmHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entry, err := findCharmHistoryEntry(args.ApplicationName, history, args.Revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sch, err := api.backend.Charm(entry.CharmURL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resourceIDs, err := api.rollbackResources(args.ApplicationName, entry)
+	if err != nil {
+		return errors.Annotate(err, "restoring resources")
+	}
+	return app.SetCharm(state.SetCharmConfig{
+		Charm:          api.stateCharm(sch),
+		Channel:        entry.Channel(),
+		ConfigSettings: entry.Config(),
+		// The application has run this charm before, so any
+		// series mismatch was already accepted.
+		ForceSeries: true,
+		ForceUnits:  args.ForceUnits,
+		ResourceIDs: resourceIDs,
+	})
+}
+
+// findCharmHistoryEntry returns the most recent entry in the history
+// whose charm has the specified revision, or the most recent entry if
+// no revision is specified.
+func findCharmHistoryEntry(
+	appName string, history []*state.CharmHistoryEntry, revision *int,
+) (*state.CharmHistoryEntry, error) {
+	if len(history) == 0 {
+		return nil, errors.NotFoundf("charm history for application %q", appName)
+	}
+	if revision == nil {
+		return history[0], nil
+	}
+	for _, entry := range history {
+		if entry.CharmURL().Revision == *revision {
+			return entry, nil
+		}
+	}
+	return nil, errors.NotFoundf("revision %d in charm history for application %q", *revision, appName)
+}
+
+// rollbackResources adds pending resources for the charm store
+// resources recorded in the history entry that differ from those the
+// application currently uses, and returns their pending IDs. Uploaded
+// resources cannot be restored, as their content is not retained.
+func (api *API) rollbackResources(appName string, entry *state.CharmHistoryEntry) (map[string]string, error) {
+	recorded, err := entry.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(recorded) == 0 {
+		return nil, nil
+	}
+	resources, err := api.backend.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current, err := resources.ListResources(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	currentRevisions := make(map[string]int)
+	for _, res := range current.Resources {
+		if res.Origin == charmresource.OriginStore {
+			currentRevisions[res.Name] = res.Revision
+		}
+	}
+	userID := api.authorizer.GetAuthTag().Id()
+	resourceIDs := make(map[string]string)
+	for _, res := range recorded {
+		if res.Origin != charmresource.OriginStore {
+			continue
+		}
+		if revision, ok := currentRevisions[res.Name]; ok && revision == res.Revision {
+			continue
+		}
+		pendingID, err := resources.AddPendingResource(appName, userID, res, nil)
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %q", res.Name)
+		}
+		resourceIDs[res.Name] = pendingID
+	}
+	return resourceIDs, nil
+}
+
 // applicationSetCharm sets the charm for the given for the application.
 func (api *API) applicationSetCharm(
 	appName string,
@@ -382,6 +617,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rollingUpgrade state.RollingUpgradeArgs,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -422,6 +658,7 @@ func (api *API) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		RollingUpgrade:     rollingUpgrade,
 	}
 	return application.SetCharm(cfg)
 }
@@ -504,7 +741,7 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(p.ApplicationName)
@@ -530,7 +767,7 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(p.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(p.ApplicationName)
@@ -572,14 +809,24 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposed := make(map[string]state.ExposedEndpoint, len(args.ExposedEndpoints))
+	for endpoint, settings := range args.ExposedEndpoints {
+		exposed[endpoint] = state.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposed)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -588,7 +835,7 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
@@ -598,6 +845,22 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return app.ClearExposed()
 }
 
+// SetEndpointBindings binds the given endpoints of an application to
+// the given spaces, leaving the bindings of other endpoints unchanged.
+func (api *API) SetEndpointBindings(args params.ApplicationSetEndpointBindings) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return err
+	}
+	return app.SetEndpointBindings(args.Bindings)
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, args params.AddApplicationUnits) ([]*state.Unit, error) {
 	application, err := backend.Application(args.ApplicationName)
@@ -615,7 +878,7 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	if err := api.checkCanWrite(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
 	units, err := addApplicationUnits(api.backend, args)
@@ -634,7 +897,7 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(unitApplicationTags(args.UnitNames)...); err != nil {
 		return errors.Trace(err)
 	}
 	var errs []string
@@ -658,6 +921,20 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	return common.DestroyErr("units", args.UnitNames, errs)
 }
 
+// unitApplicationTags returns the tags of the applications of the
+// named units, ignoring any invalid unit names.
+func unitApplicationTags(unitNames []string) []names.Tag {
+	var tags []names.Tag
+	for _, name := range unitNames {
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, names.NewApplicationTag(appName))
+	}
+	return tags
+}
+
 type appDestroy interface {
 	Destroy() (err error)
 }
@@ -667,7 +944,7 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.RemoveAllowed(); err != nil {
+	if err := api.check.RemoveAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	var (
@@ -702,7 +979,7 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
 	}
-	if err := api.check.ChangeAllowed(); err != nil {
+	if err := api.check.ChangeAllowedFor(names.NewApplicationTag(args.ApplicationName)); err != nil {
 		return errors.Trace(err)
 	}
 	app, err := api.backend.Application(args.ApplicationName)
@@ -881,6 +1158,10 @@ func (api *API) processRemoteApplication(url jujucrossmodel.ApplicationURL, alia
 		return api.processSameControllerRemoteApplication(url, alias)
 	}
 
+	// To consume an application offer, the user needs consume access to it.
+	if err := api.checkOfferAccess(url, permission.ConsumeAccess); err != nil {
+		return nil, names.ModelTag{}, errors.Trace(err)
+	}
 	offersAPI, err := api.applicationOffersAPIFactory.ApplicationOffers(url.Directory)
 	if err != nil {
 		return nil, names.ModelTag{}, errors.Trace(err)
@@ -911,6 +1192,39 @@ func (api *API) processRemoteApplication(url jujucrossmodel.ApplicationURL, alia
 	return remoteApp, sourceModelTag, err
 }
 
+// checkOfferAccess returns an error unless the authenticated user is a
+// controller superuser, or has been granted at least the specified access
+// to the application offer at the specified URL.
+func (api *API) checkOfferAccess(url jujucrossmodel.ApplicationURL, perm permission.Access) error {
+	if url.Directory != "local" {
+		// Access to offers hosted elsewhere is checked by the
+		// relevant application directory.
+		return nil
+	}
+	isControllerAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isControllerAdmin {
+		return nil
+	}
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	access, err := api.backend.GetOfferAccess(url.String(), user)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !access.EqualOrGreaterOfferAccessThan(perm) {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 func (api *API) sameControllerSourceModel(userName, modelName string) (names.ModelTag, error) {
 	// Look up the model by qualified name, ie user/model.
 	var sourceModelTag names.ModelTag
@@ -1061,6 +1375,10 @@ func (api *API) oneRemoteApplicationInfo(urlStr string) (*params.RemoteApplicati
 		return api.sameControllerRemoteApplicationInfo(*url)
 	}
 
+	// To see the details of an application offer, the user needs read access to it.
+	if err := api.checkOfferAccess(*url, permission.ReadAccess); err != nil {
+		return nil, errors.Trace(err)
+	}
 	offersAPI, err := api.applicationOffersAPIFactory.ApplicationOffers(url.Directory)
 	if err != nil {
 		return nil, errors.Trace(err)
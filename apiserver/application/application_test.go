@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -757,6 +758,148 @@ func (s *serviceSuite) TestBlockServiceSetCharmForce(c *gc.C) {
 	s.assertServiceSetCharm(c, true)
 }
 
+func (s *serviceSuite) TestServiceSetCharmRollingUpgrade(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        "cs:~who/precise/wordpress-3",
+		BatchSize:       2,
+		WaitFor:         "active",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := app.RollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.ToCharmURL().String(), gc.Equals, "cs:~who/precise/wordpress-3")
+	c.Assert(upgrade.BatchSize(), gc.Equals, 2)
+	c.Assert(upgrade.WaitFor(), gc.Equals, status.Active)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+}
+
+func (s *serviceSuite) TestServiceSetCharmRollingUpgradeInvalidWaitFor(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        "cs:~who/precise/wordpress-3",
+		BatchSize:       2,
+		WaitFor:         "bouncing",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*wait-for status "bouncing" not valid`)
+}
+
+func (s *serviceSuite) TestResumeAndAbortRollingUpgrade(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        "cs:~who/precise/wordpress-3",
+		BatchSize:       1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "application-application"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-application-0"},
+	}}
+	results, err := s.applicationAPI.ResumeRollingUpgrade(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "cannot resume running rolling upgrade")
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-application-0" is not a valid application tag`)
+
+	results, err = s.applicationAPI.AbortRollingUpgrade(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := app.RollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeAborted)
+}
+
+func (s *serviceSuite) TestBlockChangesAbortRollingUpgrade(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesAbortRollingUpgrade")
+	_, err := s.applicationAPI.AbortRollingUpgrade(params.Entities{
+		Entities: []params.Entity{{Tag: "application-application"}},
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesAbortRollingUpgrade")
+}
+
+func (s *serviceSuite) TestCharmHistory(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        "cs:~who/precise/wordpress-3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.CharmHistory(params.Entities{Entities: []params.Entity{
+		{Tag: "application-application"},
+		{Tag: "application-wordpress"},
+		{Tag: "unit-application-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	history := results.Results[0].History
+	c.Assert(history, gc.HasLen, 1)
+	c.Assert(history[0].CharmURL, gc.Equals, "cs:~who/precise/dummy-0")
+	c.Assert(history[0].Replaced.IsZero(), jc.IsFalse)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-application-0" is not a valid application tag`)
+}
+
+func (s *serviceSuite) TestRollbackCharm(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "application",
+		CharmURL:        "cs:~who/precise/wordpress-3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	revision := 3
+	err = s.applicationAPI.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+		Revision:        &revision,
+	})
+	c.Assert(err, gc.ErrorMatches, `revision 3 in charm history for application "application" not found`)
+
+	err = s.applicationAPI.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("application")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:~who/precise/dummy-0")
+
+	// The charm that was rolled back from is now in the history.
+	history, err := app.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].CharmURL().String(), gc.Equals, "cs:~who/precise/wordpress-3")
+}
+
+func (s *serviceSuite) TestRollbackCharmNoHistory(c *gc.C) {
+	s.setupServiceSetCharm(c)
+	err := s.applicationAPI.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	c.Assert(err, gc.ErrorMatches, `charm history for application "application" not found`)
+}
+
+func (s *serviceSuite) TestBlockChangesRollbackCharm(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockChangesRollbackCharm")
+	err := s.applicationAPI.RollbackCharm(params.ApplicationRollbackCharm{
+		ApplicationName: "application",
+	})
+	s.AssertBlocked(c, err, "TestBlockChangesRollbackCharm")
+}
+
 func (s *serviceSuite) TestServiceSetCharmInvalidService(c *gc.C) {
 	err := s.applicationAPI.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "badservice",
@@ -1832,7 +1975,7 @@ func (s *serviceSuite) TestServiceExpose(c *gc.C) {
 	c.Assert(svcs[1].IsExposed(), jc.IsTrue)
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1884,7 +2027,7 @@ var serviceExposeTests = []struct {
 func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1899,7 +2042,7 @@ func (s *serviceSuite) assertServiceExpose(c *gc.C) {
 func (s *serviceSuite) assertServiceExposeBlocked(c *gc.C, msg string) {
 	for i, t := range serviceExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.service})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.service})
 		s.AssertBlocked(c, err, msg)
 	}
 }
@@ -1957,7 +2100,7 @@ func (s *serviceSuite) TestServiceUnexpose(c *gc.C) {
 			svc.SetExposed()
 		}
 		c.Assert(svc.IsExposed(), gc.Equals, t.initial)
-		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{t.service})
+		err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: t.service})
 		if t.err == "" {
 			c.Assert(err, jc.ErrorIsNil)
 			svc.Refresh()
@@ -1970,6 +2113,76 @@ func (s *serviceSuite) TestServiceUnexpose(c *gc.C) {
 	}
 }
 
+func (s *serviceSuite) TestServiceExposeEndpoints(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	svc := s.AddTestingService(c, "wordpress", charm)
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsTrue)
+	c.Assert(svc.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	// Settings for individual endpoints are rejected.
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"admin-api": {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "wordpress": exposing endpoint "admin-api" on its own not supported: opened ports are not associated with endpoints`)
+
+	err = s.applicationAPI.Unexpose(params.ApplicationUnexpose{
+		ApplicationName: "wordpress",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = svc.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.IsExposed(), jc.IsFalse)
+}
+
+func (s *serviceSuite) TestSetEndpointBindings(c *gc.C) {
+	_, err := s.State.AddSpace("db", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	svc := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "mysql",
+		Bindings:        map[string]string{"server": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := svc.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+
+	err = s.applicationAPI.SetEndpointBindings(params.ApplicationSetEndpointBindings{
+		ApplicationName: "mysql",
+		Bindings:        map[string]string{"server": "missing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown space "missing" not valid`)
+}
+
+func (s *serviceSuite) TestServiceExposeUnknownEndpoint(c *gc.C) {
+	charm := s.AddTestingCharm(c, "wordpress")
+	s.AddTestingService(c, "wordpress", charm)
+
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "wordpress",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"bogus": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "wordpress": endpoint "bogus" not found`)
+}
+
 func (s *serviceSuite) setupServiceUnexpose(c *gc.C) *state.Application {
 	charm := s.AddTestingCharm(c, "dummy")
 	svc := s.AddTestingService(c, "dummy-service", charm)
@@ -1979,7 +2192,7 @@ func (s *serviceSuite) setupServiceUnexpose(c *gc.C) *state.Application {
 }
 
 func (s *serviceSuite) assertServiceUnexpose(c *gc.C, svc *state.Application) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-service"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-service"})
 	c.Assert(err, jc.ErrorIsNil)
 	svc.Refresh()
 	c.Assert(svc.IsExposed(), gc.Equals, false)
@@ -1988,7 +2201,7 @@ func (s *serviceSuite) assertServiceUnexpose(c *gc.C, svc *state.Application) {
 }
 
 func (s *serviceSuite) assertServiceUnexposeBlocked(c *gc.C, svc *state.Application, msg string) {
-	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{"dummy-service"})
+	err := s.applicationAPI.Unexpose(params.ApplicationUnexpose{ApplicationName: "dummy-service"})
 	s.AssertBlocked(c, err, msg)
 	err = svc.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2961,6 +3174,40 @@ func (s *serviceSuite) TestConsumeDirectAndURL(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *serviceSuite) TestConsumeRequiresOfferAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred"}).UserTag()
+	authorizer := apiservertesting.FakeAuthorizer{Tag: user, HasWriteTag: user}
+	resources := common.NewResources()
+	resources.RegisterNamed("applicationOffersApiFactory", s.offersApiFactory)
+	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
+	api, err := application.NewAPI(
+		application.NewStateBackend(s.State), authorizer, resources,
+		common.NewBlockChecker(s.State), application.CharmToStateCharm,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.offersApiFactory.offers = remoteOffers()
+
+	consume := func() *params.Error {
+		results, err := api.Consume(params.ConsumeApplicationArgs{
+			Args: []params.ConsumeApplicationArg{{ApplicationURL: "local:/u/me/hosted-mysql"}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		return results.Results[0].Error
+	}
+	c.Assert(consume(), gc.ErrorMatches, "permission denied")
+
+	err = s.State.CreateOfferAccess("local:/u/me/hosted-mysql", user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(consume(), gc.ErrorMatches, "permission denied")
+
+	err = s.State.UpdateOfferAccess("local:/u/me/hosted-mysql", user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(consume(), gc.IsNil)
+	_, err = s.State.RemoteApplication("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *serviceSuite) TestConsumeAlreadyExists(c *gc.C) {
 	_, err := s.otherModel.AddApplication(state.AddApplicationArgs{
 		Name:  "mysql",
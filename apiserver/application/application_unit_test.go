@@ -131,6 +131,19 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCall(c, 0, "ChangeAllowedFor", []names.Tag{
+		names.NewApplicationTag("postgresql"),
+	})
+	s.application.CheckNoCalls(c)
+}
+
 type mockBackend struct {
 	application.Backend
 	testing.Stub
@@ -201,3 +214,13 @@ func (c *mockBlockChecker) RemoveAllowed() error {
 	c.MethodCall(c, "RemoveAllowed")
 	return c.NextErr()
 }
+
+func (c *mockBlockChecker) ChangeAllowedFor(targets ...names.Tag) error {
+	c.MethodCall(c, "ChangeAllowedFor", targets)
+	return c.NextErr()
+}
+
+func (c *mockBlockChecker) RemoveAllowedFor(targets ...names.Tag) error {
+	c.MethodCall(c, "RemoveAllowedFor", targets)
+	return c.NextErr()
+}
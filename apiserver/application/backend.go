@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -28,12 +29,15 @@ type Backend interface {
 	AssignUnit(*state.Unit, state.AssignmentPolicy) error
 	AssignUnitWithPlacement(*state.Unit, *instance.Placement) error
 	Charm(*charm.URL) (Charm, error)
+	ControllerTag() names.ControllerTag
 	EndpointsRelation(...state.Endpoint) (Relation, error)
+	GetOfferAccess(offerURL string, user names.UserTag) (permission.Access, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	NewStorage() storage.Storage
+	Resources() (state.Resources, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -42,6 +46,8 @@ type Backend interface {
 type BlockChecker interface {
 	ChangeAllowed() error
 	RemoveAllowed() error
+	ChangeAllowedFor(...names.Tag) error
+	RemoveAllowedFor(...names.Tag) error
 }
 
 // Application defines a subset of the functionality provided by the
@@ -53,21 +59,34 @@ type Application interface {
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
 	Channel() csparams.Channel
+	CharmHistory() ([]*state.CharmHistoryEntry, error)
 	ClearExposed() error
 	ConfigSettings() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
 	Series() string
+	RollingUpgrade() (RollingUpgrade, error)
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEndpointBindings(map[string]string) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
 	UpdateConfigSettings(charm.Settings) error
 }
 
+// RollingUpgrade defines a subset of the functionality provided by the
+// state.RollingUpgrade type, as required by the application facade. For
+// details on the methods, see the methods on state.RollingUpgrade with
+// the same names.
+type RollingUpgrade interface {
+	Abort() error
+	Resume() error
+}
+
 // Charm defines a subset of the functionality provided by the
 // state.Charm type, as required by the application facade. For
 // details on the methods, see the methods on state.Charm with
@@ -206,6 +225,14 @@ func (a stateApplicationShim) Charm() (Charm, bool, error) {
 	return ch, force, nil
 }
 
+func (a stateApplicationShim) RollingUpgrade() (RollingUpgrade, error) {
+	upgrade, err := a.Application.RollingUpgrade()
+	if err != nil {
+		return nil, err
+	}
+	return upgrade, nil
+}
+
 type stateCharmShim struct {
 	*state.Charm
 }
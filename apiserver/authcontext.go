@@ -294,3 +294,9 @@ func (s *expirableStorageBakeryService) ExpireStorageAt(t time.Time) (authentica
 	}
 	return &expirableStorageBakeryService{service, s.key, store, s.locator}, nil
 }
+
+// MintAPIToken implements usermanager.APITokenMinter, creating the
+// macaroon for an API token using the local user bakery service.
+func (ctxt *authContext) MintAPIToken(id string, user names.UserTag, expiry time.Time) (*macaroon.Macaroon, error) {
+	return authentication.CreateAPITokenMacaroon(ctxt.localUserBakeryService, id, user, expiry)
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery/checkers"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/state"
+)
+
+// apiTokenKey is the key of the declared caveat that holds the ID of
+// the API token a macaroon was minted for.
+const apiTokenKey = "api-token"
+
+// APITokenEntity is the entity returned by UserAuthenticator when a
+// user logs in with an API token rather than with their own
+// credentials. The access of such a login is limited to that recorded
+// for the token in state.
+type APITokenEntity struct {
+	state.Entity
+
+	// TokenID holds the ID of the API token used to log in.
+	TokenID string
+}
+
+// CreateAPITokenMacaroon creates a macaroon that allows the specified
+// local user to log in, until the expiry time, with the access granted
+// by the API token with the specified ID. The root key of the macaroon
+// is removed from storage when the token expires.
+func CreateAPITokenMacaroon(
+	service ExpirableStorageBakeryService,
+	tokenID string,
+	user names.UserTag,
+	expiry time.Time,
+) (*macaroon.Macaroon, error) {
+	if !user.IsLocal() {
+		return nil, errors.NotValidf("non-local user %q", user.Id())
+	}
+	service, err := service.ExpireStorageAt(expiry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	m, err := service.NewMacaroon("", nil, []checkers.Caveat{
+		checkers.DeclaredCaveat(usernameKey, user.Id()),
+		checkers.DeclaredCaveat(apiTokenKey, tokenID),
+		checkers.TimeBeforeCaveat(expiry),
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create API token macaroon")
+	}
+	return m, nil
+}
@@ -153,7 +153,7 @@ func (u *UserAuthenticator) authenticateMacaroons(
 ) (state.Entity, error) {
 	// Check for a valid request macaroon.
 	assert := map[string]string{usernameKey: tag.Id()}
-	_, err := u.Service.CheckAny(req.Macaroons, assert, checkers.New(checkers.TimeBefore))
+	declared, err := u.Service.CheckAny(req.Macaroons, assert, checkers.New(checkers.TimeBefore))
 	if err != nil {
 		cause := err
 		logger.Debugf("local-login macaroon authentication failed: %v", cause)
@@ -194,6 +194,12 @@ func (u *UserAuthenticator) authenticateMacaroons(
 	} else if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if tokenID := declared[apiTokenKey]; tokenID != "" {
+		// The macaroon was minted for an API token, so the
+		// caller must limit the access of the login to that
+		// of the token.
+		return &APITokenEntity{Entity: entity, TokenID: tokenID}, nil
+	}
 	return entity, nil
 }
 
@@ -213,8 +213,56 @@ func (s *userAuthenticatorSuite) TestAuthenticateLocalLoginMacaroon(c *gc.C) {
 	})
 }
 
+func (s *userAuthenticatorSuite) TestAPITokenMacaroonUserLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name: "bobbrown",
+	})
+	service := mockBakeryService{
+		declared: map[string]string{
+			"username":  "bobbrown",
+			"api-token": "deadbeef",
+		},
+	}
+
+	authenticator := &authentication.UserAuthenticator{Service: &service}
+	entity, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Macaroons: []macaroon.Slice{{&macaroon.Macaroon{}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tokenEntity, ok := entity.(*authentication.APITokenEntity)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(tokenEntity.TokenID, gc.Equals, "deadbeef")
+	c.Assert(tokenEntity.Tag(), gc.Equals, user.Tag())
+}
+
+func (s *userAuthenticatorSuite) TestCreateAPITokenMacaroon(c *gc.C) {
+	service := mockBakeryService{}
+	expiry := time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC)
+	_, err := authentication.CreateAPITokenMacaroon(
+		&service, "deadbeef", names.NewUserTag("bobbrown"), expiry,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	service.CheckCallNames(c, "ExpireStorageAt", "NewMacaroon")
+	service.CheckCall(c, 0, "ExpireStorageAt", expiry)
+	service.CheckCall(c, 1, "NewMacaroon", "", []byte(nil), []checkers.Caveat{
+		checkers.DeclaredCaveat("username", "bobbrown"),
+		checkers.DeclaredCaveat("api-token", "deadbeef"),
+		{Condition: "time-before 2017-01-02T00:00:00Z"},
+	})
+}
+
+func (s *userAuthenticatorSuite) TestCreateAPITokenMacaroonExternalUser(c *gc.C) {
+	service := mockBakeryService{}
+	_, err := authentication.CreateAPITokenMacaroon(
+		&service, "deadbeef", names.NewUserTag("bob@external"), time.Now(),
+	)
+	c.Assert(err, gc.ErrorMatches, `non-local user "bob@external" not valid`)
+	service.CheckNoCalls(c)
+}
+
 type mockBakeryService struct {
 	testing.Stub
+	declared map[string]string
 }
 
 func (s *mockBakeryService) AddCaveat(m *macaroon.Macaroon, caveat checkers.Caveat) error {
@@ -224,7 +272,7 @@ func (s *mockBakeryService) AddCaveat(m *macaroon.Macaroon, caveat checkers.Cave
 
 func (s *mockBakeryService) CheckAny(ms []macaroon.Slice, assert map[string]string, checker checkers.Checker) (map[string]string, error) {
 	s.MethodCall(s, "CheckAny", ms, assert, checker)
-	return nil, s.NextErr()
+	return s.declared, s.NextErr()
 }
 
 func (s *mockBakeryService) NewMacaroon(id string, key []byte, caveats []checkers.Caveat) (*macaroon.Macaroon, error) {
@@ -5,6 +5,7 @@ package block
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -15,6 +16,10 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Block", 2, NewAPI)
+
+	// Version 3 adds support for blocks that target applications
+	// or machines, and for blocks that expire.
+	common.RegisterStandardFacade("Block", 3, NewAPI)
 }
 
 // Block defines the methods on the block API end point.
@@ -106,21 +111,60 @@ func convertBlock(b state.Block) params.BlockResult {
 		result.Error = common.ServerError(err)
 	}
 	result.Result = params.Block{
-		Id:      b.Id(),
-		Tag:     tag.String(),
-		Type:    b.Type().String(),
-		Message: b.Message(),
+		Id:        b.Id(),
+		Tag:       tag.String(),
+		Type:      b.Type().String(),
+		Message:   b.Message(),
+		CreatedBy: b.CreatedBy(),
+	}
+	targets, err := b.Targets()
+	if err != nil {
+		err := errors.Annotatef(err, "getting block %v", b.Type().String())
+		result.Error = common.ServerError(err)
+	}
+	for _, target := range targets {
+		result.Result.Targets = append(result.Result.Targets, target.String())
+	}
+	if expiry := b.Expiry(); !expiry.IsZero() {
+		result.Result.Expiry = &expiry
 	}
 	return result
 }
 
+func parseTargets(targets []string) ([]names.Tag, error) {
+	var tags []names.Tag
+	for _, target := range targets {
+		tag, err := names.ParseTag(target)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
 // SwitchBlockOn implements Block.SwitchBlockOn().
 func (a *API) SwitchBlockOn(args params.BlockSwitchParams) params.ErrorResult {
 	if err := a.checkCanWrite(); err != nil {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOn(state.ParseBlockType(args.Type), args.Message)
+	targets, err := parseTargets(args.Targets)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	blockArgs := state.BlockArgs{
+		Type:    state.ParseBlockType(args.Type),
+		Message: args.Message,
+		Targets: targets,
+	}
+	if args.Expiry != nil {
+		blockArgs.Expiry = *args.Expiry
+	}
+	if userTag, ok := a.authorizer.GetAuthTag().(names.UserTag); ok {
+		blockArgs.CreatedBy = userTag.Id()
+	}
+	err = a.access.SwitchBlockOnWithArgs(blockArgs)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
 
@@ -130,6 +174,10 @@ func (a *API) SwitchBlockOff(args params.BlockSwitchParams) params.ErrorResult {
 		return params.ErrorResult{Error: common.ServerError(err)}
 	}
 
-	err := a.access.SwitchBlockOff(state.ParseBlockType(args.Type))
+	targets, err := parseTargets(args.Targets)
+	if err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}
+	}
+	err = a.access.SwitchBlockOffForTargets(state.ParseBlockType(args.Type), targets)
 	return params.ErrorResult{Error: common.ServerError(err)}
 }
@@ -4,6 +4,8 @@
 package block_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -79,3 +81,43 @@ func (s *blockSuite) TestSwitchBlockOff(c *gc.C) {
 	c.Assert(err.Error, gc.IsNil)
 	s.assertBlockList(c, 0)
 }
+
+func (s *blockSuite) TestSwitchTargetedBlockOn(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	on := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Message: "keep mysql",
+		Targets: []string{"application-mysql"},
+		Expiry:  &expiry,
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.IsNil)
+
+	all, listErr := s.api.List()
+	c.Assert(listErr, jc.ErrorIsNil)
+	c.Assert(all.Results, gc.HasLen, 1)
+	result := all.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.Targets, jc.DeepEquals, []string{"application-mysql"})
+	c.Assert(result.Result.Expiry, gc.NotNil)
+	c.Assert(result.Result.Expiry.Equal(expiry), jc.IsTrue)
+	c.Assert(result.Result.CreatedBy, gc.Equals, s.AdminUserTag(c).Id())
+
+	off := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Targets: []string{"application-mysql"},
+	}
+	err = s.api.SwitchBlockOff(off)
+	c.Assert(err.Error, gc.IsNil)
+	s.assertBlockList(c, 0)
+}
+
+func (s *blockSuite) TestSwitchBlockOnInvalidTarget(c *gc.C) {
+	on := params.BlockSwitchParams{
+		Type:    state.RemoveBlock.String(),
+		Targets: []string{"unit-mysql-0"},
+	}
+	err := s.api.SwitchBlockOn(on)
+	c.Assert(err.Error, gc.ErrorMatches, `block target "unit-mysql-0" not valid`)
+	s.assertBlockList(c, 0)
+}
@@ -10,8 +10,8 @@ import (
 
 type blockAccess interface {
 	AllBlocks() ([]state.Block, error)
-	SwitchBlockOn(t state.BlockType, msg string) error
-	SwitchBlockOff(t state.BlockType) error
+	SwitchBlockOnWithArgs(args state.BlockArgs) error
+	SwitchBlockOffForTargets(t state.BlockType, targets []names.Tag) error
 	ModelTag() names.ModelTag
 }
 
@@ -432,7 +432,13 @@ func (c *Client) DestroyMachines(args params.DestroyMachines) error {
 		return err
 	}
 
-	if err := c.check.RemoveAllowed(); !args.Force && err != nil {
+	var machineTags []names.Tag
+	for _, id := range args.MachineNames {
+		if names.IsValidMachine(id) {
+			machineTags = append(machineTags, names.NewMachineTag(id))
+		}
+	}
+	if err := c.check.RemoveAllowedFor(machineTags...); !args.Force && err != nil {
 		return errors.Trace(err)
 	}
 
@@ -799,6 +799,47 @@ func (context *statusContext) processApplications() map[string]params.Applicatio
 	return applicationsMap
 }
 
+// processRollingUpgrade returns the progress of the application's
+// rolling charm upgrade, or nil if there is no unfinished one.
+func processRollingUpgrade(application *state.Application) (*params.RollingUpgradeStatus, error) {
+	upgrade, err := application.RollingUpgrade()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if upgrade.Status() == state.RollingUpgradeCompleted {
+		return nil, nil
+	}
+	return &params.RollingUpgradeStatus{
+		Status:    string(upgrade.Status()),
+		Message:   upgrade.Message(),
+		FromCharm: upgrade.FromCharmURL().String(),
+		ToCharm:   upgrade.ToCharmURL().String(),
+		BatchSize: upgrade.BatchSize(),
+		WaitFor:   string(upgrade.WaitFor()),
+		Released:  upgrade.Released(),
+		Updated:   upgrade.Updated(),
+	}, nil
+}
+
+// processExposedEndpoints returns the expose settings of the
+// application's endpoints, or nil if it has none.
+func processExposedEndpoints(application *state.Application) map[string]params.ExposedEndpoint {
+	exposed := application.ExposedEndpoints()
+	if len(exposed) == 0 {
+		return nil
+	}
+	result := make(map[string]params.ExposedEndpoint, len(exposed))
+	for endpoint, settings := range exposed {
+		result[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return result
+}
+
 func (context *statusContext) processApplication(application *state.Application) params.ApplicationStatus {
 	applicationCharm, _, err := application.Charm()
 	if err != nil {
@@ -806,10 +847,11 @@ func (context *statusContext) processApplication(application *state.Application)
 	}
 
 	var processedStatus = params.ApplicationStatus{
-		Charm:   applicationCharm.URL().String(),
-		Series:  application.Series(),
-		Exposed: application.IsExposed(),
-		Life:    processLife(application),
+		Charm:            applicationCharm.URL().String(),
+		Series:           application.Series(),
+		Exposed:          application.IsExposed(),
+		ExposedEndpoints: processExposedEndpoints(application),
+		Life:             processLife(application),
 	}
 
 	if latestCharm, ok := context.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
@@ -823,6 +865,11 @@ func (context *statusContext) processApplication(application *state.Application)
 		processedStatus.Err = common.ServerError(err)
 		return processedStatus
 	}
+	processedStatus.RollingUpgrade, err = processRollingUpgrade(application)
+	if err != nil {
+		processedStatus.Err = common.ServerError(err)
+		return processedStatus
+	}
 	units := context.units[application.Name()]
 	if application.IsPrincipal() {
 		processedStatus.Units = context.processUnits(units, applicationCharm.URL().String())
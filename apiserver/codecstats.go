@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+const (
+	directionLabel = "direction"
+	sentDirection  = "sent"
+	recvDirection  = "received"
+)
+
+var (
+	codecMessagesDesc = prometheus.NewDesc(
+		"juju_api_codec_messages_total",
+		"Number of Juju API messages sent and received.",
+		[]string{directionLabel}, nil,
+	)
+	codecCompressedMessagesDesc = prometheus.NewDesc(
+		"juju_api_codec_compressed_messages_total",
+		"Number of Juju API messages sent and received compressed.",
+		[]string{directionLabel}, nil,
+	)
+	codecPayloadBytesDesc = prometheus.NewDesc(
+		"juju_api_codec_payload_bytes_total",
+		"Size in bytes of the JSON encoding of Juju API messages.",
+		[]string{directionLabel}, nil,
+	)
+	codecWireBytesDesc = prometheus.NewDesc(
+		"juju_api_codec_wire_bytes_total",
+		"Size in bytes of Juju API messages after any compression.",
+		[]string{directionLabel}, nil,
+	)
+)
+
+// codecStatsCollector is a prometheus.Collector that reports the
+// traffic recorded by a jsoncodec.Stats.
+type codecStatsCollector struct {
+	stats *jsoncodec.Stats
+}
+
+func newCodecStatsCollector(stats *jsoncodec.Stats) *codecStatsCollector {
+	return &codecStatsCollector{stats: stats}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *codecStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- codecMessagesDesc
+	ch <- codecCompressedMessagesDesc
+	ch <- codecPayloadBytesDesc
+	ch <- codecWireBytesDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *codecStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	collect := func(direction string, traffic jsoncodec.Traffic) {
+		for _, m := range []struct {
+			desc  *prometheus.Desc
+			value uint64
+		}{
+			{codecMessagesDesc, traffic.Messages},
+			{codecCompressedMessagesDesc, traffic.CompressedMessages},
+			{codecPayloadBytesDesc, traffic.PayloadBytes},
+			{codecWireBytesDesc, traffic.WireBytes},
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				m.desc, prometheus.CounterValue, float64(m.value), direction,
+			)
+		}
+	}
+	collect(sentDirection, c.stats.Sent())
+	collect(recvDirection, c.stats.Received())
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/jsoncodec"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type codecStatsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&codecStatsSuite{})
+
+func (s *codecStatsSuite) TestCollector(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(newCodecStatsCollector(&jsoncodec.Stats{}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	names := make([]string, len(families))
+	for i, family := range families {
+		names[i] = family.GetName()
+		c.Assert(family.Metric, gc.HasLen, 2)
+		for _, metric := range family.Metric {
+			c.Assert(metric.GetCounter().GetValue(), gc.Equals, float64(0))
+		}
+	}
+	c.Assert(names, jc.SameContents, []string{
+		"juju_api_codec_messages_total",
+		"juju_api_codec_compressed_messages_total",
+		"juju_api_codec_payload_bytes_total",
+		"juju_api_codec_wire_bytes_total",
+	})
+}
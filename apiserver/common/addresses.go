@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
@@ -20,6 +21,7 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	ModelConfig() (*config.Config, error)
 }
 
 // APIAddresser implements the APIAddresses method
@@ -59,9 +61,14 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
 }
 
-// APIAddresses returns the list of addresses used to connect to the API.
+// APIAddresses returns the list of addresses used to connect to the API,
+// ordered to prefer the model's preferred-ip-family.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	cfg, err := api.getter.ModelConfig()
+	if err != nil {
+		return params.StringsResult{}, err
+	}
+	addrs, err := apiAddresses(api.getter, cfg.PreferredIPFamily())
 	if err != nil {
 		return params.StringsResult{}, err
 	}
@@ -70,14 +77,14 @@ func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
 	}, nil
 }
 
-func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
+func apiAddresses(getter APIHostPortsGetter, family network.IPFamily) ([]string, error) {
 	apiHostPorts, err := getter.APIHostPorts()
 	if err != nil {
 		return nil, err
 	}
 	var addrs = make([]string, 0, len(apiHostPorts))
 	for _, hostPorts := range apiHostPorts {
-		ordered := network.PrioritizeInternalHostPorts(hostPorts, false)
+		ordered := network.PrioritizeInternalHostPortsForFamily(hostPorts, false, family)
 		for _, addr := range ordered {
 			if addr != "" {
 				addrs = append(addrs, addr)
@@ -8,8 +8,10 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type stateAddresserSuite struct {
@@ -88,6 +90,20 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) TestAPIAddressesPreferredIPFamilyFirst(c *gc.C) {
+	ctlr, err := network.ParseHostPorts("10.0.2.1:17070", "[fc00::1]:17070")
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.hostPorts = [][]network.HostPort{ctlr}
+	s.fake.family = network.PreferIPv6
+
+	result, err := s.addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Result, gc.DeepEquals, []string{
+		"[fc00::1]:17070",
+		"10.0.2.1:17070",
+	})
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -102,6 +118,7 @@ var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
 	hostPorts [][]network.HostPort
+	family    network.IPFamily
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -127,3 +144,11 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (f fakeAddresses) ModelConfig() (*config.Config, error) {
+	attrs := coretesting.FakeConfig()
+	if f.family != "" {
+		attrs = attrs.Merge(coretesting.Attrs{config.PreferredIPFamilyKey: string(f.family)})
+	}
+	return config.New(config.NoDefaults, attrs)
+}
@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/state"
 )
@@ -13,6 +14,12 @@ type BlockGetter interface {
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 }
 
+// TargetedBlockGetter is implemented by BlockGetters that can also
+// find blocks that apply only to particular applications or machines.
+type TargetedBlockGetter interface {
+	GetBlockForTargets(t state.BlockType, targets []names.Tag) (state.Block, bool, error)
+}
+
 // BlockChecker checks for current blocks if any.
 type BlockChecker struct {
 	getter BlockGetter
@@ -40,6 +47,22 @@ func (c *BlockChecker) RemoveAllowed() error {
 	return c.checkBlock(state.ChangeBlock)
 }
 
+// ChangeAllowedFor checks if a change block is in place for the
+// model or for any of the given applications or machines.
+func (c *BlockChecker) ChangeAllowedFor(targets ...names.Tag) error {
+	return c.checkBlockFor(state.ChangeBlock, targets)
+}
+
+// RemoveAllowedFor checks if a remove or change block is in place
+// for the model or for any of the given applications or machines.
+func (c *BlockChecker) RemoveAllowedFor(targets ...names.Tag) error {
+	if err := c.checkBlockFor(state.RemoveBlock, targets); err != nil {
+		return err
+	}
+	// Check if change block has been enabled
+	return c.checkBlockFor(state.ChangeBlock, targets)
+}
+
 // DestroyAllowed checks if destroy block is in place.
 // Destroy block prevents destruction of current environment.
 func (c *BlockChecker) DestroyAllowed() error {
@@ -67,3 +90,21 @@ func (c *BlockChecker) checkBlock(blockType state.BlockType) error {
 	}
 	return nil
 }
+
+// checkBlockFor is like checkBlock, but also considers blocks that
+// apply only to the given targets. If the getter cannot find such
+// blocks, only model-wide blocks are checked.
+func (c *BlockChecker) checkBlockFor(blockType state.BlockType, targets []names.Tag) error {
+	getter, ok := c.getter.(TargetedBlockGetter)
+	if !ok || len(targets) == 0 {
+		return c.checkBlock(blockType)
+	}
+	aBlock, isEnabled, err := getter.GetBlockForTargets(blockType, targets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isEnabled {
+		return OperationBlockedError(aBlock.Message())
+	}
+	return nil
+}
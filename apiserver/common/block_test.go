@@ -98,3 +98,61 @@ func (s *blockCheckerSuite) assertErrorBlocked(c *gc.C, blocked bool, err error,
 		c.Assert(errors.Cause(err), jc.ErrorIsNil)
 	}
 }
+
+type targetedBlockCheckerSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	aBlock  state.Block
+	targets []names.Tag
+
+	blockchecker *common.BlockChecker
+}
+
+var _ = gc.Suite(&targetedBlockCheckerSuite{})
+
+func (s *targetedBlockCheckerSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.aBlock = mockBlock{t: state.RemoveBlock, m: "Mock BLOCK testing: REMOVE mysql"}
+	s.targets = []names.Tag{names.NewApplicationTag("mysql")}
+	s.blockchecker = common.NewBlockChecker(s)
+}
+
+func (mock *targetedBlockCheckerSuite) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	return nil, false, nil
+}
+
+func (mock *targetedBlockCheckerSuite) GetBlockForTargets(t state.BlockType, targets []names.Tag) (state.Block, bool, error) {
+	if mock.aBlock.Type() != t {
+		return nil, false, nil
+	}
+	for _, target := range targets {
+		for _, blocked := range mock.targets {
+			if target == blocked {
+				return mock.aBlock, true, nil
+			}
+		}
+	}
+	return nil, false, nil
+}
+
+func (s *targetedBlockCheckerSuite) TestRemoveAllowedFor(c *gc.C) {
+	err := s.blockchecker.RemoveAllowedFor(names.NewApplicationTag("mysql"))
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, s.aBlock.Message())
+
+	err = s.blockchecker.RemoveAllowedFor(names.NewApplicationTag("wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Model-wide checks ignore targeted blocks.
+	err = s.blockchecker.RemoveAllowed()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *targetedBlockCheckerSuite) TestChangeAllowedFor(c *gc.C) {
+	err := s.blockchecker.ChangeAllowedFor(names.NewApplicationTag("mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.aBlock = mockBlock{t: state.ChangeBlock, m: "Mock BLOCK testing: CHANGE mysql"}
+	err = s.blockchecker.ChangeAllowedFor(names.NewApplicationTag("mysql"))
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue)
+	c.Assert(err, gc.ErrorMatches, s.aBlock.Message())
+}
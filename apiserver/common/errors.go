@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/txn"
@@ -77,6 +78,22 @@ func IsDischargeRequiredError(err error) bool {
 	return ok
 }
 
+// RateLimitExceededError is the error returned when an API request is
+// rejected because the caller has made too many requests.
+type RateLimitExceededError struct {
+	// Limit describes the limit that was exceeded.
+	Limit string
+
+	// RetryAfter holds how long the caller should wait before
+	// making another request.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *RateLimitExceededError) Error() string {
+	return fmt.Sprintf("%s API rate limit exceeded, retry after %v", e.Limit, e.RetryAfter)
+}
+
 // IsUpgradeInProgress returns true if this error is caused
 // by an upgrade in progress.
 func IsUpgradeInProgressError(err error) bool {
@@ -113,6 +130,20 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// MaintenanceInProgressError returns an error which signifies that
+// an operation has been rejected because the controller is in
+// maintenance mode; the message should describe the maintenance.
+func MaintenanceInProgressError(msg string) error {
+	message := "controller is in maintenance mode"
+	if msg != "" {
+		message += ": " + msg
+	}
+	return &params.Error{
+		Message: message,
+		Code:    params.CodeMaintenanceInProgress,
+	}
+}
+
 var singletonErrorCodes = map[error]string{
 	state.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
 	state.ErrCannotEnterScope:    params.CodeCannotEnterScope,
@@ -182,8 +213,11 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 		status = http.StatusForbidden
 	case params.CodeDischargeRequired:
 		status = http.StatusUnauthorized
-	case params.CodeRetry:
+	case params.CodeRetry,
+		params.CodeMaintenanceInProgress:
 		status = http.StatusServiceUnavailable
+	case params.CodeRateLimitExceeded:
+		status = http.StatusTooManyRequests
 	}
 	return err1, status
 }
@@ -243,6 +277,13 @@ func ServerError(err error) *params.Error {
 			}
 			break
 		}
+		if err, ok := err.(*RateLimitExceededError); ok {
+			code = params.CodeRateLimitExceeded
+			info = &params.ErrorInfo{
+				RetryAfter: err.RetryAfter,
+			}
+			break
+		}
 		code = params.ErrCode(err)
 	}
 	return &params.Error{
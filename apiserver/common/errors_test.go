@@ -6,6 +6,7 @@ package common_test
 import (
 	stderrors "errors"
 	"net/http"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -181,6 +182,20 @@ var errorTransformTests = []struct {
 		}
 		return true
 	},
+}, {
+	err: &common.RateLimitExceededError{
+		Limit:      "user",
+		RetryAfter: 2 * time.Second,
+	},
+	status: http.StatusTooManyRequests,
+	code:   params.CodeRateLimitExceeded,
+	helperFunc: func(err error) bool {
+		err1, ok := err.(*params.Error)
+		if !ok || err1.Info == nil || err1.Info.RetryAfter != 2*time.Second {
+			return false
+		}
+		return true
+	},
 }, {
 	err:    unhashableError{"foo"},
 	status: http.StatusInternalServerError,
@@ -239,6 +254,7 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
+			params.CodeRateLimitExceeded,
 			params.CodeRetry:
 			continue
 		case params.CodeOperationBlocked:
@@ -109,6 +109,10 @@ func (s *stateShim) AllSpaces() ([]BackingSpace, error) {
 	return spaces, nil
 }
 
+func (s *stateShim) MoveSubnetsToSpace(spaceName string, cidrs []string, force bool) ([]string, error) {
+	return s.st.MoveSubnetsToSpace(spaceName, cidrs, force)
+}
+
 func (s *stateShim) AddSubnet(info BackingSubnetInfo) (BackingSubnet, error) {
 	// TODO(dimitern): Add multiple AZs per subnet in state.
 	var firstZone string
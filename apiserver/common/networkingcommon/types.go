@@ -116,6 +116,10 @@ type NetworkBacking interface {
 	// AllSpaces returns all known Juju network spaces.
 	AllSpaces() ([]BackingSpace, error)
 
+	// MoveSubnetsToSpace moves the given subnets into the named space,
+	// returning the names of the applications whose units are affected.
+	MoveSubnetsToSpace(spaceName string, cidrs []string, force bool) ([]string, error)
+
 	// AddSubnet creates a backing subnet for an existing subnet.
 	AddSubnet(BackingSubnetInfo) (BackingSubnet, error)
 
@@ -19,15 +19,16 @@ import (
 const EveryoneTagName = "everyone@external"
 
 // UserAccess returns the access the user has on the model state
-// and the host controller.
+// and the host controller, including any access inherited from the
+// groups of which the user is a member.
 func UserAccess(st *state.State, utag names.UserTag) (modelUser, controllerUser permission.UserAccess, err error) {
 	var none permission.UserAccess
-	modelUser, err = st.UserAccess(utag, st.ModelTag())
+	modelUser, err = st.EffectiveUserAccess(utag, st.ModelTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
 
-	controllerUser, err = state.ControllerAccess(st, utag)
+	controllerUser, err = st.EffectiveUserAccess(utag, st.ControllerTag())
 	if err != nil && !errors.IsNotFound(err) {
 		return none, none, errors.Trace(err)
 	}
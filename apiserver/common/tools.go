@@ -345,7 +345,7 @@ func NewToolsURLGetter(modelUUID string, a APIHostPortsGetter) *toolsURLGetter {
 }
 
 func (t *toolsURLGetter) ToolsURLs(v version.Binary) ([]string, error) {
-	addrs, err := apiAddresses(t.apiHostPortsGetter)
+	addrs, err := apiAddresses(t.apiHostPortsGetter, network.PreferIPv4)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,8 @@ type Controller interface {
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	ControllerMaintenance() (params.ControllerMaintenanceResult, error)
+	SetControllerMaintenance(params.ControllerMaintenance) error
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -352,7 +354,7 @@ func (c *ControllerAPI) GetControllerAccess(req params.Entities) (params.UserAcc
 			results.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		accessInfo, err := c.state.UserAccess(userTag, c.state.ControllerTag())
+		accessInfo, err := c.state.EffectiveUserAccess(userTag, c.state.ControllerTag())
 		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -619,6 +621,17 @@ func revokeControllerAccess(accessor *state.State, targetUserTag, apiUser names.
 // ChangeControllerAccess performs the requested access grant or revoke action for the
 // specified user on the controller.
 func ChangeControllerAccess(accessor *state.State, apiUser, targetUserTag names.UserTag, action params.ControllerAction, access permission.Access) error {
+	if targetUserTag.IsLocal() {
+		// Groups share the namespace of local users, so
+		// the target may be a group rather than a user.
+		_, err := accessor.Group(targetUserTag.Name())
+		if err == nil {
+			return changeGroupControllerAccess(accessor, targetUserTag.Name(), action, access)
+		}
+		if !errors.IsNotFound(err) {
+			return errors.Annotate(err, "could not look up group")
+		}
+	}
 	switch action {
 	case params.GrantControllerAccess:
 		err := grantControllerAccess(accessor, targetUserTag, apiUser, access)
@@ -633,6 +646,48 @@ func ChangeControllerAccess(accessor *state.State, apiUser, targetUserTag names.
 	}
 }
 
+// changeGroupControllerAccess performs the requested access grant or revoke
+// action for the specified group on the controller.
+func changeGroupControllerAccess(accessor *state.State, group string, action params.ControllerAction, access permission.Access) error {
+	controllerTag := accessor.ControllerTag()
+	switch action {
+	case params.GrantControllerAccess:
+		current, err := accessor.GroupAccess(group, controllerTag)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotate(err, "could not look up controller access for group")
+		}
+		// Only set access if greater access is being granted.
+		if err == nil && current.EqualOrGreaterControllerAccessThan(access) {
+			return errors.Errorf("group already has %q access or greater", access)
+		}
+		err = accessor.SetGroupAccess(group, controllerTag, access)
+		return errors.Annotate(err, "could not grant controller access")
+	case params.RevokeControllerAccess:
+		var lesser permission.Access
+		switch access {
+		case permission.LoginAccess:
+			// Revoking login access removes all access.
+			err := accessor.RemoveGroupAccess(group, controllerTag)
+			return errors.Annotate(err, "could not revoke controller access")
+		case permission.AddModelAccess:
+			// Revoking add-model access sets login.
+			lesser = permission.LoginAccess
+		case permission.SuperuserAccess:
+			// Revoking superuser sets add-model.
+			lesser = permission.AddModelAccess
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+		if _, err := accessor.GroupAccess(group, controllerTag); err != nil {
+			return errors.Annotate(err, "could not look up controller access for group")
+		}
+		err := accessor.SetGroupAccess(group, controllerTag, lesser)
+		return errors.Annotatef(err, "could not set controller access to %s", lesser)
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 func (o orderedBlockInfo) Swap(i, j int) {
 	o[i], o[j] = o[j], o[i]
 }
@@ -318,6 +318,45 @@ func (s *controllerSuite) TestRemoveBlocksNotAll(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "not supported")
 }
 
+func (s *controllerSuite) TestControllerMaintenance(c *gc.C) {
+	result, err := s.controller.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerMaintenanceResult{})
+
+	err = s.controller.SetControllerMaintenance(params.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.controller.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ControllerMaintenanceResult{
+		Maintenance: params.ControllerMaintenance{
+			Enabled: true,
+			Message: "upgrading mongo",
+		},
+	})
+}
+
+func (s *controllerSuite) TestSetControllerMaintenanceRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	controller, err := controller.NewControllerAPI(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.UserTag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = controller.SetControllerMaintenance(params.ControllerMaintenance{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Enabled, jc.IsFalse)
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	watcherId, err := s.controller.WatchAllModels()
 	c.Assert(err, jc.ErrorIsNil)
@@ -677,6 +716,32 @@ func (s *controllerSuite) TestGrantOnlyGreaterAccess(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, expectedErr)
 }
 
+func (s *controllerSuite) TestGrantAndRevokeGroup(c *gc.C) {
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	group := names.NewLocalUserTag("devs")
+
+	err = s.controllerGrant(c, group, string(permission.SuperuserAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GroupAccess("devs", s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.SuperuserAccess)
+
+	err = s.controllerGrant(c, group, string(permission.AddModelAccess))
+	c.Assert(err, gc.ErrorMatches, `group already has "add-model" access or greater`)
+
+	err = s.controllerRevoke(c, group, string(permission.SuperuserAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GroupAccess("devs", s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AddModelAccess)
+
+	err = s.controllerRevoke(c, group, string(permission.LoginAccess))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.GroupAccess("devs", s.State.ControllerTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *controllerSuite) TestGrantControllerAddRemoteUser(c *gc.C) {
 	userTag := names.NewUserTag("foobar@ubuntuone")
 
@@ -804,6 +869,27 @@ func (s *controllerSuite) TestGetControllerAccess(c *gc.C) {
 		}}})
 }
 
+func (s *controllerSuite) TestGetControllerAccessThroughGroup(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	req := params.Entities{
+		Entities: []params.Entity{{Tag: user.Tag().String()}},
+	}
+	results, err := s.controller.GetControllerAccess(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.DeepEquals, []params.UserAccessResult{{
+		Result: &params.UserAccess{
+			Access:  "superuser",
+			UserTag: user.Tag().String(),
+		}}})
+}
+
 func (s *controllerSuite) TestGetControllerAccessPermissions(c *gc.C) {
 	// Set up the user making the call.
 	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ControllerMaintenance returns the maintenance mode of the
+// controller.
+func (s *ControllerAPI) ControllerMaintenance() (params.ControllerMaintenanceResult, error) {
+	if err := s.checkHasAdmin(); err != nil {
+		return params.ControllerMaintenanceResult{}, errors.Trace(err)
+	}
+	maintenance, err := s.state.ControllerMaintenance()
+	if err != nil {
+		return params.ControllerMaintenanceResult{
+			Error: common.ServerError(err),
+		}, nil
+	}
+	return params.ControllerMaintenanceResult{
+		Maintenance: params.ControllerMaintenance{
+			Enabled: maintenance.Enabled,
+			Message: maintenance.Message,
+		},
+	}, nil
+}
+
+// SetControllerMaintenance enables or disables maintenance mode for
+// the controller. While the controller is in maintenance mode, the
+// API server rejects calls that would change any of its models.
+func (s *ControllerAPI) SetControllerMaintenance(args params.ControllerMaintenance) error {
+	if err := s.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.state.SetControllerMaintenance(args.Enabled, args.Message))
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -35,13 +36,14 @@ type ApplicationOffersAPI interface {
 type applicationOffersAPI struct {
 	authorizer                  facade.Authorizer
 	applicationOffersAPIFactory ApplicationOffersAPIFactory
-	// TODO(wallyworld) - add component to handle permissions.
+	controllerTag               names.ControllerTag
 }
 
 // createApplicationDirectoryAPI returns a new cross model API facade.
 func createApplicationOffersAPI(
 	serviceAPIFactory ApplicationOffersAPIFactory,
 	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
 ) (ApplicationOffersAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -50,6 +52,7 @@ func createApplicationOffersAPI(
 	return &applicationOffersAPI{
 		authorizer:                  authorizer,
 		applicationOffersAPIFactory: serviceAPIFactory,
+		controllerTag:               controllerTag,
 	}, nil
 }
 
@@ -59,16 +62,22 @@ func newApplicationOffersAPI(
 	authorizer facade.Authorizer,
 ) (ApplicationOffersAPI, error) {
 	apiFactory := resources.Get("applicationOffersApiFactory").(ApplicationOffersAPIFactory)
-	return createApplicationOffersAPI(apiFactory, authorizer)
+	return createApplicationOffersAPI(apiFactory, authorizer, st.ControllerTag())
 }
 
 // TODO(wallyworld) - add Remove() and Update()
 
 // ListOffers returns offers matching the filter from a application directory.
+// Unless the authenticated user is a controller superuser, only those offers
+// which the user has been granted access to are returned.
 func (api *applicationOffersAPI) ListOffers(filters params.OfferFilters) (params.ApplicationOfferResults, error) {
 	if filters.Directory == "" {
 		return params.ApplicationOfferResults{}, errors.New("application directory must be specified")
 	}
+	filters, err := api.restrictToAuthenticatedUser(filters)
+	if err != nil {
+		return params.ApplicationOfferResults{}, errors.Trace(err)
+	}
 	applicationOffers, err := api.applicationOffersAPIFactory.ApplicationOffers(filters.Directory)
 	if err != nil {
 		return params.ApplicationOfferResults{}, err
@@ -76,6 +85,33 @@ func (api *applicationOffersAPI) ListOffers(filters params.OfferFilters) (params
 	return applicationOffers.ListOffers(filters)
 }
 
+// restrictToAuthenticatedUser replaces the allowed users in each of the
+// specified filters with the authenticated user, so that only offers
+// the user has access to are matched. Controller superusers may see
+// all offers, so their filters are returned unchanged.
+func (api *applicationOffersAPI) restrictToAuthenticatedUser(filters params.OfferFilters) (params.OfferFilters, error) {
+	isControllerAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil {
+		return filters, errors.Trace(err)
+	}
+	if isControllerAdmin {
+		return filters, nil
+	}
+	allowedUsers := []string{api.authorizer.GetAuthTag().String()}
+	restricted := params.OfferFilters{
+		Directory: filters.Directory,
+		Filters:   make([]params.OfferFilter, len(filters.Filters)),
+	}
+	for i, filter := range filters.Filters {
+		filter.AllowedUserTags = allowedUsers
+		restricted.Filters[i] = filter
+	}
+	if len(restricted.Filters) == 0 {
+		restricted.Filters = []params.OfferFilter{{AllowedUserTags: allowedUsers}}
+	}
+	return restricted, nil
+}
+
 // AddOffers adds new application offerings to a directory, able to be consumed by
 // the specified users.
 func (api *applicationOffersAPI) AddOffers(offers params.AddApplicationOffers) (params.ErrorResults, error) {
@@ -132,6 +168,13 @@ func makeOfferFilterFromParams(filters []params.OfferFilter) ([]crossmodel.Appli
 				SourceLabel:            filter.SourceLabel,
 			},
 		}
+		for _, userTag := range filter.AllowedUserTags {
+			user, err := names.ParseUserTag(userTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			offerFilters[i].AllowedUsers = append(offerFilters[i].AllowedUsers, user.Id())
+		}
 		if filter.SourceModelUUIDTag != "" {
 			envTag, err := names.ParseModelTag(filter.SourceModelUUIDTag)
 			if err != nil {
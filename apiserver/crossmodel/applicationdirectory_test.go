@@ -70,13 +70,13 @@ func (s *applicationdirectorySuite) SetUpTest(c *gc.C) {
 		func() jujucrossmodel.ApplicationDirectory { return s.applicationdirectory },
 		nil,
 	)
-	s.api, err = crossmodel.CreateApplicationOffersAPI(serviceAPIFactory, s.authoriser)
+	s.api, err = crossmodel.CreateApplicationOffersAPI(serviceAPIFactory, s.authoriser, coretesting.ControllerTag)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *applicationdirectorySuite) TestUnauthorised(c *gc.C) {
 	s.authoriser = testing.FakeAuthorizer{}
-	_, err := crossmodel.CreateApplicationOffersAPI(nil, s.authoriser)
+	_, err := crossmodel.CreateApplicationOffersAPI(nil, s.authoriser, coretesting.ControllerTag)
 	c.Assert(err, gc.Equals, common.ErrPerm)
 }
 
@@ -186,3 +186,59 @@ func (s *applicationdirectorySuite) TestListOffersNoDirectory(c *gc.C) {
 	_, err := s.api.ListOffers(params.OfferFilters{})
 	c.Assert(err, gc.ErrorMatches, "application directory must be specified")
 }
+
+func (s *applicationdirectorySuite) TestListOffersRestrictedToUser(c *gc.C) {
+	var gotFilters []jujucrossmodel.ApplicationOfferFilter
+	s.applicationdirectory.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
+		s.calls = append(s.calls, "listoffers")
+		gotFilters = filters
+		return nil, nil
+	}
+	_, err := s.api.ListOffers(params.OfferFilters{
+		Directory: "local",
+		Filters: []params.OfferFilter{{
+			ApplicationURL:  "local:/u/user/servicename",
+			AllowedUserTags: []string{"user-mary"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCalls(c, []string{"listoffers"})
+	c.Assert(gotFilters, jc.DeepEquals, []jujucrossmodel.ApplicationOfferFilter{{
+		ApplicationOffer: jujucrossmodel.ApplicationOffer{
+			ApplicationURL: "local:/u/user/servicename",
+		},
+		AllowedUsers: []string{"testuser"},
+	}})
+}
+
+func (s *applicationdirectorySuite) TestListOffersNoFiltersRestrictedToUser(c *gc.C) {
+	var gotFilters []jujucrossmodel.ApplicationOfferFilter
+	s.applicationdirectory.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
+		gotFilters = filters
+		return nil, nil
+	}
+	_, err := s.api.ListOffers(params.OfferFilters{Directory: "local"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotFilters, jc.DeepEquals, []jujucrossmodel.ApplicationOfferFilter{{
+		AllowedUsers: []string{"testuser"},
+	}})
+}
+
+func (s *applicationdirectorySuite) TestListOffersSuperuserNotRestricted(c *gc.C) {
+	s.authoriser.Tag = names.NewUserTag("superuser-joe")
+	serviceAPIFactory := crossmodel.NewServiceAPIFactory(
+		func() jujucrossmodel.ApplicationDirectory { return s.applicationdirectory },
+		nil,
+	)
+	api, err := crossmodel.CreateApplicationOffersAPI(serviceAPIFactory, s.authoriser, coretesting.ControllerTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var gotFilters []jujucrossmodel.ApplicationOfferFilter
+	s.applicationdirectory.listOffers = func(filters ...jujucrossmodel.ApplicationOfferFilter) ([]jujucrossmodel.ApplicationOffer, error) {
+		gotFilters = filters
+		return nil, nil
+	}
+	_, err = api.ListOffers(params.OfferFilters{Directory: "local"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gotFilters, gc.HasLen, 0)
+}
@@ -10,12 +10,14 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	jujucrossmodel "github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -99,9 +101,161 @@ func (api *API) Offer(all params.ApplicationOffersParams) (params.ErrorResults,
 			result[resultIndex] = errResult
 		}
 	}
+
+	// Record the access granted to the offering user and the
+	// allowed users for each offer which was successfully added.
+	for i, one := range all.Offers {
+		if result[i].Error != nil {
+			continue
+		}
+		if err := api.createOfferAccess(one); err != nil {
+			result[i].Error = common.ServerError(err)
+		}
+	}
 	return params.ErrorResults{Results: result}, nil
 }
 
+// createOfferAccess grants the authenticated user admin access to the
+// specified offer, and the offer's allowed users consume access.
+func (api *API) createOfferAccess(offer params.ApplicationOfferParams) error {
+	url, err := jujucrossmodel.ParseApplicationURL(offer.ApplicationURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if url.Directory != "local" {
+		// Access to offers hosted elsewhere is managed by the
+		// relevant application directory.
+		return nil
+	}
+	owner, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	grant := func(user names.UserTag, access permission.Access) error {
+		err := api.backend.CreateOfferAccess(offer.ApplicationURL, user, access)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return errors.Annotatef(err, "granting %q access to %q", access, user.Id())
+		}
+		return nil
+	}
+	if err := grant(owner, permission.AdminAccess); err != nil {
+		return errors.Trace(err)
+	}
+	for _, userTag := range offer.AllowedUserTags {
+		user, err := names.ParseUserTag(userTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if user == owner {
+			continue
+		}
+		if err := grant(user, permission.ConsumeAccess); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// ModifyOfferAccess changes the application offer access granted to users.
+func (api *API) ModifyOfferAccess(args params.ModifyOfferAccessRequest) (result params.ErrorResults, _ error) {
+	result = params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	if len(args.Changes) == 0 {
+		return result, nil
+	}
+
+	isControllerAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	for i, arg := range args.Changes {
+		err := api.modifyOneOfferAccess(isControllerAdmin, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) modifyOneOfferAccess(isControllerAdmin bool, arg params.ModifyOfferAccess) error {
+	offerAccess := permission.Access(arg.Access)
+	if err := permission.ValidateOfferAccess(offerAccess); err != nil {
+		return errors.Annotate(err, "could not modify offer access")
+	}
+	url, err := jujucrossmodel.ParseApplicationURL(arg.OfferURL)
+	if err != nil {
+		return errors.Annotate(err, "could not modify offer access")
+	}
+	if url.Directory != "local" {
+		return errors.NotSupportedf("modifying access to offers in directory %q", url.Directory)
+	}
+	if !isControllerAdmin {
+		apiUser, ok := api.authorizer.GetAuthTag().(names.UserTag)
+		if !ok {
+			return common.ErrPerm
+		}
+		access, err := api.backend.GetOfferAccess(arg.OfferURL, apiUser)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		if access != permission.AdminAccess {
+			return common.ErrPerm
+		}
+	}
+	targetUserTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Annotate(err, "could not modify offer access")
+	}
+	return changeOfferAccess(api.backend, arg.OfferURL, targetUserTag, arg.Action, offerAccess)
+}
+
+// changeOfferAccess performs the requested access grant or revoke action for the
+// specified user on the specified offer.
+func changeOfferAccess(backend OfferAccess, offerURL string, targetUserTag names.UserTag, action params.OfferAction, access permission.Access) error {
+	switch action {
+	case params.GrantOfferAccess:
+		err := backend.CreateOfferAccess(offerURL, targetUserTag, access)
+		if !errors.IsAlreadyExists(err) {
+			return errors.Annotate(err, "could not grant offer access")
+		}
+		offerUserAccess, err := backend.GetOfferAccess(offerURL, targetUserTag)
+		if errors.IsNotFound(err) {
+			// Conflicts with prior check, must be inconsistent state.
+			err = txn.ErrExcessiveContention
+		}
+		if err != nil {
+			return errors.Annotate(err, "could not look up offer access for user")
+		}
+		// Only set access if greater access is being granted.
+		if offerUserAccess.EqualOrGreaterOfferAccessThan(access) {
+			return errors.Errorf("user already has %q access or greater", access)
+		}
+		err = backend.UpdateOfferAccess(offerURL, targetUserTag, access)
+		return errors.Annotate(err, "could not set offer access for user")
+
+	case params.RevokeOfferAccess:
+		switch access {
+		case permission.ReadAccess:
+			// Revoking read access removes all access.
+			err := backend.RemoveOfferAccess(offerURL, targetUserTag)
+			return errors.Annotate(err, "could not revoke offer access")
+		case permission.ConsumeAccess:
+			// Revoking consume access sets read-only.
+			err := backend.UpdateOfferAccess(offerURL, targetUserTag, permission.ReadAccess)
+			return errors.Annotate(err, "could not set offer access to read-only")
+		case permission.AdminAccess:
+			// Revoking admin access sets consume.
+			err := backend.UpdateOfferAccess(offerURL, targetUserTag, permission.ConsumeAccess)
+			return errors.Annotate(err, "could not set offer access to consume")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 // makeOfferedApplicationParams is a helper function that translates from a params
 // structure into data structures needed for subsequent processing.
 func (api *API) makeOfferedApplicationParams(p params.ApplicationOfferParams) (params.ApplicationOffer, error) {
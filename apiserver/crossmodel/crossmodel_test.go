@@ -9,11 +9,15 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/crossmodel"
 	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type crossmodelSuite struct {
@@ -393,3 +397,108 @@ func (s *crossmodelSuite) TestFindError(c *gc.C) {
 	c.Assert(found.Results[0].Error, gc.ErrorMatches, fmt.Sprintf(".*%v.*", msg))
 	s.applicationDirectory.CheckCallNames(c, listOffersBackendCall)
 }
+
+func (s *crossmodelSuite) TestOfferGrantsAccess(c *gc.C) {
+	s.addApplication(c, "test")
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true})
+	one := params.ApplicationOfferParams{
+		ModelTag:        "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ApplicationURL:  "local:/u/me/test",
+		ApplicationName: "test",
+		Endpoints:       []string{"db"},
+		AllowedUserTags: []string{"user-fred"},
+	}
+	s.applicationDirectory.addOffers = func(offers params.AddApplicationOffers) (params.ErrorResults, error) {
+		return params.ErrorResults{Results: make([]params.ErrorResult, len(offers.Offers))}, nil
+	}
+
+	errs, err := s.api.Offer(params.ApplicationOffersParams{Offers: []params.ApplicationOfferParams{one}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs.Results, gc.HasLen, 1)
+	c.Assert(errs.Results[0].Error, gc.IsNil)
+
+	access, err := s.State.GetOfferAccess("local:/u/me/test", s.AdminUserTag(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+	access, err = s.State.GetOfferAccess("local:/u/me/test", names.NewUserTag("fred"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+}
+
+func (s *crossmodelSuite) modifyOfferAccess(c *gc.C, user names.UserTag, action params.OfferAction, access params.OfferAccessPermission) error {
+	args := params.ModifyOfferAccessRequest{
+		Changes: []params.ModifyOfferAccess{{
+			UserTag:  user.String(),
+			Action:   action,
+			Access:   access,
+			OfferURL: "local:/u/me/test",
+		}},
+	}
+	results, err := s.api.ModifyOfferAccess(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.OneError()
+}
+
+func (s *crossmodelSuite) assertOfferAccess(c *gc.C, user names.UserTag, expected permission.Access) {
+	access, err := s.State.GetOfferAccess("local:/u/me/test", user)
+	if expected == permission.NoAccess {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+		return
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, expected)
+}
+
+func (s *crossmodelSuite) TestModifyOfferAccessGrantAndRevoke(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true}).UserTag()
+
+	err := s.modifyOfferAccess(c, user, params.GrantOfferAccess, params.OfferReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, user, permission.ReadAccess)
+
+	err = s.modifyOfferAccess(c, user, params.GrantOfferAccess, params.OfferAdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, user, permission.AdminAccess)
+
+	err = s.modifyOfferAccess(c, user, params.GrantOfferAccess, params.OfferConsumeAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "consume" access or greater`)
+
+	err = s.modifyOfferAccess(c, user, params.RevokeOfferAccess, params.OfferAdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, user, permission.ConsumeAccess)
+
+	err = s.modifyOfferAccess(c, user, params.RevokeOfferAccess, params.OfferConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, user, permission.ReadAccess)
+
+	err = s.modifyOfferAccess(c, user, params.RevokeOfferAccess, params.OfferReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, user, permission.NoAccess)
+}
+
+func (s *crossmodelSuite) TestModifyOfferAccessInvalidAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true}).UserTag()
+	err := s.modifyOfferAccess(c, user, params.GrantOfferAccess, "write")
+	c.Assert(err, gc.ErrorMatches, `could not modify offer access: "write" offer access not valid`)
+}
+
+func (s *crossmodelSuite) TestModifyOfferAccessRequiresOfferAdmin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true}).UserTag()
+	mary := s.Factory.MakeUser(c, &factory.UserParams{Name: "mary", NoModelUser: true}).UserTag()
+	err := s.State.CreateOfferAccess("local:/u/me/test", user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.authorizer = apiservertesting.FakeAuthorizer{Tag: user}
+	s.api, err = crossmodel.CreateAPI(s.applicationDirectory, crossmodel.GetStateAccess(s.State), s.authorizer, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyOfferAccess(c, mary, params.GrantOfferAccess, params.OfferReadAccess)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = s.State.UpdateOfferAccess("local:/u/me/test", user, permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modifyOfferAccess(c, mary, params.GrantOfferAccess, params.OfferReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOfferAccess(c, mary, permission.ReadAccess)
+}
@@ -10,7 +10,9 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 const (
@@ -71,6 +73,26 @@ func (m *mockState) ModelTag() names.ModelTag {
 	return names.NewModelTag("uuid")
 }
 
+func (m *mockState) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (m *mockState) GetOfferAccess(offerURL string, user names.UserTag) (permission.Access, error) {
+	return permission.NoAccess, errors.New("not implemented")
+}
+
+func (m *mockState) CreateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockState) UpdateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockState) RemoveOfferAccess(offerURL string, user names.UserTag) error {
+	return errors.New("not implemented")
+}
+
 func (m *mockState) ModelName() (string, error) {
 	return "prod", nil
 }
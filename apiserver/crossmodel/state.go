@@ -6,6 +6,7 @@ package crossmodel
 import (
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
@@ -14,10 +15,21 @@ import (
 type Backend interface {
 	Application(name string) (*state.Application, error)
 	ForModel(modelTag names.ModelTag) (*state.State, error)
+	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelUUID() string
 	WatchOfferedApplications() state.StringsWatcher
 	ModelName() (string, error)
+	OfferAccess
+}
+
+// OfferAccess provides methods to manage the access users have
+// been granted to application offers.
+type OfferAccess interface {
+	GetOfferAccess(offerURL string, user names.UserTag) (permission.Access, error)
+	CreateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error
+	UpdateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error
+	RemoveOfferAccess(offerURL string, user names.UserTag) error
 }
 
 var getStateAccess = func(st *state.State) Backend {
@@ -47,6 +47,12 @@ func ServerBakeryService(srv *Server) (authentication.BakeryService, error) {
 	return auth.(*authentication.ExternalMacaroonAuthenticator).Service, nil
 }
 
+// ServerMintAPIToken mints the macaroon for an API token using the
+// server's local user bakery service.
+func ServerMintAPIToken(srv *Server, id string, user names.UserTag, expiry time.Time) (*macaroon.Macaroon, error) {
+	return srv.authCtxt.MintAPIToken(id, user, expiry)
+}
+
 // ServerAuthenticatorForTag calls the authenticatorForTag method
 // of the server's authContext.
 func ServerAuthenticatorForTag(srv *Server, tag names.Tag) (authentication.EntityAuthenticator, error) {
@@ -126,6 +132,13 @@ func TestingMigratingRoot(st *state.State) rpc.Root {
 	return restrictRoot(r, migrationClientMethodsOnly)
 }
 
+// TestingMaintenanceRoot returns a restricted srvRoot as if the
+// controller is in maintenance mode.
+func TestingMaintenanceRoot(st *state.State, message string) rpc.Root {
+	r := TestingAPIRoot(st)
+	return restrictRoot(r, maintenanceMethodsOnly(message))
+}
+
 // TestingControllerOnlyRoot returns a restricted srvRoot as if
 // logged in to the root of the API path.
 func TestingControllerOnlyRoot() rpc.Root {
@@ -140,6 +153,13 @@ func TestingModelOnlyRoot() rpc.Root {
 	return restrictRoot(r, modelFacadesOnly)
 }
 
+// TestingAPITokenRoot returns a restricted srvRoot as if logged in
+// to a model with an API token limited to the specified facades.
+func TestingAPITokenRoot(facades []string) rpc.Root {
+	r := TestingModelOnlyRoot()
+	return restrictRoot(r, apiTokenFacadesOnly(facades))
+}
+
 // TestingRestrictedRoot returns a restricted srvRoot.
 func TestingRestrictedRoot(check func(string, string) error) rpc.Root {
 	r := TestingAPIRoot(nil)
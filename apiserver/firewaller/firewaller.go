@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -20,6 +21,12 @@ import (
 func init() {
 	// Version 0 is no longer supported.
 	common.RegisterStandardFacade("Firewaller", 3, NewFirewallerAPI)
+
+	// Version 4 adds GetExposedSourceCIDRs and WatchSubnets, for
+	// applications exposed to specific CIDRs or spaces, and
+	// SetStatusData, for reporting ingress rules the provider doesn't
+	// support.
+	common.RegisterStandardFacade("Firewaller", 4, NewFirewallerAPI)
 }
 
 // FirewallerAPI provides access to the Firewaller API facade.
@@ -203,12 +210,13 @@ func (f *FirewallerAPI) GetMachinePorts(args params.MachinePortsParams) (params.
 			network.SortPortRanges(portRanges)
 
 			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
-				result.Results[i].Ports = append(result.Results[i].Ports,
-					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
-					})
+				for _, unitName := range portRangeMap[portRange] {
+					result.Results[i].Ports = append(result.Results[i].Ports,
+						params.MachinePortRange{
+							UnitTag:   names.NewUnitTag(unitName).String(),
+							PortRange: params.FromNetworkPortRange(portRange),
+						})
+				}
 			}
 		}
 	}
@@ -286,6 +294,85 @@ func (f *FirewallerAPI) GetExposed(args params.Entities) (params.BoolResults, er
 	return result, nil
 }
 
+// GetExposedSourceCIDRs returns, for each given application, the
+// CIDRs that may access the ports opened by its units. The result is
+// empty for applications that are not exposed.
+func (f *FirewallerAPI) GetExposedSourceCIDRs(args params.Entities) (params.StringsResults, error) {
+	result := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.StringsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].Result, err = f.exposedSourceCIDRs(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// exposedSourceCIDRs returns the sorted CIDRs allowed by the
+// application's expose settings, with the subnets of any spaces
+// expanded to their CIDRs. Expose settings are only accepted for
+// all endpoints together, so the CIDRs apply to every opened port.
+func (f *FirewallerAPI) exposedSourceCIDRs(application *state.Application) ([]string, error) {
+	if !application.IsExposed() {
+		return nil, nil
+	}
+	exposed := application.ExposedEndpoints()
+	if len(exposed) == 0 {
+		return []string{allSourceCIDR}, nil
+	}
+	cidrs := set.NewStrings()
+	for _, settings := range exposed {
+		if settings.AllowsAll() {
+			return []string{allSourceCIDR}, nil
+		}
+		cidrs = cidrs.Union(set.NewStrings(settings.ExposeToCIDRs...))
+		for _, spaceName := range settings.ExposeToSpaces {
+			space, err := f.st.Space(spaceName)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs.Add(subnet.CIDR())
+			}
+		}
+	}
+	return cidrs.SortedValues(), nil
+}
+
+// WatchSubnets returns a NotifyWatcher that notifies when subnets are
+// added, removed or moved between spaces, changing the CIDRs of the
+// spaces that applications are exposed to.
+func (f *FirewallerAPI) WatchSubnets() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := f.st.WatchSubnets()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = f.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// allSourceCIDR is the CIDR allowing access from anywhere.
+const allSourceCIDR = "0.0.0.0/0"
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
@@ -315,6 +402,32 @@ func (f *FirewallerAPI) GetAssignedMachine(args params.Entities) (params.StringR
 	return result, nil
 }
 
+// SetStatusData sets the given keys in the status data of each given
+// machine, without changing the status itself. Keys with nil values are
+// removed.
+func (f *FirewallerAPI) SetStatusData(args params.SetStatus) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetStatusData(entity.Data)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 func (f *FirewallerAPI) getEntity(canAccess common.AuthFunc, tag names.Tag) (state.Entity, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
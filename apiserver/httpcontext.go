@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
+	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -123,6 +124,11 @@ func (ctxt *httpContext) stateForRequestAuthenticated(r *http.Request) (
 		// "unauthorized".
 		return nil, nil, nil, errors.Trace(errors.NewUnauthorized(err, ""))
 	}
+	if _, ok := entity.(*authentication.APITokenEntity); ok {
+		// The HTTP endpoints do not limit access to that granted
+		// by an API token, so tokens may not be used with them.
+		return nil, nil, nil, errors.NewUnauthorized(nil, "API tokens not supported for HTTP endpoints")
+	}
 	return st, releaser, entity, nil
 }
 
@@ -19,6 +19,10 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("MachineManager", 2, NewMachineManagerAPI)
+
+	// Version 3 adds AddMachinePools, RemoveMachinePools and
+	// SetMachinePoolSizes.
+	common.RegisterStandardFacade("MachineManager", 3, NewMachineManagerAPI)
 }
 
 // MachineManagerAPI provides access to the MachineManager API facade.
@@ -78,6 +82,98 @@ func (mm *MachineManagerAPI) AddMachines(args params.AddMachines) (params.AddMac
 	return results, nil
 }
 
+// AddMachinePools adds pools of machines that are provisioned ahead
+// of demand, so that units can be placed on them without waiting for
+// new instances.
+func (mm *MachineManagerAPI) AddMachinePools(args params.AddMachinePools) (params.AddMachinePoolResults, error) {
+	results := params.AddMachinePoolResults{
+		Results: make([]params.AddMachinePoolResult, len(args.Pools)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, p := range args.Pools {
+		if p.Series == "" {
+			conf, err := mm.st.ModelConfig()
+			if err != nil {
+				return results, errors.Trace(err)
+			}
+			p.Series = config.PreferredSeries(conf)
+		}
+		pool, err := mm.st.AddMachinePool(p.Size, p.Series, p.Constraints)
+		results.Results[i].Error = common.ServerError(err)
+		if err == nil {
+			results.Results[i].Pool = pool.Id()
+		}
+	}
+	return results, nil
+}
+
+// RemoveMachinePools removes machine pools and destroys the unclaimed
+// machines they hold.
+func (mm *MachineManagerAPI) RemoveMachinePools(args params.MachinePools) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.RemoveAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, id := range args.Pools {
+		pool, err := mm.st.MachinePool(id)
+		if err == nil {
+			err = pool.Remove()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// SetMachinePoolSizes changes the number of unclaimed machines held
+// by machine pools.
+func (mm *MachineManagerAPI) SetMachinePoolSizes(args params.MachinePoolSizes) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Pools)),
+	}
+
+	canWrite, err := mm.authorizer.HasPermission(permission.WriteAccess, mm.st.ModelTag())
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	if !canWrite {
+		return results, common.ErrPerm
+	}
+
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, p := range args.Pools {
+		pool, err := mm.st.MachinePool(p.Pool)
+		if err == nil {
+			err = pool.SetSize(p.Size)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
 func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Machine, error) {
 	if p.ParentId != "" && p.ContainerType == "" {
 		return nil, fmt.Errorf("parent machine specified without container type")
@@ -5,6 +5,7 @@ package machinemanager_test
 
 import (
 	"errors"
+	"fmt"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -119,10 +121,86 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddMachinePools(c *gc.C) {
+	results, err := s.api.AddMachinePools(params.AddMachinePools{
+		Pools: []params.AddMachinePoolParams{{
+			Size:        5,
+			Series:      "trusty",
+			Constraints: constraints.MustParse("mem=4G"),
+		}, {
+			Size:   1,
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AddMachinePoolResults{
+		Results: []params.AddMachinePoolResult{{Pool: "0"}, {Pool: "1"}},
+	})
+	c.Assert(s.st.pools, jc.DeepEquals, []params.AddMachinePoolParams{{
+		Size:        5,
+		Series:      "trusty",
+		Constraints: constraints.MustParse("mem=4G"),
+	}, {
+		Size:   1,
+		Series: "xenial",
+	}})
+}
+
+func (s *MachineManagerSuite) TestAddMachinePoolsStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.AddMachinePools(params.AddMachinePools{
+		Pools: []params.AddMachinePoolParams{{Size: 1, Series: "trusty"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.AddMachinePoolResults{
+		Results: []params.AddMachinePoolResult{{
+			Error: &params.Error{Message: "boom", Code: ""},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestRemoveMachinePools(c *gc.C) {
+	results, err := s.api.RemoveMachinePools(params.MachinePools{
+		Pools: []string{"0", "1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}},
+	})
+	c.Assert(s.st.poolCalls, jc.DeepEquals, []string{"Remove 0", "Remove 1"})
+}
+
+func (s *MachineManagerSuite) TestSetMachinePoolSizes(c *gc.C) {
+	results, err := s.api.SetMachinePoolSizes(params.MachinePoolSizes{
+		Pools: []params.MachinePoolSize{{Pool: "0", Size: 3}, {Pool: "1", Size: 1}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}},
+	})
+	c.Assert(s.st.poolCalls, jc.DeepEquals, []string{"SetSize 0 3", "SetSize 1 1"})
+}
+
+func (s *MachineManagerSuite) TestSetMachinePoolSizesStateError(c *gc.C) {
+	s.st.err = errors.New("boom")
+	results, err := s.api.SetMachinePoolSizes(params.MachinePoolSizes{
+		Pools: []params.MachinePoolSize{{Pool: "0", Size: 3}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "boom", Code: ""},
+		}},
+	})
+	c.Assert(s.st.poolCalls, gc.HasLen, 0)
+}
+
 type mockState struct {
-	calls    int
-	machines []state.MachineTemplate
-	err      error
+	calls     int
+	machines  []state.MachineTemplate
+	pools     []params.AddMachinePoolParams
+	poolCalls []string
+	err       error
 }
 
 func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machine, error) {
@@ -132,6 +210,46 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) AddMachinePool(size int, series string, cons constraints.Value) (machinemanager.MachinePool, error) {
+	st.calls++
+	st.pools = append(st.pools, params.AddMachinePoolParams{
+		Size:        size,
+		Series:      series,
+		Constraints: cons,
+	})
+	if st.err != nil {
+		return nil, st.err
+	}
+	return &mockMachinePool{st: st, id: fmt.Sprint(len(st.pools) - 1)}, nil
+}
+
+func (st *mockState) MachinePool(id string) (machinemanager.MachinePool, error) {
+	st.calls++
+	if st.err != nil {
+		return nil, st.err
+	}
+	return &mockMachinePool{st: st, id: id}, nil
+}
+
+type mockMachinePool struct {
+	st *mockState
+	id string
+}
+
+func (p *mockMachinePool) Id() string {
+	return p.id
+}
+
+func (p *mockMachinePool) SetSize(size int) error {
+	p.st.poolCalls = append(p.st.poolCalls, fmt.Sprintf("SetSize %s %d", p.id, size))
+	return nil
+}
+
+func (p *mockMachinePool) Remove() error {
+	p.st.poolCalls = append(p.st.poolCalls, "Remove "+p.id)
+	return nil
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	return &mockBlock{}, false, nil
 }
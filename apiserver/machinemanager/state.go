@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
@@ -19,6 +20,8 @@ type stateInterface interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	AddMachinePool(size int, series string, cons constraints.Value) (MachinePool, error)
+	MachinePool(id string) (MachinePool, error)
 
 	GetModel(names.ModelTag) (Model, error)
 	Cloud(string) (cloud.Cloud, error)
@@ -58,6 +61,22 @@ func (s stateShim) AddMachineInsideMachine(template state.MachineTemplate, paren
 	return s.State.AddMachineInsideMachine(template, parentId, containerType)
 }
 
+func (s stateShim) AddMachinePool(size int, series string, cons constraints.Value) (MachinePool, error) {
+	p, err := s.State.AddMachinePool(size, series, cons)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s stateShim) MachinePool(id string) (MachinePool, error) {
+	p, err := s.State.MachinePool(id)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
 func (s stateShim) GetModel(tag names.ModelTag) (Model, error) {
 	m, err := s.State.GetModel(tag)
 	if err != nil {
@@ -74,3 +93,9 @@ type Model interface {
 
 	Config() (*config.Config, error)
 }
+
+type MachinePool interface {
+	Id() string
+	SetSize(size int) error
+	Remove() error
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenanceflag provides the API that lets agents watch and
+// query the maintenance mode of the controller.
+package maintenanceflag
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes the maintenance mode of the controller.
+type Backend interface {
+	ControllerMaintenance() (state.ControllerMaintenance, error)
+	WatchControllerMaintenance() state.NotifyWatcher
+}
+
+// Facade lets agents watch and get the controller's maintenance mode.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// New creates a Facade backed by backend and resources. If auth
+// doesn't identity the client as a machine agent, it will return
+// common.ErrPerm.
+func New(backend Backend, resources facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// Maintenance returns the current maintenance mode of the controller.
+func (facade *Facade) Maintenance() params.ControllerMaintenanceResult {
+	maintenance, err := facade.backend.ControllerMaintenance()
+	if err != nil {
+		return params.ControllerMaintenanceResult{
+			Error: common.ServerError(err),
+		}
+	}
+	return params.ControllerMaintenanceResult{
+		Maintenance: params.ControllerMaintenance{
+			Enabled: maintenance.Enabled,
+			Message: maintenance.Message,
+		},
+	}
+}
+
+// Watch returns an id for use with the NotifyWatcher facade, which
+// notifies of changes to the result of Maintenance.
+func (facade *Facade) Watch() params.NotifyWatchResult {
+	watch := facade.backend.WatchControllerMaintenance()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenanceflag_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/maintenanceflag"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (*FacadeSuite) TestAcceptsMachineAgent(c *gc.C) {
+	facade, err := maintenanceflag.New(nil, nil, agentAuth{machine: true})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(facade, gc.NotNil)
+}
+
+func (*FacadeSuite) TestRejectsNonMachineAgent(c *gc.C) {
+	facade, err := maintenanceflag.New(nil, nil, agentAuth{})
+	c.Check(err, gc.Equals, common.ErrPerm)
+	c.Check(facade, gc.IsNil)
+}
+
+func (*FacadeSuite) TestMaintenance(c *gc.C) {
+	backend := &mockBackend{maintenance: state.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	}}
+	facade, err := maintenanceflag.New(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Maintenance()
+	c.Check(result, jc.DeepEquals, params.ControllerMaintenanceResult{
+		Maintenance: params.ControllerMaintenance{
+			Enabled: true,
+			Message: "upgrading mongo",
+		},
+	})
+}
+
+func (*FacadeSuite) TestMaintenanceError(c *gc.C) {
+	backend := &mockBackend{err: errors.New("boom")}
+	facade, err := maintenanceflag.New(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Maintenance()
+	c.Check(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (*FacadeSuite) TestWatch(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	backend := &mockBackend{watcher: &mockWatcher{changes: changes}}
+	resources := common.NewResources()
+	facade, err := maintenanceflag.New(backend, resources, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Watch()
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.NotifyWatcherId, gc.Equals, "1")
+	c.Check(resources.Get("1"), gc.Equals, backend.watcher)
+}
+
+func (*FacadeSuite) TestWatchError(c *gc.C) {
+	changes := make(chan struct{})
+	close(changes)
+	backend := &mockBackend{watcher: &mockWatcher{
+		changes: changes,
+		err:     errors.New("blam"),
+	}}
+	facade, err := maintenanceflag.New(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Watch()
+	c.Check(result.Error, gc.ErrorMatches, "blam")
+	c.Check(result.NotifyWatcherId, gc.Equals, "")
+}
+
+// agentAuth implements facade.Authorizer for use in the tests.
+type agentAuth struct {
+	facade.Authorizer
+	machine bool
+}
+
+// AuthMachineAgent is part of the facade.Authorizer interface.
+func (auth agentAuth) AuthMachineAgent() bool {
+	return auth.machine
+}
+
+// mockBackend implements maintenanceflag.Backend for use in the tests.
+type mockBackend struct {
+	maintenance state.ControllerMaintenance
+	err         error
+	watcher     *mockWatcher
+}
+
+// ControllerMaintenance is part of the maintenanceflag.Backend interface.
+func (mock *mockBackend) ControllerMaintenance() (state.ControllerMaintenance, error) {
+	return mock.maintenance, mock.err
+}
+
+// WatchControllerMaintenance is part of the maintenanceflag.Backend interface.
+func (mock *mockBackend) WatchControllerMaintenance() state.NotifyWatcher {
+	return mock.watcher
+}
+
+// mockWatcher implements state.NotifyWatcher for use in the tests.
+type mockWatcher struct {
+	state.NotifyWatcher
+	changes chan struct{}
+	err     error
+}
+
+// Changes is part of the state.NotifyWatcher interface.
+func (mock *mockWatcher) Changes() <-chan struct{} {
+	return mock.changes
+}
+
+// Err is part of the state.NotifyWatcher interface.
+func (mock *mockWatcher) Err() error {
+	return mock.err
+}
+
+// Stop is part of the state.NotifyWatcher interface.
+func (mock *mockWatcher) Stop() error {
+	return nil
+}
@@ -521,6 +521,15 @@ func (st *mockState) RemoveModelUser(tag names.UserTag) error {
 
 func (st *mockState) UserAccess(tag names.UserTag, target names.Tag) (permission.UserAccess, error) {
 	st.MethodCall(st, "ModelUser", tag, target)
+	return st.userAccess(tag)
+}
+
+func (st *mockState) EffectiveUserAccess(tag names.UserTag, target names.Tag) (permission.UserAccess, error) {
+	st.MethodCall(st, "EffectiveUserAccess", tag, target)
+	return st.userAccess(tag)
+}
+
+func (st *mockState) userAccess(tag names.UserTag) (permission.UserAccess, error) {
 	for _, user := range st.users {
 		if user.UserTag != tag {
 			continue
@@ -549,6 +558,29 @@ func (st *mockState) SetUserAccess(subject names.UserTag, target names.Tag, acce
 	return permission.UserAccess{}, st.NextErr()
 }
 
+func (st *mockState) Group(name string) (*state.Group, error) {
+	st.MethodCall(st, "Group", name)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return nil, errors.NotFoundf("group %q", name)
+}
+
+func (st *mockState) GroupAccess(name string, target names.Tag) (permission.Access, error) {
+	st.MethodCall(st, "GroupAccess", name, target)
+	return permission.NoAccess, st.NextErr()
+}
+
+func (st *mockState) SetGroupAccess(name string, target names.Tag, access permission.Access) error {
+	st.MethodCall(st, "SetGroupAccess", name, target, access)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveGroupAccess(name string, target names.Tag) error {
+	st.MethodCall(st, "RemoveGroupAccess", name, target)
+	return st.NextErr()
+}
+
 func (st *mockState) ModelConfigDefaultValues() (config.ModelDefaultAttributes, error) {
 	st.MethodCall(st, "ModelConfigDefaultValues")
 	return st.cfgDefaults, nil
@@ -711,9 +711,10 @@ func userAuthorizedToChangeAccess(st common.ModelManagerBackend, userIsAdmin boo
 		return nil
 	}
 
-	// Get the current user's ModelUser for the Model to see if the user has
-	// permission to grant or revoke permissions on the model.
-	currentUser, err := st.UserAccess(userTag, st.ModelTag())
+	// Get the current user's access to the Model, including any granted
+	// through their groups, to see if the user has permission to grant or
+	// revoke permissions on the model.
+	currentUser, err := st.EffectiveUserAccess(userTag, st.ModelTag())
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// No, this user doesn't have permission.
@@ -740,6 +741,18 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 		return errors.Trace(err)
 	}
 
+	if targetUserTag.IsLocal() {
+		// Groups share the namespace of local users, so
+		// the target may be a group rather than a user.
+		_, err := st.Group(targetUserTag.Name())
+		if err == nil {
+			return changeGroupModelAccess(st, modelTag, targetUserTag.Name(), action, access)
+		}
+		if !errors.IsNotFound(err) {
+			return errors.Annotate(err, "could not look up group")
+		}
+	}
+
 	switch action {
 	case params.GrantModelAccess:
 		_, err = st.AddModelUser(modelTag.Id(), state.UserAccessSpec{User: targetUserTag, CreatedBy: apiUser, Access: access})
@@ -796,6 +809,52 @@ func changeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 	}
 }
 
+// changeGroupModelAccess performs the requested access grant or revoke action
+// for the specified group on the specified model.
+func changeGroupModelAccess(st common.ModelManagerBackend, modelTag names.ModelTag, group string, action params.ModelAction, access permission.Access) error {
+	switch action {
+	case params.GrantModelAccess:
+		current, err := st.GroupAccess(group, modelTag)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotate(err, "could not look up model access for group")
+		}
+		// Only set access if greater access is being granted.
+		if err == nil && current.EqualOrGreaterModelAccessThan(access) {
+			return errors.Errorf("group already has %q access or greater", access)
+		}
+		err = st.SetGroupAccess(group, modelTag, access)
+		return errors.Annotate(err, "could not grant model access")
+
+	case params.RevokeModelAccess:
+		switch access {
+		case permission.ReadAccess:
+			// Revoking read access removes all access.
+			err := st.RemoveGroupAccess(group, modelTag)
+			return errors.Annotate(err, "could not revoke model access")
+		case permission.WriteAccess:
+			// Revoking write access sets read-only.
+			if _, err := st.GroupAccess(group, modelTag); err != nil {
+				return errors.Annotate(err, "could not look up model access for group")
+			}
+			err := st.SetGroupAccess(group, modelTag, permission.ReadAccess)
+			return errors.Annotate(err, "could not set model access to read-only")
+		case permission.AdminAccess:
+			// Revoking admin access sets read-write.
+			if _, err := st.GroupAccess(group, modelTag); err != nil {
+				return errors.Annotate(err, "could not look up model access for group")
+			}
+			err := st.SetGroupAccess(group, modelTag, permission.WriteAccess)
+			return errors.Annotate(err, "could not set model access to read-write")
+
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 // ModelDefaults returns the default config values used when creating a new model.
 func (m *ModelManagerAPI) ModelDefaults() (params.ModelDefaultsResult, error) {
 	result := params.ModelDefaultsResult{}
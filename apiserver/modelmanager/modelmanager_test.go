@@ -1046,6 +1046,35 @@ func (s *modelManagerStateSuite) TestGrantMissingUserFails(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, expectedErr)
 }
 
+func (s *modelManagerStateSuite) TestGrantAndRevokeGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	group := names.NewLocalUserTag("devs")
+	err = s.grant(c, group, params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GroupAccess("devs", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.grant(c, group, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, gc.ErrorMatches, `group already has "read" access or greater`)
+
+	err = s.revoke(c, group, params.ModelWriteAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GroupAccess("devs", st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+
+	err = s.revoke(c, group, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.GroupAccess("devs", st.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *modelManagerStateSuite) TestGrantMissingModelFails(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	user := s.Factory.MakeModelUser(c, nil)
@@ -1217,6 +1246,29 @@ func (s *modelManagerStateSuite) TestGrantToModelReadAccess(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *modelManagerStateSuite) TestGrantToModelAdminAccessThroughGroup(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", st.ModelTag(), permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAPIUser(c, user.UserTag())
+
+	other := names.NewUserTag("other@remote")
+	err = s.grant(c, other, params.ModelReadAccess, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelUser, err := st.UserAccess(other, st.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelUser.Access, gc.Equals, permission.ReadAccess)
+}
+
 func (s *modelManagerStateSuite) TestGrantToModelWriteAccess(c *gc.C) {
 	s.setAPIUser(c, s.AdminUserTag(c))
 	st := s.Factory.MakeModel(c, nil)
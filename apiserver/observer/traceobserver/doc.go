@@ -0,0 +1,7 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package traceobserver provides an implementation
+// of apiserver/observer.ObserverFactory that records
+// a tracing span for each API request.
+package traceobserver
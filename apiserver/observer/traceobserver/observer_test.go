@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package traceobserver_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/observer/traceobserver"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+)
+
+type observerSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	exporter *recordingExporter
+	factory  observer.ObserverFactory
+}
+
+var _ = gc.Suite(&observerSuite{})
+
+type recordingExporter struct {
+	spans []tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (s *observerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Unix(0, 0))
+	s.exporter = &recordingExporter{}
+
+	var err error
+	s.factory, err = traceobserver.NewObserverFactory(traceobserver.Config{
+		Tracer: tracing.NewTracer(s.clock, s.exporter),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *observerSuite) TestValidateConfig(c *gc.C) {
+	_, err := traceobserver.NewObserverFactory(traceobserver.Config{})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Tracer not valid")
+}
+
+func (s *observerSuite) TestRPCObserver(c *gc.C) {
+	o := s.factory()
+	o.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	rpcObserver := o.RPCObserver()
+
+	traceID := tracing.NewTraceID()
+	req := rpc.Request{
+		Type:    "Client",
+		Version: 1,
+		Action:  "FullStatus",
+	}
+	rpcObserver.ServerRequest(&rpc.Header{
+		RequestId: 7,
+		Request:   req,
+		TraceID:   traceID,
+	}, nil)
+	s.clock.Advance(time.Second)
+	rpcObserver.ServerReply(req, &rpc.Header{
+		RequestId: 7,
+		ErrorCode: "unauthorized access",
+		TraceID:   traceID,
+	}, nil)
+
+	c.Assert(s.exporter.spans, gc.HasLen, 1)
+	span := s.exporter.spans[0]
+	c.Assert(span.TraceID, gc.Equals, traceID)
+	c.Assert(span.Name, gc.Equals, "Client.FullStatus")
+	c.Assert(span.Duration, gc.Equals, time.Second)
+	c.Assert(span.Tags, jc.DeepEquals, map[string]string{
+		"facade":     "Client",
+		"version":    "1",
+		"method":     "FullStatus",
+		"request-id": "7",
+		"error-code": "unauthorized access",
+		"entity":     "user-bob",
+		"model":      coretesting.ModelTag.Id(),
+	})
+}
+
+func (s *observerSuite) TestRPCObserverGeneratesTraceID(c *gc.C) {
+	rpcObserver := s.factory().RPCObserver()
+	req := rpc.Request{Type: "Pinger", Action: "Ping"}
+	rpcObserver.ServerRequest(&rpc.Header{Request: req}, nil)
+	rpcObserver.ServerReply(req, &rpc.Header{}, nil)
+
+	c.Assert(s.exporter.spans, gc.HasLen, 1)
+	c.Assert(tracing.IsValidTraceID(s.exporter.spans[0].TraceID), jc.IsTrue)
+	c.Assert(s.exporter.spans[0].Tags["entity"], gc.Equals, "")
+}
+
+func (s *observerSuite) TestRequestContext(c *gc.C) {
+	rpcObserver := rpc.NewObserverMultiplexer(s.factory().RPCObserver())
+	req := rpc.Request{Type: "Uniter", Action: "SetCharmURL"}
+	rpcObserver.ServerRequest(&rpc.Header{Request: req}, nil)
+
+	// Operations run with the request's context are recorded as
+	// children of the request's span.
+	ctx := rpc.RequestContext(rpcObserver)
+	tracing.StartChildSpan(ctx, "txn").Finish()
+	rpcObserver.ServerReply(req, &rpc.Header{}, nil)
+
+	c.Assert(s.exporter.spans, gc.HasLen, 2)
+	child, parent := s.exporter.spans[0], s.exporter.spans[1]
+	c.Assert(child.Name, gc.Equals, "txn")
+	c.Assert(child.TraceID, gc.Equals, parent.TraceID)
+	c.Assert(child.ParentID, gc.Equals, parent.SpanID)
+}
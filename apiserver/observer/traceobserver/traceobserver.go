@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package traceobserver
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/juju/errors"
+	"golang.org/x/net/context"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/tracing"
+)
+
+// Span tag names.
+const (
+	facadeTag    = "facade"
+	versionTag   = "version"
+	methodTag    = "method"
+	requestIDTag = "request-id"
+	errorCodeTag = "error-code"
+	entityTag    = "entity"
+	modelTag     = "model"
+)
+
+// Config contains the configuration for an Observer.
+type Config struct {
+	// Tracer is the tracing.Tracer used to record API request spans.
+	Tracer *tracing.Tracer
+}
+
+// Validate validates the observer factory configuration.
+func (cfg Config) Validate() error {
+	if cfg.Tracer == nil {
+		return errors.NotValidf("nil Tracer")
+	}
+	return nil
+}
+
+// NewObserverFactory returns a function that, when called, returns a
+// new Observer. Each Observer records a span for every API request
+// made on its connection.
+func NewObserverFactory(config Config) (observer.ObserverFactory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	return func() observer.Observer {
+		return &Observer{tracer: config.Tracer}
+	}, nil
+}
+
+// Observer is an API server connection observer that records a
+// tracing span for each API request.
+type Observer struct {
+	tracer *tracing.Tracer
+
+	// mu guards the fields below it.
+	mu     sync.Mutex
+	entity string
+	model  string
+}
+
+// Login is part of the observer.Observer interface.
+func (o *Observer) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if entity != nil {
+		o.entity = entity.String()
+	}
+	o.model = model.Id()
+}
+
+// Join is part of the observer.Observer interface.
+func (*Observer) Join(req *http.Request, connectionID uint64) {}
+
+// Leave is part of the observer.Observer interface.
+func (*Observer) Leave() {}
+
+// RPCObserver is part of the observer.Observer interface.
+func (o *Observer) RPCObserver() rpc.Observer {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return &rpcObserver{
+		tracer: o.tracer,
+		entity: o.entity,
+		model:  o.model,
+	}
+}
+
+type rpcObserver struct {
+	tracer *tracing.Tracer
+	entity string
+	model  string
+	span   *tracing.ActiveSpan
+}
+
+// ServerRequest is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	req := hdr.Request
+	o.span = o.tracer.StartSpan(hdr.TraceID, "", req.Type+"."+req.Action)
+	o.span.SetTag(facadeTag, req.Type)
+	o.span.SetTag(versionTag, strconv.Itoa(req.Version))
+	o.span.SetTag(methodTag, req.Action)
+	o.span.SetTag(requestIDTag, strconv.FormatUint(hdr.RequestId, 10))
+	if o.entity != "" {
+		o.span.SetTag(entityTag, o.entity)
+	}
+	if o.model != "" {
+		o.span.SetTag(modelTag, o.model)
+	}
+}
+
+// RequestContext is part of the rpc.RequestContexter interface. It
+// returns a context carrying the request's span, so that the facade
+// call and the operations it performs are recorded as its children.
+func (o *rpcObserver) RequestContext(ctx context.Context) context.Context {
+	return tracing.ContextWithSpan(ctx, o.span)
+}
+
+// ServerReply is part of the rpc.Observer interface.
+func (o *rpcObserver) ServerReply(req rpc.Request, hdr *rpc.Header, body interface{}) {
+	if o.span == nil {
+		return
+	}
+	if hdr.ErrorCode != "" {
+		o.span.SetTag(errorCodeTag, hdr.ErrorCode)
+	}
+	o.span.Finish()
+}
@@ -5,6 +5,7 @@ package params
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/macaroon.v1"
@@ -42,6 +43,11 @@ type ErrorInfo struct {
 	// If it is empty, the macaroon will be associated with
 	// the original URL from which the error was returned.
 	MacaroonPath string `json:"macaroon-path,omitempty"`
+
+	// RetryAfter holds how long the client should wait before
+	// making another request. This field is associated with the
+	// CodeRateLimitExceeded error code.
+	RetryAfter time.Duration `json:"retry-after,omitempty"`
 }
 
 func (e Error) Error() string {
@@ -83,6 +89,7 @@ const (
 	CodeAlreadyExists             = "already exists"
 	CodeUpgradeInProgress         = "upgrade in progress"
 	CodeMigrationInProgress       = "model migration in progress"
+	CodeMaintenanceInProgress     = "controller maintenance in progress"
 	CodeActionNotAvailable        = "action no longer available"
 	CodeOperationBlocked          = "operation is blocked"
 	CodeLeadershipClaimDenied     = "leadership claim denied"
@@ -94,6 +101,7 @@ const (
 	CodeDischargeRequired         = "macaroon discharge required"
 	CodeRedirect                  = "redirection required"
 	CodeRetry                     = "retry"
+	CodeRateLimitExceeded         = "rate-limit-exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -212,6 +220,10 @@ func IsCodeUpgradeInProgress(err error) bool {
 	return ErrCode(err) == CodeUpgradeInProgress
 }
 
+func IsCodeMaintenanceInProgress(err error) bool {
+	return ErrCode(err) == CodeMaintenanceInProgress
+}
+
 func IsCodeOperationBlocked(err error) bool {
 	return ErrCode(err) == CodeOperationBlocked
 }
@@ -239,3 +251,7 @@ func IsMethodNotAllowed(err error) bool {
 func IsRedirect(err error) bool {
 	return ErrCode(err) == CodeRedirect
 }
+
+func IsCodeRateLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeRateLimitExceeded
+}
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Block describes a Juju block that protects model from
 // corruption.
 type Block struct {
@@ -19,6 +21,19 @@ type Block struct {
 	// Message is a descriptive or an explanatory message
 	// that the block was created with.
 	Message string `json:"message,omitempty"`
+
+	// Targets holds the tags of the applications and machines
+	// the block applies to. If empty, the block applies to the
+	// whole model.
+	Targets []string `json:"targets,omitempty"`
+
+	// Expiry, if set, holds the time after which the block
+	// no longer applies.
+	Expiry *time.Time `json:"expiry,omitempty"`
+
+	// CreatedBy holds the name of the user that switched
+	// the block on, if known.
+	CreatedBy string `json:"created-by,omitempty"`
 }
 
 // BlockSwitchParams holds the parameters for switching
@@ -31,6 +46,15 @@ type BlockSwitchParams struct {
 	// Message is a descriptive or an explanatory message
 	// that accompanies the switch.
 	Message string `json:"message,omitempty"`
+
+	// Targets holds the tags of the applications and machines
+	// the block applies to. If empty, the block applies to the
+	// whole model.
+	Targets []string `json:"targets,omitempty"`
+
+	// Expiry, if set, holds the time after which the block
+	// no longer applies. It is ignored when switching a block off.
+	Expiry *time.Time `json:"expiry,omitempty"`
 }
 
 // BlockResult holds the result of an API call to retrieve details
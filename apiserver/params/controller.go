@@ -33,6 +33,19 @@ type RemoveBlocksArgs struct {
 	All bool `json:"all"`
 }
 
+// ControllerMaintenance holds the maintenance mode of a controller.
+type ControllerMaintenance struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+}
+
+// ControllerMaintenanceResult holds the maintenance mode of a
+// controller, or an error.
+type ControllerMaintenanceResult struct {
+	Maintenance ControllerMaintenance `json:"maintenance"`
+	Error       *Error                `json:"error,omitempty"`
+}
+
 // ModelStatus holds information about the status of a juju model.
 type ModelStatus struct {
 	ModelTag           string             `json:"model-tag"`
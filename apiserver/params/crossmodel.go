@@ -472,3 +472,38 @@ type ConsumeApplicationResult struct {
 type ConsumeApplicationResults struct {
 	Results []ConsumeApplicationResult `json:"results"`
 }
+
+// ModifyOfferAccessRequest holds the parameters for making grant and
+// revoke offer calls.
+type ModifyOfferAccessRequest struct {
+	Changes []ModifyOfferAccess `json:"changes"`
+}
+
+// ModifyOfferAccess holds the details of a single grant or revoke
+// of access to an application offer.
+type ModifyOfferAccess struct {
+	UserTag  string                `json:"user-tag"`
+	Action   OfferAction           `json:"action"`
+	Access   OfferAccessPermission `json:"access"`
+	OfferURL string                `json:"offer-url"`
+}
+
+// OfferAction is an action that can be performed on an offer.
+type OfferAction string
+
+// Actions that can be preformed on an offer.
+const (
+	GrantOfferAccess  OfferAction = "grant"
+	RevokeOfferAccess OfferAction = "revoke"
+)
+
+// OfferAccessPermission is the type of permission that a user has to
+// access an application offer.
+type OfferAccessPermission string
+
+// Offer access permissions that may be set on a user.
+const (
+	OfferAdminAccess   OfferAccessPermission = "admin"
+	OfferConsumeAccess OfferAccessPermission = "consume"
+	OfferReadAccess    OfferAccessPermission = "read"
+)
@@ -606,16 +606,25 @@ type AgentVersionResult struct {
 
 // ProvisioningInfo holds machine provisioning info.
 type ProvisioningInfo struct {
-	Constraints      constraints.Value         `json:"constraints"`
-	Series           string                    `json:"series"`
-	Placement        string                    `json:"placement"`
-	Jobs             []multiwatcher.MachineJob `json:"jobs"`
-	Volumes          []VolumeParams            `json:"volumes,omitempty"`
-	Tags             map[string]string         `json:"tags,omitempty"`
-	SubnetsToZones   map[string][]string       `json:"subnets-to-zones,omitempty"`
-	ImageMetadata    []CloudImageMetadata      `json:"image-metadata,omitempty"`
-	EndpointBindings map[string]string         `json:"endpoint-bindings,omitempty"`
-	ControllerConfig map[string]interface{}    `json:"controller-config,omitempty"`
+	Constraints       constraints.Value          `json:"constraints"`
+	Series            string                     `json:"series"`
+	Placement         string                     `json:"placement"`
+	Jobs              []multiwatcher.MachineJob  `json:"jobs"`
+	Volumes           []VolumeParams             `json:"volumes,omitempty"`
+	Tags              map[string]string          `json:"tags,omitempty"`
+	SubnetsToZones    map[string][]string        `json:"subnets-to-zones,omitempty"`
+	ImageMetadata     []CloudImageMetadata       `json:"image-metadata,omitempty"`
+	EndpointBindings  map[string]string          `json:"endpoint-bindings,omitempty"`
+	ControllerConfig  map[string]interface{}     `json:"controller-config,omitempty"`
+	CharmLXDProfiles  map[string]CharmLXDProfile `json:"charm-lxd-profiles,omitempty"`
+	CharmApplications []string                   `json:"charm-applications,omitempty"`
+}
+
+// CharmLXDProfile holds an LXD profile supplied by a charm.
+type CharmLXDProfile struct {
+	Description string                       `json:"description,omitempty"`
+	Config      map[string]string            `json:"config,omitempty"`
+	Devices     map[string]map[string]string `json:"devices,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -713,6 +722,19 @@ type GUIVersionRequest struct {
 	Version version.Number `json:"version"`
 }
 
+// FacadeSchema holds the JSON schema describing the methods of one
+// version of an API facade.
+type FacadeSchema struct {
+	Name    string                 `json:"name"`
+	Version int                    `json:"version"`
+	Schema  map[string]interface{} `json:"schema"`
+}
+
+// FacadeSchemas holds the response to a /schema GET request.
+type FacadeSchemas struct {
+	Facades []FacadeSchema `json:"facades"`
+}
+
 // LogMessage is a structured logging entry.
 type LogMessage struct {
 	Entity    string    `json:"tag"`
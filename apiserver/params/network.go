@@ -597,6 +597,32 @@ type CreateSpaceParams struct {
 	ProviderId string   `json:"provider-id,omitempty"`
 }
 
+// MoveSubnetsParams holds the arguments of the MoveSubnets API call.
+type MoveSubnetsParams struct {
+	Args []MoveSubnetsParam `json:"args"`
+}
+
+// MoveSubnetsParam holds the tag of the space to move subnets to, and
+// the tags of the subnets to move. If Force is true, the move is made
+// even if it leaves endpoint bindings unsatisfiable.
+type MoveSubnetsParam struct {
+	SpaceTag   string   `json:"space-tag"`
+	SubnetTags []string `json:"subnet-tags"`
+	Force      bool     `json:"force"`
+}
+
+// MoveSubnetsResults holds the results of a MoveSubnets API call.
+type MoveSubnetsResults struct {
+	Results []MoveSubnetsResult `json:"results"`
+}
+
+// MoveSubnetsResult holds the names of the applications affected by
+// moving subnets to a space, or an error.
+type MoveSubnetsResult struct {
+	Applications []string `json:"applications,omitempty"`
+	Error        *Error   `json:"error,omitempty"`
+}
+
 // ListSpacesResults holds the list of all available spaces.
 type ListSpacesResults struct {
 	Results []Space `json:"results"`
@@ -631,9 +657,10 @@ type ProxyConfig struct {
 
 // ProxyConfigResult contains information needed to configure a clients proxy settings
 type ProxyConfigResult struct {
-	ProxySettings    ProxyConfig `json:"proxy-settings"`
-	APTProxySettings ProxyConfig `json:"apt-proxy-settings"`
-	Error            *Error      `json:"error,omitempty"`
+	ProxySettings     ProxyConfig `json:"proxy-settings"`
+	JujuProxySettings ProxyConfig `json:"juju-proxy-settings"`
+	APTProxySettings  ProxyConfig `json:"apt-proxy-settings"`
+	Error             *Error      `json:"error,omitempty"`
 }
 
 // ProxyConfigResults contains information needed to configure multiple clients proxy settings
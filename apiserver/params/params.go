@@ -193,6 +193,56 @@ type AddMachinesResults struct {
 	Machines []AddMachinesResult `json:"machines"`
 }
 
+// AddMachinePoolParams encapsulates the parameters used to add a pool
+// of machines that are provisioned ahead of demand.
+type AddMachinePoolParams struct {
+	// Size is the number of unclaimed machines the pool should hold.
+	Size int `json:"size"`
+
+	// Series is the series of the pooled machines. If it is empty,
+	// the model's default series is used.
+	Series string `json:"series,omitempty"`
+
+	// Constraints are used when provisioning the pooled machines.
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// AddMachinePools holds the parameters for making the AddMachinePools call.
+type AddMachinePools struct {
+	Pools []AddMachinePoolParams `json:"pools"`
+}
+
+// AddMachinePoolResults holds the results of an AddMachinePools call.
+type AddMachinePoolResults struct {
+	Results []AddMachinePoolResult `json:"results"`
+}
+
+// AddMachinePoolResult holds the id of a machine pool added by the
+// AddMachinePools call for a single pool.
+type AddMachinePoolResult struct {
+	Pool  string `json:"pool"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// MachinePools holds the ids of machine pools for the
+// RemoveMachinePools call.
+type MachinePools struct {
+	Pools []string `json:"pools"`
+}
+
+// MachinePoolSize holds the number of unclaimed machines a machine
+// pool should hold.
+type MachinePoolSize struct {
+	Pool string `json:"pool"`
+	Size int    `json:"size"`
+}
+
+// MachinePoolSizes holds the parameters for making the
+// SetMachinePoolSizes call.
+type MachinePoolSizes struct {
+	Pools []MachinePoolSize `json:"pools"`
+}
+
 // AddMachinesResult holds the name of a machine added by the
 // api.client.AddMachine call for a single machine.
 type AddMachinesResult struct {
@@ -276,11 +326,99 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// BatchSize, if non-zero, is the number of units that are upgraded
+	// at a time. This field is only understood by Application facade
+	// version 4 and greater.
+	BatchSize int `json:"batch-size,omitempty"`
+
+	// WaitFor is the workload status that each batch of units must
+	// reach before the next batch is upgraded. This field is only
+	// understood by Application facade version 4 and greater.
+	WaitFor string `json:"wait-for,omitempty"`
+}
+
+// RollingUpgradeStatus holds the progress of an application's charm
+// upgrade that is applied to its units in batches.
+type RollingUpgradeStatus struct {
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	FromCharm string    `json:"from-charm"`
+	ToCharm   string    `json:"to-charm"`
+	BatchSize int       `json:"batch-size"`
+	WaitFor   string    `json:"wait-for,omitempty"`
+	Released  []string  `json:"released"`
+	Updated   time.Time `json:"updated"`
+}
+
+// ApplicationRollbackCharm holds the parameters for setting an
+// application's charm back to one recorded in its charm history.
+type ApplicationRollbackCharm struct {
+	// ApplicationName is the name of the application to roll back.
+	ApplicationName string `json:"application"`
+
+	// Revision, if set, is the revision of the charm to roll back to.
+	// Otherwise the application is rolled back to the charm it ran
+	// before its most recent charm change.
+	Revision *int `json:"revision,omitempty"`
+
+	// ForceUnits forces the rollback on units in an error state.
+	ForceUnits bool `json:"force-units"`
+}
+
+// CharmHistoryResults holds the charm histories of applications.
+type CharmHistoryResults struct {
+	Results []CharmHistoryResult `json:"results"`
+}
+
+// CharmHistoryResult holds the charm history of an application, most
+// recent first, or an error.
+type CharmHistoryResult struct {
+	History []CharmHistoryEntry `json:"history,omitempty"`
+	Error   *Error              `json:"error,omitempty"`
+}
+
+// CharmHistoryEntry describes a charm that an application ran before
+// its charm was changed.
+type CharmHistoryEntry struct {
+	CharmURL  string                 `json:"charm-url"`
+	Channel   string                 `json:"channel,omitempty"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Resources []CharmHistoryResource `json:"resources,omitempty"`
+	Replaced  time.Time              `json:"replaced"`
+}
+
+// CharmHistoryResource describes a resource recorded in an
+// application's charm history.
+type CharmHistoryResource struct {
+	Name     string `json:"name"`
+	Origin   string `json:"origin"`
+	Revision int    `json:"revision"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
+// ExposedEndpoints, if set, restricts the sources that may access the
+// application's endpoints, keyed by endpoint name; the empty name
+// stands for all endpoints.
 type ApplicationExpose struct {
-	ApplicationName string `json:"application"`
+	ApplicationName  string                     `json:"application"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the spaces and CIDRs that may access an
+// exposed application endpoint. An endpoint exposed without either
+// may be accessed from anywhere.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// ApplicationSetEndpointBindings holds the parameters for rebinding
+// application endpoints to spaces. Endpoints not in Bindings keep
+// their current bindings.
+type ApplicationSetEndpointBindings struct {
+	ApplicationName string            `json:"application"`
+	Bindings        map[string]string `json:"bindings"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -548,6 +686,10 @@ type ContainerConfig struct {
 	AptProxy                proxy.Settings `json:"apt-proxy"`
 	AptMirror               string         `json:"apt-mirror"`
 	*UpdateBehavior
+
+	// CloudInitUserData holds the model's cloudinit-userdata config,
+	// to be merged into the container's cloud-config.
+	CloudInitUserData map[string]interface{} `json:"cloudinit-userdata,omitempty"`
 }
 
 // ProvisioningScriptParams contains the parameters for the
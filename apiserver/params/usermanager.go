@@ -5,6 +5,8 @@ package params
 
 import (
 	"time"
+
+	"gopkg.in/macaroon.v1"
 )
 
 // UserInfo holds information on a user.
@@ -16,6 +18,11 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// Groups holds the names of the groups of which the user is
+	// a member. Access granted to these groups is included in
+	// Access.
+	Groups []string `json:"groups,omitempty"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
@@ -67,3 +74,100 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// AddGroups holds the parameters for adding new groups.
+type AddGroups struct {
+	Groups []AddGroup `json:"groups"`
+}
+
+// AddGroup stores the parameters to add one group.
+type AddGroup struct {
+	Name string `json:"name"`
+}
+
+// ModifyGroupMembers holds the parameters for changing the
+// members of groups.
+type ModifyGroupMembers struct {
+	Changes []ModifyGroupMember `json:"changes"`
+}
+
+// GroupMemberAction is an action that can be performed
+// on the members of a group.
+type GroupMemberAction string
+
+// Actions that can be performed on the members of a group.
+const (
+	AddGroupMember    GroupMemberAction = "add"
+	RemoveGroupMember GroupMemberAction = "remove"
+)
+
+// ModifyGroupMember stores the parameters to add a user to, or
+// remove a user from, one group.
+type ModifyGroupMember struct {
+	Group   string            `json:"group"`
+	UserTag string            `json:"user-tag"`
+	Action  GroupMemberAction `json:"action"`
+}
+
+// GroupInfo holds information about a group.
+type GroupInfo struct {
+	Name        string    `json:"name"`
+	Members     []string  `json:"members"`
+	CreatedBy   string    `json:"created-by"`
+	DateCreated time.Time `json:"date-created"`
+}
+
+// GroupInfoResults holds the result of the Groups API call.
+type GroupInfoResults struct {
+	Results []GroupInfo `json:"results"`
+}
+
+// AddAPITokens holds the parameters for adding API tokens.
+type AddAPITokens struct {
+	Tokens []AddAPIToken `json:"tokens"`
+}
+
+// AddAPIToken holds the parameters for adding an API token, which
+// allows a user to access a single model with limited access until
+// it expires or is removed.
+type AddAPIToken struct {
+	// UserTag holds the tag of the user on whose behalf the token
+	// acts. If empty, the token is for the authenticated user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// ModelTag holds the tag of the only model the token may be used
+	// with.
+	ModelTag string `json:"model-tag"`
+
+	// Access holds the greatest access to the model the token grants.
+	Access string `json:"access"`
+
+	// Facades, if non-empty, holds the names of the only facades
+	// that may be used with the token.
+	Facades []string `json:"facades,omitempty"`
+
+	// Expires holds the time at which the token expires.
+	Expires time.Time `json:"expires"`
+}
+
+// AddAPITokenResults holds the results of the AddAPITokens API call.
+type AddAPITokenResults struct {
+	Results []AddAPITokenResult `json:"results"`
+}
+
+// AddAPITokenResult holds the result of adding an API token.
+type AddAPITokenResult struct {
+	// ID holds the ID of the token, used to remove it.
+	ID string `json:"id,omitempty"`
+
+	// Macaroon holds the macaroon that is presented to the
+	// controller when logging in with the token.
+	Macaroon *macaroon.Macaroon `json:"macaroon,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// RemoveAPITokens holds the IDs of the API tokens to remove.
+type RemoveAPITokens struct {
+	IDs []string `json:"ids"`
+}
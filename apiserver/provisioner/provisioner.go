@@ -34,6 +34,13 @@ var logger = loggo.GetLogger("juju.apiserver.provisioner")
 
 func init() {
 	common.RegisterStandardFacade("Provisioner", 3, NewProvisionerAPI)
+
+	// Version 4 adds charm-supplied LXD profiles to the provisioning
+	// info, and WatchApplicationCharms.
+	common.RegisterStandardFacade("Provisioner", 4, NewProvisionerAPI)
+
+	// Version 5 adds WatchMachinePools and TopUpMachinePools.
+	common.RegisterStandardFacade("Provisioner", 5, NewProvisionerAPI)
 }
 
 // ProvisionerAPI provides access to the Provisioner API facade.
@@ -253,6 +260,11 @@ func (p *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerConf
 		// TODO(jam): Do we want to handle ImageStream here, or do we
 		// hide it from them? (all cached images must come from the
 		// same image stream?)
+		config, err := p.st.ModelConfig()
+		if err != nil {
+			return result, err
+		}
+		cfg[container.ConfigIPFamily] = string(config.PreferredIPFamily())
 	}
 
 	result.ManagerConfig = cfg
@@ -278,6 +290,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.Proxy = config.ProxySettings()
 	result.AptProxy = config.AptProxySettings()
 	result.AptMirror = config.AptMirror()
+	result.CloudInitUserData = config.CloudInitUserData()
 
 	return result, nil
 }
@@ -528,6 +541,74 @@ func (p *ProvisionerAPI) WatchMachineErrorRetry() (params.NotifyWatchResult, err
 	return result, nil
 }
 
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// the names of applications whose charms or lives change, so that
+// container provisioners can refresh charm-supplied LXD profiles.
+func (p *ProvisionerAPI) WatchApplicationCharms() (params.StringsWatchResult, error) {
+	watch := p.st.WatchApplicationCharms()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: p.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchUnitAssignments returns a StringsWatcher that notifies of the
+// ids of machines whose principal units, or their subordinates,
+// change, so that container provisioners can refresh charm-supplied
+// LXD profiles.
+func (p *ProvisionerAPI) WatchUnitAssignments() (params.StringsWatchResult, error) {
+	watch := p.st.WatchUnitAssignments()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: p.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// WatchMachinePools returns a NotifyWatcher that notifies when machine
+// pools change or have machines join or leave them.
+func (p *ProvisionerAPI) WatchMachinePools() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	if !p.authorizer.AuthController() {
+		return result, common.ErrPerm
+	}
+	watch := p.st.WatchMachinePools()
+	// Consume any initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = p.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// TopUpMachinePools adds machines to every machine pool that holds
+// fewer machines than its size, and returns the ids of the machines
+// that were added.
+func (p *ProvisionerAPI) TopUpMachinePools() (params.StringsResult, error) {
+	result := params.StringsResult{}
+	if !p.authorizer.AuthController() {
+		return result, common.ErrPerm
+	}
+	machines, err := p.st.TopUpMachinePools()
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	result.Result = make([]string, len(machines))
+	for i, m := range machines {
+		result.Result[i] = m.Id()
+	}
+	return result, nil
+}
+
 // ReleaseContainerAddresses finds addresses allocated to a container and marks
 // them as Dead, to be released and removed. It accepts container tags as
 // arguments.
@@ -1166,6 +1166,21 @@ func (s *withoutControllerSuite) TestContainerManagerConfig(c *gc.C) {
 	})
 }
 
+func (s *withoutControllerSuite) TestContainerManagerConfigLXDIPFamily(c *gc.C) {
+	cfg := s.getManagerConfig(c, instance.LXD)
+	c.Assert(cfg, jc.DeepEquals, map[string]string{
+		container.ConfigModelUUID: coretesting.ModelTag.Id(),
+		container.ConfigIPFamily:  "ipv4",
+	})
+
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"preferred-ip-family": "dual",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	cfg = s.getManagerConfig(c, instance.LXD)
+	c.Assert(cfg[container.ConfigIPFamily], gc.Equals, "dual")
+}
+
 func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	attrs := map[string]interface{}{
 		"http-proxy":            "http://proxy.example.com:9000",
@@ -1343,6 +1358,109 @@ func (s *withoutControllerSuite) TestWatchMachineErrorRetry(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{})
 }
 
+func (s *withoutControllerSuite) TestWatchApplicationCharms(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.provisioner.WatchApplicationCharms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 0)
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Adding an application triggers a change.
+	s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	wc.AssertChange("lxd-profile")
+	wc.AssertNoChange()
+}
+
+func (s *withoutControllerSuite) TestWatchUnitAssignments(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.provisioner.WatchUnitAssignments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, gc.HasLen, 0)
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine triggers a change.
+	app := s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.machines[1].Id())
+	wc.AssertNoChange()
+}
+
+func (s *withoutControllerSuite) TestWatchMachinePools(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	_, err := s.provisioner.WatchMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Verify the resources were registered and stop them when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned"
+	// in the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Adding a machine pool triggers a change.
+	_, err = s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *withoutControllerSuite) TestTopUpMachinePools(c *gc.C) {
+	_, err := s.State.AddMachinePool(2, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.provisioner.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.HasLen, 2)
+	for _, id := range result.Result {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(m.Pool(), gc.Equals, "0")
+	}
+
+	result, err = s.provisioner.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.HasLen, 0)
+}
+
+func (s *withoutControllerSuite) TestTopUpMachinePoolsRequiresController(c *gc.C) {
+	anAuthorizer := s.authorizer
+	anAuthorizer.Controller = false
+	anAuthorizer.Tag = s.machines[1].Tag()
+	aProvisioner, err := provisioner.NewProvisionerAPI(s.State, s.resources, anAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = aProvisioner.TopUpMachinePools()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *withoutControllerSuite) TestFindTools(c *gc.C) {
 	args := params.FindToolsParams{
 		MajorVersion: -1,
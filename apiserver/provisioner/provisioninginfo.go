@@ -17,10 +17,12 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -89,21 +91,71 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine) (*params.Provisio
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
+	lxdProfiles, lxdApplications, err := p.machineLXDProfiles(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get charm lxd profiles")
+	}
 
 	return &params.ProvisioningInfo{
-		Constraints:      cons,
-		Series:           m.Series(),
-		Placement:        m.Placement(),
-		Jobs:             jobs,
-		Volumes:          volumes,
-		Tags:             tags,
-		SubnetsToZones:   subnetsToZones,
-		EndpointBindings: endpointBindings,
-		ImageMetadata:    imageMetadata,
-		ControllerConfig: controllerCfg,
+		Constraints:       cons,
+		Series:            m.Series(),
+		Placement:         m.Placement(),
+		Jobs:              jobs,
+		Volumes:           volumes,
+		Tags:              tags,
+		SubnetsToZones:    subnetsToZones,
+		EndpointBindings:  endpointBindings,
+		ImageMetadata:     imageMetadata,
+		ControllerConfig:  controllerCfg,
+		CharmLXDProfiles:  lxdProfiles,
+		CharmApplications: lxdApplications,
 	}, nil
 }
 
+// machineLXDProfiles returns the LXD profiles supplied by the charms of
+// the units assigned to the machine and their subordinates, keyed by
+// profile name, and the names of the applications of those units.
+// Profiles are only returned for LXD containers.
+func (p *ProvisionerAPI) machineLXDProfiles(m *state.Machine) (map[string]params.CharmLXDProfile, []string, error) {
+	if m.ContainerType() != instance.LXD {
+		return nil, nil, nil
+	}
+	model, err := p.st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	units, err := m.Units()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var profiles map[string]params.CharmLXDProfile
+	applications := make(set.Strings)
+	for _, unit := range units {
+		app, err := unit.Application()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		applications.Add(app.Name())
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		profile := ch.LXDProfile()
+		if profile.Empty() {
+			continue
+		}
+		if profiles == nil {
+			profiles = make(map[string]params.CharmLXDProfile)
+		}
+		profiles[lxdprofile.Name(model.Name(), app.Name())] = params.CharmLXDProfile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles, applications.SortedValues(), nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
@@ -127,24 +127,53 @@ func (api *ProxyUpdaterAPI) proxyConfig() params.ProxyConfigResult {
 	}
 
 	result.ProxySettings = proxyUtilsSettingsToProxySettingsParam(env.ProxySettings())
+	result.JujuProxySettings = proxyUtilsSettingsToProxySettingsParam(
+		mergeProxySettings(env.JujuProxySettings(), env.ProxySettings()),
+	)
 	result.APTProxySettings = proxyUtilsSettingsToProxySettingsParam(env.AptProxySettings())
 
-	var noProxy []string
-	if result.ProxySettings.NoProxy != "" {
-		noProxy = strings.Split(result.ProxySettings.NoProxy, ",")
-	}
-
-	noProxySet := set.NewStrings(noProxy...)
+	var apiAddresses []string
 	for _, host := range apiHostPorts {
 		for _, hp := range host {
-			noProxySet.Add(hp.Address.Value)
+			apiAddresses = append(apiAddresses, hp.Address.Value)
 		}
 	}
-	result.ProxySettings.NoProxy = strings.Join(noProxySet.SortedValues(), ",")
+	result.ProxySettings.NoProxy = extendNoProxy(result.ProxySettings.NoProxy, apiAddresses)
+	result.JujuProxySettings.NoProxy = extendNoProxy(result.JujuProxySettings.NoProxy, apiAddresses)
 
 	return result
 }
 
+// mergeProxySettings returns the proxy settings agents use: those in
+// preferred, with any that are unset taken from fallback.
+func mergeProxySettings(preferred, fallback proxy.Settings) proxy.Settings {
+	merged := preferred
+	if merged.Http == "" {
+		merged.Http = fallback.Http
+	}
+	if merged.Https == "" {
+		merged.Https = fallback.Https
+	}
+	if merged.Ftp == "" {
+		merged.Ftp = fallback.Ftp
+	}
+	if merged.NoProxy == "" {
+		merged.NoProxy = fallback.NoProxy
+	}
+	return merged
+}
+
+// extendNoProxy returns the comma separated noProxy value with the
+// given addresses added, removing any duplicates.
+func extendNoProxy(noProxy string, addresses []string) string {
+	var values []string
+	if noProxy != "" {
+		values = strings.Split(noProxy, ",")
+	}
+	noProxySet := set.NewStrings(append(values, addresses...)...)
+	return strings.Join(noProxySet.SortedValues(), ",")
+}
+
 // ProxyConfig returns the proxy settings for the current environment
 func (api *ProxyUpdaterAPI) ProxyConfig(args params.Entities) params.ProxyConfigResults {
 	var result params.ProxyConfigResult
@@ -110,6 +110,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfig(c *gc.C) {
 	r := params.ProxyConfigResult{
 		ProxySettings: params.ProxyConfig{
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: noProxy},
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: noProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
 	}
@@ -134,6 +136,8 @@ func (s *ProxyUpdaterSuite) TestProxyConfigExtendsExisting(c *gc.C) {
 	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
 		ProxySettings: params.ProxyConfig{
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
 	})
@@ -157,11 +161,66 @@ func (s *ProxyUpdaterSuite) TestProxyConfigNoDuplicates(c *gc.C) {
 	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
 		ProxySettings: params.ProxyConfig{
 			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "http proxy", HTTPS: "https proxy", FTP: "", NoProxy: expectedNoProxy},
 		APTProxySettings: params.ProxyConfig{
 			HTTP: "http://http proxy", HTTPS: "https://https proxy", FTP: "", NoProxy: ""},
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestProxyConfigJujuProxy(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"http-proxy":       "http proxy",
+		"juju-http-proxy":  "juju http proxy",
+		"juju-https-proxy": "juju https proxy",
+		"juju-no-proxy":    "10.0.0.0/8",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCallNames(c,
+		"ModelConfig",
+		"APIHostPorts",
+	)
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		ProxySettings: params.ProxyConfig{
+			HTTP: "http proxy", NoProxy: "0.1.2.3,0.1.2.4,0.1.2.5"},
+		JujuProxySettings: params.ProxyConfig{
+			HTTP: "juju http proxy", HTTPS: "juju https proxy", NoProxy: "0.1.2.3,0.1.2.4,0.1.2.5,10.0.0.0/8"},
+		APTProxySettings: params.ProxyConfig{
+			HTTP: "http://http proxy"},
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigJujuNoProxyOnly(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"http-proxy":    "http proxy",
+		"no-proxy":      "9.9.9.9",
+		"juju-no-proxy": "10.0.0.0/8",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+
+	// The agents use the legacy http proxy, so the controller
+	// addresses are added to the juju-no-proxy list they use with it.
+	c.Assert(cfg.Results[0].JujuProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP: "http proxy", NoProxy: "0.1.2.3,0.1.2.4,0.1.2.5,10.0.0.0/8",
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestProxyConfigJujuHTTPProxyOnly(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"http-proxy":      "http proxy",
+		"no-proxy":        "9.9.9.9",
+		"juju-http-proxy": "juju http proxy",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+
+	// The legacy no-proxy list is kept for the agents, along with
+	// the controller addresses.
+	c.Assert(cfg.Results[0].JujuProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP: "juju http proxy", NoProxy: "0.1.2.3,0.1.2.4,0.1.2.5,9.9.9.9",
+	})
+}
+
 type stubBackend struct {
 	*testing.Stub
 
@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/controller"
+)
+
+const (
+	userRateLimit  = "user"
+	modelRateLimit = "model"
+)
+
+// RateLimitConfig holds the limits applied to the rate of API requests
+// made by users.
+type RateLimitConfig struct {
+	// User is the limit applied to each user without an override.
+	User controller.APIRateLimit
+
+	// UserOverrides holds limits for particular users, keyed by
+	// user name, that replace User.
+	UserOverrides map[string]controller.APIRateLimit
+
+	// Model is the limit applied to the requests made by all users
+	// to each model.
+	Model controller.APIRateLimit
+}
+
+// apiRateLimiter limits the rate of API requests made by users, both
+// per user and per model, using a token bucket for each.
+type apiRateLimiter struct {
+	clock    clock.Clock
+	config   RateLimitConfig
+	exceeded *prometheus.CounterVec
+
+	// mu guards the fields below it.
+	mu           sync.Mutex
+	userBuckets  map[string]*tokenBucket
+	modelBuckets map[string]*tokenBucket
+}
+
+func newAPIRateLimiter(clock clock.Clock, config RateLimitConfig, registerer prometheus.Registerer) (*apiRateLimiter, error) {
+	exceeded := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "juju",
+		Subsystem: "api",
+		Name:      "rate_limited_requests_total",
+		Help:      "Number of Juju API requests rejected for exceeding a rate limit.",
+	}, []string{"limit"})
+	if registerer != nil {
+		registerer.Unregister(exceeded)
+		if err := registerer.Register(exceeded); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &apiRateLimiter{
+		clock:        clock,
+		config:       config,
+		exceeded:     exceeded,
+		userBuckets:  make(map[string]*tokenBucket),
+		modelBuckets: make(map[string]*tokenBucket),
+	}, nil
+}
+
+// userLimit returns the limit that applies to the given user.
+func (l *apiRateLimiter) userLimit(user names.UserTag) controller.APIRateLimit {
+	if limit, ok := l.config.UserOverrides[user.Id()]; ok {
+		return limit
+	}
+	return l.config.User
+}
+
+// checkUser returns a function, suitable for restrictRoot, that
+// rejects the requests of the given user, made to the model with the
+// given UUID, that exceed the rate limits. If the user logged into
+// the controller rather than a model, modelUUID is empty and only the
+// user's limit applies. The Pinger facade is never limited, so that
+// the connection is kept alive.
+func (l *apiRateLimiter) checkUser(user names.UserTag, modelUUID string) func(string, string) error {
+	return func(facadeName, _ string) error {
+		if facadeName == "Pinger" {
+			return nil
+		}
+		return l.acquire(user, modelUUID)
+	}
+}
+
+// acquire takes a token from the buckets of the user and the model,
+// or returns a *common.RateLimitExceededError if either is empty.
+func (l *apiRateLimiter) acquire(user names.UserTag, modelUUID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+
+	type limitBucket struct {
+		kind   string
+		bucket *tokenBucket
+	}
+	var buckets []limitBucket
+	if limit := l.userLimit(user); !limit.Unlimited() {
+		buckets = append(buckets, limitBucket{
+			userRateLimit, l.bucket(l.userBuckets, user.Id(), limit, now),
+		})
+	}
+	if limit := l.config.Model; modelUUID != "" && !limit.Unlimited() {
+		buckets = append(buckets, limitBucket{
+			modelRateLimit, l.bucket(l.modelBuckets, modelUUID, limit, now),
+		})
+	}
+	// Only take tokens when all of the buckets have one,
+	// so a rejected request does not count against any limit.
+	for _, b := range buckets {
+		if wait := b.bucket.wait(now); wait > 0 {
+			l.exceeded.WithLabelValues(b.kind).Inc()
+			return &common.RateLimitExceededError{
+				Limit:      b.kind,
+				RetryAfter: wait,
+			}
+		}
+	}
+	for _, b := range buckets {
+		b.bucket.tokens--
+	}
+	return nil
+}
+
+// bucket returns the bucket with the given key, creating it with
+// the given limit if necessary.
+func (l *apiRateLimiter) bucket(buckets map[string]*tokenBucket, key string, limit controller.APIRateLimit, now time.Time) *tokenBucket {
+	b, ok := buckets[key]
+	if !ok {
+		b = newTokenBucket(limit, now)
+		buckets[key] = b
+	}
+	return b
+}
+
+// tokenBucket implements the token bucket algorithm: tokens are added
+// to the bucket at a fixed rate, up to its capacity, and each request
+// takes one.
+type tokenBucket struct {
+	limit  controller.APIRateLimit
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit controller.APIRateLimit, now time.Time) *tokenBucket {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	return &tokenBucket{
+		limit:  limit,
+		tokens: float64(limit.Burst),
+		last:   now,
+	}
+}
+
+// wait refills the bucket according to the time elapsed since it was
+// last refilled, and returns how long the caller must wait before a
+// token is available. It returns zero if a token is available now.
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed.Seconds()*float64(b.limit.Rate))
+		b.last = now
+	}
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / float64(b.limit.Rate) * float64(time.Second))
+}
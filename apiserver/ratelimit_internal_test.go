@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type rateLimitSuite struct {
+	coretesting.BaseSuite
+	clock *jujutesting.Clock
+}
+
+var _ = gc.Suite(&rateLimitSuite{})
+
+var (
+	rateLimitBob   = names.NewUserTag("bob")
+	rateLimitAdmin = names.NewUserTag("admin")
+)
+
+func (s *rateLimitSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = jujutesting.NewClock(time.Time{})
+}
+
+func (s *rateLimitSuite) newLimiter(c *gc.C, config RateLimitConfig) *apiRateLimiter {
+	limiter, err := newAPIRateLimiter(s.clock, config, prometheus.NewRegistry())
+	c.Assert(err, jc.ErrorIsNil)
+	return limiter
+}
+
+func (s *rateLimitSuite) TestUnlimited(c *gc.C) {
+	limiter := s.newLimiter(c, RateLimitConfig{})
+	check := limiter.checkUser(rateLimitBob, coretesting.ModelTag.Id())
+	for i := 0; i < 100; i++ {
+		c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	}
+}
+
+func (s *rateLimitSuite) TestUserLimit(c *gc.C) {
+	limiter := s.newLimiter(c, RateLimitConfig{
+		User: controller.APIRateLimit{Rate: 2, Burst: 3},
+	})
+	check := limiter.checkUser(rateLimitBob, coretesting.ModelTag.Id())
+	for i := 0; i < 3; i++ {
+		c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	}
+	err := check("Client", "FullStatus")
+	c.Assert(err, gc.ErrorMatches, `user API rate limit exceeded, retry after 500ms`)
+	c.Assert(common.ServerError(err), jc.DeepEquals, &params.Error{
+		Message: err.Error(),
+		Code:    params.CodeRateLimitExceeded,
+		Info:    &params.ErrorInfo{RetryAfter: 500 * time.Millisecond},
+	})
+
+	// The Pinger is never limited.
+	c.Assert(check("Pinger", "Ping"), jc.ErrorIsNil)
+
+	// Other users have their own bucket.
+	otherCheck := limiter.checkUser(rateLimitAdmin, coretesting.ModelTag.Id())
+	c.Assert(otherCheck("Client", "FullStatus"), jc.ErrorIsNil)
+
+	s.clock.Advance(500 * time.Millisecond)
+	c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(check("Client", "FullStatus"), gc.ErrorMatches, `user API rate limit exceeded, .*`)
+}
+
+func (s *rateLimitSuite) TestUserOverrides(c *gc.C) {
+	limiter := s.newLimiter(c, RateLimitConfig{
+		User: controller.APIRateLimit{Rate: 1, Burst: 1},
+		UserOverrides: map[string]controller.APIRateLimit{
+			"admin": {},
+		},
+	})
+	check := limiter.checkUser(rateLimitAdmin, coretesting.ModelTag.Id())
+	for i := 0; i < 10; i++ {
+		c.Assert(check("Client", "FullStatus"), jc.ErrorIsNil)
+	}
+}
+
+func (s *rateLimitSuite) TestModelLimit(c *gc.C) {
+	limiter := s.newLimiter(c, RateLimitConfig{
+		User:  controller.APIRateLimit{Rate: 10, Burst: 10},
+		Model: controller.APIRateLimit{Rate: 1, Burst: 2},
+	})
+	modelUUID := coretesting.ModelTag.Id()
+	bobCheck := limiter.checkUser(rateLimitBob, modelUUID)
+	adminCheck := limiter.checkUser(rateLimitAdmin, modelUUID)
+	c.Assert(bobCheck("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(adminCheck("Client", "FullStatus"), jc.ErrorIsNil)
+	c.Assert(bobCheck("Client", "FullStatus"), gc.ErrorMatches, `model API rate limit exceeded, retry after 1s`)
+
+	// Controller logins are not subject to the model limit.
+	controllerCheck := limiter.checkUser(rateLimitBob, "")
+	c.Assert(controllerCheck("Controller", "AllModels"), jc.ErrorIsNil)
+}
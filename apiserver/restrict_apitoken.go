@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+)
+
+// apiTokenFacadesOnly returns a function, suitable for restrictRoot,
+// that allows only the specified facades to be used by a login made
+// with an API token. If no facades are specified, all facades are
+// allowed. The Pinger facade is always allowed, so that the
+// connection is kept alive.
+func apiTokenFacadesOnly(facades []string) func(string, string) error {
+	allowed := set.NewStrings(facades...)
+	return func(facadeName, _ string) error {
+		if !isAPITokenFacade(allowed, facadeName) {
+			return errors.NewNotSupported(nil, fmt.Sprintf("facade %q not supported for API token", facadeName))
+		}
+		return nil
+	}
+}
+
+func isAPITokenFacade(allowed set.Strings, facadeName string) bool {
+	return allowed.IsEmpty() || allowed.Contains(facadeName) || facadeName == "Pinger"
+}
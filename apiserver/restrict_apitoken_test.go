@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/testing"
+)
+
+type restrictAPITokenSuite struct {
+	testing.BaseSuite
+	root rpc.Root
+}
+
+var _ = gc.Suite(&restrictAPITokenSuite{})
+
+func (s *restrictAPITokenSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.root = apiserver.TestingAPITokenRoot([]string{"Client"})
+}
+
+func (s *restrictAPITokenSuite) TestAllowed(c *gc.C) {
+	s.assertMethod(c, "Client", 1, "FullStatus")
+	s.assertMethod(c, "Pinger", 1, "Ping")
+}
+
+func (s *restrictAPITokenSuite) TestBlocked(c *gc.C) {
+	caller, err := s.root.FindMethod("Application", 3, "Deploy")
+	c.Assert(err, gc.ErrorMatches, `facade "Application" not supported for API token`)
+	c.Assert(errors.IsNotSupported(err), jc.IsTrue)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *restrictAPITokenSuite) TestControllerFacadesBlocked(c *gc.C) {
+	root := apiserver.TestingAPITokenRoot(nil)
+	caller, err := root.FindMethod("UserManager", 1, "SetPassword")
+	c.Assert(err, gc.ErrorMatches, `facade "UserManager" not supported for model API connection`)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (s *restrictAPITokenSuite) assertMethod(c *gc.C, facadeName string, version int, method string) {
+	caller, err := s.root.FindMethod(facadeName, version, method)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(caller, gc.NotNil)
+}
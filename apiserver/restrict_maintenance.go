@@ -0,0 +1,363 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/common"
+)
+
+// maintenanceMethodsOnly returns a check function for restrictRoot
+// that blocks every API call that might change the controller's
+// models, returning an error that includes the given maintenance
+// message.
+func maintenanceMethodsOnly(message string) func(string, string) error {
+	return func(facadeName, methodName string) error {
+		if !IsMethodAllowedDuringMaintenance(facadeName, methodName) {
+			return common.MaintenanceInProgressError(message)
+		}
+		return nil
+	}
+}
+
+// IsMethodAllowedDuringMaintenance returns whether the given API call
+// is allowed while the controller is in maintenance mode.
+func IsMethodAllowedDuringMaintenance(facadeName, methodName string) bool {
+	methods, ok := allowedMethodsDuringMaintenance[facadeName]
+	if !ok {
+		return false
+	}
+	return methods.Contains(methodName)
+}
+
+// watcherMethods holds the methods of the watcher facades, which
+// only deliver changes from watchers that have already been started.
+var watcherMethods = set.NewStrings("Next", "Stop")
+
+// allowedMethodsDuringMaintenance stores the API calls that are not
+// blocked while the controller is in maintenance mode, keyed by
+// facade name. They are the calls that do not change anything; the
+// calls with which agents report their status, addresses, network
+// config and tools, so that the status of the controller's models
+// remains accurate and running agents do not fail; the calls with which
+// agents keep singular and application leadership, so that leaders
+// do not change because of maintenance; and the calls needed to
+// manage maintenance itself.
+//
+// Every other call is blocked, so a facade method that is added
+// later is blocked until it is explicitly allowed here.
+var allowedMethodsDuringMaintenance = map[string]set.Strings{
+	"Pinger": set.NewStrings(
+		"Ping",
+	),
+	"AllWatcher":                   watcherMethods,
+	"AllModelWatcher":              watcherMethods,
+	"NotifyWatcher":                watcherMethods,
+	"StringsWatcher":               watcherMethods,
+	"RelationUnitsWatcher":         watcherMethods,
+	"VolumeAttachmentsWatcher":     watcherMethods,
+	"FilesystemAttachmentsWatcher": watcherMethods,
+	"EntityWatcher":                watcherMethods,
+	"MigrationStatusWatcher":       watcherMethods,
+
+	// Client facades.
+	"Client": set.NewStrings(
+		"FullStatus", // for "juju status"
+		"StatusHistory",
+		"WatchAll",
+		"ModelInfo",
+		"ModelUserInfo",
+		"GetModelConstraints",
+		"AgentVersion",
+		"APIHostPorts",
+		"FindTools",
+		"PrivateAddress",
+		"PublicAddress",
+		"GetBundleChanges",
+	),
+	"Application": set.NewStrings(
+		"Get",
+		"GetCharmURL",
+		"GetConstraints",
+		"CharmRelations",
+		"CharmHistory",
+	),
+	"Controller": set.NewStrings(
+		"AllModels",
+		"ListBlockedModels",
+		"ModelConfig",
+		"HostedModelConfigs",
+		"ModelStatus",
+		"ControllerConfig",
+		"GetControllerAccess",
+		"WatchAllModels",
+		"ControllerMaintenance",
+		"SetControllerMaintenance", // so that maintenance can be ended
+	),
+	"ModelManager": set.NewStrings(
+		"ListModels",
+		"ModelInfo",
+		"ModelStatus",
+		"ModelDefaults",
+	),
+	"ModelConfig": set.NewStrings(
+		"ModelGet",
+	),
+	"SSHClient": set.NewStrings(
+		"PublicAddress",
+		"PrivateAddress",
+		"BestAPIVersion",
+		"AllAddresses",
+		"PublicKeys",
+		"Proxy",
+	),
+	"Block": set.NewStrings(
+		"List",
+	),
+	"Charms": set.NewStrings(
+		"CharmInfo",
+		"IsMetered",
+		"List",
+	),
+	"Storage": set.NewStrings(
+		"ListFilesystems",
+		"ListPools",
+		"ListStorageDetails",
+		"ListVolumes",
+		"StorageDetails",
+	),
+	"Spaces": set.NewStrings(
+		"ListSpaces",
+	),
+	"Subnets": set.NewStrings(
+		"AllSpaces",
+		"AllZones",
+		"ListSubnets",
+	),
+	"Action": set.NewStrings(
+		"Actions",
+		"ApplicationsCharmsActions",
+		"FindActionTagsByPrefix",
+		"FindActionsByNames",
+		"ListAll",
+		"ListCompleted",
+		"ListPending",
+		"ListRunning",
+	),
+	"Annotations": set.NewStrings(
+		"Get",
+	),
+	"Cloud": set.NewStrings(
+		"Cloud",
+		"Clouds",
+		"CloudSpec",
+		"Credential",
+		"DefaultCloud",
+		"InstanceTypes",
+		"UserCredentials",
+	),
+	"UserManager": set.NewStrings(
+		"Groups",
+		"UserInfo",
+	),
+
+	// Agent facades.
+	"Agent": set.NewStrings(
+		"GetEntities",
+		"IsMaster",
+		"StateServingInfo",
+		"ControllerConfig",
+		"ModelConfig",
+		"WatchForModelConfigChanges",
+		"WatchCredentials",
+	),
+	"MaintenanceFlag": set.NewStrings(
+		"Maintenance",
+	),
+	"Singular": set.NewStrings(
+		"Claim",
+		"Wait",
+	),
+	"LeadershipService": set.NewStrings(
+		"ClaimLeadership",
+		"BlockUntilLeadershipReleased",
+	),
+	"Machiner": set.NewStrings(
+		"Life",
+		"Watch",
+		"Jobs",
+		"APIAddresses",
+		"APIHostPorts",
+		"CACert",
+		"ModelUUID",
+		"WatchAPIHostPorts",
+		"SetStatus",
+		"SetMachineAddresses",
+		"SetObservedNetworkConfig",
+	),
+	"Uniter": set.NewStrings(
+		"Life",
+		"Watch",
+		"Actions",
+		"AllMachinePorts",
+		"APIAddresses",
+		"APIHostPorts",
+		"ApplicationStatus",
+		"AssignedMachine",
+		"AvailabilityZone",
+		"CACert",
+		"CharmArchiveSha256",
+		"CharmModifiedVersion",
+		"CharmURL",
+		"ConfigSettings",
+		"CurrentModel",
+		"GetMeterStatus",
+		"GetPrincipal",
+		"HasSubordinates",
+		"JoinedRelations",
+		"ModelConfig",
+		"ModelUUID",
+		"NetworkConfig",
+		"PrivateAddress",
+		"ProviderType",
+		"PublicAddress",
+		"Read",
+		"ReadRemoteSettings",
+		"ReadSettings",
+		"Relation",
+		"RelationById",
+		"Resolved",
+		"StorageAttachmentLife",
+		"StorageAttachments",
+		"UnitStatus",
+		"UnitStorageAttachments",
+		"UnitStorageConstraints",
+		"WatchActionNotifications",
+		"WatchAPIHostPorts",
+		"WatchApplicationRelations",
+		"WatchConfigSettings",
+		"WatchForModelConfigChanges",
+		"WatchLeadershipSettings",
+		"WatchMeterStatus",
+		"WatchRelationUnits",
+		"WatchStorageAttachments",
+		"WatchUnitAddresses",
+		"WatchUnitStorageAttachments",
+		"WorkloadVersion",
+		"SetStatus",
+		"SetAgentStatus",
+		"SetUnitStatus",
+		"SetApplicationStatus",
+	),
+	"Deployer": set.NewStrings(
+		"Life",
+		"WatchUnits",
+		"APIAddresses",
+		"APIHostPorts",
+		"CACert",
+		"ConnectionInfo",
+		"ModelUUID",
+		"StateAddresses",
+		"WatchAPIHostPorts",
+		"SetStatus",
+	),
+	"Provisioner": set.NewStrings(
+		"Life",
+		"Status",
+		"InstanceId",
+		"InstanceStatus",
+		"Constraints",
+		"ContainerConfig",
+		"ContainerManagerConfig",
+		"ControllerConfig",
+		"DistributionGroup",
+		"FindTools",
+		"MachinesWithTransientErrors",
+		"ModelConfig",
+		"ProvisioningInfo",
+		"Series",
+		"Tools",
+		"WatchAllContainers",
+		"WatchContainers",
+		"WatchForModelConfigChanges",
+		"WatchMachineErrorRetry",
+		"WatchModelMachines",
+		"SetStatus",
+		"SetInstanceStatus",
+	),
+	"Firewaller": set.NewStrings(
+		"Life",
+		"Watch",
+		"InstanceId",
+		"GetAssignedMachine",
+		"GetExposed",
+		"GetExposedSourceCIDRs",
+		"GetMachineActiveSubnets",
+		"GetMachinePorts",
+		"ModelConfig",
+		"SetStatusData",
+		"WatchForModelConfigChanges",
+		"WatchModelMachines",
+		"WatchOpenedPorts",
+		"WatchSubnets",
+		"WatchUnits",
+	),
+	"InstancePoller": set.NewStrings(
+		"Life",
+		"Status",
+		"InstanceId",
+		"InstanceStatus",
+		"AreManuallyProvisioned",
+		"ProviderAddresses",
+		"ModelConfig",
+		"WatchForModelConfigChanges",
+		"WatchModelMachines",
+		"SetInstanceStatus",
+	),
+	"UnitAssigner": set.NewStrings(
+		"WatchUnitAssignments",
+		"SetAgentStatus",
+	),
+	"Upgrader": set.NewStrings(
+		"DesiredVersion",
+		"Tools",
+		"WatchAPIVersion",
+		"SetTools",
+	),
+	"Logger": set.NewStrings(
+		"LoggingConfig",
+		"WatchLoggingConfig",
+	),
+	"ProxyUpdater": set.NewStrings(
+		"ProxyConfig",
+		"WatchForProxyConfigAndAPIHostPortChanges",
+	),
+	"KeyUpdater": set.NewStrings(
+		"AuthorisedKeys",
+		"WatchAuthorisedKeys",
+	),
+	"LifeFlag": set.NewStrings(
+		"Life",
+		"Watch",
+	),
+	"MigrationFlag": set.NewStrings(
+		"Phase",
+		"Watch",
+	),
+	"MigrationMinion": set.NewStrings(
+		"Watch",
+	),
+	"RetryStrategy": set.NewStrings(
+		"RetryStrategy",
+		"WatchRetryStrategy",
+	),
+	"Reboot": set.NewStrings(
+		"WatchForRebootEvent",
+	),
+	"MeterStatus": set.NewStrings(
+		"GetMeterStatus",
+		"WatchMeterStatus",
+	),
+}
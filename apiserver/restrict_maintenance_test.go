@@ -0,0 +1,211 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	apileadership "github.com/juju/juju/api/leadership"
+	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/api/singular"
+	apiuniter "github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/worker/leadership"
+	"github.com/juju/juju/worker/machiner"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type restrictMaintenanceSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictMaintenanceSuite{})
+
+func (r *restrictMaintenanceSuite) TestAllowedMethods(c *gc.C) {
+	root := apiserver.TestingMaintenanceRoot(nil, "upgrading mongo")
+	checkAllowed := func(facade string, version int, method string) {
+		caller, err := root.FindMethod(facade, version, method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+	checkAllowed("Client", 1, "FullStatus")
+	checkAllowed("Client", 1, "GetModelConstraints")
+	checkAllowed("Controller", 3, "ListBlockedModels")
+	checkAllowed("Controller", 3, "SetControllerMaintenance")
+	checkAllowed("SSHClient", 2, "PublicAddress")
+	checkAllowed("Pinger", 1, "Ping")
+	checkAllowed("NotifyWatcher", 1, "Next")
+	checkAllowed("Machiner", 1, "SetStatus")
+	checkAllowed("Machiner", 1, "SetMachineAddresses")
+	checkAllowed("Machiner", 1, "SetObservedNetworkConfig")
+	checkAllowed("Uniter", 4, "SetAgentStatus")
+	checkAllowed("Uniter", 4, "WatchConfigSettings")
+	checkAllowed("Uniter", 4, "Resolved")
+	checkAllowed("Upgrader", 1, "SetTools")
+	checkAllowed("Singular", 1, "Claim")
+	checkAllowed("Singular", 1, "Wait")
+	checkAllowed("LeadershipService", 2, "ClaimLeadership")
+	checkAllowed("LeadershipService", 2, "BlockUntilLeadershipReleased")
+}
+
+func (r *restrictMaintenanceSuite) TestFindDisallowedMethod(c *gc.C) {
+	root := apiserver.TestingMaintenanceRoot(nil, "upgrading mongo")
+	for _, call := range []struct {
+		facade  string
+		version int
+		method  string
+	}{
+		{"Client", 1, "AddMachines"},
+		{"Client", 1, "ModelSet"},
+		{"Controller", 3, "DestroyController"},
+		{"Uniter", 4, "SetCharmURL"},
+		{"Uniter", 4, "Merge"},
+	} {
+		caller, err := root.FindMethod(call.facade, call.version, call.method)
+		c.Check(params.IsCodeMaintenanceInProgress(err), jc.IsTrue)
+		c.Check(err, gc.ErrorMatches, "controller is in maintenance mode: upgrading mongo")
+		c.Check(caller, gc.IsNil)
+	}
+}
+
+func (r *restrictMaintenanceSuite) TestIsMethodAllowedDuringMaintenance(c *gc.C) {
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Application", "Deploy"), jc.IsFalse)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Application", "Get"), jc.IsTrue)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Provisioner", "SetInstanceInfo"), jc.IsFalse)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Provisioner", "SetInstanceStatus"), jc.IsTrue)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Cleaner", "Cleanup"), jc.IsFalse)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Singular", "Claim"), jc.IsTrue)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("LeadershipService", "ClaimLeadership"), jc.IsTrue)
+}
+
+func (r *restrictMaintenanceSuite) TestUnlistedMethodsBlocked(c *gc.C) {
+	// Methods are only allowed if they are listed, whatever
+	// their names suggest.
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Application", "GetAndReset"), jc.IsFalse)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("Client", "WatchAndDestroy"), jc.IsFalse)
+	c.Check(apiserver.IsMethodAllowedDuringMaintenance("NewFacade", "SetStatus"), jc.IsFalse)
+}
+
+type maintenanceAgentSuite struct {
+	jujutesting.JujuConnSuite
+}
+
+var _ = gc.Suite(&maintenanceAgentSuite{})
+
+// waitForMaintenance waits until the API server blocks a harmless
+// mutating call made over the given unit agent's connection.
+func (s *maintenanceAgentSuite) waitForMaintenance(c *gc.C, conn api.Connection, tag names.Tag) {
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	version := conn.BestFacadeVersion("Uniter")
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		err := conn.APICall("Uniter", version, "", "ClearResolved", args, nil)
+		if params.IsCodeMaintenanceInProgress(err) {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for maintenance mode")
+}
+
+func (s *maintenanceAgentSuite) TestAgentsKeepLeadership(c *gc.C) {
+	controller, controllerPassword := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	unit, unitPassword := s.Factory.MakeUnitReturningPassword(c, nil)
+	controllerConn := s.OpenAPIAsMachine(c, controller.Tag(), controllerPassword, "nonce")
+	unitConn := s.OpenAPIAs(c, unit.Tag(), unitPassword)
+
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForMaintenance(c, unitConn, unit.Tag())
+
+	claimer := apileadership.NewClient(unitConn)
+	err = claimer.ClaimLeadership(unit.ApplicationName(), unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	// Extending the claim keeps the unit the leader.
+	err = claimer.ClaimLeadership(unit.ApplicationName(), unit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	singularAPI, err := singular.NewAPI(controllerConn, controller.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = singularAPI.Claim(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = singularAPI.Claim(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *maintenanceAgentSuite) TestMachinerRuns(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, nil)
+	conn := s.OpenAPIAsMachine(c, m.Tag(), password, "nonce")
+	unit, unitPassword := s.Factory.MakeUnitReturningPassword(c, nil)
+	unitConn := s.OpenAPIAs(c, unit.Tag(), unitPassword)
+
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForMaintenance(c, unitConn, unit.Tag())
+
+	w, err := machiner.NewMachiner(machiner.Config{
+		MachineAccessor: machiner.APIMachineAccessor{State: apimachiner.NewState(conn)},
+		Tag:             m.MachineTag(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The machiner reports the machine's addresses and network
+	// config before it marks the machine started.
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		if statusInfo.Status == status.Started {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("timed out waiting for machine to be started")
+		}
+	}
+	workertest.CheckAlive(c, w)
+}
+
+func (s *maintenanceAgentSuite) TestUniterRemoteStateWatcherRuns(c *gc.C) {
+	unit, password := s.Factory.MakeUnitReturningPassword(c, nil)
+	conn := s.OpenAPIAs(c, unit.Tag(), password)
+
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForMaintenance(c, conn, unit.Tag())
+
+	tracker := leadership.NewTracker(unit.UnitTag(), apileadership.NewClient(conn), clock.WallClock, time.Minute)
+	defer workertest.CleanKill(c, tracker)
+	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:             remotestate.NewAPIState(apiuniter.NewState(conn, unit.UnitTag())),
+		LeadershipTracker: tracker,
+		UnitTag:           unit.UnitTag(),
+		UpdateStatusChannel: func() <-chan time.Time {
+			return nil
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The watcher signals once it has read the unit's initial
+	// state, including its resolved mode.
+	select {
+	case <-w.RemoteStateChanged():
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for remote state")
+	}
+	workertest.CheckAlive(c, w)
+}
@@ -0,0 +1,10 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+// NewRollingUpgraderAPIForTest returns a RollingUpgraderAPI that uses
+// the supplied backend.
+func NewRollingUpgraderAPIForTest(backend Backend) *RollingUpgraderAPI {
+	return &RollingUpgraderAPI{backend: backend}
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("RollingUpgrader", 1, NewRollingUpgraderAPI)
+}
+
+// Backend defines the state methods used by the RollingUpgrader facade.
+type Backend interface {
+	AdvanceRollingUpgrades() error
+}
+
+// RollingUpgraderAPI implements the API used by the worker that
+// releases the units of an application to a new charm in batches.
+type RollingUpgraderAPI struct {
+	backend Backend
+}
+
+// NewRollingUpgraderAPI creates a new server-side RollingUpgrader API
+// facade.
+func NewRollingUpgraderAPI(
+	st *state.State,
+	_ facade.Resources,
+	authorizer facade.Authorizer,
+) (*RollingUpgraderAPI, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &RollingUpgraderAPI{backend: st}, nil
+}
+
+// AdvanceRollingUpgrades checks the progress of all running rolling
+// upgrades in the model, releasing the next batch of units of each
+// upgrade whose previous batch has completed, and pausing any upgrade
+// with units in error.
+func (api *RollingUpgraderAPI) AdvanceRollingUpgrades() (params.ErrorResult, error) {
+	if err := api.backend.AdvanceRollingUpgrades(); err != nil {
+		return params.ErrorResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ErrorResult{}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/rollingupgrader"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type rollingUpgraderSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&rollingUpgraderSuite{})
+
+func (s *rollingUpgraderSuite) TestNewAPIRefusesNonController(c *gc.C) {
+	api, err := rollingupgrader.NewRollingUpgraderAPI(nil, nil, apiservertesting.FakeAuthorizer{})
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *rollingUpgraderSuite) TestAdvanceRollingUpgrades(c *gc.C) {
+	backend := &mockBackend{}
+	api := rollingupgrader.NewRollingUpgraderAPIForTest(backend)
+	result, err := api.AdvanceRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResult{})
+	c.Assert(backend.calls, gc.Equals, 1)
+}
+
+func (s *rollingUpgraderSuite) TestAdvanceRollingUpgradesError(c *gc.C) {
+	backend := &mockBackend{err: errors.New("boom")}
+	api := rollingupgrader.NewRollingUpgraderAPIForTest(backend)
+	result, err := api.AdvanceRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	calls int
+	err   error
+}
+
+func (b *mockBackend) AdvanceRollingUpgrades() error {
+	b.calls++
+	return b.err
+}
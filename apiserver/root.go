@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"golang.org/x/net/context"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/tracing"
 )
 
 var (
@@ -50,6 +52,11 @@ type apiHandler struct {
 	resources *common.Resources
 	entity    state.Entity
 
+	// apiToken holds the API token the entity logged in with, if
+	// any. The access of such a login is limited to that granted by
+	// the token.
+	apiToken *state.APIToken
+
 	// An empty modelUUID means that the user has logged in through the
 	// root of the API server rather than the /model/:model-uuid/api
 	// path, logins processed with v2 or later will only offer the
@@ -85,6 +92,9 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 	if err := r.resources.RegisterNamed("applicationOffersApiFactory", apiFactory); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := r.resources.RegisterNamed("apiTokenMinter", apiTokenMinterResource{srv.authCtxt}); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return r, nil
 }
 
@@ -107,9 +117,10 @@ func (r *apiHandler) Kill() {
 // available for an RPC call and allow the RPC code to instantiate an object
 // and place a call on its method.
 type srvCaller struct {
+	name      string
 	objMethod rpcreflect.ObjMethod
 	goType    reflect.Type
-	creator   func(id string) (reflect.Value, error)
+	creator   func(ctx context.Context, id string) (reflect.Value, error)
 }
 
 // ParamsType defines the parameters that should be supplied to this function.
@@ -126,12 +137,21 @@ func (s *srvCaller) ResultType() reflect.Type {
 
 // Call takes the object Id and an instance of ParamsType to create an object and place
 // a call on its method. It then returns an instance of ResultType.
-func (s *srvCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
-	objVal, err := s.creator(objId)
+// If the request is being traced, the call is recorded as a child
+// span of the request, the facade is given a state that traces the
+// queries and transactions it runs, and facade methods that take a
+// context are passed one carrying that span.
+func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	span := tracing.StartChildSpan(ctx, s.name)
+	defer span.Finish()
+	span.SetTag("component", "facade")
+	ctx = tracing.ContextWithSpan(ctx, span)
+
+	objVal, err := s.creator(ctx, objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return s.objMethod.Call(objVal, arg)
+	return s.objMethod.Call(ctx, objVal, arg)
 }
 
 // apiRoot implements basic method dispatching to the facade registry.
@@ -173,8 +193,16 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		return nil, err
 	}
 
-	creator := func(id string) (reflect.Value, error) {
+	creator := func(ctx context.Context, id string) (reflect.Value, error) {
 		objKey := objectKey{name: rootName, version: version, objId: id}
+		if tracing.SpanFromContext(ctx) != nil {
+			// Facades are shared by all the calls made on the
+			// connection, so a traced call gets its own, using a
+			// state which records its operations under the call.
+			facadeCtx := r.facadeContext(objKey)
+			facadeCtx.state = r.state.WithContext(ctx)
+			return r.newFacade(rootName, version, goType, facadeCtx)
+		}
 		r.objectMutex.RLock()
 		objValue, ok := r.objectCache[objKey]
 		r.objectMutex.RUnlock()
@@ -188,42 +216,53 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		}
 		// Now that we have the write lock, check one more time in case
 		// someone got the write lock before us.
-		factory, err := common.Facades.GetFactory(rootName, version)
+		objValue, err := r.newFacade(rootName, version, goType, r.facadeContext(objKey))
 		if err != nil {
-			// We don't check for IsNotFound here, because it
-			// should have already been handled in the GetType
-			// check.
 			return reflect.Value{}, err
 		}
-		obj, err := factory(r.facadeContext(objKey))
-		if err != nil {
-			return reflect.Value{}, err
-		}
-		objValue = reflect.ValueOf(obj)
-		if !objValue.Type().AssignableTo(goType) {
-			return reflect.Value{}, errors.Errorf(
-				"internal error, %s(%d) claimed to return %s but returned %T",
-				rootName, version, goType, obj)
-		}
-		if goType.Kind() == reflect.Interface {
-			// If the original function wanted to return an
-			// interface type, the indirection in the factory via
-			// an interface{} strips the original interface
-			// information off. So here we have to create the
-			// interface again, and assign it.
-			asInterface := reflect.New(goType).Elem()
-			asInterface.Set(objValue)
-			objValue = asInterface
-		}
 		r.objectCache[objKey] = objValue
 		return objValue, nil
 	}
 	return &srvCaller{
+		name:      rootName + "." + methodName,
 		creator:   creator,
 		objMethod: objMethod,
 	}, nil
 }
 
+// newFacade creates the facade with the given name and version, which
+// must be of the given type, for the given context.
+func (r *apiRoot) newFacade(rootName string, version int, goType reflect.Type, ctx *facadeContext) (reflect.Value, error) {
+	factory, err := common.Facades.GetFactory(rootName, version)
+	if err != nil {
+		// We don't check for IsNotFound here, because it
+		// should have already been handled in the GetType
+		// check.
+		return reflect.Value{}, err
+	}
+	obj, err := factory(ctx)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	objValue := reflect.ValueOf(obj)
+	if !objValue.Type().AssignableTo(goType) {
+		return reflect.Value{}, errors.Errorf(
+			"internal error, %s(%d) claimed to return %s but returned %T",
+			rootName, version, goType, obj)
+	}
+	if goType.Kind() == reflect.Interface {
+		// If the original function wanted to return an
+		// interface type, the indirection in the factory via
+		// an interface{} strips the original interface
+		// information off. So here we have to create the
+		// interface again, and assign it.
+		asInterface := reflect.New(goType).Elem()
+		asInterface.Set(objValue)
+		objValue = asInterface
+	}
+	return objValue, nil
+}
+
 func (r *apiRoot) dispose(key objectKey) {
 	r.objectMutex.Lock()
 	defer r.objectMutex.Unlock()
@@ -232,15 +271,17 @@ func (r *apiRoot) dispose(key objectKey) {
 
 func (r *apiRoot) facadeContext(key objectKey) *facadeContext {
 	return &facadeContext{
-		r:   r,
-		key: key,
+		r:     r,
+		key:   key,
+		state: r.state,
 	}
 }
 
 // facadeContext implements facade.Context
 type facadeContext struct {
-	r   *apiRoot
-	key objectKey
+	r     *apiRoot
+	key   objectKey
+	state *state.State
 }
 
 // Abort is part of of the facade.Context interface.
@@ -265,7 +306,7 @@ func (ctx *facadeContext) Resources() facade.Resources {
 
 // State is part of of the facade.Context interface.
 func (ctx *facadeContext) State() *state.State {
-	return ctx.r.state
+	return ctx.state
 }
 
 // StatePool is part of of the facade.Context interface.
@@ -388,12 +429,22 @@ func (r *apiHandler) GetAuthEntity() state.Entity {
 
 // HasPermission returns true if the logged in user can perform <operation> on <target>.
 func (r *apiHandler) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserAccess, r.entity.Tag(), operation, target)
+	return common.HasPermission(r.userAccess, r.entity.Tag(), operation, target)
 }
 
 // UserHasPermission returns true if the passed in user can perform <operation> on <target>.
 func (r *apiHandler) UserHasPermission(user names.UserTag, operation permission.Access, target names.Tag) (bool, error) {
-	return common.HasPermission(r.state.UserAccess, user, operation, target)
+	return common.HasPermission(r.userAccess, user, operation, target)
+}
+
+// userAccess returns the access the subject has on the target, limited
+// by the API token used to log in, if any.
+func (r *apiHandler) userAccess(subject names.UserTag, target names.Tag) (permission.UserAccess, error) {
+	access, err := r.state.EffectiveUserAccess(subject, target)
+	if err != nil || r.apiToken == nil {
+		return access, err
+	}
+	return limitAPITokenAccess(r.apiToken, subject, target, access)
 }
 
 // DescribeFacades returns the list of available Facades and their Versions
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc/jsonschema"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// schemaHandler serves the JSON schemas of the registered API facades
+// to authenticated users. The "facade" query parameter may be used to
+// restrict the response to the versions of a single facade.
+type schemaHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements http.Handler.
+func (h *schemaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if err := h.serveGet(w, req); err != nil {
+		if err := sendError(w, errors.Trace(err)); err != nil {
+			logger.Errorf("%v", err)
+		}
+	}
+}
+
+func (h *schemaHandler) serveGet(w http.ResponseWriter, req *http.Request) error {
+	if req.Method != "GET" {
+		return errors.MethodNotAllowedf("unsupported method: %q", req.Method)
+	}
+	_, releaser, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	releaser()
+
+	schemas, err := facadeSchemas(common.Facades, req.URL.Query().Get("facade"))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, schemas))
+}
+
+// facadeSchemas returns the JSON schemas of all versions of the
+// facades in the given registry, or of the named facade if name is
+// non-empty.
+func facadeSchemas(registry *facade.Registry, name string) (params.FacadeSchemas, error) {
+	var result params.FacadeSchemas
+	for _, description := range registry.List() {
+		if name != "" && description.Name != name {
+			continue
+		}
+		for _, version := range description.Versions {
+			facadeType, err := registry.GetType(description.Name, version)
+			if err != nil {
+				return params.FacadeSchemas{}, errors.Trace(err)
+			}
+			result.Facades = append(result.Facades, params.FacadeSchema{
+				Name:    description.Name,
+				Version: version,
+				Schema:  jsonschema.ForObjType(rpcreflect.ObjTypeOf(facadeType)),
+			})
+		}
+	}
+	if name != "" && len(result.Facades) == 0 {
+		return params.FacadeSchemas{}, errors.NotFoundf("facade %q", name)
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type schemaSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) schemaURL(c *gc.C, facade string) string {
+	u := s.baseURL(c)
+	u.Path = "/schema"
+	if facade != "" {
+		u.RawQuery = "facade=" + facade
+	}
+	return u.String()
+}
+
+func (s *schemaSuite) TestGetSchema(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.schemaURL(c, "Pinger"),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var schemas params.FacadeSchemas
+	err := json.Unmarshal(body, &schemas)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(schemas.Facades, gc.Not(gc.HasLen), 0)
+	for _, schema := range schemas.Facades {
+		c.Check(schema.Name, gc.Equals, "Pinger")
+		c.Check(schema.Schema["properties"], jc.DeepEquals, map[string]interface{}{
+			"Ping": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+			"Stop": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		})
+	}
+}
+
+func (s *schemaSuite) TestGetSchemaAllFacades(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.schemaURL(c, ""),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var schemas params.FacadeSchemas
+	err := json.Unmarshal(body, &schemas)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	names := make(map[string]bool)
+	for _, schema := range schemas.Facades {
+		names[schema.Name] = true
+	}
+	c.Assert(names["Client"], jc.IsTrue)
+	c.Assert(names["Application"], jc.IsTrue)
+}
+
+func (s *schemaSuite) TestGetSchemaUnknownFacade(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.schemaURL(c, "NoSuchFacade"),
+	})
+	body := assertResponse(c, resp, http.StatusNotFound, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Equals, `facade "NoSuchFacade" not found`)
+}
+
+func (s *schemaSuite) TestGetSchemaMethodNotAllowed(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "POST",
+		url:    s.schemaURL(c, ""),
+	})
+	body := assertResponse(c, resp, http.StatusMethodNotAllowed, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, `unsupported method: "POST"`)
+}
+
+func (s *schemaSuite) TestGetSchemaUnauthorized(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.schemaURL(c, ""),
+	})
+	assertResponse(c, resp, http.StatusUnauthorized, params.ContentTypeJSON)
+}
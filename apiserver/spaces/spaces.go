@@ -5,6 +5,7 @@ package spaces
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
@@ -16,12 +17,16 @@ import (
 
 func init() {
 	common.RegisterStandardFacade("Spaces", 2, NewAPI)
+
+	// Version 3 adds MoveSubnets.
+	common.RegisterStandardFacade("Spaces", 3, NewAPI)
 }
 
 // API defines the methods the Spaces API facade implements.
 type API interface {
 	CreateSpaces(params.CreateSpacesParams) (params.ErrorResults, error)
 	ListSpaces() (params.ListSpacesResults, error)
+	MoveSubnets(params.MoveSubnetsParams) (params.MoveSubnetsResults, error)
 }
 
 // spacesAPI implements the API interface.
@@ -106,3 +111,48 @@ func (api *spacesAPI) ListSpaces() (results params.ListSpacesResults, err error)
 	}
 	return results, nil
 }
+
+// MoveSubnets moves subnets from their current spaces into the given
+// space. The names of the applications whose units were told about the
+// move are returned for each argument.
+func (api *spacesAPI) MoveSubnets(args params.MoveSubnetsParams) (params.MoveSubnetsResults, error) {
+	results := params.MoveSubnetsResults{
+		Results: make([]params.MoveSubnetsResult, len(args.Args)),
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backing.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return results, errors.Trace(err)
+	}
+	if !isAdmin {
+		return results, common.ServerError(common.ErrPerm)
+	}
+	if err := networkingcommon.SupportsSpaces(api.backing); err != nil {
+		return results, common.ServerError(errors.Trace(err))
+	}
+
+	for i, arg := range args.Args {
+		applications, err := api.moveSubnets(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Applications = applications
+	}
+	return results, nil
+}
+
+func (api *spacesAPI) moveSubnets(arg params.MoveSubnetsParam) ([]string, error) {
+	spaceTag, err := names.ParseSpaceTag(arg.SpaceTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := make([]string, len(arg.SubnetTags))
+	for i, tag := range arg.SubnetTags {
+		subnetTag, err := names.ParseSubnetTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs[i] = subnetTag.Id()
+	}
+	return api.backing.MoveSubnetsToSpace(spaceTag.Id(), cidrs, arg.Force)
+}
@@ -357,3 +357,60 @@ func (s *SpacesSuite) TestListSpacesNotSupportedError(c *gc.C) {
 	_, err := s.facade.ListSpaces()
 	c.Assert(err, gc.ErrorMatches, "spaces not supported")
 }
+
+func (s *SpacesSuite) TestMoveSubnets(c *gc.C) {
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetsParam{{
+			SpaceTag:   "space-dmz",
+			SubnetTags: []string{"subnet-10.0.0.0/24", "subnet-10.1.0.0/24"},
+			Force:      true,
+		}, {
+			SpaceTag:   "bad-tag",
+			SubnetTags: []string{"subnet-10.0.0.0/24"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Applications, jc.DeepEquals, []string{"mysql"})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+
+	apiservertesting.CheckMethodCalls(c, apiservertesting.SharedStub,
+		apiservertesting.BackingCall("ModelConfig"),
+		apiservertesting.BackingCall("CloudSpec"),
+		apiservertesting.ProviderCall("Open", apiservertesting.BackingInstance.EnvConfig),
+		apiservertesting.ZonedNetworkingEnvironCall("SupportsSpaces"),
+		apiservertesting.BackingCall("MoveSubnetsToSpace", "dmz", []string{"10.0.0.0/24", "10.1.0.0/24"}, true),
+	)
+}
+
+func (s *SpacesSuite) TestMoveSubnetsError(c *gc.C) {
+	apiservertesting.SharedStub.SetErrors(
+		nil, // Backing.ModelConfig()
+		nil, // Backing.CloudSpec()
+		nil, // Provider.Open()
+		nil, // ZonedNetworkingEnviron.SupportsSpaces()
+		errors.New("bindings would be left unsatisfiable"), // Backing.MoveSubnetsToSpace()
+	)
+
+	results, err := s.facade.MoveSubnets(params.MoveSubnetsParams{
+		Args: []params.MoveSubnetsParam{{
+			SpaceTag:   "space-dmz",
+			SubnetTags: []string{"subnet-10.0.0.0/24"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "bindings would be left unsatisfiable")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsNotAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	facade, err := spaces.NewAPIWithBacking(
+		apiservertesting.BackingInstance, s.resources, s.authorizer,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.MoveSubnets(params.MoveSubnetsParams{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	apiservertesting.CheckMethodCalls(c, apiservertesting.SharedStub)
+}
@@ -518,6 +518,14 @@ func (sb *StubBacking) AddSpace(name string, providerId network.Id, subnets []st
 	return nil
 }
 
+func (sb *StubBacking) MoveSubnetsToSpace(spaceName string, cidrs []string, force bool) ([]string, error) {
+	sb.MethodCall(sb, "MoveSubnetsToSpace", spaceName, cidrs, force)
+	if err := sb.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{"mysql"}, nil
+}
+
 // GoString implements fmt.GoStringer.
 func (se *StubBacking) GoString() string {
 	return "&StubBacking{}"
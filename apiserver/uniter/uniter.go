@@ -223,11 +223,12 @@ func (u *UniterAPIV3) getOneMachinePorts(canAccess common.AuthFunc, machineTag s
 		}
 		network.SortPortRanges(portRanges)
 		for _, portRange := range portRanges {
-			unitName := portRangesToUnits[portRange]
-			resultPorts = append(resultPorts, params.MachinePortRange{
-				UnitTag:   names.NewUnitTag(unitName).String(),
-				PortRange: params.FromNetworkPortRange(portRange),
-			})
+			for _, unitName := range portRangesToUnits[portRange] {
+				resultPorts = append(resultPorts, params.MachinePortRange{
+					UnitTag:   names.NewUnitTag(unitName).String(),
+					PortRange: params.FromNetworkPortRange(portRange),
+				})
+			}
 		}
 	}
 	return params.MachinePortsResult{
@@ -573,9 +574,52 @@ func (u *UniterAPIV3) charmModifiedVersion(tagStr string, canAccess func(names.T
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
+	upgrade, err := u.holdingRollingUpgrade(service)
+	if err != nil {
+		return -1, err
+	}
+	if upgrade != nil {
+		return upgrade.FromCharmModifiedVersion(), nil
+	}
 	return service.CharmModifiedVersion(), nil
 }
 
+// holdingRollingUpgrade returns the rolling upgrade of the application,
+// if there is one that holds the authenticated unit back on the charm
+// it is running.
+func (u *UniterAPIV3) holdingRollingUpgrade(app *state.Application) (*state.RollingUpgrade, error) {
+	upgrade, err := app.RollingUpgrade()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !upgrade.Holds(u.auth.GetAuthTag().Id()) {
+		return nil, nil
+	}
+	return upgrade, nil
+}
+
+// heldCharmURL returns the charm URL that the authenticated unit
+// should upgrade to, taking into account any rolling upgrade of the
+// application that has not yet released the unit.
+func (u *UniterAPIV3) heldCharmURL(app *state.Application) (*charm.URL, bool, error) {
+	curl, force := app.CharmURL()
+	upgrade, err := u.holdingRollingUpgrade(app)
+	if err != nil || upgrade == nil {
+		return curl, force, err
+	}
+	unit, err := u.st.Unit(u.auth.GetAuthTag().Id())
+	if err != nil {
+		return nil, false, err
+	}
+	if unitURL, _ := unit.CharmURL(); unitURL != nil {
+		// The unit keeps its charm until it is released.
+		return unitURL, false, nil
+	}
+	return curl, force, nil
+}
+
 // CharmURL returns the charm URL for all given units or services.
 func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults, error) {
 	result := params.StringBoolResults{
@@ -597,10 +641,16 @@ func (u *UniterAPIV3) CharmURL(args params.Entities) (params.StringBoolResults,
 			var unitOrService state.Entity
 			unitOrService, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrService.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if app, isApp := unitOrService.(*state.Application); isApp {
+					curl, ok, err = u.heldCharmURL(app)
+				} else {
+					charmURLer := unitOrService.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -1628,6 +1678,12 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		return nil, errors.Trace(err)
 	}
 
+	modelConfig, err := u.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	family := modelConfig.PreferredIPFamily()
+
 	var results []params.NetworkConfig
 	if boundSpace == "" {
 		logger.Debugf(
@@ -1643,6 +1699,17 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		results = append(results, params.NetworkConfig{
 			Address: privateAddress.Value,
 		})
+		if family == network.DualStack {
+			// Publish the best address of the other IP family after
+			// the preferred one.
+			for _, addr := range network.SelectIngressAddresses(machine.Addresses(), false, family) {
+				if addr.Type != privateAddress.Type {
+					results = append(results, params.NetworkConfig{
+						Address: addr.Value,
+					})
+				}
+			}
+		}
 		return results, nil
 	} else {
 		logger.Debugf("endpoint %q is explicitly bound to space %q", bindingName, boundSpace)
@@ -1662,6 +1729,7 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		machineID, addresses, unit.Name(), service.Name(), bindings,
 	)
 
+	var spaceAddresses []network.Address
 	for _, addr := range addresses {
 		subnet, err := addr.Subnet()
 		if errors.IsNotFound(err) {
@@ -1677,10 +1745,22 @@ func (u *UniterAPIV3) getOneNetworkConfig(canAccess common.AuthFunc, unitTagArg,
 		}
 		logger.Debugf("endpoint %q bound to space %q has address %q", bindingName, boundSpace, addr)
 
-		// TODO(dimitern): Fill in the rest later (see linked LKK card above).
-		results = append(results, params.NetworkConfig{
-			Address: addr.Value(),
-		})
+		spaceAddresses = append(spaceAddresses, network.NewAddress(addr.Value()))
+	}
+
+	// Addresses of the model's preferred IP family come first, so the
+	// binding's primary address is taken from that family when possible.
+	preferredType := family.PreferredType()
+	for _, preferred := range []bool{true, false} {
+		for _, addr := range spaceAddresses {
+			if (addr.Type == preferredType) != preferred {
+				continue
+			}
+			// TODO(dimitern): Fill in the rest later (see linked LKK card above).
+			results = append(results, params.NetworkConfig{
+				Address: addr.Value,
+			})
+		}
 	}
 
 	return results, nil
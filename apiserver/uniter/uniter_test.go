@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/apiserver/uniter"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -854,6 +856,40 @@ func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLHeldByRollingUpgrade(c *gc.C) {
+	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	oldVersion := s.wordpress.CharmModifiedVersion()
+	newCharm := s.Factory.MakeCharm(c, &jujuFactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm:          newCharm,
+		RollingUpgrade: state.RollingUpgradeArgs{BatchSize: 1},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	assertCharm := func(curl *charm.URL, version int) {
+		urlResult, err := s.uniter.CharmURL(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(urlResult.Results, jc.DeepEquals, []params.StringBoolResult{{Result: curl.String()}})
+		versionResult, err := s.uniter.CharmModifiedVersion(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(versionResult.Results, jc.DeepEquals, []params.IntResult{{Result: version}})
+	}
+
+	// The unit is held back on its charm until it is released.
+	assertCharm(s.wpCharm.URL(), oldVersion)
+
+	upgrade, err := s.wordpress.RollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	assertCharm(newCharm.URL(), oldVersion+1)
+}
+
 func (s *uniterSuite) TestOpenPorts(c *gc.C) {
 	openedPorts, err := s.wordpressUnit.OpenedPorts()
 	c.Assert(err, jc.ErrorIsNil)
@@ -2656,3 +2692,31 @@ func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpoint(c
 		},
 	})
 }
+
+func (s *uniterNetworkConfigSuite) TestNetworkConfigForImplicitlyBoundEndpointDualStack(c *gc.C) {
+	err := s.base.State.UpdateModelConfig(map[string]interface{}{
+		config.PreferredIPFamilyKey: "dual",
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	addresses := append(s.base.machine1.ProviderAddresses(), network.NewAddress("fd00::20"))
+	err = s.base.machine1.SetProviderAddresses(addresses...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setupUniterAPIForUnit(c, s.base.mysqlUnit)
+	args := params.UnitsNetworkConfig{Args: []params.UnitNetworkConfig{
+		{BindingName: "server", UnitTag: s.base.mysqlUnit.Tag().String()},
+	}}
+
+	// The preferred private address comes first, followed by the best
+	// address of the other IP family.
+	result, err := s.base.uniter.NetworkConfig(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UnitNetworkConfigResults{
+		Results: []params.UnitNetworkConfigResult{
+			{Config: []params.NetworkConfig{
+				{Address: "10.0.0.20"},
+				{Address: "fd00::20"},
+			}},
+		},
+	})
+}
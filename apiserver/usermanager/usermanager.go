@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
@@ -23,12 +24,19 @@ func init() {
 	common.RegisterStandardFacade("UserManager", 1, NewUserManagerAPI)
 }
 
+// APITokenMinter mints the macaroons that are presented when logging
+// in with API tokens.
+type APITokenMinter interface {
+	MintAPIToken(id string, user names.UserTag, expiry time.Time) (*macaroon.Macaroon, error)
+}
+
 // UserManagerAPI implements the user manager interface and is the concrete
 // implementation of the api end point.
 type UserManagerAPI struct {
 	state      *state.State
 	authorizer facade.Authorizer
 	check      *common.BlockChecker
+	minter     APITokenMinter
 	apiUser    names.UserTag
 	isAdmin    bool
 }
@@ -52,10 +60,14 @@ func NewUserManagerAPI(
 		return nil, errors.Trace(err)
 	}
 
+	// The minter is only available when running in the API server.
+	minter, _ := resources.Get("apiTokenMinter").(APITokenMinter)
+
 	return &UserManagerAPI{
 		state:      st,
 		authorizer: authorizer,
 		check:      common.NewBlockChecker(st),
+		minter:     minter,
 		apiUser:    apiUser,
 		isAdmin:    isAdmin,
 	}, nil
@@ -280,6 +292,22 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		}
 	}
 
+	var groupsForUser = func(userTag names.UserTag, result *params.UserInfoResult) {
+		if result.Result == nil {
+			return
+		}
+		// Lookup the groups from which the user inherits access.
+		groups, err := api.state.UserGroups(userTag)
+		if err != nil {
+			result.Result = nil
+			result.Error = common.ServerError(err)
+			return
+		}
+		for _, group := range groups {
+			result.Result.Groups = append(result.Result.Groups, group.Name())
+		}
+	}
+
 	var infoForUser = func(user *state.User) params.UserInfoResult {
 		var lastLogin *time.Time
 		userLastLogin, err := user.LastLogin()
@@ -301,6 +329,7 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 			},
 		}
 		accessForUser(user.UserTag(), &result)
+		groupsForUser(user.UserTag(), &result)
 		return result
 	}
 
@@ -338,6 +367,7 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 				},
 			}
 			accessForUser(userTag, &result)
+			groupsForUser(userTag, &result)
 			results.Results = append(results.Results, result)
 			continue
 		}
@@ -352,6 +382,109 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 	return results, nil
 }
 
+// AddGroup adds one or more groups to the controller.
+func (api *UserManagerAPI) AddGroup(args params.AddGroups) (params.ErrorResults, error) {
+	var result params.ErrorResults
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Groups))
+	for i, arg := range args.Groups {
+		if _, err := api.state.AddGroup(arg.Name, api.apiUser.Id()); err != nil {
+			err = errors.Annotate(err, "failed to create group")
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// ModifyGroupMembers adds users to, or removes users from, groups.
+func (api *UserManagerAPI) ModifyGroupMembers(args params.ModifyGroupMembers) (params.ErrorResults, error) {
+	var result params.ErrorResults
+
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isSuperUser {
+		return result, common.ErrPerm
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Changes))
+	for i, arg := range args.Changes {
+		result.Results[i].Error = common.ServerError(api.modifyGroupMember(arg))
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) modifyGroupMember(arg params.ModifyGroupMember) error {
+	userTag, err := names.ParseUserTag(arg.UserTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	group, err := api.state.Group(arg.Group)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch arg.Action {
+	case params.AddGroupMember:
+		return errors.Trace(group.AddMember(userTag))
+	case params.RemoveGroupMember:
+		return errors.Trace(group.RemoveMember(userTag))
+	}
+	return errors.Errorf("unknown action %q", arg.Action)
+}
+
+// Groups returns information on the groups on the controller. Users
+// without superuser access only see the groups of which they are a
+// member.
+func (api *UserManagerAPI) Groups() (params.GroupInfoResults, error) {
+	var result params.GroupInfoResults
+
+	isSuperUser, err := api.hasControllerAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	var groups []*state.Group
+	if isSuperUser {
+		groups, err = api.state.AllGroups()
+	} else {
+		groups, err = api.state.UserGroups(api.apiUser)
+	}
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.GroupInfo, len(groups))
+	for i, group := range groups {
+		info := params.GroupInfo{
+			Name:        group.Name(),
+			Members:     []string{},
+			CreatedBy:   group.CreatedBy(),
+			DateCreated: group.DateCreated(),
+		}
+		for _, member := range group.Members() {
+			info.Members = append(info.Members, member.Id())
+		}
+		result.Results[i] = info
+	}
+	return result, nil
+}
+
 // SetPassword changes the stored password for the specified users.
 func (api *UserManagerAPI) SetPassword(args params.EntityPasswords) (params.ErrorResults, error) {
 	if err := api.check.ChangeAllowed(); err != nil {
@@ -396,3 +529,106 @@ func (api *UserManagerAPI) setPassword(arg params.EntityPassword) error {
 	}
 	return nil
 }
+
+// AddAPITokens adds API tokens, which allow their users to access a
+// single model with limited access until they expire or are removed.
+// Users may add tokens for themselves; superusers may add tokens for
+// any local user.
+func (api *UserManagerAPI) AddAPITokens(args params.AddAPITokens) (params.AddAPITokenResults, error) {
+	result := params.AddAPITokenResults{
+		Results: make([]params.AddAPITokenResult, len(args.Tokens)),
+	}
+	if api.minter == nil {
+		return result, errors.NotSupportedf("API tokens")
+	}
+	for i, arg := range args.Tokens {
+		id, m, err := api.addAPIToken(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].ID = id
+		result.Results[i].Macaroon = m
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) addAPIToken(arg params.AddAPIToken) (string, *macaroon.Macaroon, error) {
+	user := api.apiUser
+	if arg.UserTag != "" {
+		var err error
+		user, err = names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+	}
+	if user != api.apiUser && !api.isAdmin {
+		return "", nil, common.ErrPerm
+	}
+	modelTag, err := names.ParseModelTag(arg.ModelTag)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	access := permission.Access(arg.Access)
+	if err := permission.ValidateModelAccess(access); err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	// There is no point in minting a token that grants more access
+	// than the user has.
+	canAccess, err := api.authorizer.UserHasPermission(user, access, modelTag)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if !canAccess {
+		return "", nil, errors.Errorf("user %q does not have %q access to the model", user.Id(), access)
+	}
+	token, err := api.state.AddAPIToken(state.AddAPITokenArgs{
+		User:      user,
+		Model:     modelTag,
+		Access:    access,
+		Facades:   arg.Facades,
+		Expires:   arg.Expires,
+		CreatedBy: api.apiUser,
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	m, err := api.minter.MintAPIToken(token.ID(), user, token.Expires())
+	if err != nil {
+		// Don't leave behind a token that cannot be used.
+		if err := api.state.RemoveAPIToken(token.ID()); err != nil {
+			logger.Errorf("cannot remove API token %q: %v", token.ID(), err)
+		}
+		return "", nil, errors.Trace(err)
+	}
+	return token.ID(), m, nil
+}
+
+// RemoveAPITokens removes API tokens, so that they may no longer be
+// used. Users may remove their own tokens; superusers may remove any
+// token.
+func (api *UserManagerAPI) RemoveAPITokens(args params.RemoveAPITokens) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.IDs)),
+	}
+	for i, id := range args.IDs {
+		if err := api.removeAPIToken(id); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func (api *UserManagerAPI) removeAPIToken(id string) error {
+	token, err := api.state.APIToken(id)
+	if errors.IsNotFound(err) && !api.isAdmin {
+		// Don't reveal whether other users' tokens exist.
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if token.User() != api.apiUser && !api.isAdmin {
+		return common.ErrPerm
+	}
+	return errors.Trace(api.state.RemoveAPIToken(id))
+}
@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
@@ -523,6 +524,136 @@ func (s *userManagerSuite) TestUserInfoEveryonePermission(c *gc.C) {
 	})
 }
 
+func (s *userManagerSuite) TestUserInfoInheritedAccess(c *gc.C) {
+	userAardvark := s.Factory.MakeUser(c, &factory.UserParams{Name: "aardvark", DisplayName: "Aard Vark"})
+	group, err := s.State.AddGroup("devs", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(userAardvark.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ControllerTag(), permission.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.UserInfoRequest{Entities: []params.Entity{{Tag: userAardvark.Tag().String()}}}
+	results, err := s.usermanager.UserInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UserInfoResults{
+		Results: []params.UserInfoResult{{Result: &params.UserInfo{
+			Username:       "aardvark",
+			DisplayName:    "Aard Vark",
+			Access:         "add-model",
+			CreatedBy:      s.adminName,
+			DateCreated:    userAardvark.DateCreated(),
+			LastConnection: lastLoginPointer(c, userAardvark),
+			Groups:         []string{"devs"},
+		}}},
+	})
+}
+
+func (s *userManagerSuite) TestAddGroup(c *gc.C) {
+	args := params.AddGroups{Groups: []params.AddGroup{{Name: "devs"}, {Name: "not/valid"}}}
+	result, err := s.usermanager.AddGroup(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `failed to create group: invalid group name "not/valid"`}},
+		},
+	})
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.adminName)
+}
+
+func (s *userManagerSuite) TestBlockAddGroup(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockAddGroup")
+	_, err := s.usermanager.AddGroup(params.AddGroups{Groups: []params.AddGroup{{Name: "devs"}}})
+	s.AssertBlocked(c, err, "TestBlockAddGroup")
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestAddGroupAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = usermanager.AddGroup(params.AddGroups{Groups: []params.AddGroup{{Name: "devs"}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *userManagerSuite) TestModifyGroupMembers(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	_, err := s.State.AddGroup("devs", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ModifyGroupMembers{Changes: []params.ModifyGroupMember{{
+		Group:   "devs",
+		UserTag: alex.Tag().String(),
+		Action:  params.AddGroupMember,
+	}, {
+		Group:   "ops",
+		UserTag: alex.Tag().String(),
+		Action:  params.AddGroupMember,
+	}}}
+	result, err := s.usermanager.ModifyGroupMembers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `group "ops" not found`)
+
+	group, err := s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{alex.UserTag()})
+
+	args.Changes[0].Action = params.RemoveGroupMember
+	result, err = s.usermanager.ModifyGroupMembers(params.ModifyGroupMembers{Changes: args.Changes[:1]})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	err = group.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestGroups(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	devs, err := s.State.AddGroup("devs", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+	err = devs.AddMember(alex.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	ops, err := s.State.AddGroup("ops", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.usermanager.Groups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.GroupInfoResults{
+		Results: []params.GroupInfo{{
+			Name:        "devs",
+			Members:     []string{"alex"},
+			CreatedBy:   s.adminName,
+			DateCreated: devs.DateCreated(),
+		}, {
+			Name:        "ops",
+			Members:     []string{},
+			CreatedBy:   s.adminName,
+			DateCreated: ops.DateCreated(),
+		}},
+	})
+
+	// Normal users only see the groups they are members of.
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = usermanager.Groups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Name, gc.Equals, "devs")
+}
+
 func lastLoginPointer(c *gc.C, user *state.User) *time.Time {
 	lastLogin, err := user.LastLogin()
 	if err != nil {
@@ -810,3 +941,111 @@ func (s *userManagerSuite) TestRemoveUserBulkSharedModels(c *gc.C) {
 	c.Assert(alice.IsDeleted(), jc.IsTrue)
 
 }
+
+type fakeAPITokenMinter struct {
+	id     string
+	user   names.UserTag
+	expiry time.Time
+}
+
+func (m *fakeAPITokenMinter) MintAPIToken(id string, user names.UserTag, expiry time.Time) (*macaroon.Macaroon, error) {
+	m.id, m.user, m.expiry = id, user, expiry
+	return macaroon.New([]byte("root-key"), id, "juju")
+}
+
+func (m *fakeAPITokenMinter) Stop() error {
+	return nil
+}
+
+func (s *userManagerSuite) newAPITokenUserManager(c *gc.C, tag names.UserTag) (*usermanager.UserManagerAPI, *fakeAPITokenMinter) {
+	minter := &fakeAPITokenMinter{}
+	resources := common.NewResources()
+	err := resources.RegisterNamed("apiTokenMinter", minter)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := usermanager.NewUserManagerAPI(s.State, resources, apiservertesting.FakeAuthorizer{Tag: tag})
+	c.Assert(err, jc.ErrorIsNil)
+	return api, minter
+}
+
+func (s *userManagerSuite) TestAddAPITokens(c *gc.C) {
+	api, minter := s.newAPITokenUserManager(c, s.AdminUserTag(c))
+	expires := time.Now().Add(time.Hour).Round(time.Second).UTC()
+	result, err := api.AddAPITokens(params.AddAPITokens{Tokens: []params.AddAPIToken{{
+		ModelTag: s.State.ModelTag().String(),
+		Access:   "read",
+		Facades:  []string{"Client"},
+		Expires:  expires,
+	}, {
+		ModelTag: s.State.ModelTag().String(),
+		Access:   "superuser",
+		Expires:  expires,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Macaroon, gc.NotNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"superuser" model access not valid`)
+
+	id := result.Results[0].ID
+	c.Assert(minter.id, gc.Equals, id)
+	c.Assert(minter.user, gc.Equals, s.AdminUserTag(c))
+	c.Assert(minter.expiry, gc.Equals, expires)
+
+	token, err := s.State.APIToken(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.User(), gc.Equals, s.AdminUserTag(c))
+	c.Assert(token.ModelTag(), gc.Equals, s.State.ModelTag())
+	c.Assert(token.Access(), gc.Equals, permission.ReadAccess)
+	c.Assert(token.Facades(), jc.DeepEquals, []string{"Client"})
+}
+
+func (s *userManagerSuite) TestAddAPITokensForOtherUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	api, _ := s.newAPITokenUserManager(c, alex.UserTag())
+	result, err := api.AddAPITokens(params.AddAPITokens{Tokens: []params.AddAPIToken{{
+		UserTag:  bob.Tag().String(),
+		ModelTag: s.State.ModelTag().String(),
+		Access:   "read",
+		Expires:  time.Now().Add(time.Hour),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
+
+func (s *userManagerSuite) TestAddAPITokensNotSupported(c *gc.C) {
+	_, err := s.usermanager.AddAPITokens(params.AddAPITokens{})
+	c.Assert(err, gc.ErrorMatches, "API tokens not supported")
+}
+
+func (s *userManagerSuite) TestRemoveAPITokens(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	args := state.AddAPITokenArgs{
+		User:      alex.UserTag(),
+		Model:     s.State.ModelTag(),
+		Access:    permission.ReadAccess,
+		Expires:   time.Now().Add(time.Hour),
+		CreatedBy: alex.UserTag(),
+	}
+	alexToken, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+	args.User = s.AdminUserTag(c)
+	adminToken, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, _ := s.newAPITokenUserManager(c, alex.UserTag())
+	result, err := api.RemoveAPITokens(params.RemoveAPITokens{
+		IDs: []string{alexToken.ID(), adminToken.ID(), "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, "permission denied")
+
+	_, err = s.State.APIToken(alexToken.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.APIToken(adminToken.ID())
+	c.Assert(err, jc.ErrorIsNil)
+}
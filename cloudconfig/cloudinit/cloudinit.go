@@ -5,10 +5,13 @@
 package cloudinit
 
 import (
+	"sort"
 	"strings"
 
+	"github.com/juju/errors"
 	"github.com/juju/utils/packaging/commands"
 	"github.com/juju/utils/packaging/config"
+	"github.com/juju/utils/set"
 	"github.com/juju/utils/shell"
 	"github.com/juju/utils/ssh"
 )
@@ -229,6 +232,81 @@ func (cfg *cloudConfig) BootCmds() []string {
 	return cmds
 }
 
+// MergeUserData is defined on the UserDataConfig interface.
+func (cfg *cloudConfig) MergeUserData(userData map[string]interface{}) ([]string, error) {
+	var ignored []string
+	for key, value := range userData {
+		switch key {
+		case "preruncmd", "postruncmd", "packages", "bootcmd":
+			list, err := userDataStringList(value)
+			if err != nil {
+				return nil, errors.Annotatef(err, "cloud-init userdata key %q", key)
+			}
+			switch key {
+			case "preruncmd":
+				cfg.attrs["runcmd"] = append(list, cfg.RunCmds()...)
+			case "postruncmd":
+				cfg.AddScripts(list...)
+			case "packages":
+				packages := set.NewStrings(cfg.Packages()...)
+				for _, pack := range list {
+					if !packages.Contains(pack) {
+						cfg.AddPackage(pack)
+						packages.Add(pack)
+					}
+				}
+			case "bootcmd":
+				// AddBootCmd joins its args into a single command.
+				for _, cmd := range list {
+					cfg.AddBootCmd(cmd)
+				}
+			}
+		case "users":
+			users, _ := cfg.attrs["users"].([]map[string]interface{})
+			list, ok := value.([]interface{})
+			if !ok {
+				return nil, errors.Errorf("cloud-init userdata key %q: expected list, got %T", key, value)
+			}
+			for i, user := range list {
+				userMap, ok := user.(map[string]interface{})
+				if !ok {
+					return nil, errors.Errorf("cloud-init userdata key %q: expected map for entry %d, got %T", key, i, user)
+				}
+				users = append(users, userMap)
+			}
+			cfg.SetAttr("users", users)
+		default:
+			if _, ok := cfg.attrs[key]; ok {
+				ignored = append(ignored, key)
+				continue
+			}
+			cfg.SetAttr(key, value)
+		}
+	}
+	sort.Strings(ignored)
+	return ignored, nil
+}
+
+// userDataStringList returns the value as a list of strings. Lists
+// read from YAML or JSON hold their items as interface{} values.
+func userDataStringList(value interface{}) ([]string, error) {
+	switch value := value.(type) {
+	case []string:
+		return copyStringSlice(value), nil
+	case []interface{}:
+		list := make([]string, len(value))
+		for i, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.Errorf("expected string for entry %d, got %T", i, item)
+			}
+			list[i] = s
+		}
+		return list, nil
+	}
+	return nil, errors.Errorf("expected list of strings, got %T", value)
+}
+
 // SetDisableEC2Metadata is defined on the EC2MetadataConfig interface.
 func (cfg *cloudConfig) SetDisableEC2Metadata(set bool) {
 	cfg.SetAttr("disable_ec2_metadata", set)
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/packaging"
 	sshtesting "github.com/juju/utils/ssh/testing"
 	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	coretesting "github.com/juju/juju/testing"
@@ -443,3 +444,48 @@ func (S) TestWindowsRender(c *gc.C) {
 	c.Assert(data, gc.NotNil)
 	c.Assert(string(data), gc.Equals, compareOutput, gc.Commentf("test %q output differs", "windows renderer"))
 }
+
+func (S) TestMergeUserData(c *gc.C) {
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg.AddRunCmd("juju-cmd")
+	cfg.AddPackage("curl")
+	cfg.AddBootCmd("juju-boot")
+	cfg.SetAttr("hostname", "juju-host")
+	cfg.AddUser(&cloudinit.User{Name: "ubuntu"})
+
+	ignored, err := cfg.MergeUserData(map[string]interface{}{
+		"preruncmd":  []interface{}{"pre-1", "pre-2"},
+		"postruncmd": []string{"post"},
+		"packages":   []interface{}{"curl", "python"},
+		"bootcmd":    []string{"user-boot-1", "user-boot-2"},
+		"users": []interface{}{
+			map[string]interface{}{"name": "fred"},
+		},
+		"hostname": "user-host",
+		"timezone": "Europe/London",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ignored, jc.DeepEquals, []string{"hostname"})
+	c.Assert(cfg.RunCmds(), jc.DeepEquals, []string{"pre-1", "pre-2", "juju-cmd", "post"})
+	c.Assert(cfg.Packages(), jc.DeepEquals, []string{"curl", "python"})
+	c.Assert(cfg.BootCmds(), jc.DeepEquals, []string{"juju-boot", "user-boot-1", "user-boot-2"})
+
+	data, err := cfg.RenderYAML()
+	c.Assert(err, jc.ErrorIsNil)
+	var rendered map[string]interface{}
+	err = goyaml.Unmarshal(data, &rendered)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rendered["hostname"], gc.Equals, "juju-host")
+	c.Assert(rendered["timezone"], gc.Equals, "Europe/London")
+	c.Assert(rendered["users"], gc.HasLen, 2)
+}
+
+func (S) TestMergeUserDataInvalidList(c *gc.C) {
+	cfg, err := cloudinit.New("xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cfg.MergeUserData(map[string]interface{}{
+		"packages": "python",
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud-init userdata key "packages": expected list of strings, got string`)
+}
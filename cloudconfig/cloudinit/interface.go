@@ -53,6 +53,7 @@ type CloudConfig interface {
 	WrittenFilesConfig
 	RenderConfig
 	AdvancedPackagingConfig
+	UserDataConfig
 }
 
 // SystemUpdateConfig is the interface for managing all system update options.
@@ -186,6 +187,18 @@ type BootCmdsConfig interface {
 	BootCmds() []string
 }
 
+// UserDataConfig is the interface for merging user-supplied cloud-init
+// config into the config generated by Juju.
+type UserDataConfig interface {
+	// MergeUserData merges the given cloud-init config into the config.
+	// The preruncmd and postruncmd lists are run before and after the
+	// commands added with AddRunCmd; packages, bootcmd and users are
+	// added to those already set. Any other key is set unless it has
+	// already been set, in which case it is left alone and returned
+	// in the list of ignored keys.
+	MergeUserData(map[string]interface{}) (ignored []string, err error)
+}
+
 // EC2MetadataConfig is the interface for all EC2-metadata related settings.
 type EC2MetadataConfig interface {
 	// SetDisableEC2Metadata sets whether access to the EC2 metadata service is
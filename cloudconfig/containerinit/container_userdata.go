@@ -243,6 +243,10 @@ func CloudInitUserData(
 		cloudConfig.SetAttr("hostname", instanceConfig.MachineContainerHostname)
 	}
 
+	if err := udata.ConfigureCustomOverrides(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	data, err := cloudConfig.RenderYAML()
 	if err != nil {
 		return nil, errors.Trace(err)
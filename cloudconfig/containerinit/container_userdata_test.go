@@ -310,6 +310,27 @@ func (s *UserDataSuite) TestCloudInitUserDataFallbackConfigWithContainerHostname
 	c.Assert(strings.Join(linesToMatch, "\n")+"\n", gc.Equals, strings.Join(expectedLinesToMatch, "\n")+"\n")
 }
 
+func (s *UserDataSuite) TestCloudInitUserDataMergesUserConfig(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/lxd/0")
+	c.Assert(err, jc.ErrorIsNil)
+	instanceConfig.CloudInitUserData = map[string]interface{}{
+		"preruncmd":  []interface{}{"mkdir /tmp/preruncmd"},
+		"postruncmd": []interface{}{"mkdir /tmp/postruncmd"},
+		"timezone":   "Europe/London",
+	}
+	data, err := containerinit.CloudInitUserData(instanceConfig, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var config map[string]interface{}
+	err = yaml.Unmarshal(data, &config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config["timezone"], gc.Equals, "Europe/London")
+	runcmd, ok := config["runcmd"].([]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(runcmd[0], gc.Equals, "mkdir /tmp/preruncmd")
+	c.Assert(runcmd[len(runcmd)-1], gc.Equals, "mkdir /tmp/postruncmd")
+}
+
 func assertUserData(c *gc.C, cloudConf cloudinit.CloudConfig, expected string) {
 	data, err := cloudConf.RenderYAML()
 	c.Assert(err, jc.ErrorIsNil)
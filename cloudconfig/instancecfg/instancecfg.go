@@ -154,6 +154,16 @@ type InstanceConfig struct {
 	// ifup when bridging bonded interfaces. See bugs #1594855 and
 	// #1269921.
 	NetBondReconfigureDelay int
+
+	// CloudInitUserData defines a set of cloud-init config that the
+	// user wants merged into the cloud-config Juju generates for the
+	// instance, taken from the model's cloudinit-userdata config.
+	CloudInitUserData map[string]interface{}
+
+	// Profiles holds the names of the charm-supplied LXD profiles to
+	// apply to the instance, in addition to the default profile. It is
+	// only used for LXD containers.
+	Profiles []string
 }
 
 // ControllerConfig represents controller-specific initialization information
@@ -775,6 +785,7 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 	); err != nil {
 		return errors.Trace(err)
 	}
+	icfg.CloudInitUserData = cfg.CloudInitUserData()
 	if icfg.Controller != nil {
 		// Add NUMACTL preference. Needed to work for both bootstrap and high availability
 		// Only makes sense for controller
@@ -28,15 +28,15 @@ func configureCloudinit(icfg *instancecfg.InstanceConfig, cloudcfg cloudinit.Clo
 	}
 	if icfg.Bootstrap != nil {
 		err = udata.ConfigureBasic()
-		if err != nil {
-			return nil, err
-		}
-		return udata, nil
+	} else {
+		err = udata.Configure()
 	}
-	err = udata.Configure()
 	if err != nil {
 		return nil, err
 	}
+	if err := udata.ConfigureCustomOverrides(); err != nil {
+		return nil, err
+	}
 	return udata, nil
 }
 
@@ -41,6 +41,10 @@ type UserdataConfig interface {
 	// ConfigureJuju updates the provided cloudinit.Config with configuration
 	// to initialise a Juju machine agent.
 	ConfigureJuju() error
+	// ConfigureCustomOverrides merges the cloud-init config supplied by
+	// the user, through the cloudinit-userdata model config, into the
+	// provided cloudinit.Config.
+	ConfigureCustomOverrides() error
 }
 
 // NewUserdataConfig is supposed to take in an instanceConfig as well as a
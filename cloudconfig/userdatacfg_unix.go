@@ -85,6 +85,21 @@ func (w *unixConfigure) Configure() error {
 	return w.ConfigureJuju()
 }
 
+// ConfigureCustomOverrides implements UserdataConfig.ConfigureCustomOverrides.
+func (w *unixConfigure) ConfigureCustomOverrides() error {
+	if len(w.icfg.CloudInitUserData) == 0 {
+		return nil
+	}
+	ignored, err := w.conf.MergeUserData(w.icfg.CloudInitUserData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(ignored) > 0 {
+		logger.Warningf("ignoring cloudinit-userdata keys already set by Juju: %s", strings.Join(ignored, ", "))
+	}
+	return nil
+}
+
 // ConfigureBasic updates the provided cloudinit.Config with
 // basic configuration to initialise an OS image, such that it can
 // be connected to via SSH, and log to a standard location.
@@ -45,6 +45,16 @@ func (w *windowsConfigure) Configure() error {
 	return w.ConfigureJuju()
 }
 
+// ConfigureCustomOverrides implements UserdataConfig.ConfigureCustomOverrides.
+// Windows machines are configured with a PowerShell script rather than
+// cloud-config, so the user-supplied config cannot be merged.
+func (w *windowsConfigure) ConfigureCustomOverrides() error {
+	if len(w.icfg.CloudInitUserData) > 0 {
+		logger.Warningf("cloudinit-userdata is not supported on %s, ignoring", w.icfg.Series)
+	}
+	return nil
+}
+
 func (w *windowsConfigure) ConfigureBasic() error {
 
 	tmpDir, err := paths.TempDir(w.icfg.Series)
@@ -0,0 +1,122 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageBindSummary = `
+Changes the spaces that application endpoints are bound to.`[1:]
+
+var usageBindDetails = `
+Binds each given endpoint of a deployed application to a space, in the
+same way as the --bind option of ` + "`juju deploy`" + `. Endpoints that
+are not given keep their current bindings.
+
+Every machine hosting a unit of the application must have an address in
+each new space. This includes containers that are already running, as
+they are not given new addresses.
+
+The units of the application run the config-changed hook, so that the
+charm can pick up its new network-get results. The ingress addresses
+each unit publishes in the settings of relations on a rebound endpoint
+are updated, so related units run the relation-changed hook.
+
+Examples:
+    juju bind mysql server=db
+    juju bind wordpress website=public db=internal
+
+See also:
+    deploy
+    spaces`[1:]
+
+// NewBindCommand returns a command which changes the endpoint
+// bindings of an application.
+func NewBindCommand() cmd.Command {
+	return modelcmd.Wrap(&bindCommand{})
+}
+
+type bindAPI interface {
+	Close() error
+	SetEndpointBindings(application string, bindings map[string]string) error
+}
+
+type bindCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Bindings        map[string]string
+	api             bindAPI
+}
+
+// Info implements Command.Info.
+func (c *bindCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "bind",
+		Args:    "<application> <endpoint>=<space> ...",
+		Purpose: usageBindSummary,
+		Doc:     usageBindDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *bindCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	if len(args) == 1 {
+		return errors.New("no bindings specified")
+	}
+	c.ApplicationName = args[0]
+	c.Bindings = make(map[string]string)
+	for _, arg := range args[1:] {
+		parts := strings.Split(arg, "=")
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("invalid binding %q: expected <endpoint>=<space>", arg)
+		}
+		endpoint, space := parts[0], parts[1]
+		if !names.IsValidSpace(space) {
+			return errors.Errorf("invalid binding %q: %q is not a valid space name", arg, space)
+		}
+		if _, ok := c.Bindings[endpoint]; ok {
+			return errors.Errorf("endpoint %q bound more than once", endpoint)
+		}
+		c.Bindings[endpoint] = space
+	}
+	return nil
+}
+
+func (c *bindCommand) getAPI() (bindAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *bindCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.SetEndpointBindings(c.ApplicationName, c.Bindings)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type BindSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockBindAPI
+}
+
+var _ = gc.Suite(&BindSuite{})
+
+func (s *BindSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockBindAPI{Stub: &testing.Stub{}}
+}
+
+func (s *BindSuite) runBind(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, application.NewBindCommandForTest(s.mockAPI), args...)
+}
+
+func (s *BindSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql/0", "server=db"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no bindings specified",
+	}, {
+		args: []string{"mysql", "db"},
+		err:  `invalid binding "db": expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "=db"},
+		err:  `invalid binding "=db": expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "server=db=x"},
+		err:  `invalid binding "server=db=x": expected <endpoint>=<space>`,
+	}, {
+		args: []string{"mysql", "server=%bad"},
+		err:  `invalid binding "server=%bad": "%bad" is not a valid space name`,
+	}, {
+		args: []string{"mysql", "server=db", "server=public"},
+		err:  `endpoint "server" bound more than once`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runBind(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *BindSuite) TestBind(c *gc.C) {
+	_, err := s.runBind(c, "mysql", "server=db", "monitoring=admin")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetEndpointBindings", []interface{}{"mysql", map[string]string{
+			"server":     "db",
+			"monitoring": "admin",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *BindSuite) TestErrorFromAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runBind(c, "mysql", "server=db")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBindAPI struct {
+	*testing.Stub
+}
+
+func (a *mockBindAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockBindAPI) SetEndpointBindings(application string, bindings map[string]string) error {
+	a.MethodCall(a, "SetEndpointBindings", application, bindings)
+	return a.NextErr()
+}
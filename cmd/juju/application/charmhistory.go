@@ -0,0 +1,173 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageCharmHistorySummary = `
+Lists the charms an application ran before its recent upgrades.`[1:]
+
+var usageCharmHistoryDetails = `
+Each time an application's charm is changed, the charm it was running
+is recorded in its charm history, along with the application's config
+and resources at the time. The most recent changes are listed first.
+Charms recorded in the history are kept by the controller, so that
+the application can be rolled back to them with ` + "`juju rollback-charm`" + `.
+
+Examples:
+    juju charm-history mysql
+    juju charm-history mysql --format yaml
+
+See also:
+    rollback-charm
+    upgrade-charm`[1:]
+
+// NewCharmHistoryCommand returns a command which lists an
+// application's charm history.
+func NewCharmHistoryCommand() cmd.Command {
+	return modelcmd.Wrap(&charmHistoryCommand{})
+}
+
+type charmHistoryAPI interface {
+	Close() error
+	CharmHistory(application string) ([]params.CharmHistoryEntry, error)
+}
+
+type charmHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out             cmd.Output
+	ApplicationName string
+	api             charmHistoryAPI
+}
+
+// CharmHistoryEntry defines the serialization behaviour of an entry in
+// an application's charm history.
+type CharmHistoryEntry struct {
+	Charm     string                 `yaml:"charm" json:"charm"`
+	Revision  int                    `yaml:"revision" json:"revision"`
+	Channel   string                 `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Replaced  time.Time              `yaml:"replaced" json:"replaced"`
+	Config    map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	Resources map[string]string      `yaml:"resources,omitempty" json:"resources,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *charmHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "charm-history",
+		Args:    "<application>",
+		Purpose: usageCharmHistorySummary,
+		Doc:     usageCharmHistoryDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *charmHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCharmHistoryTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *charmHistoryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *charmHistoryCommand) getAPI() (charmHistoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *charmHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	history, err := client.CharmHistory(c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(history) == 0 {
+		ctx.Infof("No charm history for application %q.", c.ApplicationName)
+		return nil
+	}
+	entries := make([]CharmHistoryEntry, len(history))
+	for i, entry := range history {
+		curl, err := charm.ParseURL(entry.CharmURL)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var resources map[string]string
+		for _, res := range entry.Resources {
+			if resources == nil {
+				resources = make(map[string]string)
+			}
+			if res.Origin == charmresource.OriginStore.String() {
+				resources[res.Name] = fmt.Sprintf("revision %d", res.Revision)
+			} else {
+				resources[res.Name] = res.Origin
+			}
+		}
+		entries[i] = CharmHistoryEntry{
+			Charm:     entry.CharmURL,
+			Revision:  curl.Revision,
+			Channel:   entry.Channel,
+			Replaced:  entry.Replaced,
+			Config:    entry.Config,
+			Resources: resources,
+		}
+	}
+	return c.out.Write(ctx, entries)
+}
+
+func formatCharmHistoryTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]CharmHistoryEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Revision", "Charm", "Channel", "Replaced")
+	for _, entry := range entries {
+		w.Println(entry.Revision, entry.Charm, entry.Channel, common.FormatTime(&entry.Replaced, true))
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type CharmHistorySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockCharmHistoryAPI
+}
+
+var _ = gc.Suite(&CharmHistorySuite{})
+
+func (s *CharmHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockCharmHistoryAPI{Stub: &testing.Stub{}}
+}
+
+func (s *CharmHistorySuite) runCharmHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, application.NewCharmHistoryCommandForTest(s.mockAPI), args...)
+}
+
+func (s *CharmHistorySuite) TestNoArguments(c *gc.C) {
+	_, err := s.runCharmHistory(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+}
+
+func (s *CharmHistorySuite) TestInvalidApplication(c *gc.C) {
+	_, err := s.runCharmHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "mysql/0"`)
+}
+
+func (s *CharmHistorySuite) TestTooManyArguments(c *gc.C) {
+	_, err := s.runCharmHistory(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
+
+func (s *CharmHistorySuite) TestErrorFromAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runCharmHistory(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CharmHistorySuite) TestNoHistory(c *gc.C) {
+	ctx, err := s.runCharmHistory(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No charm history for application \"mysql\".\n")
+}
+
+func (s *CharmHistorySuite) TestTabular(c *gc.C) {
+	s.mockAPI.history = []params.CharmHistoryEntry{{
+		CharmURL: "cs:mysql-12",
+		Channel:  "stable",
+		Replaced: time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+	}, {
+		CharmURL: "cs:mysql-10",
+		Replaced: time.Date(2017, 2, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := s.runCharmHistory(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"CharmHistory", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"Revision  Charm        Channel  Replaced\n"+
+		"12        cs:mysql-12  stable   2017-03-01 12:00:00Z\n"+
+		"10        cs:mysql-10           2017-02-01 12:00:00Z\n",
+	)
+}
+
+func (s *CharmHistorySuite) TestYAML(c *gc.C) {
+	s.mockAPI.history = []params.CharmHistoryEntry{{
+		CharmURL: "cs:mysql-12",
+		Config:   map[string]interface{}{"dataset-size": "80%"},
+		Resources: []params.CharmHistoryResource{
+			{Name: "backup", Origin: "store", Revision: 3},
+			{Name: "tuning", Origin: "upload"},
+		},
+		Replaced: time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := s.runCharmHistory(c, "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+- charm: cs:mysql-12
+  revision: 12
+  replaced: 2017-03-01T12:00:00Z
+  config:
+    dataset-size: 80%
+  resources:
+    backup: revision 3
+    tuning: upload
+`[1:])
+}
+
+type mockCharmHistoryAPI struct {
+	*testing.Stub
+	history []params.CharmHistoryEntry
+}
+
+func (a *mockCharmHistoryAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockCharmHistoryAPI) CharmHistory(application string) ([]params.CharmHistoryEntry, error) {
+	a.MethodCall(a, "CharmHistory", application)
+	return a.history, a.NextErr()
+}
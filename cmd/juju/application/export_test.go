@@ -74,6 +74,21 @@ func NewConsumeCommandForTest(api applicationConsumeAPI) cmd.Command {
 	return modelcmd.Wrap(&consumeCommand{api: api})
 }
 
+// NewCharmHistoryCommandForTest returns a CharmHistoryCommand with the specified api.
+func NewCharmHistoryCommandForTest(api charmHistoryAPI) cmd.Command {
+	return modelcmd.Wrap(&charmHistoryCommand{api: api})
+}
+
+// NewRollbackCharmCommandForTest returns a RollbackCharmCommand with the specified api.
+func NewRollbackCharmCommandForTest(api rollbackCharmAPI) cmd.Command {
+	return modelcmd.Wrap(&rollbackCharmCommand{api: api})
+}
+
+// NewBindCommandForTest returns a BindCommand with the specified api.
+func NewBindCommandForTest(api bindAPI) cmd.Command {
+	return modelcmd.Wrap(&bindCommand{api: api})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
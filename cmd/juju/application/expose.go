@@ -4,10 +4,14 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +23,16 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+The --to-cidrs and --to-spaces options restrict access to the given
+CIDRs and to the subnets of the given spaces. They apply to all of the
+application's open ports, and replace any previous restrictions.
+Individual endpoints can't be exposed on their own, because the ports
+opened by units are not associated with endpoints.
+
 Examples:
     juju expose wordpress
+    juju expose mysql --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose mysql --to-spaces admin
 
 See also: 
     unexpose`[1:]
@@ -34,6 +46,8 @@ func NewExposeCommand() cmd.Command {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ToSpaces        []string
+	ToCIDRs         []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +59,44 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewStringsValue(nil, &c.ToSpaces), "to-spaces", "Allow access from the subnets of the given comma-separated spaces")
+	f.Var(cmd.NewStringsValue(nil, &c.ToCIDRs), "to-cidrs", "Allow access from the given comma-separated CIDRs")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range c.ToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
+// exposedEndpoints returns the expose settings requested by the
+// command's options, or nil if there are none.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	if len(c.ToSpaces) == 0 && len(c.ToCIDRs) == 0 {
+		return nil
+	}
+	// The empty endpoint name stands for all endpoints.
+	return map[string]params.ExposedEndpoint{
+		"": {
+			ExposeToSpaces: c.ToSpaces,
+			ExposeToCIDRs:  c.ToCIDRs,
+		},
+	}
+}
+
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeEndpoints(serviceName string, exposed map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +116,10 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if exposed := c.exposedEndpoints(); exposed != nil {
+		err = client.ExposeEndpoints(c.ApplicationName, exposed)
+	} else {
+		err = client.Expose(c.ApplicationName)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
 }
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageRollbackCharmSummary = `
+Rolls an application back to a charm it ran before.`[1:]
+
+var usageRollbackCharmDetails = `
+Sets the application's charm back to one recorded in its charm history,
+as listed by ` + "`juju charm-history`" + `. By default, the application is
+rolled back to the charm it ran before its most recent upgrade; use
+--to-revision to choose an older charm from the history.
+
+The config settings recorded in the history are applied to the
+application again. Resources from the charm store are set back to the
+revisions recorded in the history; resources that were uploaded cannot
+be restored, and are left as they are.
+
+The units of the application are upgraded to the charm in the same way
+as with ` + "`juju upgrade-charm`" + `. Use --force-units to roll back units
+that are in an error state.
+
+Examples:
+    juju rollback-charm mysql
+    juju rollback-charm mysql --to-revision 12
+
+See also:
+    charm-history
+    upgrade-charm`[1:]
+
+// NewRollbackCharmCommand returns a command which sets an
+// application's charm back to one in its charm history.
+func NewRollbackCharmCommand() cmd.Command {
+	return modelcmd.Wrap(&rollbackCharmCommand{})
+}
+
+type rollbackCharmAPI interface {
+	Close() error
+	RollbackCharm(application string, revision *int, forceUnits bool) error
+}
+
+type rollbackCharmCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Revision        int
+	ForceUnits      bool
+	api             rollbackCharmAPI
+}
+
+// Info implements Command.Info.
+func (c *rollbackCharmCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollback-charm",
+		Args:    "<application>",
+		Purpose: usageRollbackCharmSummary,
+		Doc:     usageRollbackCharmDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rollbackCharmCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.Revision, "to-revision", -1, "Revision of the charm in the history to roll back to")
+	f.BoolVar(&c.ForceUnits, "force-units", false, "Roll back all units immediately, even if in error state")
+}
+
+// Init implements Command.Init.
+func (c *rollbackCharmCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	if c.Revision < -1 {
+		return errors.New("--to-revision must not be negative")
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *rollbackCharmCommand) getAPI() (rollbackCharmAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *rollbackCharmCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	var revision *int
+	if c.Revision >= 0 {
+		revision = &c.Revision
+	}
+	err = client.RollbackCharm(c.ApplicationName, revision, c.ForceUnits)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RollbackCharmSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockRollbackCharmAPI
+}
+
+var _ = gc.Suite(&RollbackCharmSuite{})
+
+func (s *RollbackCharmSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockRollbackCharmAPI{Stub: &testing.Stub{}}
+}
+
+func (s *RollbackCharmSuite) runRollbackCharm(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, application.NewRollbackCharmCommandForTest(s.mockAPI), args...)
+}
+
+func (s *RollbackCharmSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "--to-revision", "-2"},
+		err:  "--to-revision must not be negative",
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runRollbackCharm(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RollbackCharmSuite) TestRollbackPrevious(c *gc.C) {
+	_, err := s.runRollbackCharm(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RollbackCharm", []interface{}{"mysql", (*int)(nil), false}},
+		{"Close", nil},
+	})
+}
+
+func (s *RollbackCharmSuite) TestRollbackToRevision(c *gc.C) {
+	_, err := s.runRollbackCharm(c, "mysql", "--to-revision", "12", "--force-units")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "RollbackCharm", "Close")
+	args := s.mockAPI.Calls()[0].Args
+	c.Assert(*(args[1].(*int)), gc.Equals, 12)
+	c.Assert(args[2], jc.IsTrue)
+}
+
+func (s *RollbackCharmSuite) TestErrorFromAPI(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runRollbackCharm(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockRollbackCharmAPI struct {
+	*testing.Stub
+}
+
+func (a *mockRollbackCharmAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockRollbackCharmAPI) RollbackCharm(application string, revision *int, forceUnits bool) error {
+	a.MethodCall(a, "RollbackCharm", application, revision, forceUnits)
+	return a.NextErr()
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 )
 
@@ -64,6 +65,8 @@ func NewUpgradeCharmCommand() cmd.Command {
 type CharmUpgradeClient interface {
 	GetCharmURL(string) (*charm.URL, error)
 	SetCharm(application.SetCharmConfig) error
+	ResumeRollingUpgrade(string) error
+	AbortRollingUpgrade(string) error
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -119,6 +122,17 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// BatchSize, if non-zero, is the number of units to upgrade at a time.
+	BatchSize int
+
+	// WaitFor is the workload status each batch of units must reach
+	// before the next batch is upgraded.
+	WaitFor string
+
+	// Resume and Abort continue or stop a paused rolling upgrade.
+	Resume bool
+	Abort  bool
 }
 
 const upgradeCharmDoc = `
@@ -192,6 +206,22 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+The --batch-size flag causes the controller to upgrade the application's units
+a few at a time rather than all at once. With --wait-for, each batch must reach
+the given workload status before the next batch is upgraded; otherwise the next
+batch is upgraded once the previous one is running the new charm. If any
+upgraded unit goes into an error state, the upgrade is paused. Its progress is
+shown in the output of "juju status".
+
+  juju upgrade-charm foo --batch-size 2 --wait-for active
+
+A paused upgrade may be continued, once the failed units have been resolved,
+with the --resume flag, or stopped with the --abort flag. Units that were not
+upgraded before an upgrade is aborted keep their current charm.
+
+  juju upgrade-charm foo --resume
+  juju upgrade-charm foo --abort
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -214,6 +244,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade this many units at a time")
+	f.StringVar(&c.WaitFor, "wait-for", "", "Workload status each batch of units must reach before the next batch is upgraded")
+	f.BoolVar(&c.Resume, "resume", false, "Continue a paused rolling upgrade")
+	f.BoolVar(&c.Abort, "abort", false, "Stop a rolling upgrade")
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -237,6 +271,26 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must not be negative")
+	}
+	if c.WaitFor != "" {
+		if c.BatchSize == 0 {
+			return errors.Errorf("--wait-for requires --batch-size")
+		}
+		if !status.Status(c.WaitFor).KnownWorkloadStatus() {
+			return errors.Errorf("invalid workload status %q for --wait-for", c.WaitFor)
+		}
+	}
+	if c.Resume || c.Abort {
+		if c.Resume && c.Abort {
+			return errors.Errorf("--resume and --abort are mutually exclusive")
+		}
+		if c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1 || c.BatchSize != 0 ||
+			c.ForceUnits || c.ForceSeries || len(c.Resources) > 0 || len(c.Storage) > 0 || c.Config.Path != "" {
+			return errors.Errorf("--resume and --abort cannot be combined with other upgrade flags")
+		}
+	}
 	return nil
 }
 
@@ -266,6 +320,14 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
+	if c.Resume {
+		err := charmUpgradeClient.ResumeRollingUpgrade(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if c.Abort {
+		err := charmUpgradeClient.AbortRollingUpgrade(c.ApplicationName)
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -340,6 +402,8 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:         c.ForceUnits,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
+		BatchSize:          c.BatchSize,
+		WaitFor:            c.WaitFor,
 	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
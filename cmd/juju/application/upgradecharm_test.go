@@ -196,6 +196,67 @@ func (s *UpgradeCharmSuite) TestStorageConstraintsMinFacadeVersionNoServerVersio
 		"updating storage constraints at upgrade-charm time is not supported by this server")
 }
 
+func (s *UpgradeCharmSuite) TestRollingUpgrade(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--wait-for", "active")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 1, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		BatchSize: 2,
+		WaitFor:   "active",
+	})
+}
+
+func (s *UpgradeCharmSuite) TestResumeRollingUpgrade(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--resume")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCalls(c, []testing.StubCall{
+		{"ResumeRollingUpgrade", []interface{}{"foo"}},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestAbortRollingUpgrade(c *gc.C) {
+	s.charmUpgradeClient.SetErrors(errors.New("cannot abort completed rolling upgrade"))
+	_, err := s.runUpgradeCharm(c, "foo", "--abort")
+	c.Assert(err, gc.ErrorMatches, "cannot abort completed rolling upgrade")
+	s.charmUpgradeClient.CheckCalls(c, []testing.StubCall{
+		{"AbortRollingUpgrade", []interface{}{"foo"}},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"foo", "--batch-size", "-1"},
+		err:  "--batch-size must not be negative",
+	}, {
+		args: []string{"foo", "--wait-for", "active"},
+		err:  "--wait-for requires --batch-size",
+	}, {
+		args: []string{"foo", "--batch-size", "1", "--wait-for", "bouncing"},
+		err:  `invalid workload status "bouncing" for --wait-for`,
+	}, {
+		args: []string{"foo", "--resume", "--abort"},
+		err:  "--resume and --abort are mutually exclusive",
+	}, {
+		args: []string{"foo", "--resume", "--batch-size", "1"},
+		err:  "--resume and --abort cannot be combined with other upgrade flags",
+	}, {
+		args: []string{"foo", "--abort", "--revision", "3"},
+		err:  "--resume and --abort cannot be combined with other upgrade flags",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runUpgradeCharm(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *UpgradeCharmSuite) TestConfigSettings(c *gc.C) {
 	tempdir := c.MkDir()
 	configFile := filepath.Join(tempdir, "config.yaml")
@@ -731,6 +792,16 @@ func (m *mockCharmUpgradeClient) SetCharm(cfg application.SetCharmConfig) error
 	return m.NextErr()
 }
 
+func (m *mockCharmUpgradeClient) ResumeRollingUpgrade(applicationName string) error {
+	m.MethodCall(m, "ResumeRollingUpgrade", applicationName)
+	return m.NextErr()
+}
+
+func (m *mockCharmUpgradeClient) AbortRollingUpgrade(applicationName string) error {
+	m.MethodCall(m, "AbortRollingUpgrade", applicationName)
+	return m.NextErr()
+}
+
 type mockModelConfigGetter struct {
 	ModelConfigGetter
 	testing.Stub
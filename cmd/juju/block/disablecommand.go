@@ -5,9 +5,12 @@ package block
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -27,6 +30,17 @@ type disableCommand struct {
 	apiFunc func(newAPIRoot) (blockClientAPI, error)
 	target  string
 	message string
+	scope   blockScope
+	until   string
+	expiry  time.Time
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *disableCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.scope.setFlags(f, "Disable the commands only for")
+	f.StringVar(&c.until, "until", "",
+		"Re-enable the commands automatically at this time (RFC3339) or after this duration")
 }
 
 // Init implements Command.
@@ -39,11 +53,38 @@ func (c *disableCommand) Init(args []string) error {
 	if !ok {
 		return errors.Errorf("bad command set, valid options: %s", validTargets)
 	}
+	if err := c.scope.init(target); err != nil {
+		return errors.Trace(err)
+	}
 	c.target = target
 	c.message = strings.Join(args, " ")
+	if c.until != "" {
+		expiry, err := parseExpiry(c.until, time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expiry = expiry
+	}
 	return nil
 }
 
+// parseExpiry parses the value of the --until flag, which may be
+// either an RFC3339 time or a duration relative to now.
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		d, durationErr := time.ParseDuration(value)
+		if durationErr != nil {
+			return time.Time{}, errors.Errorf("invalid --until value %q: expected a time (RFC3339) or a duration", value)
+		}
+		expiry = now.Add(d)
+	}
+	if !expiry.After(now) {
+		return time.Time{}, errors.Errorf("invalid --until value %q: must be in the future", value)
+	}
+	return expiry, nil
+}
+
 // Info implements Command.
 func (c *disableCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -56,7 +97,7 @@ func (c *disableCommand) Info() *cmd.Info {
 
 type blockClientAPI interface {
 	Close() error
-	SwitchBlockOn(blockType, msg string) error
+	SwitchScopedBlockOn(blockType, msg string, targets []names.Tag, expiry time.Time) error
 }
 
 // Run implements Command.Run
@@ -67,7 +108,7 @@ func (c *disableCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
-	return api.SwitchBlockOn(c.target, c.message)
+	return api.SwitchScopedBlockOn(c.target, c.message, c.scope.targets, c.expiry)
 }
 
 var disableCommandDoc = `
@@ -79,6 +120,14 @@ Disabled commands must be manually enabled to proceed.
 
 Some commands offer a --force option that can be used to bypass the disabling.
 ` + commandSets + `
+The remove-object and all command sets can be disabled for particular
+applications and machines only, using the --application and --machine
+options. Commands are then disabled only where they would remove or change
+those applications, their units, or those machines.
+
+Disabled commands can be re-enabled automatically by specifying, with
+--until, either a time in RFC3339 format or a duration.
+
 Examples:
     # To prevent the model from being destroyed:
     juju disable-command destroy-model "Check with SA before destruction."
@@ -89,6 +138,15 @@ Examples:
     # To prevent changes to the model:
     juju disable-command all "Model locked down"
 
+    # To prevent the mysql application and its units from being removed:
+    juju disable-command remove-object --application mysql
+
+    # To freeze changes to the model until Monday 09:00 UTC:
+    juju disable-command all --until 2017-06-05T09:00:00Z "Change freeze"
+
+    # To prevent changes to machine 0 for the next two hours:
+    juju disable-command all --machine 0 --until 2h
+
 See also:
     disabled-commands
     enable-command
@@ -4,9 +4,12 @@
 package block_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/testing"
@@ -36,6 +39,27 @@ func (s *disableCommandSuite) TestInit(c *gc.C) {
 			args: []string{"remove-object"},
 		}, {
 			args: []string{"all", "lots", "of", "args"},
+		}, {
+			args: []string{"remove-object", "--application", "mysql,wordpress", "--machine", "0"},
+		}, {
+			args: []string{"remove-object", "--application", "-bad-"},
+			err:  `invalid application name "-bad-"`,
+		}, {
+			args: []string{"remove-object", "--machine", "zero"},
+			err:  `invalid machine id "zero"`,
+		}, {
+			args: []string{"destroy-model", "--application", "mysql"},
+			err:  "destroy-model cannot be limited to applications or machines",
+		}, {
+			args: []string{"all", "--until", "2h"},
+		}, {
+			args: []string{"all", "--until", "2100-01-01T09:00:00Z"},
+		}, {
+			args: []string{"all", "--until", "2000-01-01T09:00:00Z"},
+			err:  `invalid --until value "2000-01-01T09:00:00Z": must be in the future`,
+		}, {
+			args: []string{"all", "--until", "Monday"},
+			err:  `invalid --until value "Monday": expected a time (RFC3339) or a duration`,
 		},
 	} {
 		cmd := block.NewDisableCommand()
@@ -81,6 +105,34 @@ func (s *disableCommandSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *disableCommandSuite) TestRunScoped(c *gc.C) {
+	mockClient := &mockBlockClient{}
+	cmd := block.NewDisableCommandForTest(mockClient, nil)
+	_, err := testing.RunCommand(c, cmd,
+		"remove-object", "--application", "mysql", "--machine", "0,1",
+		"--until", "2100-01-01T09:00:00Z", "keep", "mysql",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mockClient.blockType, gc.Equals, "BlockRemove")
+	c.Check(mockClient.message, gc.Equals, "keep mysql")
+	c.Check(mockClient.targets, jc.DeepEquals, []names.Tag{
+		names.NewApplicationTag("mysql"),
+		names.NewMachineTag("0"),
+		names.NewMachineTag("1"),
+	})
+	c.Check(mockClient.expiry, jc.DeepEquals, time.Date(2100, 1, 1, 9, 0, 0, 0, time.UTC))
+}
+
+func (s *disableCommandSuite) TestRunUntilDuration(c *gc.C) {
+	mockClient := &mockBlockClient{}
+	cmd := block.NewDisableCommandForTest(mockClient, nil)
+	before := time.Now()
+	_, err := testing.RunCommand(c, cmd, "all", "--until", "2h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mockClient.expiry.Before(before.Add(2*time.Hour)), jc.IsFalse)
+	c.Check(mockClient.expiry.After(time.Now().Add(2*time.Hour)), jc.IsFalse)
+}
+
 func (s *disableCommandSuite) TestRunError(c *gc.C) {
 	mockClient := &mockBlockClient{err: errors.New("boom")}
 	cmd := block.NewDisableCommandForTest(mockClient, nil)
@@ -91,6 +143,8 @@ func (s *disableCommandSuite) TestRunError(c *gc.C) {
 type mockBlockClient struct {
 	blockType string
 	message   string
+	targets   []names.Tag
+	expiry    time.Time
 	err       error
 }
 
@@ -98,8 +152,10 @@ func (c *mockBlockClient) Close() error {
 	return nil
 }
 
-func (c *mockBlockClient) SwitchBlockOn(blockType, message string) error {
+func (c *mockBlockClient) SwitchScopedBlockOn(blockType, message string, targets []names.Tag, expiry time.Time) error {
 	c.blockType = blockType
 	c.message = message
+	c.targets = targets
+	c.expiry = expiry
 	return c.err
 }
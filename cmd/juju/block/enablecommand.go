@@ -6,6 +6,8 @@ package block
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -25,6 +27,13 @@ type enableCommand struct {
 	modelcmd.ModelCommandBase
 	apiFunc func(newAPIRoot) (unblockClientAPI, error)
 	target  string
+	scope   blockScope
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *enableCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.scope.setFlags(f, "Enable the commands that were disabled only for")
 }
 
 // Init implements Command.
@@ -37,6 +46,9 @@ func (c *enableCommand) Init(args []string) error {
 	if !ok {
 		return errors.Errorf("bad command set, valid options: %s", validTargets)
 	}
+	if err := c.scope.init(target); err != nil {
+		return errors.Trace(err)
+	}
 	c.target = target
 	return cmd.CheckEmpty(args)
 }
@@ -54,7 +66,7 @@ func (c *enableCommand) Info() *cmd.Info {
 // unblockClientAPI defines the client API methods that unblock command uses.
 type unblockClientAPI interface {
 	Close() error
-	SwitchBlockOff(blockType string) error
+	SwitchScopedBlockOff(blockType string, targets []names.Tag) error
 }
 
 // Run implements Command.
@@ -65,7 +77,7 @@ func (c *enableCommand) Run(_ *cmd.Context) error {
 	}
 	defer api.Close()
 
-	return api.SwitchBlockOff(c.target)
+	return api.SwitchScopedBlockOff(c.target, c.scope.targets)
 }
 
 const enableDoc = `
@@ -77,6 +89,9 @@ Disabled commands must be manually enabled to proceed.
 
 Some commands offer a --force option that can be used to bypass a block.
 ` + commandSets + `
+Commands that were disabled for particular applications or machines are
+enabled by specifying the same --application and --machine options.
+
 Examples:
     # To allow the model to be destroyed:
     juju enable-command destroy-model
@@ -87,6 +102,9 @@ Examples:
     # To allow changes to the model:
     juju enable-command all
 
+    # To allow the mysql application and its units to be removed:
+    juju enable-command remove-object --application mysql
+
 See also:
     disable-command
     disabled-commands
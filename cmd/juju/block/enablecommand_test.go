@@ -8,6 +8,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/testing"
@@ -38,6 +39,11 @@ func (s *enableCommandSuite) TestInit(c *gc.C) {
 		}, {
 			args: []string{"all", "extra"},
 			err:  `unrecognized args: ["extra"]`,
+		}, {
+			args: []string{"all", "--application", "mysql", "--machine", "0"},
+		}, {
+			args: []string{"destroy-model", "--machine", "0"},
+			err:  "destroy-model cannot be limited to applications or machines",
 		},
 	} {
 		cmd := block.NewEnableCommand()
@@ -78,6 +84,15 @@ func (s *enableCommandSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *enableCommandSuite) TestRunScoped(c *gc.C) {
+	mockClient := &mockUnblockClient{}
+	cmd := block.NewEnableCommandForTest(mockClient, nil)
+	_, err := testing.RunCommand(c, cmd, "remove-object", "--application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mockClient.blockType, gc.Equals, "BlockRemove")
+	c.Check(mockClient.targets, jc.DeepEquals, []names.Tag{names.NewApplicationTag("mysql")})
+}
+
 func (s *enableCommandSuite) TestRunError(c *gc.C) {
 	mockClient := &mockUnblockClient{err: errors.New("boom")}
 	cmd := block.NewEnableCommandForTest(mockClient, nil)
@@ -87,6 +102,7 @@ func (s *enableCommandSuite) TestRunError(c *gc.C) {
 
 type mockUnblockClient struct {
 	blockType string
+	targets   []names.Tag
 	err       error
 }
 
@@ -94,7 +110,8 @@ func (c *mockUnblockClient) Close() error {
 	return nil
 }
 
-func (c *mockUnblockClient) SwitchBlockOff(blockType string) error {
+func (c *mockUnblockClient) SwitchScopedBlockOff(blockType string, targets []names.Tag) error {
 	c.blockType = blockType
+	c.targets = targets
 	return c.err
 }
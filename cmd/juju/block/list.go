@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
 )
@@ -149,8 +150,12 @@ type controllerListAPI interface {
 
 // BlockInfo defines the serialization behaviour of the block information.
 type BlockInfo struct {
-	Commands string `yaml:"command-set" json:"command-set"`
-	Message  string `yaml:"message,omitempty" json:"message,omitempty"`
+	Commands     string   `yaml:"command-set" json:"command-set"`
+	Message      string   `yaml:"message,omitempty" json:"message,omitempty"`
+	Applications []string `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     []string `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Expires      string   `yaml:"expires,omitempty" json:"expires,omitempty"`
+	CreatedBy    string   `yaml:"disabled-by,omitempty" json:"disabled-by,omitempty"`
 }
 
 // formatBlockInfo takes a set of Block and creates a
@@ -162,10 +167,28 @@ func formatBlockInfo(all []params.Block) []BlockInfo {
 		if !ok {
 			set = "<unknown>"
 		}
-		output[i] = BlockInfo{
-			Commands: set,
-			Message:  one.Message,
+		info := BlockInfo{
+			Commands:  set,
+			Message:   one.Message,
+			CreatedBy: one.CreatedBy,
 		}
+		for _, target := range one.Targets {
+			tag, err := names.ParseTag(target)
+			if err != nil {
+				logger.Warningf("ignoring invalid block target %q", target)
+				continue
+			}
+			switch tag := tag.(type) {
+			case names.ApplicationTag:
+				info.Applications = append(info.Applications, tag.Id())
+			case names.MachineTag:
+				info.Machines = append(info.Machines, tag.Id())
+			}
+		}
+		if one.Expiry != nil {
+			info.Expires = common.FormatTime(one.Expiry, true)
+		}
+		output[i] = info
 	}
 	return output
 }
@@ -184,15 +207,35 @@ func formatBlocks(writer io.Writer, value interface{}) error {
 
 	tw := output.TabWriter(writer)
 	w := output.Wrapper{tw}
-	w.Println("Disabled commands", "Message")
+	w.Println("Disabled commands", "Scope", "Expires", "By", "Message")
 	for _, info := range blocks {
-		w.Println(info.Commands, info.Message)
+		expires := info.Expires
+		if expires == "" {
+			expires = "-"
+		}
+		by := info.CreatedBy
+		if by == "" {
+			by = "-"
+		}
+		w.Println(info.Commands, formatScope(info), expires, by, info.Message)
 	}
 	tw.Flush()
 
 	return nil
 }
 
+// formatScope returns a short description of what the block applies to.
+func formatScope(info BlockInfo) string {
+	if len(info.Applications) == 0 && len(info.Machines) == 0 {
+		return "model"
+	}
+	scope := append([]string{}, info.Applications...)
+	for _, id := range info.Machines {
+		scope = append(scope, "machine "+id)
+	}
+	return strings.Join(scope, ", ")
+}
+
 type newControllerAPIRoot interface {
 	NewControllerAPIRoot() (api.Connection, error)
 }
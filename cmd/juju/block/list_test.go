@@ -5,6 +5,7 @@ package block_test
 
 import (
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -79,13 +80,53 @@ func (s *listCommandSuite) TestList(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stderr(ctx), gc.Equals, "")
 	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
-		"Disabled commands  Message\n"+
-		"destroy-model      Sysadmins in control.\n"+
-		"all                just temporary\n"+
+		"Disabled commands  Scope  Expires  By  Message\n"+
+		"destroy-model      model  -        -   Sysadmins in control.\n"+
+		"all                model  -        -   just temporary\n"+
 		"\n",
 	)
 }
 
+func (s *listCommandSuite) scopedMock() *mockListClient {
+	expiry := time.Date(2100, 1, 1, 9, 0, 0, 0, time.UTC)
+	return &mockListClient{
+		blocks: []params.Block{{
+			Type:      "BlockRemove",
+			Message:   "keep mysql",
+			Targets:   []string{"application-mysql", "machine-0"},
+			Expiry:    &expiry,
+			CreatedBy: "bob",
+		}},
+	}
+}
+
+func (s *listCommandSuite) TestListScoped(c *gc.C) {
+	cmd := block.NewListCommandForTest(s.scopedMock(), nil)
+	ctx, err := testing.RunCommand(c, cmd)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Disabled commands  Scope             Expires               By   Message\n"+
+		"remove-object      mysql, machine 0  2100-01-01 09:00:00Z  bob  keep mysql\n"+
+		"\n",
+	)
+}
+
+func (s *listCommandSuite) TestListScopedYAML(c *gc.C) {
+	cmd := block.NewListCommandForTest(s.scopedMock(), nil)
+	ctx, err := testing.RunCommand(c, cmd, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- command-set: remove-object\n"+
+		"  message: keep mysql\n"+
+		"  applications:\n"+
+		"  - mysql\n"+
+		"  machines:\n"+
+		"  - \"0\"\n"+
+		"  expires: 2100-01-01 09:00:00Z\n"+
+		"  disabled-by: bob\n",
+	)
+}
+
 func (s *listCommandSuite) TestListYAML(c *gc.C) {
 	cmd := block.NewListCommandForTest(s.mock(), nil)
 	ctx, err := testing.RunCommand(c, cmd, "--format", "yaml")
@@ -4,8 +4,12 @@
 package block
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	apiblock "github.com/juju/juju/api/block"
@@ -48,6 +52,51 @@ func operationFromType(blockType string) string {
 	return value
 }
 
+// blockScope holds the applications and machines that a block
+// applies to, as specified on the command line.
+type blockScope struct {
+	applications string
+	machines     string
+	targets      []names.Tag
+}
+
+func (s *blockScope) setFlags(f *gnuflag.FlagSet, purpose string) {
+	f.StringVar(&s.applications, "application", "", purpose+" these comma-separated applications")
+	f.StringVar(&s.machines, "machine", "", purpose+" these comma-separated machines")
+}
+
+// init validates the scope flags and records the tags of the
+// targets for a block of the given API type.
+func (s *blockScope) init(blockType string) error {
+	s.targets = nil
+	for _, name := range splitList(s.applications) {
+		if !names.IsValidApplication(name) {
+			return errors.Errorf("invalid application name %q", name)
+		}
+		s.targets = append(s.targets, names.NewApplicationTag(name))
+	}
+	for _, id := range splitList(s.machines) {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+		s.targets = append(s.targets, names.NewMachineTag(id))
+	}
+	if len(s.targets) > 0 && blockType == apiDestroyModel {
+		return errors.Errorf("%s cannot be limited to applications or machines", cmdDestroyModel)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 type newAPIRoot interface {
 	NewAPIRoot() (api.Connection, error)
 }
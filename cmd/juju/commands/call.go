@@ -0,0 +1,162 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newCallCommand() cmd.Command {
+	return modelcmd.Wrap(&callCommand{})
+}
+
+const callDoc = `
+Invokes a method on an API facade and prints the raw response.
+
+The facade may be followed by ".v<N>" to select a particular version
+of it; by default the newest version offered by the controller is used.
+Parameters are given as a JSON document with --params, and must match
+the facade method's schema, which the controller publishes at the
+/schema HTTP endpoint. The method is only invoked if the current user
+is allowed to call it.
+
+Facades are called on a connection to the current model. Controller
+facades, such as Controller, ModelManager, UserManager and Cloud, are
+not available on model connections; use --controller to call them on
+a connection to the controller instead.
+
+This command is intended for scripting and exploring the API; the
+facades and methods it calls may change between Juju releases.
+
+Examples:
+    juju call Client FullStatus --params '{"patterns": []}'
+    juju call Application.v4 Get --params '{"application": "mysql"}'
+    juju call --controller --format yaml ModelManager ListModels --params '{"tag": "user-admin"}'
+`
+
+// callAPI is the part of the API used by the call command.
+type callAPI interface {
+	AllFacadeVersions() map[string][]int
+	APICall(objType string, version int, id, request string, params, response interface{}) error
+	Close() error
+}
+
+var (
+	newModelCallAPI = func(c *callCommand) (callAPI, error) {
+		return c.NewAPIRoot()
+	}
+	newControllerCallAPI = func(c *callCommand) (callAPI, error) {
+		return c.NewControllerAPIRoot()
+	}
+)
+
+// getCallAPI returns a connection to the model, or to the controller
+// if --controller was specified.
+func getCallAPI(c *callCommand) (callAPI, error) {
+	if c.controller {
+		return newControllerCallAPI(c)
+	}
+	return newModelCallAPI(c)
+}
+
+var facadeVersionRE = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*)(?:\.v([0-9]+))?$`)
+
+// callCommand invokes an arbitrary API facade method.
+type callCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	facade     string
+	version    int
+	method     string
+	id         string
+	controller bool
+	paramsJSON string
+	params     interface{}
+}
+
+// Info implements cmd.Command.
+func (c *callCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "call",
+		Args:    "<facade>[.v<version>] <method>",
+		Purpose: "Invokes an API facade method and prints the response.",
+		Doc:     callDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *callCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "json", map[string]cmd.Formatter{
+		"json": cmd.FormatJson,
+		"yaml": cmd.FormatYaml,
+	})
+	f.StringVar(&c.paramsJSON, "params", "", "JSON-encoded parameters of the method")
+	f.StringVar(&c.id, "id", "", "Facade ID, for facades that require one")
+	f.BoolVar(&c.controller, "controller", false, "Call the facade on a controller connection")
+}
+
+// Init implements cmd.Command.
+func (c *callCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("facade and method must be specified")
+	}
+	m := facadeVersionRE.FindStringSubmatch(args[0])
+	if m == nil {
+		return errors.Errorf("invalid facade %q", args[0])
+	}
+	c.facade = m[1]
+	if m[2] != "" {
+		version, err := strconv.Atoi(m[2])
+		if err != nil {
+			return errors.Errorf("invalid facade version %q", m[2])
+		}
+		c.version = version
+	}
+	c.method = args[1]
+	if c.paramsJSON != "" {
+		if err := json.Unmarshal([]byte(c.paramsJSON), &c.params); err != nil {
+			return errors.Annotate(err, "invalid --params")
+		}
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *callCommand) Run(ctx *cmd.Context) error {
+	api, err := getCallAPI(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	version := c.version
+	if version == 0 {
+		versions := api.AllFacadeVersions()[c.facade]
+		if len(versions) == 0 {
+			return errors.NotFoundf("facade %q", c.facade)
+		}
+		for _, v := range versions {
+			if v > version {
+				version = v
+			}
+		}
+	}
+
+	var result interface{}
+	if err := api.APICall(c.facade, version, c.id, c.method, c.params, &result); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return c.out.Write(ctx, result)
+}
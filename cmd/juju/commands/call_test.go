@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type callSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api           *fakeCallAPI
+	controllerAPI *fakeCallAPI
+	store         *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&callSuite{})
+
+func (s *callSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeCallAPI{
+		versions: map[string][]int{"Client": {1}, "Application": {1, 4, 5}},
+		result:   map[string]interface{}{"life": "alive"},
+	}
+	s.controllerAPI = &fakeCallAPI{
+		versions: map[string][]int{"ModelManager": {2}},
+		result:   map[string]interface{}{"models": []interface{}{}},
+	}
+	s.PatchValue(&newModelCallAPI, func(*callCommand) (callAPI, error) {
+		return s.api, nil
+	})
+	s.PatchValue(&newControllerCallAPI, func(*callCommand) (callAPI, error) {
+		return s.controllerAPI, nil
+	})
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models:       map[string]jujuclient.ModelDetails{"admin/mymodel": {}},
+		CurrentModel: "admin/mymodel",
+	}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *callSuite) runCall(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &callCommand{}
+	command.SetClientStore(s.store)
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *callSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "facade and method must be specified",
+	}, {
+		args: []string{"Client"},
+		err:  "facade and method must be specified",
+	}, {
+		args: []string{"Client.v", "FullStatus"},
+		err:  `invalid facade "Client.v"`,
+	}, {
+		args: []string{"Client", "FullStatus", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"Client", "FullStatus", "--params", "{bad"},
+		err:  "invalid --params: .*",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runCall(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *callSuite) TestCallNewestVersion(c *gc.C) {
+	ctx, err := s.runCall(c, "Application", "Get", "--params", `{"application": "mysql"}`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.calls, jc.DeepEquals, []fakeCall{{
+		facade:  "Application",
+		version: 5,
+		method:  "Get",
+		params:  map[string]interface{}{"application": "mysql"},
+	}})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `{"life":"alive"}`+"\n")
+}
+
+func (s *callSuite) TestCallExplicitVersion(c *gc.C) {
+	ctx, err := s.runCall(c, "Application.v4", "Get", "--id", "x", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.calls, jc.DeepEquals, []fakeCall{{
+		facade:  "Application",
+		version: 4,
+		id:      "x",
+		method:  "Get",
+	}})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "life: alive\n")
+}
+
+func (s *callSuite) TestCallModelFacade(c *gc.C) {
+	_, err := s.runCall(c, "Client", "FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.calls, gc.HasLen, 1)
+	c.Assert(s.controllerAPI.calls, gc.HasLen, 0)
+}
+
+func (s *callSuite) TestCallControllerFacade(c *gc.C) {
+	ctx, err := s.runCall(c, "--controller", "ModelManager", "ListModels", "--params", `{"tag": "user-admin"}`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.calls, gc.HasLen, 0)
+	c.Assert(s.controllerAPI.calls, jc.DeepEquals, []fakeCall{{
+		facade:  "ModelManager",
+		version: 2,
+		method:  "ListModels",
+		params:  map[string]interface{}{"tag": "user-admin"},
+	}})
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `{"models":[]}`+"\n")
+}
+
+func (s *callSuite) TestCallUnknownFacade(c *gc.C) {
+	_, err := s.runCall(c, "Nope", "Get")
+	c.Assert(err, gc.ErrorMatches, `facade "Nope" not found`)
+	c.Assert(s.api.calls, gc.HasLen, 0)
+}
+
+func (s *callSuite) TestCallError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := s.runCall(c, "Client", "FullStatus")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *callSuite) TestCallBlocked(c *gc.C) {
+	s.api.err = common.OperationBlockedError("TestCallBlocked")
+	_, err := s.runCall(c, "Client", "FullStatus")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestCallBlocked.*")
+}
+
+type fakeCall struct {
+	facade  string
+	version int
+	id      string
+	method  string
+	params  interface{}
+}
+
+type fakeCallAPI struct {
+	versions map[string][]int
+	result   interface{}
+	err      error
+	calls    []fakeCall
+}
+
+func (f *fakeCallAPI) AllFacadeVersions() map[string][]int {
+	return f.versions
+}
+
+func (f *fakeCallAPI) APICall(objType string, version int, id, request string, params, response interface{}) error {
+	f.calls = append(f.calls, fakeCall{
+		facade:  objType,
+		version: version,
+		id:      id,
+		method:  request,
+		params:  params,
+	})
+	if f.err != nil {
+		return f.err
+	}
+	*(response.(*interface{})) = f.result
+	return nil
+}
+
+func (f *fakeCallAPI) Close() error {
+	return nil
+}
@@ -275,6 +275,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand(nil))
+	r.Register(newCallCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -282,6 +283,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSyncToolsCommand())
 	r.Register(newUpgradeJujuCommand(nil))
 	r.Register(application.NewUpgradeCharmCommand())
+	r.Register(application.NewCharmHistoryCommand())
+	r.Register(application.NewRollbackCharmCommand())
 
 	// Charm tool commands.
 	r.Register(newHelpToolCommand())
@@ -313,6 +316,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewLogoutCommand())
 	r.Register(user.NewRemoveCommand())
 	r.Register(user.NewWhoAmICommand())
+	r.Register(user.NewAddGroupCommand())
+	r.Register(user.NewAddToGroupCommand())
+	r.Register(user.NewListGroupsCommand())
+	r.Register(user.NewAddTokenCommand())
+	r.Register(user.NewRemoveTokenCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -320,6 +328,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage machines
 	r.Register(machine.NewAddCommand())
+	r.Register(machine.NewAddMachinePoolCommand())
+	r.Register(machine.NewRemoveMachinePoolCommand())
+	r.Register(machine.NewSetMachinePoolSizeCommand())
 	r.Register(machine.NewRemoveCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
@@ -354,6 +365,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewDefaultDeployCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
+	r.Register(application.NewBindCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
 	r.Register(application.NewServiceSetConstraintsCommand())
 
@@ -372,6 +384,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage spaces
 	r.Register(space.NewAddCommand())
 	r.Register(space.NewListCommand())
+	r.Register(space.NewMoveCommand())
 	if featureflag.Enabled(feature.PostNetCLIMVP) {
 		r.Register(space.NewRemoveCommand())
 		r.Register(space.NewUpdateCommand())
@@ -395,6 +408,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
+	r.Register(controller.NewControllerMaintenanceCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
 
@@ -390,13 +390,17 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-machine",
+	"add-machine-pool",
 	"add-model",
 	"add-relation",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
 	"add-subnet",
+	"add-to-group",
+	"add-token",
 	"add-unit",
 	"add-user",
 	"agree",
@@ -404,11 +408,14 @@ var commandNames = []string{
 	"allocate",
 	"autoload-credentials",
 	"backups",
+	"bind",
 	"bootstrap",
 	"budgets",
 	"cached-images",
+	"call",
 	"change-user-password",
 	"charm",
+	"charm-history",
 	"clouds",
 	"config",
 	"collect-metrics",
@@ -418,6 +425,7 @@ var commandNames = []string{
 	"create-storage-pool",
 	"credentials",
 	"controller-config",
+	"controller-maintenance",
 	"debug-hooks",
 	"debug-log",
 	"remove-user",
@@ -436,6 +444,7 @@ var commandNames = []string{
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"groups",
 	"gui",
 	"help",
 	"help-tool",
@@ -450,6 +459,7 @@ var commandNames = []string{
 	"list-controllers",
 	"list-credentials",
 	"list-disabled-commands",
+	"list-groups",
 	"list-machines",
 	"list-models",
 	"list-plans",
@@ -468,6 +478,7 @@ var commandNames = []string{
 	"model-config",
 	"model-defaults",
 	"models",
+	"move-to-space",
 	"plans",
 	"regions",
 	"register",
@@ -478,13 +489,16 @@ var commandNames = []string{
 	"remove-cloud",
 	"remove-credential",
 	"remove-machine",
+	"remove-machine-pool",
 	"remove-relation",
 	"remove-ssh-key",
+	"remove-token",
 	"remove-unit",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
 	"revoke",
+	"rollback-charm",
 	"run",
 	"run-action",
 	"scp",
@@ -492,6 +506,7 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-machine-pool-size",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -90,6 +90,17 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewControllerMaintenanceCommandForTest returns a
+// controllerMaintenanceCommand with the function used to open the API
+// connection mocked out.
+func NewControllerMaintenanceCommandForTest(api controllerMaintenanceAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerMaintenanceCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -0,0 +1,131 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewControllerMaintenanceCommand returns a command that allows a
+// controller admin to put the controller into, and take it out of,
+// maintenance mode.
+func NewControllerMaintenanceCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerMaintenanceCommand{})
+}
+
+type controllerMaintenanceCommand struct {
+	modelcmd.ControllerCommandBase
+	api controllerMaintenanceAPI
+
+	enabled *bool
+	message string
+}
+
+type controllerMaintenanceAPI interface {
+	Close() error
+	ControllerMaintenance() (params.ControllerMaintenance, error)
+	SetControllerMaintenance(enabled bool, message string) error
+}
+
+var controllerMaintenanceDoc = `
+While a controller is in maintenance mode, its API server rejects
+every request that would change any of its models, such as deploying
+applications or adding machines. Read-only requests, such as those
+made by "juju status", are still allowed, as are status updates from
+agents. Provisioning, firewalling and cleanup of all the models in the
+controller are paused until maintenance mode is turned off.
+
+This is intended for use during database maintenance or planned
+upgrades. The message given with --message is included in the errors
+returned for rejected requests.
+
+Without arguments, the current maintenance mode is shown.
+
+Examples:
+    juju controller-maintenance on --message "Upgrading MongoDB, back at 14:00"
+    juju controller-maintenance off
+    juju controller-maintenance
+
+See also:
+    disable-command
+`
+
+// Info implements Command.Info.
+func (c *controllerMaintenanceCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-maintenance",
+		Args:    "[on|off]",
+		Purpose: "Turn maintenance mode for a controller on or off.",
+		Doc:     controllerMaintenanceDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerMaintenanceCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.message, "message", "", "Reason for the maintenance, reported to rejected clients")
+}
+
+// Init implements Command.Init.
+func (c *controllerMaintenanceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		if c.message != "" {
+			return errors.New("--message requires on")
+		}
+		return nil
+	}
+	var enabled bool
+	switch args[0] {
+	case "on":
+		enabled = true
+	case "off":
+		if c.message != "" {
+			return errors.New("--message cannot be used with off")
+		}
+	default:
+		return errors.Errorf("expected on or off, got %q", args[0])
+	}
+	c.enabled = &enabled
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *controllerMaintenanceCommand) getAPI() (controllerMaintenanceAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *controllerMaintenanceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.enabled != nil {
+		return errors.Trace(client.SetControllerMaintenance(*c.enabled, c.message))
+	}
+	maintenance, err := client.ControllerMaintenance()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch {
+	case !maintenance.Enabled:
+		fmt.Fprintln(ctx.Stdout, "off")
+	case maintenance.Message == "":
+		fmt.Fprintln(ctx.Stdout, "on")
+	default:
+		fmt.Fprintf(ctx.Stdout, "on: %s\n", maintenance.Message)
+	}
+	return nil
+}
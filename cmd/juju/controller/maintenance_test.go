@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type controllerMaintenanceSuite struct {
+	baseControllerSuite
+	api   *fakeControllerMaintenanceAPI
+	store *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&controllerMaintenanceSuite{})
+
+func (s *controllerMaintenanceSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeControllerMaintenanceAPI{}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *controllerMaintenanceSuite) newCommand() cmd.Command {
+	return controller.NewControllerMaintenanceCommandForTest(s.api, s.store)
+}
+
+func (s *controllerMaintenanceSuite) TestOn(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "on", "--message", "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.set, jc.DeepEquals, &params.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	})
+}
+
+func (s *controllerMaintenanceSuite) TestOff(c *gc.C) {
+	_, err := testing.RunCommand(c, s.newCommand(), "off")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.set, jc.DeepEquals, &params.ControllerMaintenance{})
+}
+
+func (s *controllerMaintenanceSuite) TestShow(c *gc.C) {
+	s.api.current = params.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	}
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "on: upgrading mongo\n")
+	c.Assert(s.api.set, gc.IsNil)
+}
+
+func (s *controllerMaintenanceSuite) TestShowOff(c *gc.C) {
+	ctx, err := testing.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "off\n")
+}
+
+func (s *controllerMaintenanceSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"maybe"},
+		err:  `expected on or off, got "maybe"`,
+	}, {
+		args: []string{"off", "--message", "foo"},
+		err:  "--message cannot be used with off",
+	}, {
+		args: []string{"--message", "foo"},
+		err:  "--message requires on",
+	}, {
+		args: []string{"on", "whoops"},
+		err:  `unrecognized args: \["whoops"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.set, gc.IsNil)
+}
+
+func (s *controllerMaintenanceSuite) TestAPIError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := testing.RunCommand(c, s.newCommand(), "on")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeControllerMaintenanceAPI struct {
+	current params.ControllerMaintenance
+	set     *params.ControllerMaintenance
+	err     error
+}
+
+func (f *fakeControllerMaintenanceAPI) Close() error {
+	return nil
+}
+
+func (f *fakeControllerMaintenanceAPI) ControllerMaintenance() (params.ControllerMaintenance, error) {
+	return f.current, f.err
+}
+
+func (f *fakeControllerMaintenanceAPI) SetControllerMaintenance(enabled bool, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.set = &params.ControllerMaintenance{
+		Enabled: enabled,
+		Message: message,
+	}
+	return nil
+}
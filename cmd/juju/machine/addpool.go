@@ -0,0 +1,127 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+)
+
+const addMachinePoolDoc = `
+A machine pool keeps a number of machines provisioned ahead of demand,
+so that units can be deployed without waiting for new instances to start.
+When a unit is placed without an explicit placement directive, a pooled
+machine matching the unit's constraints is used in preference to adding
+a new machine. The pool is topped up again as machines are taken from it.
+
+The series defaults to the model's default-series. Constraints given with
+--constraints are merged with the model constraints when the pooled
+machines are provisioned.
+
+Examples:
+    juju add-machine-pool --size 5
+    juju add-machine-pool --size 2 --constraints "mem=8G cores=4"
+    juju add-machine-pool --size 3 --series xenial
+
+See also:
+    add-machine
+    remove-machine
+    remove-machine-pool
+    set-machine-pool-size
+`
+
+// NewAddMachinePoolCommand returns a command that adds a pool of
+// pre-provisioned machines to a model.
+func NewAddMachinePoolCommand() cmd.Command {
+	return modelcmd.Wrap(&addMachinePoolCommand{})
+}
+
+// addMachinePoolCommand adds a machine pool to the model.
+type addMachinePoolCommand struct {
+	modelcmd.ModelCommandBase
+	api AddMachinePoolAPI
+
+	Size           int
+	Series         string
+	ConstraintsStr string
+	Constraints    constraints.Value
+}
+
+// Info implements Command.Info.
+func (c *addMachinePoolCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-machine-pool",
+		Purpose: "Keeps a pool of machines provisioned ahead of demand.",
+		Doc:     addMachinePoolDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addMachinePoolCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.Size, "size", 0, "The number of machines to keep in the pool")
+	f.StringVar(&c.Series, "series", "", "The series of the pooled machines")
+	f.StringVar(&c.ConstraintsStr, "constraints", "", "Constraints for the pooled machines")
+}
+
+// Init implements Command.Init.
+func (c *addMachinePoolCommand) Init(args []string) error {
+	if c.Size <= 0 {
+		return errors.New("--size must be a positive number")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// AddMachinePoolAPI defines the API methods used by the
+// add-machine-pool command.
+type AddMachinePoolAPI interface {
+	AddMachinePool(size int, series string, cons constraints.Value) (string, error)
+	Close() error
+}
+
+func (c *addMachinePoolCommand) getAPI() (AddMachinePoolAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *addMachinePoolCommand) Run(ctx *cmd.Context) error {
+	var err error
+	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
+	if err != nil {
+		return err
+	}
+	if c.Constraints.Container != nil {
+		return errors.Errorf("container constraint %q not allowed when adding a machine pool", *c.Constraints.Container)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	id, err := client.AddMachinePool(c.Size, c.Series, c.Constraints)
+	if params.IsCodeOperationBlocked(err) {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("created machine pool %v with %d machines", id, c.Size)
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type AddMachinePoolSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeAddMachinePoolAPI
+}
+
+var _ = gc.Suite(&AddMachinePoolSuite{})
+
+func (s *AddMachinePoolSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeAddMachinePoolAPI{}
+}
+
+func (s *AddMachinePoolSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, machine.NewAddMachinePoolCommandForTest(s.fake), args...)
+}
+
+func (s *AddMachinePoolSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{
+		{
+			errorString: "--size must be a positive number",
+		}, {
+			args:        []string{"--size", "0"},
+			errorString: "--size must be a positive number",
+		}, {
+			args:        []string{"--size", "2", "extra"},
+			errorString: `unrecognized args: \["extra"\]`,
+		}, {
+			args: []string{"--size", "2"},
+		},
+	} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(machine.NewAddMachinePoolCommandForTest(s.fake), test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *AddMachinePoolSuite) TestAddMachinePool(c *gc.C) {
+	ctx, err := s.run(c, "--size", "5", "--series", "xenial", "--constraints", "mem=8G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.size, gc.Equals, 5)
+	c.Assert(s.fake.series, gc.Equals, "xenial")
+	c.Assert(s.fake.cons, jc.DeepEquals, constraints.MustParse("mem=8G"))
+	c.Assert(testing.Stderr(ctx), gc.Equals, "created machine pool 0 with 5 machines\n")
+}
+
+func (s *AddMachinePoolSuite) TestAddMachinePoolContainerConstraint(c *gc.C) {
+	_, err := s.run(c, "--size", "1", "--constraints", "container=lxd")
+	c.Assert(err, gc.ErrorMatches, `container constraint "lxd" not allowed when adding a machine pool`)
+}
+
+func (s *AddMachinePoolSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "--size", "1")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+type fakeAddMachinePoolAPI struct {
+	size   int
+	series string
+	cons   constraints.Value
+	err    error
+}
+
+func (f *fakeAddMachinePoolAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAddMachinePoolAPI) AddMachinePool(size int, series string, cons constraints.Value) (string, error) {
+	f.size = size
+	f.series = series
+	f.cons = cons
+	if f.err != nil {
+		return "", f.err
+	}
+	return "0", nil
+}
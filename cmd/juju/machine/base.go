@@ -54,29 +54,41 @@ var newAPIClientForMachines = func(c *baselistMachinesCommand) (statusAPI, error
 
 // Run implements Command.Run for baseMachinesCommand.
 func (c *baselistMachinesCommand) Run(ctx *cmd.Context) error {
-	apiclient, err := newAPIClientForMachines(c)
+	fullStatus, err := c.fullStatus(ctx)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	formatter := status.NewStatusFormatter(fullStatus, c.isoTime)
+	formatted := formatter.MachineFormat(c.machineIds)
+	return c.out.Write(ctx, formatted)
+}
+
+// fullStatus returns the status of the model, reporting any partial
+// failure to ctx.Stderr.
+func (c *baselistMachinesCommand) fullStatus(ctx *cmd.Context) (*params.FullStatus, error) {
+	apiclient, err := newAPIClientForMachines(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer apiclient.Close()
 
 	fullStatus, err := apiclient.Status(nil)
 	if err != nil {
 		if fullStatus == nil {
 			// Status call completely failed, there is nothing to report
-			return err
+			return nil, err
 		}
 		// Display any error, but continue to print status if some was returned
 		fmt.Fprintf(ctx.Stderr, "%v\n", err)
 	} else if fullStatus == nil {
-		return errors.Errorf("unable to obtain the current status")
+		return nil, errors.Errorf("unable to obtain the current status")
 	}
-
-	formatter := status.NewStatusFormatter(fullStatus, c.isoTime)
-	formatted := formatter.MachineFormat(c.machineIds)
-	return c.out.Write(ctx, formatted)
+	return fullStatus, nil
 }
 
 func (c *baselistMachinesCommand) tabular(writer io.Writer, value interface{}) error {
+	if drift, ok := value.(formattedDrift); ok {
+		return formatDriftTabular(writer, drift)
+	}
 	return status.FormatMachineTabular(writer, c.color, value)
 }
@@ -28,6 +28,24 @@ func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI Machine
 	return modelcmd.Wrap(cmd), &AddCommand{cmd}
 }
 
+// NewAddMachinePoolCommandForTest returns an add-machine-pool command
+// with the api provided as specified.
+func NewAddMachinePoolCommandForTest(api AddMachinePoolAPI) cmd.Command {
+	return modelcmd.Wrap(&addMachinePoolCommand{api: api})
+}
+
+// NewRemoveMachinePoolCommandForTest returns a remove-machine-pool
+// command with the api provided as specified.
+func NewRemoveMachinePoolCommandForTest(api RemoveMachinePoolAPI) cmd.Command {
+	return modelcmd.Wrap(&removeMachinePoolCommand{api: api})
+}
+
+// NewSetMachinePoolSizeCommandForTest returns a set-machine-pool-size
+// command with the api provided as specified.
+func NewSetMachinePoolSizeCommandForTest(api SetMachinePoolSizeAPI) cmd.Command {
+	return modelcmd.Wrap(&setMachinePoolSizeCommand{api: api})
+}
+
 // NewListCommandForTest returns a listMachineCommand with specified api
 func NewListCommandForTest(api statusAPI) cmd.Command {
 	cmd := newListMachinesCommand(api)
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const removeMachinePoolDoc = `
+Removes a machine pool and destroys the machines it holds that have not
+been claimed by a unit. Machines already taken from the pool are left
+running.
+
+Examples:
+    juju remove-machine-pool 0
+
+See also:
+    add-machine-pool
+    set-machine-pool-size
+`
+
+// NewRemoveMachinePoolCommand returns a command that removes a machine
+// pool from a model.
+func NewRemoveMachinePoolCommand() cmd.Command {
+	return modelcmd.Wrap(&removeMachinePoolCommand{})
+}
+
+// removeMachinePoolCommand removes a machine pool from the model.
+type removeMachinePoolCommand struct {
+	modelcmd.ModelCommandBase
+	api RemoveMachinePoolAPI
+
+	PoolId string
+}
+
+// Info implements Command.Info.
+func (c *removeMachinePoolCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-machine-pool",
+		Args:    "<pool id>",
+		Purpose: "Removes a machine pool and its unclaimed machines.",
+		Doc:     removeMachinePoolDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeMachinePoolCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements Command.Init.
+func (c *removeMachinePoolCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine pool specified")
+	}
+	c.PoolId = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// RemoveMachinePoolAPI defines the API methods used by the
+// remove-machine-pool command.
+type RemoveMachinePoolAPI interface {
+	RemoveMachinePool(id string) error
+	Close() error
+}
+
+func (c *removeMachinePoolCommand) getAPI() (RemoveMachinePoolAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *removeMachinePoolCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.RemoveMachinePool(c.PoolId)
+	if params.IsCodeOperationBlocked(err) {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("removed machine pool %v", c.PoolId)
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type RemoveMachinePoolSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeMachinePoolAPI
+}
+
+var _ = gc.Suite(&RemoveMachinePoolSuite{})
+
+func (s *RemoveMachinePoolSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeMachinePoolAPI{}
+}
+
+func (s *RemoveMachinePoolSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, machine.NewRemoveMachinePoolCommandForTest(s.fake), args...)
+}
+
+func (s *RemoveMachinePoolSuite) TestInit(c *gc.C) {
+	err := testing.InitCommand(machine.NewRemoveMachinePoolCommandForTest(s.fake), nil)
+	c.Check(err, gc.ErrorMatches, "no machine pool specified")
+	err = testing.InitCommand(machine.NewRemoveMachinePoolCommandForTest(s.fake), []string{"0", "1"})
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["1"\]`)
+}
+
+func (s *RemoveMachinePoolSuite) TestRemoveMachinePool(c *gc.C) {
+	ctx, err := s.run(c, "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.removed, jc.DeepEquals, []string{"0"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "removed machine pool 0\n")
+}
+
+func (s *RemoveMachinePoolSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "0")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+type fakeMachinePoolAPI struct {
+	removed []string
+	sizes   map[string]int
+	err     error
+}
+
+func (f *fakeMachinePoolAPI) Close() error {
+	return nil
+}
+
+func (f *fakeMachinePoolAPI) RemoveMachinePool(id string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+func (f *fakeMachinePoolAPI) SetMachinePoolSize(id string, size int) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.sizes == nil {
+		f.sizes = make(map[string]int)
+	}
+	f.sizes[id] = size
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const setMachinePoolSizeDoc = `
+Changes the number of machines a machine pool keeps provisioned ahead of
+demand. Growing the pool adds machines to it; shrinking the pool destroys
+its surplus unclaimed machines.
+
+Examples:
+    juju set-machine-pool-size 0 10
+
+See also:
+    add-machine-pool
+    remove-machine-pool
+`
+
+// NewSetMachinePoolSizeCommand returns a command that resizes a
+// machine pool.
+func NewSetMachinePoolSizeCommand() cmd.Command {
+	return modelcmd.Wrap(&setMachinePoolSizeCommand{})
+}
+
+// setMachinePoolSizeCommand changes the size of a machine pool.
+type setMachinePoolSizeCommand struct {
+	modelcmd.ModelCommandBase
+	api SetMachinePoolSizeAPI
+
+	PoolId string
+	Size   int
+}
+
+// Info implements Command.Info.
+func (c *setMachinePoolSizeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-machine-pool-size",
+		Args:    "<pool id> <size>",
+		Purpose: "Changes the number of machines held by a machine pool.",
+		Doc:     setMachinePoolSizeDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setMachinePoolSizeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements Command.Init.
+func (c *setMachinePoolSizeCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine pool specified")
+	case 1:
+		return errors.New("no size specified")
+	}
+	c.PoolId = args[0]
+	size, err := strconv.Atoi(args[1])
+	if err != nil || size <= 0 {
+		return errors.Errorf("size must be a positive number, got %q", args[1])
+	}
+	c.Size = size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// SetMachinePoolSizeAPI defines the API methods used by the
+// set-machine-pool-size command.
+type SetMachinePoolSizeAPI interface {
+	SetMachinePoolSize(id string, size int) error
+	Close() error
+}
+
+func (c *setMachinePoolSizeCommand) getAPI() (SetMachinePoolSizeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *setMachinePoolSizeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.SetMachinePoolSize(c.PoolId, c.Size)
+	if params.IsCodeOperationBlocked(err) {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("machine pool %v now holds %d machines", c.PoolId, c.Size)
+	return nil
+}
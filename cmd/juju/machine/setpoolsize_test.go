@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type SetMachinePoolSizeSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeMachinePoolAPI
+}
+
+var _ = gc.Suite(&SetMachinePoolSizeSuite{})
+
+func (s *SetMachinePoolSizeSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeMachinePoolAPI{}
+}
+
+func (s *SetMachinePoolSizeSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return testing.RunCommand(c, machine.NewSetMachinePoolSizeCommandForTest(s.fake), args...)
+}
+
+func (s *SetMachinePoolSizeSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{
+		{
+			errorString: "no machine pool specified",
+		}, {
+			args:        []string{"0"},
+			errorString: "no size specified",
+		}, {
+			args:        []string{"0", "0"},
+			errorString: `size must be a positive number, got "0"`,
+		}, {
+			args:        []string{"0", "many"},
+			errorString: `size must be a positive number, got "many"`,
+		}, {
+			args:        []string{"0", "2", "extra"},
+			errorString: `unrecognized args: \["extra"\]`,
+		}, {
+			args: []string{"0", "2"},
+		},
+	} {
+		c.Logf("test %d", i)
+		err := testing.InitCommand(machine.NewSetMachinePoolSizeCommandForTest(s.fake), test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *SetMachinePoolSizeSuite) TestSetMachinePoolSize(c *gc.C) {
+	ctx, err := s.run(c, "0", "10")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.sizes, jc.DeepEquals, map[string]int{"0": 10})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "machine pool 0 now holds 10 machines\n")
+}
+
+func (s *SetMachinePoolSizeSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "0", "1")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
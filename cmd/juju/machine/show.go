@@ -4,9 +4,19 @@
 package machine
 
 import (
+	"fmt"
+	"io"
+	"sort"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
 
+	"github.com/juju/juju/agent/drift"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 const showMachineCommandDoc = `
@@ -21,6 +31,10 @@ Examples:
     # Display status for machines 1, 2 & 3
     juju show-machine 1 2 3
 
+    # Display the files written by Juju that have been changed
+    # outside of Juju on machine 0
+    juju show-machine --drift 0
+
 `
 
 // NewShowMachineCommand returns a command that shows details on the specified machine[s].
@@ -38,6 +52,7 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+	drift bool
 }
 
 // Info implements Command.Info.
@@ -55,3 +70,86 @@ func (c *showMachineCommand) Init(args []string) error {
 	c.machineIds = args
 	return nil
 }
+
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.drift, "drift", false, "Show the files written by Juju that have been changed outside of Juju")
+}
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if !c.drift {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	fullStatus, err := c.fullStatus(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	drift := formattedDrift{Machines: make(map[string]machineDrift)}
+	for id, m := range fullStatus.Machines {
+		collectDrift(drift.Machines, id, m)
+	}
+	if len(c.machineIds) > 0 {
+		show := make(map[string]bool)
+		for _, id := range c.machineIds {
+			show[id] = true
+		}
+		for id := range drift.Machines {
+			if !show[id] {
+				delete(drift.Machines, id)
+			}
+		}
+	}
+	return c.out.Write(ctx, drift)
+}
+
+// formattedDrift holds the drifted artifacts of each machine, as
+// reported by the machine agents in their status data.
+type formattedDrift struct {
+	Machines map[string]machineDrift `json:"machines" yaml:"machines"`
+}
+
+// machineDrift maps the name of each drifted artifact on a machine
+// to its location.
+type machineDrift map[string]string
+
+func collectDrift(result map[string]machineDrift, id string, m params.MachineStatus) {
+	artifacts := make(machineDrift)
+	switch data := m.AgentStatus.Data[drift.StatusDataKey].(type) {
+	case map[string]interface{}:
+		for name, path := range data {
+			artifacts[name] = fmt.Sprint(path)
+		}
+	case map[string]string:
+		for name, path := range data {
+			artifacts[name] = path
+		}
+	}
+	result[id] = artifacts
+	for containerId, container := range m.Containers {
+		collectDrift(result, containerId, container)
+	}
+}
+
+func formatDriftTabular(writer io.Writer, drift formattedDrift) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Artifact", "Path")
+	ids := make([]string, 0, len(drift.Machines))
+	for id := range drift.Machines {
+		ids = append(ids, id)
+	}
+	for _, id := range utils.SortStringsNaturally(ids) {
+		names := make([]string, 0, len(drift.Machines[id]))
+		for name := range drift.Machines[id] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			w.Println(id, name, drift.Machines[id][name])
+		}
+	}
+	tw.Flush()
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/testing"
 )
@@ -130,3 +131,45 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(testing.Stdout(context), gc.Equals, ""+
 		"{\"model\":\"dummyenv\",\"machines\":{\"0\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.1\",\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"instance-id\":\"juju-badd06-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.1\",\"10.0.1.1\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"constraints\":\"mem=3584M\",\"hardware\":\"availability-zone=us-east-1\"},\"1\":{\"juju-status\":{\"current\":\"started\"},\"dns-name\":\"10.0.0.2\",\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"instance-id\":\"juju-badd06-1\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.2\",\"10.0.1.2\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}},\"containers\":{\"1/lxd/0\":{\"juju-status\":{\"current\":\"pending\"},\"dns-name\":\"10.0.0.3\",\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"instance-id\":\"juju-badd06-1-lxd-0\",\"machine-status\":{},\"series\":\"trusty\",\"network-interfaces\":{\"eth0\":{\"ip-addresses\":[\"10.0.0.3\",\"10.0.1.3\"],\"mac-address\":\"aa:bb:cc:dd:ee:ff\",\"is-up\":true}}}}}}}\n")
 }
+
+type fakeDriftStatusAPI struct {
+	fakeStatusAPI
+}
+
+func (f *fakeDriftStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	result, err := f.fakeStatusAPI.Status(c)
+	if err != nil {
+		return nil, err
+	}
+	m := result.Machines["0"]
+	m.AgentStatus.Info = "drifted"
+	m.AgentStatus.Data = map[string]interface{}{
+		"drift": map[string]interface{}{
+			"proxy-settings":  "/home/ubuntu/.juju-proxy",
+			"authorized-keys": "~ubuntu/.ssh/authorized_keys",
+		},
+	}
+	result.Machines["0"] = m
+	return result, nil
+}
+
+func (s *MachineShowCommandSuite) TestShowDrift(c *gc.C) {
+	context, err := testing.RunCommand(c, machine.NewShowCommandForTest(&fakeDriftStatusAPI{}), "--drift")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    authorized-keys: ~ubuntu/.ssh/authorized_keys\n"+
+		"    proxy-settings: /home/ubuntu/.juju-proxy\n"+
+		"  \"1\": {}\n"+
+		"  1/lxd/0: {}\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowDriftTabular(c *gc.C) {
+	context, err := testing.RunCommand(c, machine.NewShowCommandForTest(&fakeDriftStatusAPI{}), "--drift", "--format", "tabular", "0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, ""+
+		"Machine  Artifact         Path\n"+
+		"0        authorized-keys  ~ubuntu/.ssh/authorized_keys\n"+
+		"0        proxy-settings   /home/ubuntu/.juju-proxy\n")
+}
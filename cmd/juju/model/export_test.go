@@ -107,3 +107,21 @@ func NewRevokeCommandForTest(api RevokeModelAPI, store jujuclient.ClientStore) (
 	cmd.SetClientStore(store)
 	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
 }
+
+// NewGrantOfferCommandForTest returns a GrantCommand with the offers api provided as specified.
+func NewGrantOfferCommandForTest(api GrantOfferAPI, store jujuclient.ClientStore) (cmd.Command, *GrantCommand) {
+	cmd := &grantCommand{
+		offersAPI: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &GrantCommand{cmd}
+}
+
+// NewRevokeOfferCommandForTest returns a RevokeCommand with the offers api provided as specified.
+func NewRevokeOfferCommandForTest(api RevokeOfferAPI, store jujuclient.ClientStore) (cmd.Command, *RevokeCommand) {
+	cmd := &revokeCommand{
+		offersAPI: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd), &RevokeCommand{cmd}
+}
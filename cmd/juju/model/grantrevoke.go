@@ -7,13 +7,15 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
+	crossmodelapi "github.com/juju/juju/api/crossmodel"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/permission"
 )
 
 var usageGrantSummary = `
-Grants access level to a Juju user for a model or controller.`[1:]
+Grants access level to a Juju user for a model, controller, or application offer.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
@@ -21,6 +23,9 @@ By default, the controller is the current controller.
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
 
+Access may also be granted to a group created with ` + "`juju add-group`" + `,
+in which case all members of the group inherit that access.
+
 Valid access levels for models are:
     read
     write
@@ -31,6 +36,11 @@ Valid access levels for controllers are:
     add-model
     superuser
 
+Valid access levels for application offers are:
+    read
+    consume
+    admin
+
 Examples:
 Grant user 'joe' 'read' access to model 'mymodel':
 
@@ -48,12 +58,21 @@ Grant user 'maria' 'add-model' access to the controller:
 
     juju grant maria add-model
 
+Grant the members of group 'devs' 'write' access to model 'mymodel':
+
+    juju grant devs write mymodel
+
+Grant user 'joe' 'consume' access to application offer 'local:/u/mary/mysql':
+
+    juju grant joe consume local:/u/mary/mysql
+
 See also: 
     revoke
-    add-user`
+    add-user
+    add-group`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model or controller`[1:]
+Revokes access from a Juju user for a model, controller, or application offer`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
@@ -75,6 +94,10 @@ Revoke 'add-model' access from user 'maria' to the controller:
 
     juju revoke maria add-model
 
+Revoke 'consume' access from user 'joe' for application offer 'local:/u/mary/mysql':
+
+    juju revoke joe consume local:/u/mary/mysql
+
 See also: 
     grant`[1:]
 
@@ -83,6 +106,7 @@ type accessCommand struct {
 
 	User       string
 	ModelNames []string
+	OfferURLs  []string
 	Access     string
 }
 
@@ -97,12 +121,27 @@ func (c *accessCommand) Init(args []string) error {
 	}
 
 	c.User = args[0]
-	c.ModelNames = args[2:]
 	c.Access = args[1]
 	// Special case for backwards compatibility.
 	if c.Access == "addmodel" {
 		c.Access = "add-model"
 	}
+	c.ModelNames, c.OfferURLs = nil, nil
+	for _, arg := range args[2:] {
+		// Model names never parse as application URLs, so anything
+		// that does is taken to be an application offer.
+		if _, err := crossmodel.ParseApplicationURL(arg); err == nil {
+			c.OfferURLs = append(c.OfferURLs, arg)
+		} else {
+			c.ModelNames = append(c.ModelNames, arg)
+		}
+	}
+	if len(c.OfferURLs) > 0 {
+		if len(c.ModelNames) > 0 {
+			return errors.New("models and offers cannot be specified together")
+		}
+		return permission.ValidateOfferAccess(permission.Access(c.Access))
+	}
 	if len(c.ModelNames) > 0 {
 		if err := permission.ValidateControllerAccess(permission.Access(c.Access)); err == nil {
 			return errors.Errorf("You have specified a controller access permission %q.\n"+
@@ -116,6 +155,11 @@ func (c *accessCommand) Init(args []string) error {
 			"If you intended to change model access, you need to specify one or more model names.\n"+
 			"See 'juju help grant'.", c.Access)
 	}
+	if permission.Access(c.Access) == permission.ConsumeAccess {
+		return errors.Errorf("You have specified an offer access permission %q.\n"+
+			"If you intended to change offer access, you need to specify one or more offer URLs.\n"+
+			"See 'juju help grant'.", c.Access)
+	}
 	return nil
 }
 
@@ -127,14 +171,15 @@ func NewGrantCommand() cmd.Command {
 // grantCommand represents the command to grant a user access to one or more models.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api       GrantModelAPI
+	offersAPI GrantOfferAPI
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <permission> [<model name> ...]",
+		Args:    "<user name> <permission> [<model name> ... | <offer url> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -151,6 +196,17 @@ func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
 	return c.NewControllerAPIClient()
 }
 
+func (c *grantCommand) getOfferAPI() (GrantOfferAPI, error) {
+	if c.offersAPI != nil {
+		return c.offersAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodelapi.NewClient(root), nil
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
@@ -163,8 +219,17 @@ type GrantControllerAPI interface {
 	GrantController(user, access string) error
 }
 
+// GrantOfferAPI defines the API functions used by the grant command.
+type GrantOfferAPI interface {
+	Close() error
+	GrantOffer(user, access string, offerURLs ...string) error
+}
+
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if len(c.OfferURLs) > 0 {
+		return c.runForOffers()
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
@@ -195,6 +260,16 @@ func (c *grantCommand) runForModel() error {
 	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
 }
 
+func (c *grantCommand) runForOffers() error {
+	client, err := c.getOfferAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.GrantOffer(c.User, c.Access, c.OfferURLs...), block.BlockChange)
+}
+
 // NewRevokeCommand returns a new revoke command.
 func NewRevokeCommand() cmd.Command {
 	return modelcmd.WrapController(&revokeCommand{})
@@ -203,14 +278,15 @@ func NewRevokeCommand() cmd.Command {
 // revokeCommand revokes a user's access to models.
 type revokeCommand struct {
 	accessCommand
-	api RevokeModelAPI
+	api       RevokeModelAPI
+	offersAPI RevokeOfferAPI
 }
 
 // Info implements cmd.Command.
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <permission> [<model name> ...]",
+		Args:    "<user> <permission> [<model name> ... | <offer url> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -227,6 +303,17 @@ func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
 	return c.NewControllerAPIClient()
 }
 
+func (c *revokeCommand) getOfferAPI() (RevokeOfferAPI, error) {
+	if c.offersAPI != nil {
+		return c.offersAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crossmodelapi.NewClient(root), nil
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
@@ -239,8 +326,17 @@ type RevokeControllerAPI interface {
 	RevokeController(user, access string) error
 }
 
+// RevokeOfferAPI defines the API functions used by the revoke command.
+type RevokeOfferAPI interface {
+	Close() error
+	RevokeOffer(user, access string, offerURLs ...string) error
+}
+
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if len(c.OfferURLs) > 0 {
+		return c.runForOffers()
+	}
 	if len(c.ModelNames) > 0 {
 		return c.runForModel()
 	}
@@ -270,3 +366,13 @@ func (c *revokeCommand) runForModel() error {
 	}
 	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, models...), block.BlockChange)
 }
+
+func (c *revokeCommand) runForOffers() error {
+	client, err := c.getOfferAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return block.ProcessBlockedError(client.RevokeOffer(c.User, c.Access, c.OfferURLs...), block.BlockChange)
+}
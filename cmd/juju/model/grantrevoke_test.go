@@ -183,6 +183,51 @@ func (s *grantSuite) TestModelAccessForController(c *gc.C) {
 	c.Check(msg, gc.Matches, `You have specified a model access permission "write".*`)
 }
 
+func (s *grantSuite) TestInitOffers(c *gc.C) {
+	wrappedCmd, grantCmd := model.NewGrantOfferCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "consume", "local:/u/mary/mysql", "local:/u/mary/db2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(grantCmd.OfferURLs, jc.DeepEquals, []string{"local:/u/mary/mysql", "local:/u/mary/db2"})
+	c.Assert(grantCmd.ModelNames, gc.HasLen, 0)
+}
+
+func (s *grantSuite) TestInitOffersAndModels(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantOfferCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "read", "local:/u/mary/mysql", "model1"})
+	c.Assert(err, gc.ErrorMatches, "models and offers cannot be specified together")
+}
+
+func (s *grantSuite) TestModelAccessForOffer(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantOfferCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "write", "local:/u/mary/mysql"})
+	c.Assert(err, gc.ErrorMatches, `"write" offer access not valid`)
+}
+
+func (s *grantSuite) TestOfferAccessForController(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantOfferCommandForTest(s.fake, s.store)
+	err := testing.InitCommand(wrappedCmd, []string{"bob", "consume"})
+	msg := strings.Replace(err.Error(), "\n", "", -1)
+	c.Check(msg, gc.Matches, `You have specified an offer access permission "consume".*`)
+}
+
+func (s *grantSuite) TestGrantOffer(c *gc.C) {
+	wrappedCmd, _ := model.NewGrantOfferCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, wrappedCmd, "sam", "consume", "local:/u/mary/mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.access, gc.Equals, "consume")
+	c.Assert(s.fake.offerURLs, jc.DeepEquals, []string{"local:/u/mary/mysql"})
+}
+
+func (s *revokeSuite) TestRevokeOffer(c *gc.C) {
+	wrappedCmd, _ := model.NewRevokeOfferCommandForTest(s.fake, s.store)
+	_, err := testing.RunCommand(c, wrappedCmd, "sam", "read", "local:/u/mary/mysql", "local:/u/mary/db2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.access, gc.Equals, "read")
+	c.Assert(s.fake.offerURLs, jc.DeepEquals, []string{"local:/u/mary/mysql", "local:/u/mary/db2"})
+}
+
 func (s *grantSuite) TestControllerAccessForModel(c *gc.C) {
 	wrappedCmd, _ := model.NewRevokeCommandForTest(s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{"bob", "superuser", "default"})
@@ -195,6 +240,7 @@ type fakeGrantRevokeAPI struct {
 	user       string
 	access     string
 	modelUUIDs []string
+	offerURLs  []string
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -213,3 +259,18 @@ func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) err
 	f.modelUUIDs = modelUUIDs
 	return f.err
 }
+
+func (f *fakeGrantRevokeAPI) GrantOffer(user, access string, offerURLs ...string) error {
+	return f.fakeOffer(user, access, offerURLs...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeOffer(user, access string, offerURLs ...string) error {
+	return f.fakeOffer(user, access, offerURLs...)
+}
+
+func (f *fakeGrantRevokeAPI) fakeOffer(user, access string, offerURLs ...string) error {
+	f.user = user
+	f.access = access
+	f.offerURLs = offerURLs
+	return f.err
+}
@@ -38,6 +38,13 @@ func NewUpdateCommandForTest(api SpaceAPI) cmd.Command {
 	return modelcmd.Wrap(updateCmd)
 }
 
+func NewMoveCommandForTest(api SpaceAPI) cmd.Command {
+	moveCmd := &moveCommand{
+		SpaceCommandBase: SpaceCommandBase{api: api},
+	}
+	return modelcmd.Wrap(moveCmd)
+}
+
 type RenameCommand struct {
 	*renameCommand
 }
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/set"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewMoveCommand returns a command used to move subnets to a space.
+func NewMoveCommand() cmd.Command {
+	return modelcmd.Wrap(&moveCommand{})
+}
+
+// moveCommand calls the API to move subnets into an existing space.
+type moveCommand struct {
+	SpaceCommandBase
+	Name  string
+	CIDRs set.Strings
+	Force bool
+}
+
+const moveCommandDoc = `
+Moves the given subnets (using their CIDRs) from their current spaces
+into the named space.
+
+Moving a subnet changes the space of every address in it, so an
+application endpoint bound to the subnet's old space may no longer be
+satisfiable on a machine hosting one of the application's units. Such
+moves are refused unless --force is given.
+
+The units of applications deployed to machines with addresses in the
+moved subnets run the config-changed hook, so that charms can pick up
+their new network-get results.
+
+Examples:
+    juju move-to-space db 10.0.1.0/24
+    juju move-to-space --force public 10.0.1.0/24 10.0.2.0/24
+
+See also:
+    add-space
+    spaces
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *moveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "move-to-space",
+		Args:    "<name> <CIDR1> [ <CIDR2> ...]",
+		Purpose: "Move subnets to a network space",
+		Doc:     strings.TrimSpace(moveCommandDoc),
+	}
+}
+
+// SetFlags is defined on the cmd.Command interface.
+func (c *moveCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SpaceCommandBase.SetFlags(f)
+	f.BoolVar(&c.Force, "force", false, "Move the subnets even if endpoint bindings are left unsatisfiable")
+}
+
+// Init is defined on the cmd.Command interface. It checks the
+// arguments for sanity and sets up the command to run.
+func (c *moveCommand) Init(args []string) error {
+	var err error
+	c.Name, c.CIDRs, err = ParseNameAndCIDRs(args, false)
+	return errors.Trace(err)
+}
+
+// Run implements Command.Run.
+func (c *moveCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithAPI(ctx, func(api SpaceAPI, ctx *cmd.Context) error {
+		cidrs := c.CIDRs.SortedValues()
+		applications, err := api.MoveSubnets(c.Name, cidrs, c.Force)
+		if err != nil {
+			if params.IsCodeUnauthorized(err) {
+				common.PermissionsMessage(ctx.Stderr, "move subnets")
+			}
+			return errors.Annotatef(err, "cannot move subnets to space %q", c.Name)
+		}
+
+		ctx.Infof("moved subnets %s to space %q", strings.Join(cidrs, ", "), c.Name)
+		if len(applications) > 0 {
+			ctx.Infof("affected applications: %s", strings.Join(applications, ", "))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package space_test
+
+import (
+	"github.com/juju/errors"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/space"
+)
+
+type MoveSuite struct {
+	BaseSpaceSuite
+}
+
+var _ = gc.Suite(&MoveSuite{})
+
+func (s *MoveSuite) SetUpTest(c *gc.C) {
+	s.BaseSpaceSuite.SetUpTest(c)
+	s.command = space.NewMoveCommandForTest(s.api)
+	c.Assert(s.command, gc.NotNil)
+}
+
+func (s *MoveSuite) TestRunWithSubnetsSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`moved subnets 10.1.2.0/24, 4.3.2.0/28 to space "myspace"\n`+
+			`affected applications: mysql\n`,
+		"", // no stdout, just stderr
+		"myspace", "10.1.2.0/24", "4.3.2.0/28",
+	)
+
+	s.api.CheckCallNames(c, "MoveSubnets", "Close")
+	s.api.CheckCall(c,
+		0, "MoveSubnets",
+		"myspace", s.Strings("10.1.2.0/24", "4.3.2.0/28"), false,
+	)
+}
+
+func (s *MoveSuite) TestRunWithForce(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`moved subnets 10.1.2.0/24 to space "myspace"\n`+
+			`affected applications: mysql\n`,
+		"",
+		"--force", "myspace", "10.1.2.0/24",
+	)
+
+	s.api.CheckCall(c, 0, "MoveSubnets", "myspace", s.Strings("10.1.2.0/24"), true)
+}
+
+func (s *MoveSuite) TestRunWithoutSubnetsFails(c *gc.C) {
+	s.AssertRunFails(c,
+		"invalid arguments specified: CIDRs required but not provided",
+		"myspace",
+	)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *MoveSuite) TestRunWhenSpacesAPIFails(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+
+	s.AssertRunFails(c,
+		`cannot move subnets to space "foo": boom`,
+		"foo", "10.1.2.0/24",
+	)
+
+	s.api.CheckCallNames(c, "MoveSubnets", "Close")
+}
@@ -217,3 +217,11 @@ func (sa *StubAPI) RenameSpace(name, newName string) error {
 	sa.MethodCall(sa, "RenameSpace", name, newName)
 	return sa.NextErr()
 }
+
+func (sa *StubAPI) MoveSubnets(name string, subnetIds []string, force bool) ([]string, error) {
+	sa.MethodCall(sa, "MoveSubnets", name, subnetIds, force)
+	if err := sa.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{"mysql"}, nil
+}
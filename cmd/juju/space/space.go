@@ -47,6 +47,10 @@ type SpaceAPI interface {
 
 	// RenameSpace changes the name of the space.
 	RenameSpace(name, newName string) error
+
+	// MoveSubnets moves the subnets with the given CIDRs into the
+	// named space, returning the names of the affected applications.
+	MoveSubnets(name string, subnetIds []string, force bool) ([]string, error)
 }
 
 var logger = loggo.GetLogger("juju.cmd.juju.space")
@@ -137,6 +141,10 @@ func (m *mvpAPIShim) ListSpaces() ([]params.Space, error) {
 	return m.facade.ListSpaces()
 }
 
+func (m *mvpAPIShim) MoveSubnets(name string, subnetIds []string, force bool) ([]string, error) {
+	return m.facade.MoveSubnets(name, subnetIds, force)
+}
+
 // NewAPI returns a SpaceAPI for the root api endpoint that the
 // environment command returns.
 func (c *SpaceCommandBase) NewAPI() (SpaceAPI, error) {
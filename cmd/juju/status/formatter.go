@@ -4,6 +4,7 @@
 package status
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/utils/series"
@@ -164,21 +165,23 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	}
 
 	out := applicationStatus{
-		Err:           application.Err,
-		Charm:         application.Charm,
-		Series:        application.Series,
-		OS:            strings.ToLower(appOS.String()),
-		CharmOrigin:   charmOrigin,
-		CharmName:     charmName,
-		CharmRev:      charmRev,
-		Exposed:       application.Exposed,
-		Life:          application.Life,
-		Relations:     application.Relations,
-		CanUpgradeTo:  application.CanUpgradeTo,
-		SubordinateTo: application.SubordinateTo,
-		Units:         make(map[string]unitStatus),
-		StatusInfo:    sf.getApplicationStatusInfo(application),
-		Version:       application.WorkloadVersion,
+		Err:              application.Err,
+		Charm:            application.Charm,
+		Series:           application.Series,
+		OS:               strings.ToLower(appOS.String()),
+		CharmOrigin:      charmOrigin,
+		CharmName:        charmName,
+		CharmRev:         charmRev,
+		Exposed:          application.Exposed,
+		ExposedEndpoints: formatExposedEndpoints(application.ExposedEndpoints),
+		Life:             application.Life,
+		Relations:        application.Relations,
+		CanUpgradeTo:     application.CanUpgradeTo,
+		SubordinateTo:    application.SubordinateTo,
+		Units:            make(map[string]unitStatus),
+		StatusInfo:       sf.getApplicationStatusInfo(application),
+		Version:          application.WorkloadVersion,
+		Upgrade:          formatRollingUpgrade(application.RollingUpgrade),
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
@@ -191,6 +194,44 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 	return out
 }
 
+// formatExposedEndpoints returns the expose settings of an
+// application's endpoints, with "*" standing for all endpoints.
+func formatExposedEndpoints(exposed map[string]params.ExposedEndpoint) map[string]exposedEndpoint {
+	if len(exposed) == 0 {
+		return nil
+	}
+	out := make(map[string]exposedEndpoint, len(exposed))
+	for endpoint, settings := range exposed {
+		if endpoint == "" {
+			endpoint = "*"
+		}
+		out[endpoint] = exposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return out
+}
+
+// formatRollingUpgrade returns a one line summary of the progress of
+// a rolling charm upgrade.
+func formatRollingUpgrade(upgrade *params.RollingUpgradeStatus) string {
+	if upgrade == nil {
+		return ""
+	}
+	units := "units"
+	if len(upgrade.Released) == 1 {
+		units = "unit"
+	}
+	summary := fmt.Sprintf("%s, %d %s released to %s",
+		upgrade.Status, len(upgrade.Released), units, upgrade.ToCharm,
+	)
+	if upgrade.Message != "" {
+		summary += ": " + upgrade.Message
+	}
+	return summary
+}
+
 func (sf *statusFormatter) formatRemoteApplication(name string, application params.RemoteApplicationStatus) remoteApplicationStatus {
 	out := remoteApplicationStatus{
 		Err:            application.Err,
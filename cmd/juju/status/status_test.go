@@ -4653,6 +4653,32 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatRollingUpgrade(c *gc.C) {
+	c.Check(formatRollingUpgrade(nil), gc.Equals, "")
+	c.Check(formatRollingUpgrade(&params.RollingUpgradeStatus{
+		Status:   "running",
+		ToCharm:  "cs:quantal/mysql-2",
+		Released: []string{"mysql/0"},
+	}), gc.Equals, "running, 1 unit released to cs:quantal/mysql-2")
+	c.Check(formatRollingUpgrade(&params.RollingUpgradeStatus{
+		Status:   "paused",
+		Message:  `unit mysql/1 is in error: hook failed: "upgrade-charm"`,
+		ToCharm:  "cs:quantal/mysql-2",
+		Released: []string{"mysql/0", "mysql/1"},
+	}), gc.Equals, `paused, 2 units released to cs:quantal/mysql-2: unit mysql/1 is in error: hook failed: "upgrade-charm"`)
+}
+
+func (s *StatusSuite) TestFormatExposedEndpoints(c *gc.C) {
+	c.Check(formatExposedEndpoints(nil), gc.IsNil)
+	c.Check(formatExposedEndpoints(map[string]params.ExposedEndpoint{
+		"":         {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"db-admin": {ExposeToSpaces: []string{"admin"}},
+	}), jc.DeepEquals, map[string]exposedEndpoint{
+		"*":        {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"db-admin": {ExposeToSpaces: []string{"admin"}},
+	})
+}
+
 type tableSections map[string][]string
 
 func sectionTitle(lines []string) string {
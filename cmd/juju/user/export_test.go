@@ -37,6 +37,10 @@ type DisenableUserBase struct {
 	*disenableUserBase
 }
 
+type AddToGroupCommand struct {
+	*addToGroupCommand
+}
+
 func NewAddCommandForTest(api AddUserAPI, store jujuclient.ClientStore, modelAPI modelcmd.ModelAPI) (cmd.Command, *AddCommand) {
 	c := &addCommand{api: api}
 	c.SetClientStore(store)
@@ -126,3 +130,43 @@ func NewWhoAmICommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &whoAmICommand{store: store}
 	return c
 }
+
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddToGroupCommandForTest returns an add-to-group command with the api
+// provided as specified.
+func NewAddToGroupCommandForTest(api GroupAPI, store jujuclient.ClientStore) (cmd.Command, *AddToGroupCommand) {
+	c := &addToGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &AddToGroupCommand{c}
+}
+
+// NewListGroupsCommandForTest returns a groups command with the api
+// provided as specified.
+func NewListGroupsCommandForTest(api GroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listGroupsCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddTokenCommandForTest returns an add-token command with the api
+// provided as specified.
+func NewAddTokenCommandForTest(api TokenAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addTokenCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// NewRemoveTokenCommandForTest returns a remove-token command with the
+// api provided as specified.
+func NewRemoveTokenCommandForTest(api TokenAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeTokenCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageAddGroupSummary = `
+Adds a Juju group to a controller.`[1:]
+
+var usageAddGroupDetails = `
+A group is a set of users on a controller. Access granted to a
+group with ` + "`juju grant`" + ` applies to all of its members, in
+addition to any access granted to the users directly.
+
+Group names share the namespace of user names, so a group cannot
+have the same name as a user.
+
+Examples:
+    juju add-group devs
+    juju grant devs write mymodel
+
+See also:
+    add-to-group
+    groups
+    grant`[1:]
+
+var usageAddToGroupSummary = `
+Adds Juju users to a group.`[1:]
+
+var usageAddToGroupDetails = `
+Users added to a group inherit the access granted to that group.
+
+Examples:
+    juju add-to-group devs bob mary
+
+See also:
+    add-group
+    groups
+    show-user`[1:]
+
+var usageListGroupsSummary = `
+Lists Juju groups on a controller.`[1:]
+
+var usageListGroupsDetails = `
+Users with superuser access to the controller see all of the groups.
+Other users see only the groups of which they are a member.
+
+Examples:
+    juju groups
+    juju groups --format yaml
+
+See also:
+    add-group
+    add-to-group`[1:]
+
+// GroupAPI defines the API methods that the group commands use.
+type GroupAPI interface {
+	AddGroup(name string) error
+	AddToGroup(group string, usernames ...string) error
+	Groups() ([]params.GroupInfo, error)
+	Close() error
+}
+
+// groupCommandBase is a common base for the group commands.
+type groupCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api GroupAPI
+}
+
+func (c *groupCommandBase) getGroupAPI() (GroupAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddGroupCommand returns a command to add a group to a controller.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds a group to a controller.
+type addGroupCommand struct {
+	groupCommandBase
+	Group string
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-group",
+		Args:    "<group name>",
+		Purpose: usageAddGroupSummary,
+		Doc:     usageAddGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.AddGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q added", c.Group)
+	return nil
+}
+
+// NewAddToGroupCommand returns a command to add users to a group.
+func NewAddToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addToGroupCommand{})
+}
+
+// addToGroupCommand adds users to a group.
+type addToGroupCommand struct {
+	groupCommandBase
+	Group string
+	Users []string
+}
+
+// Info implements Command.Info.
+func (c *addToGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-to-group",
+		Args:    "<group name> <user name> ...",
+		Purpose: usageAddToGroupSummary,
+		Doc:     usageAddToGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addToGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	if len(args) == 1 {
+		return errors.New("no user names supplied")
+	}
+	c.Group = args[0]
+	c.Users = args[1:]
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addToGroupCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.AddToGroup(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
+
+// NewListGroupsCommand returns a command to list the groups on a controller.
+func NewListGroupsCommand() cmd.Command {
+	return modelcmd.WrapController(&listGroupsCommand{})
+}
+
+// listGroupsCommand lists the groups on a controller.
+type listGroupsCommand struct {
+	groupCommandBase
+	out cmd.Output
+}
+
+// GroupInfo defines the serialization behaviour of the group information.
+type GroupInfo struct {
+	Name    string   `yaml:"name" json:"name"`
+	Members []string `yaml:"members" json:"members"`
+}
+
+// Info implements Command.Info.
+func (c *listGroupsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "groups",
+		Purpose: usageListGroupsSummary,
+		Doc:     usageListGroupsDetails,
+		Aliases: []string{"list-groups"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.groupCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatGroupsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listGroupsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listGroupsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getGroupAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Groups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result) == 0 {
+		ctx.Infof("No groups to display.")
+		return nil
+	}
+	groups := make([]GroupInfo, len(result))
+	for i, group := range result {
+		groups[i] = GroupInfo{
+			Name:    group.Name,
+			Members: group.Members,
+		}
+	}
+	return c.out.Write(ctx, groups)
+}
+
+func formatGroupsTabular(writer io.Writer, value interface{}) error {
+	groups, ok := value.([]GroupInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", groups, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Name", "Members")
+	for _, group := range groups {
+		w.Println(group.Name, strings.Join(group.Members, ", "))
+	}
+	tw.Flush()
+	return nil
+}
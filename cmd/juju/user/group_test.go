@@ -0,0 +1,136 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type GroupSuite struct {
+	BaseSuite
+	mock *mockGroupAPI
+}
+
+var _ = gc.Suite(&GroupSuite{})
+
+func (s *GroupSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockGroupAPI{}
+}
+
+func (s *GroupSuite) TestAddGroupInit(c *gc.C) {
+	addGroupCommand := user.NewAddGroupCommandForTest(s.mock, s.store)
+	err := testing.InitCommand(addGroupCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no group name supplied")
+	err = testing.InitCommand(addGroupCommand, []string{"devs", "ops"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["ops"\]`)
+}
+
+func (s *GroupSuite) TestAddGroup(c *gc.C) {
+	addGroupCommand := user.NewAddGroupCommandForTest(s.mock, s.store)
+	ctx, err := testing.RunCommand(c, addGroupCommand, "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "devs")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Group \"devs\" added\n")
+}
+
+func (s *GroupSuite) TestBlockAddGroup(c *gc.C) {
+	s.mock.err = common.OperationBlockedError("TestBlockAddGroup")
+	addGroupCommand := user.NewAddGroupCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, addGroupCommand, "devs")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockAddGroup.*")
+}
+
+func (s *GroupSuite) TestAddToGroupInit(c *gc.C) {
+	wrappedCommand, command := user.NewAddToGroupCommandForTest(s.mock, s.store)
+	err := testing.InitCommand(wrappedCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no group name supplied")
+	err = testing.InitCommand(wrappedCommand, []string{"devs"})
+	c.Assert(err, gc.ErrorMatches, "no user names supplied")
+	err = testing.InitCommand(wrappedCommand, []string{"devs", "bob", "mary"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.Group, gc.Equals, "devs")
+	c.Assert(command.Users, jc.DeepEquals, []string{"bob", "mary"})
+}
+
+func (s *GroupSuite) TestAddToGroup(c *gc.C) {
+	wrappedCommand, _ := user.NewAddToGroupCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "devs", "bob", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "devs")
+	c.Assert(s.mock.users, jc.DeepEquals, []string{"bob", "mary"})
+}
+
+func (s *GroupSuite) TestAddToGroupError(c *gc.C) {
+	s.mock.err = errors.New("boom")
+	wrappedCommand, _ := user.NewAddToGroupCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, wrappedCommand, "devs", "bob")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *GroupSuite) TestListGroupsTabular(c *gc.C) {
+	s.mock.groups = []params.GroupInfo{
+		{Name: "devs", Members: []string{"bob", "mary"}},
+		{Name: "ops", Members: []string{"jim"}},
+	}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"Name  Members\n"+
+		"devs  bob, mary\n"+
+		"ops   jim\n"+
+		"\n")
+}
+
+func (s *GroupSuite) TestListGroupsYAML(c *gc.C) {
+	s.mock.groups = []params.GroupInfo{
+		{Name: "devs", Members: []string{"bob"}},
+	}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mock, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- name: devs\n"+
+		"  members:\n"+
+		"  - bob\n")
+}
+
+func (s *GroupSuite) TestListGroupsNone(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, "")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "No groups to display.\n")
+}
+
+type mockGroupAPI struct {
+	err    error
+	group  string
+	users  []string
+	groups []params.GroupInfo
+}
+
+func (m *mockGroupAPI) Close() error {
+	return nil
+}
+
+func (m *mockGroupAPI) AddGroup(name string) error {
+	m.group = name
+	return m.err
+}
+
+func (m *mockGroupAPI) AddToGroup(group string, usernames ...string) error {
+	m.group = group
+	m.users = usernames
+	return m.err
+}
+
+func (m *mockGroupAPI) Groups() ([]params.GroupInfo, error) {
+	return m.groups, m.err
+}
@@ -24,6 +24,9 @@ var helpDetails = `
 By default, the YAML format is used and the user name is the current
 user.
 
+The access shown includes any access inherited from the groups
+of which the user is a member.
+
 
 Examples:
     juju show-user
@@ -77,6 +80,9 @@ type UserInfo struct {
 	DateCreated    string `yaml:"date-created,omitempty" json:"date-created,omitempty"`
 	LastConnection string `yaml:"last-connection,omitempty" json:"last-connection,omitempty"`
 	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+
+	// Groups holds the groups from which the user inherits access.
+	Groups []string `yaml:"groups,omitempty" json:"groups,omitempty"`
 }
 
 // Info implements Command.Info.
@@ -146,6 +152,7 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 			DisplayName: info.DisplayName,
 			Access:      info.Access,
 			Disabled:    info.Disabled,
+			Groups:      info.Groups,
 		}
 		// TODO(wallyworld) record login information about external users.
 		if names.NewUserTag(info.Username).IsLocal() {
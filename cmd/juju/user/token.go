@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/api/usermanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/permission"
+)
+
+var usageAddTokenSummary = `
+Adds an API token that gives limited access to a model.`[1:]
+
+var usageAddTokenDetails = `
+An API token allows automation, such as a CI system, to use a single
+model on your behalf without knowing your password. The token grants
+no more than the specified access to the model, and expires after
+the specified duration. The token may optionally be limited to a set
+of API facades.
+
+The token is printed to stdout. Set it as the value of the
+JUJU_API_TOKEN environment variable to use it in place of the local
+account details; the controller and model must still be known to the
+client.
+
+The token's ID is printed to stderr, and is used to remove the token
+with ` + "`juju remove-token`" + `.
+
+API tokens cannot be used to manage users or controllers, or with
+commands that upload or download files, such as deploying local charms.
+
+Examples:
+    juju add-token -m mymodel --access read --expires 24h
+    export JUJU_API_TOKEN=$(juju add-token -m mymodel --access write)
+    juju add-token --facades Client,Application
+
+See also:
+    remove-token`[1:]
+
+var usageRemoveTokenSummary = `
+Removes API tokens.`[1:]
+
+var usageRemoveTokenDetails = `
+Removed API tokens can no longer be used to log in. Users may remove
+their own tokens; controller superusers may remove any token.
+
+Examples:
+    juju remove-token 5d9c5c61-4ad5-4e5b-8bcb-a3d7bbd4b8f5
+
+See also:
+    add-token`[1:]
+
+// TokenAPI defines the API methods that the token commands use.
+type TokenAPI interface {
+	AddAPIToken(params.AddAPIToken) (string, *macaroon.Macaroon, error)
+	RemoveAPITokens(ids ...string) error
+	Close() error
+}
+
+// NewAddTokenCommand returns a command to add an API token.
+func NewAddTokenCommand() cmd.Command {
+	return modelcmd.Wrap(&addTokenCommand{})
+}
+
+// addTokenCommand adds an API token for the current user.
+type addTokenCommand struct {
+	modelcmd.ModelCommandBase
+	api TokenAPI
+
+	Access  string
+	Expires time.Duration
+	Facades string
+}
+
+// Info implements Command.Info.
+func (c *addTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-token",
+		Purpose: usageAddTokenSummary,
+		Doc:     usageAddTokenDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addTokenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Access, "access", "read", "The access the token grants to the model: read, write or admin")
+	f.DurationVar(&c.Expires, "expires", 24*time.Hour, "How long until the token expires")
+	f.StringVar(&c.Facades, "facades", "", "Comma separated list of the only facades that may be used with the token")
+}
+
+// Init implements Command.Init.
+func (c *addTokenCommand) Init(args []string) error {
+	if err := permission.ValidateModelAccess(permission.Access(c.Access)); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Expires <= 0 {
+		return errors.New("expiry duration must be positive")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *addTokenCommand) getAPI() (TokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return usermanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *addTokenCommand) Run(ctx *cmd.Context) error {
+	store := c.ClientStore()
+	model, err := store.ModelByName(c.ControllerName(), c.ModelName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	account, err := store.AccountDetails(c.ControllerName())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	var facades []string
+	if c.Facades != "" {
+		facades = strings.Split(c.Facades, ",")
+	}
+	expires := time.Now().Add(c.Expires)
+	id, m, err := client.AddAPIToken(params.AddAPIToken{
+		ModelTag: names.NewModelTag(model.ModelUUID).String(),
+		Access:   c.Access,
+		Facades:  facades,
+		Expires:  expires,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	token, err := jujuclient.EncodeAPIToken(jujuclient.APIToken{
+		User:      account.User,
+		Macaroons: []macaroon.Slice{{m}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added API token %s, which expires at %s", id, expires.UTC().Format(time.RFC3339))
+	fmt.Fprintln(ctx.Stdout, token)
+	return nil
+}
+
+// NewRemoveTokenCommand returns a command to remove API tokens.
+func NewRemoveTokenCommand() cmd.Command {
+	return modelcmd.WrapController(&removeTokenCommand{})
+}
+
+// removeTokenCommand removes API tokens.
+type removeTokenCommand struct {
+	modelcmd.ControllerCommandBase
+	api TokenAPI
+	IDs []string
+}
+
+// Info implements Command.Info.
+func (c *removeTokenCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-token",
+		Args:    "<token id> ...",
+		Purpose: usageRemoveTokenSummary,
+		Doc:     usageRemoveTokenDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeTokenCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no token IDs supplied")
+	}
+	c.IDs = args
+	return nil
+}
+
+func (c *removeTokenCommand) getAPI() (TokenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *removeTokenCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	return errors.Trace(client.RemoveAPITokens(c.IDs...))
+}
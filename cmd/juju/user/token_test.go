@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type TokenSuite struct {
+	BaseSuite
+	mock *mockTokenAPI
+}
+
+var _ = gc.Suite(&TokenSuite{})
+
+func (s *TokenSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockTokenAPI{}
+	s.store.Models["testing"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"current-user/mymodel": {testing.ModelTag.Id()},
+		},
+		CurrentModel: "current-user/mymodel",
+	}
+}
+
+func (s *TokenSuite) TestAddTokenInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--access", "superuser"},
+		err:  `"superuser" model access not valid`,
+	}, {
+		args: []string{"--expires", "-1h"},
+		err:  "expiry duration must be positive",
+	}, {
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		addTokenCommand := user.NewAddTokenCommandForTest(s.mock, s.store)
+		err := testing.InitCommand(addTokenCommand, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *TokenSuite) TestAddToken(c *gc.C) {
+	before := time.Now()
+	addTokenCommand := user.NewAddTokenCommandForTest(s.mock, s.store)
+	ctx, err := testing.RunCommand(c, addTokenCommand,
+		"--access", "write", "--expires", "2h", "--facades", "Client,Application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.mock.args.ModelTag, gc.Equals, testing.ModelTag.String())
+	c.Assert(s.mock.args.Access, gc.Equals, "write")
+	c.Assert(s.mock.args.Facades, jc.DeepEquals, []string{"Client", "Application"})
+	c.Assert(s.mock.args.Expires.After(before.Add(2*time.Hour)), jc.IsTrue)
+
+	c.Assert(testing.Stderr(ctx), gc.Matches, "Added API token token-id, which expires at .*\n")
+	token, err := jujuclient.DecodeAPIToken(strings.TrimSpace(testing.Stdout(ctx)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.User, gc.Equals, "current-user")
+	c.Assert(token.Macaroons, gc.HasLen, 1)
+	c.Assert(token.Macaroons[0][0].Id(), gc.Equals, "token-id")
+}
+
+func (s *TokenSuite) TestAddTokenError(c *gc.C) {
+	s.mock.err = errors.New("boom")
+	addTokenCommand := user.NewAddTokenCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, addTokenCommand)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *TokenSuite) TestRemoveTokenInit(c *gc.C) {
+	removeTokenCommand := user.NewRemoveTokenCommandForTest(s.mock, s.store)
+	err := testing.InitCommand(removeTokenCommand, []string{})
+	c.Assert(err, gc.ErrorMatches, "no token IDs supplied")
+}
+
+func (s *TokenSuite) TestRemoveToken(c *gc.C) {
+	removeTokenCommand := user.NewRemoveTokenCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, removeTokenCommand, "id1", "id2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.removed, jc.DeepEquals, []string{"id1", "id2"})
+}
+
+type mockTokenAPI struct {
+	err     error
+	args    params.AddAPIToken
+	removed []string
+}
+
+func (m *mockTokenAPI) Close() error {
+	return nil
+}
+
+func (m *mockTokenAPI) AddAPIToken(args params.AddAPIToken) (string, *macaroon.Macaroon, error) {
+	m.args = args
+	if m.err != nil {
+		return "", nil, m.err
+	}
+	mac, err := macaroon.New([]byte("root-key"), "token-id", "juju")
+	if err != nil {
+		return "", nil, err
+	}
+	return "token-id", mac, nil
+}
+
+func (m *mockTokenAPI) RemoveAPITokens(ids ...string) error {
+	m.removed = ids
+	return m.err
+}
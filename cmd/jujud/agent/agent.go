@@ -7,6 +7,7 @@ agent contains jujud's machine agent.
 package agent
 
 import (
+	"path/filepath"
 	"sync"
 
 	"github.com/juju/cmd"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/cmd/jujud/util"
 )
 
@@ -42,11 +44,16 @@ type AgentConf interface {
 
 	// ChangeConfig modifies this configuration using the given mutator.
 	ChangeConfig(change agent.ConfigMutator) error
+
+	// DriftTracker returns the tracker that records the fingerprint
+	// of the agent's config, and of the other artifacts the agent
+	// writes to its machine.
+	DriftTracker() *drift.Tracker
 }
 
 // NewAgentConf returns a new value that satisfies AgentConf
 func NewAgentConf(dataDir string) AgentConf {
-	return &agentConf{dataDir: dataDir}
+	return &agentConf{dataDir: dataDir, tracker: drift.NewTracker()}
 }
 
 // agentConf handles command-line flags shared by all agents.
@@ -54,6 +61,7 @@ type agentConf struct {
 	dataDir string
 	mu      sync.Mutex
 	_config agent.ConfigSetterWriter
+	tracker *drift.Tracker
 }
 
 // AddFlags injects common agent flags into f.
@@ -86,12 +94,21 @@ func (c *agentConf) ReadConfig(tag string) error {
 	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	conf, err := agent.ReadConfig(agent.ConfigPath(c.dataDir, t))
+	configPath := agent.ConfigPath(c.dataDir, t)
+	conf, err := agent.ReadConfig(configPath)
 	if err != nil {
 		return err
 	}
 	c._config = conf
-	return nil
+	// The baseline is kept with the agent's other state, so that
+	// changes made to its config while it was not running are
+	// detected too.
+	baselinePath := filepath.Join(agent.Dir(c.dataDir, t), "drift-baseline.json")
+	tracker := c.driftTracker()
+	if err := tracker.SetStore(baselinePath); err != nil {
+		return errors.Trace(err)
+	}
+	return tracker.TrackFile("agent-config", configPath)
 }
 
 // ChangeConfig modifies this configuration using the given mutator.
@@ -104,7 +121,24 @@ func (ch *agentConf) ChangeConfig(change agent.ConfigMutator) error {
 	if err := ch._config.Write(); err != nil {
 		return errors.Annotate(err, "cannot write agent configuration")
 	}
-	return nil
+	configPath := agent.ConfigPath(ch.dataDir, ch._config.Tag())
+	return ch.driftTracker().RecordFile("agent-config", configPath)
+}
+
+// DriftTracker is part of the AgentConf interface.
+func (c *agentConf) DriftTracker() *drift.Tracker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.driftTracker()
+}
+
+// driftTracker returns the agent's drift tracker, creating it if
+// necessary. It must be called with c.mu held.
+func (c *agentConf) driftTracker() *drift.Tracker {
+	if c.tracker == nil {
+		c.tracker = drift.NewTracker()
+	}
+	return c.tracker
 }
 
 // CurrentConfig returns the agent config for this agent.
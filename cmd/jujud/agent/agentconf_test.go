@@ -4,11 +4,17 @@
 package agent
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -34,6 +40,29 @@ func (s *agentConfSuite) TestChangeConfigSuccess(c *gc.C) {
 	c.Assert(mcsw.WriteCalled, jc.IsTrue)
 }
 
+func (s *agentConfSuite) TestChangeConfigRecordsDrift(c *gc.C) {
+	conf := agentConf{
+		dataDir: c.MkDir(),
+		_config: &mockConfigSetterWriter{},
+	}
+
+	err := conf.ChangeConfig(func(agent.ConfigSetter) error {
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	drifted, err := conf.DriftTracker().Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// The mock doesn't write the config, so writing it now is drift.
+	configPath := agent.ConfigPath(conf.dataDir, names.NewMachineTag("0"))
+	c.Assert(os.MkdirAll(filepath.Dir(configPath), 0755), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(configPath, []byte("changed"), 0600), jc.ErrorIsNil)
+	drifted, err = conf.DriftTracker().Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, jc.DeepEquals, []drift.Artifact{{Name: "agent-config", Path: configPath}})
+}
+
 func (s *agentConfSuite) TestChangeConfigMutateFailure(c *gc.C) {
 	mcsw := &mockConfigSetterWriter{}
 
@@ -78,3 +107,7 @@ func (c *mockConfigSetterWriter) Write() error {
 	c.WriteCalled = true
 	return c.WriteError
 }
+
+func (c *mockConfigSetterWriter) Tag() names.Tag {
+	return names.NewMachineTag("0")
+}
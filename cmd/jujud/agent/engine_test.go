@@ -43,6 +43,7 @@ var (
 		"firewaller",
 		"instance-poller",
 		"machine-undertaker",
+		"maintenance-inactive-flag",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
@@ -54,9 +55,11 @@ var (
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
+		"rolling-upgrader",
 	}
 	migratingModelWorkers = []string{
 		"environ-tracker",
+		"maintenance-inactive-flag",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
@@ -110,6 +113,7 @@ var (
 	}
 	notMigratingMachineWorkers = []string{
 		"api-address-updater",
+		"config-drift-checker",
 		"disk-manager",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
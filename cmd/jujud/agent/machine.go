@@ -37,6 +37,7 @@ import (
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api"
 	apiagent "github.com/juju/juju/api/agent"
@@ -48,6 +49,7 @@ import (
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/observer/metricobserver"
+	"github.com/juju/juju/apiserver/observer/traceobserver"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/cert"
@@ -73,6 +75,7 @@ import (
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
 	"github.com/juju/juju/storage/looputil"
+	"github.com/juju/juju/tracing"
 	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/watcher"
@@ -161,6 +164,12 @@ type AgentConfigWriter interface {
 	CurrentConfig() agent.Config
 }
 
+// driftTrackerGetter is implemented by agent config writers that
+// record the fingerprint of the agent config they write.
+type driftTrackerGetter interface {
+	DriftTracker() *drift.Tracker
+}
+
 // NewMachineAgentCmd creates a Command which handles parsing
 // command-line arguments and instantiating and running a
 // MachineAgent.
@@ -291,9 +300,16 @@ func NewMachineAgent(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The agent's workers record the artifacts they write with
+	// the same tracker as the agent config.
+	tracker := drift.NewTracker()
+	if getter, ok := agentConfWriter.(driftTrackerGetter); ok {
+		tracker = getter.DriftTracker()
+	}
 	a := &MachineAgent{
 		machineId:                   machineId,
 		AgentConfigWriter:           agentConfWriter,
+		driftTracker:                tracker,
 		configChangedVal:            voyeur.NewValue(true),
 		bufferedLogger:              bufferedLogger,
 		workersStarted:              make(chan struct{}),
@@ -352,6 +368,10 @@ type MachineAgent struct {
 	txnmetricsCollector        *txnmetrics.Collector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
+	// driftTracker records the fingerprints of the artifacts the
+	// agent and its workers write to the machine.
+	driftTracker *drift.Tracker
+
 	// Only API servers have hubs. This is temporary until the apiserver and
 	// peergrouper have manifolds.
 	centralHub *pubsub.StructuredHub
@@ -540,6 +560,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			ValidateMigration:    a.validateMigration,
 			PrometheusRegisterer: a.prometheusRegistry,
 			CentralHub:           a.centralHub,
+			DriftTracker:         a.driftTracker,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -1110,6 +1131,7 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		RollingUpgradeInterval:      10 * time.Second,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -1193,6 +1215,19 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	tracer, traceExporter, err := newTracer(controllerConfig, clock.WallClock)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create tracer")
+	}
+	stopTraceExporter := func() {
+		if traceExporter == nil {
+			return
+		}
+		if err := worker.Stop(traceExporter); err != nil {
+			logger.Warningf("error stopping trace exporter: %v", err)
+		}
+	}
+
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
@@ -1201,8 +1236,10 @@ func (a *MachineAgent) newAPIserverWorker(
 		newAuditEntrySink(st, logDir),
 		auditErrorHandler,
 		a.prometheusRegistry,
+		tracer,
 	)
 	if err != nil {
+		stopTraceExporter()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 	statePool := state.NewStatePool(st)
@@ -1233,14 +1270,61 @@ func (a *MachineAgent) newAPIserverWorker(
 		NewObserver:                   newObserver,
 		StatePool:                     statePool,
 		RegisterIntrospectionHandlers: registerIntrospectionHandlers,
+		RateLimits: apiserver.RateLimitConfig{
+			User:          controllerConfig.APIUserRateLimit(),
+			UserOverrides: controllerConfig.APIUserRateLimitOverrides(),
+			Model:         controllerConfig.APIModelRateLimit(),
+		},
+		PrometheusRegisterer: a.prometheusRegistry,
 	})
 	if err != nil {
+		stopTraceExporter()
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	// Queued spans are exported when the API server stops.
+	go func() {
+		server.Wait()
+		stopTraceExporter()
+	}()
 
 	return server, nil
 }
 
+// Trace exporter queue settings. Spans recorded while the queue is
+// full are dropped rather than delaying API requests.
+const (
+	traceExportQueueSize     = 10000
+	traceExportBatchSize     = 500
+	traceExportFlushInterval = 5 * time.Second
+)
+
+// newTracer returns the tracer used to record API request spans, and
+// the worker exporting them, if tracing is enabled in the controller
+// config. Otherwise it returns nil for both.
+func newTracer(controllerConfig controller.Config, clock clock.Clock) (*tracing.Tracer, *tracing.BatchExporter, error) {
+	if !controllerConfig.TracingEnabled() {
+		return nil, nil, nil
+	}
+	var exporters tracing.MultiExporter
+	if path := controllerConfig.TracingExportFile(); path != "" {
+		exporters = append(exporters, tracing.NewFileExporter(path))
+	}
+	if collectorURL := controllerConfig.TracingCollectorURL(); collectorURL != "" {
+		exporters = append(exporters, tracing.NewCollectorExporter(http.DefaultClient, collectorURL))
+	}
+	exporter, err := tracing.NewBatchExporter(tracing.BatchExporterConfig{
+		Exporter:      exporters,
+		Clock:         clock,
+		QueueSize:     traceExportQueueSize,
+		BatchSize:     traceExportBatchSize,
+		FlushInterval: traceExportFlushInterval,
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return tracing.NewTracer(clock, exporter), exporter, nil
+}
+
 func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 	persistFn := st.PutAuditEntryFn()
 	fileSinkFn := audit.NewLogFileSink(logDir)
@@ -1273,6 +1357,7 @@ func newObserverFn(
 	persistAuditEntry audit.AuditEntrySinkFn,
 	auditErrorHandler observer.ErrorHandler,
 	prometheusRegisterer prometheus.Registerer,
+	tracer *tracing.Tracer,
 ) (observer.ObserverFactory, error) {
 
 	var observerFactories []observer.ObserverFactory
@@ -1309,6 +1394,17 @@ func newObserverFn(
 	}
 	observerFactories = append(observerFactories, metricObserver)
 
+	// Tracing observer.
+	if tracer != nil {
+		traceObserver, err := traceobserver.NewObserverFactory(traceobserver.Config{
+			Tracer: tracer,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "creating trace observer factory")
+		}
+		observerFactories = append(observerFactories, traceObserver)
+	}
+
 	return observer.ObserverFactoryMultiplexer(observerFactories...), nil
 
 }
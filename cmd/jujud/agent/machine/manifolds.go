@@ -14,6 +14,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
 	apideployer "github.com/juju/juju/api/deployer"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/authenticationworker"
 	"github.com/juju/juju/worker/centralhub"
+	"github.com/juju/juju/worker/configdrift"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
@@ -145,6 +147,11 @@ type ManifoldsConfig struct {
 
 	// DepEngineReporter is a dependency engine reporter.
 	DepEngineReporter dependency.Reporter
+
+	// DriftTracker records the fingerprints of the artifacts the
+	// agent and its workers write to the machine, so that the config
+	// drift worker can report those changed outside of Juju.
+	DriftTracker *drift.Tracker
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -387,6 +394,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			WorkerFunc:      proxyupdater.NewWorker,
 			ExternalUpdate:  externalUpdateProxyFunc,
 			InProcessUpdate: proxyconfig.DefaultConfig.Set,
+			Tracker:         config.DriftTracker,
 		})),
 
 		// The api address updater is a leaf worker that rewrites agent config
@@ -431,6 +439,20 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		authenticationWorkerName: ifNotMigrating(authenticationworker.Manifold(authenticationworker.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
+			Tracker:       config.DriftTracker,
+		})),
+
+		// The config drift worker periodically checks whether the
+		// artifacts written by the proxy updater, the ssh authkeys
+		// updater and the agent itself, and the apt sources tracked
+		// by the proxy updater, have been changed outside of Juju,
+		// and reports any drift in the machine status.
+		configDriftName: ifNotMigrating(configdrift.Manifold(configdrift.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Tracker:       config.DriftTracker,
+			Clock:         config.Clock,
+			Interval:      5 * time.Minute,
 		})),
 
 		// The storageProvisioner worker manages provisioning
@@ -532,6 +554,7 @@ const (
 	logSenderName            = "log-sender"
 	deployerName             = "unit-agent-deployer"
 	authenticationWorkerName = "ssh-authkeys-updater"
+	configDriftName          = "config-drift-checker"
 	storageProvisionerName   = "storage-provisioner"
 	resumerName              = "mgo-txn-resumer"
 	identityFileWriterName   = "ssh-identity-writer"
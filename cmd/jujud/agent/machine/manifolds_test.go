@@ -47,6 +47,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"central-hub",
+		"config-drift-checker",
 		"disk-manager",
 		"host-key-reporter",
 		"log-forwarder",
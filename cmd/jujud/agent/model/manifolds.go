@@ -34,11 +34,13 @@ import (
 	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/machineundertaker"
+	"github.com/juju/juju/worker/maintenanceflag"
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/rollingupgrade"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -81,6 +83,10 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// RollingUpgradeInterval determines how often the rolling-upgrade
+	// worker will check the progress of batched charm upgrades.
+	RollingUpgradeInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerMaxHistoryTime time.Duration
@@ -180,6 +186,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     migrationflag.NewFacade,
 			NewWorker:     migrationflag.NewWorker,
 		})),
+		// The maintenance-inactive flag is set while the controller
+		// is not in maintenance mode. Workers that would change the
+		// model or its cloud resources, and so would be refused by
+		// the API server during maintenance anyway, depend on it via
+		// ifNotInMaintenance.
+		maintenanceInactiveFlagName: ifNotDead(maintenanceflag.Manifold(maintenanceflag.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     maintenanceflag.NewFacade,
+			NewWorker:     maintenanceflag.NewWorker,
+		})),
 		migrationMasterName: ifNotDead(migrationmaster.Manifold(migrationmaster.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -237,19 +253,19 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: discoverspaces.NewFacade,
 			NewWorker: discoverspaces.NewWorker,
 		})),
-		computeProvisionerName: ifNotMigrating(provisioner.Manifold(provisioner.ManifoldConfig{
+		computeProvisionerName: ifNotInMaintenance(provisioner.Manifold(provisioner.ManifoldConfig{
 			AgentName:          agentName,
 			APICallerName:      apiCallerName,
 			EnvironName:        environTrackerName,
 			NewProvisionerFunc: provisioner.NewEnvironProvisioner,
 		})),
-		storageProvisionerName: ifNotMigrating(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
+		storageProvisionerName: ifNotInMaintenance(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
 			EnvironName:   environTrackerName,
 			Scope:         modelTag,
 		})),
-		firewallerName: ifNotMigrating(firewaller.Manifold(firewaller.ManifoldConfig{
+		firewallerName: ifNotInMaintenance(firewaller.Manifold(firewaller.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
 		})),
@@ -275,10 +291,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		rollingUpgraderName: ifNotMigrating(rollingupgrade.Manifold(rollingupgrade.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.RollingUpgradeInterval,
+
+			NewFacade: rollingupgrade.NewAPIFacade,
+			NewWorker: rollingupgrade.NewWorker,
+		})),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
-		stateCleanerName: ifNotMigrating(cleaner.Manifold(cleaner.ManifoldConfig{
+		stateCleanerName: ifNotInMaintenance(cleaner.Manifold(cleaner.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
 		statusHistoryPrunerName: ifNotMigrating(statushistorypruner.Manifold(statushistorypruner.ManifoldConfig{
@@ -366,6 +390,17 @@ var (
 		},
 		Occupy: migrationFortressName,
 	}.Decorate
+
+	// ifNotInMaintenance wraps a manifold such that it only runs
+	// if neither a migration nor controller maintenance is in
+	// progress. It is otherwise equivalent to ifNotMigrating.
+	ifNotInMaintenance = engine.Housing{
+		Flags: []string{
+			migrationInactiveFlagName,
+			maintenanceInactiveFlagName,
+		},
+		Occupy: migrationFortressName,
+	}.Decorate
 )
 
 const (
@@ -383,6 +418,8 @@ const (
 	migrationInactiveFlagName = "migration-inactive-flag"
 	migrationMasterName       = "migration-master"
 
+	maintenanceInactiveFlagName = "maintenance-inactive-flag"
+
 	environTrackerName       = "environ-tracker"
 	undertakerName           = "undertaker"
 	spaceImporterName        = "space-importer"
@@ -393,6 +430,7 @@ const (
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	rollingUpgraderName      = "rolling-upgrader"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
@@ -44,12 +44,14 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"instance-poller",
 		"is-responsible-flag",
 		"machine-undertaker",
+		"maintenance-inactive-flag",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
 		"migration-master",
 		"not-alive-flag",
 		"not-dead-flag",
+		"rolling-upgrader",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
@@ -87,6 +89,24 @@ func (s *ManifoldsSuite) TestFlagDependencies(c *gc.C) {
 	}
 }
 
+func (s *ManifoldsSuite) TestMaintenanceDependencies(c *gc.C) {
+	manifolds := model.Manifolds(model.ManifoldsConfig{
+		Agent: &mockAgent{},
+	})
+	for _, name := range []string{
+		"compute-provisioner",
+		"storage-provisioner",
+		"firewaller",
+		"state-cleaner",
+	} {
+		c.Logf("checking %s", name)
+		inputs := set.NewStrings(manifolds[name].Inputs...)
+		c.Check(inputs.Contains("maintenance-inactive-flag"), jc.IsTrue)
+	}
+	inputs := set.NewStrings(manifolds["instance-poller"].Inputs...)
+	c.Check(inputs.Contains("maintenance-inactive-flag"), jc.IsFalse)
+}
+
 func (s *ManifoldsSuite) TestStateCleanerIgnoresLifeFlags(c *gc.C) {
 	manifolds := model.Manifolds(model.ManifoldsConfig{
 		Agent: &mockAgent{},
@@ -153,6 +173,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"instance-poller",
 		"is-responsible-flag",
 		"machine-undertaker",
+		"maintenance-inactive-flag",
 		"metric-worker",
 		"migration-fortress",
 		"migration-inactive-flag",
@@ -160,6 +181,7 @@ func (s *ManifoldsCrossModelSuite) TestNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"rolling-upgrader",
 		"space-importer",
 		"spaces-imported-gate",
 		"state-cleaner",
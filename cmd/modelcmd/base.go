@@ -26,7 +26,9 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju"
+	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/tracing"
 )
 
 var errNoNameSpecified = errors.New("no name specified")
@@ -56,6 +58,12 @@ type JujuCommandBase struct {
 	modelAPI_   ModelAPI
 	apiOpenFunc api.OpenFunc
 	authOpts    AuthOpts
+
+	// debugTrace holds whether API requests made by the command
+	// should be traced, and traceID the ID of the trace once one
+	// has been generated.
+	debugTrace bool
+	traceID    string
 }
 
 // closeContext closes the command's API context
@@ -71,6 +79,7 @@ func (c *JujuCommandBase) closeContext() {
 // SetFlags implements cmd.Command.SetFlags.
 func (c *JujuCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	c.authOpts.SetFlags(f)
+	f.BoolVar(&c.debugTrace, "debug-trace", false, "Trace API requests and print the trace ID")
 }
 
 // SetModelAPI sets the api used to access model information.
@@ -116,12 +125,24 @@ func (c *JujuCommandBase) NewAPIRoot(
 			accountDetails = &jujuclient.AccountDetails{}
 		}
 	}
+	// An API token in the environment takes precedence over the
+	// local account details.
+	token, err := apiTokenFromEnv()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if token != nil {
+		accountDetails = &jujuclient.AccountDetails{User: token.User}
+	}
 	param, err := c.NewAPIConnectionParams(
 		store, controllerName, modelName, accountDetails,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if token != nil {
+		param.Macaroons = token.Macaroons
+	}
 	conn, err := juju.NewAPIConnection(param)
 	if modelName != "" && params.ErrCode(err) == params.CodeModelNotFound {
 		return nil, c.missingModelError(store, controllerName, modelName)
@@ -129,6 +150,20 @@ func (c *JujuCommandBase) NewAPIRoot(
 	return conn, err
 }
 
+// apiTokenFromEnv returns the API token held in the environment, or
+// nil if there is none.
+func apiTokenFromEnv() (*jujuclient.APIToken, error) {
+	value := os.Getenv(osenv.JujuAPITokenEnvKey)
+	if value == "" {
+		return nil, nil
+	}
+	token, err := jujuclient.DecodeAPIToken(value)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid %s", osenv.JujuAPITokenEnvKey)
+	}
+	return token, nil
+}
+
 func (c *JujuCommandBase) missingModelError(store jujuclient.ClientStore, controllerName, modelName string) error {
 	// First, we'll try and clean up the missing model from the local cache.
 	err := store.RemoveModel(controllerName, modelName)
@@ -180,13 +215,36 @@ func (c *JujuCommandBase) NewAPIConnectionParams(
 		}
 	}
 
-	return newAPIConnectionParams(
+	connParams, err := newAPIConnectionParams(
 		store, controllerName, modelName,
 		accountDetails,
 		bakeryClient,
 		c.apiOpen,
 		getPassword,
 	)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	connParams.DialOpts.TraceID = c.apiTraceID()
+	return connParams, nil
+}
+
+// apiTraceID returns the ID of the trace that the command's API
+// requests are part of, or the empty string if the --debug-trace
+// flag was not specified. The trace ID is printed the first time it
+// is generated so that the requests can be found in the traces
+// exported by the controller.
+func (c *JujuCommandBase) apiTraceID() string {
+	if !c.debugTrace {
+		return ""
+	}
+	if c.traceID == "" {
+		c.traceID = tracing.NewTraceID()
+		if c.cmdContext != nil {
+			fmt.Fprintf(c.cmdContext.Stderr, "API trace ID: %s\n", c.traceID)
+		}
+	}
+	return c.traceID
 }
 
 // HTTPClient returns an http.Client that contains the loaded
@@ -9,6 +9,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
 )
 
 type BaseCommandSuite struct {
@@ -73,6 +75,28 @@ func (s *BaseCommandSuite) TestUnknownModelNotCurrent(c *gc.C) {
 	s.assertUnknownModel(c, "admin/goodmodel", "admin/goodmodel")
 }
 
+func (s *BaseCommandSuite) TestDebugTrace(c *gc.C) {
+	var traceIDs []string
+	apiOpen := func(_ *api.Info, opts api.DialOpts) (api.Connection, error) {
+		traceIDs = append(traceIDs, opts.TraceID)
+		return nil, errors.New("no API for you")
+	}
+	cmd := modelcmd.NewModelCommandBase(s.store, "foo", "admin/goodmodel")
+	cmd.SetAPIOpen(apiOpen)
+	f := gnuflag.NewFlagSet("", gnuflag.ContinueOnError)
+	cmd.SetFlags(f)
+	err := f.Parse(false, []string{"--debug-trace"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i < 2; i++ {
+		_, err := cmd.NewAPIRoot()
+		c.Assert(err, gc.ErrorMatches, "no API for you")
+	}
+	c.Assert(traceIDs, gc.HasLen, 2)
+	c.Assert(tracing.IsValidTraceID(traceIDs[0]), jc.IsTrue)
+	c.Assert(traceIDs[1], gc.Equals, traceIDs[0])
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
@@ -284,6 +284,22 @@ var parseConstraintsTests = []struct {
 		args:    []string{"spaces="},
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones",
+		args:    []string{"zones=az1", "zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
 	// instance type
 	{
 		summary: "set instance type",
@@ -508,6 +524,9 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
 	{"All", constraints.Value{
@@ -520,6 +539,7 @@ var constraintsRoundtripTests = []roundTrip{
 		Tags:         &[]string{"foo", "bar"},
 		Spaces:       &[]string{"space1", "^space2"},
 		InstanceType: strp("foo"),
+		Zones:        &[]string{"az1", "az2"},
 	}},
 }
 
@@ -597,6 +617,15 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=az1,az2")
+	c.Check(cons.HasZones(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -13,6 +14,10 @@ import (
 const (
 	ConfigModelUUID = "model-uuid"
 	ConfigLogDir    = "log-dir"
+
+	// ConfigIPFamily is the model's preferred-ip-family, used to
+	// decide whether IPv6 is enabled on the container bridge.
+	ConfigIPFamily = "ip-family"
 )
 
 // ManagerConfig contains the initialization parameters for the ContainerManager.
@@ -49,6 +54,22 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can
+// apply charm-supplied LXD profiles to their containers.
+type LXDProfileManager interface {
+	// EnsureLXDProfile creates the named profile, or replaces its
+	// config and devices if it already exists.
+	EnsureLXDProfile(name string, profile *lxdprofile.Profile) error
+
+	// SetContainerLXDProfiles replaces the charm profiles applied to
+	// the container identified by instance id with the named ones.
+	SetContainerLXDProfiles(id instance.Id, names []string) error
+
+	// RemoveUnusedLXDProfiles removes the charm profiles that are
+	// not applied to any container.
+	RemoveUnusedLXDProfiles() error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
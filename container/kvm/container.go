@@ -83,6 +83,7 @@ func (c *kvmContainer) Start(params StartParams) error {
 		CpuCores:      params.CpuCores,
 		RootDisk:      params.RootDisk,
 		Interfaces:    interfaces,
+		Volumes:       params.Volumes,
 	}); err != nil {
 		return err
 	}
@@ -17,6 +17,22 @@ type StartParams struct {
 	CpuCores         uint64
 	RootDisk         uint64 // GB
 	ImageDownloadURL string
+	Volumes          []VolumeParams
+}
+
+// VolumeParams describes a volume, backed by an image file on the host,
+// to be attached to the container as a virtio disk.
+type VolumeParams struct {
+	// Serial is the serial number of the disk seen by the guest.
+	Serial string
+
+	// Size is the size of the volume in MiB.
+	Size uint64
+
+	// HostDir is the directory on the host in which the volume image
+	// is created. If empty, the image is created alongside the
+	// container's own disks.
+	HostDir string
 }
 
 // Container represents a virtualized container instance and provides
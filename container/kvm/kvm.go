@@ -166,6 +166,10 @@ func (manager *containerManager) CreateContainer(
 	startParams.Series = series
 	startParams.Network = networkConfig
 	startParams.UserDataFile = userDataFilename
+	startParams.Volumes, err = volumeParams(storageConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
 
 	// If the Simplestream requested is anything but released, update
 	// our StartParams to request it.
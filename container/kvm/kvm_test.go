@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
@@ -19,6 +20,9 @@ import (
 	kvmtesting "github.com/juju/juju/container/kvm/testing"
 	containertesting "github.com/juju/juju/container/testing"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -140,6 +144,54 @@ func (s *KVMSuite) TestCreateContainerUtilizesDailySimpleStream(c *gc.C) {
 	c.Assert(kvm.TestStartParams.ImageDownloadURL, gc.Equals, "http://cloud-images.ubuntu.com/daily")
 }
 
+func (s *KVMSuite) TestCreateContainerWithVolumes(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/kvm/0")
+	c.Assert(err, jc.ErrorIsNil)
+	networkConfig := container.BridgeNetworkConfig("nic42", 0, nil)
+	storageConfig := &container.StorageConfig{
+		Volumes: []storage.VolumeParams{{
+			Tag:      names.NewVolumeTag("1/kvm/0/0"),
+			Size:     1024,
+			Provider: provider.HostLoopProviderType,
+		}, {
+			Tag:        names.NewVolumeTag("3"),
+			Size:       2048,
+			Provider:   provider.HostLoopProviderType,
+			Attributes: map[string]interface{}{"host-dir": "/srv/images"},
+		}},
+	}
+	containertesting.CreateContainerWithMachineAndNetworkAndStorageConfig(
+		c, s.manager, instanceConfig, networkConfig, storageConfig,
+	)
+	c.Assert(kvm.TestStartParams.Volumes, jc.DeepEquals, []kvm.VolumeParams{
+		{Serial: "juju-1-kvm-0-0", Size: 1024},
+		{Serial: "juju-3", Size: 2048, HostDir: "/srv/images"},
+	})
+}
+
+func (s *KVMSuite) TestCreateContainerUnsupportedVolumes(c *gc.C) {
+	instanceConfig, err := containertesting.MockMachineConfig("1/kvm/0")
+	c.Assert(err, jc.ErrorIsNil)
+	networkConfig := container.BridgeNetworkConfig("nic42", 0, nil)
+	storageConfig := &container.StorageConfig{
+		Volumes: []storage.VolumeParams{{
+			Tag:      names.NewVolumeTag("0"),
+			Size:     1024,
+			Provider: provider.LoopProviderType,
+		}},
+	}
+	callback := func(status.Status, string, map[string]interface{}) error { return nil }
+	_, _, err = s.manager.CreateContainer(
+		instanceConfig, constraints.Value{}, "quantal", networkConfig, storageConfig, callback,
+	)
+	c.Assert(err, gc.ErrorMatches, "loop volumes on kvm containers not supported")
+}
+
+func (s *KVMSuite) TestVolumeDeviceLink(c *gc.C) {
+	c.Assert(kvm.VolumeSerial(names.NewVolumeTag("1/kvm/0/2")), gc.Equals, "juju-1-kvm-0-2")
+	c.Assert(kvm.VolumeDeviceLink(names.NewVolumeTag("5")), gc.Equals, "/dev/disk/by-id/virtio-juju-5")
+}
+
 func (s *KVMSuite) TestStartContainerUtilizesSimpleStream(c *gc.C) {
 
 	startParams := kvm.StartParams{
@@ -24,6 +24,15 @@ type DiskInfo interface {
 	Driver() string
 }
 
+// SerialDiskInfo is implemented by disks that expose a serial number to
+// the guest, so that the guest can identify them independently of the
+// order in which they are attached.
+type SerialDiskInfo interface {
+	DiskInfo
+	// Serial is the serial number of the disk.
+	Serial() string
+}
+
 // InterfaceInfo represents network interface parameters for a kvm domain.
 type InterfaceInfo interface {
 	// MAC returns the interfaces MAC address.
@@ -99,6 +108,10 @@ func NewDomain(p domainParams) (Domain, error) {
 		if err != nil {
 			return Domain{}, errors.Trace(err)
 		}
+		var serial string
+		if sd, ok := diskInfo.(SerialDiskInfo); ok {
+			serial = sd.Serial()
+		}
 		switch diskInfo.Driver() {
 		case "raw":
 			d.Disk = append(d.Disk, Disk{
@@ -107,6 +120,7 @@ func NewDomain(p domainParams) (Domain, error) {
 				Driver: DiskDriver{Type: diskInfo.Driver(), Name: "qemu"},
 				Source: DiskSource{File: diskInfo.Source()},
 				Target: DiskTarget{Dev: devID},
+				Serial: serial,
 			})
 		case "qcow2":
 			d.Disk = append(d.Disk, Disk{
@@ -364,6 +378,7 @@ type Disk struct {
 	Driver DiskDriver `xml:"driver"`
 	Source DiskSource `xml:"source"`
 	Target DiskTarget `xml:"target"`
+	Serial string     `xml:"serial,omitempty"`
 }
 
 // DiskDriver is the type of virtual disk. We generate it dynamically.
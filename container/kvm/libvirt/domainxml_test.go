@@ -135,6 +135,36 @@ func (domainXMLSuite) TestNewDomain(c *gc.C) {
 	}
 }
 
+func (domainXMLSuite) TestNewDomainVolumesAndInterfaces(c *gc.C) {
+	ifaces := []InterfaceInfo{
+		dummyInterface{mac: "00:16:3e:00:00:01", parent: "br-eth0", name: "eth0"},
+		dummyInterface{mac: "00:16:3e:00:00:02", parent: "br-eth1", name: "eth1"},
+	}
+	disks := []DiskInfo{
+		dummyDisk{driver: "qcow2", source: "/some/path"},
+		dummyDisk{driver: "raw", source: "/another/path"},
+		dummySerialDisk{dummyDisk{driver: "raw", source: "/volume/path"}, "juju-0-1"},
+	}
+	params := dummyParams{ifaceInfo: ifaces, diskInfo: disks, memory: 1024, cpuCores: 2, hostname: "juju-someid", arch: "amd64"}
+
+	d, err := NewDomain(params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(d.Disk, gc.HasLen, 3)
+	c.Check(d.Disk[1].Serial, gc.Equals, "")
+	c.Check(d.Disk[2], jc.DeepEquals, Disk{
+		Device: "disk",
+		Type:   "file",
+		Driver: DiskDriver{Type: "raw", Name: "qemu"},
+		Source: DiskSource{File: "/volume/path"},
+		Target: DiskTarget{Dev: "vdc"},
+		Serial: "juju-0-1",
+	})
+	c.Assert(d.Interface, gc.HasLen, 2)
+	c.Check(d.Interface[0].Source.Bridge, gc.Equals, "br-eth0")
+	c.Check(d.Interface[1].Source.Bridge, gc.Equals, "br-eth1")
+	c.Check(d.Interface[1].MAC.Address, gc.Equals, "00:16:3e:00:00:02")
+}
+
 func (domainXMLSuite) TestNewDomainError(c *gc.C) {
 	d, err := NewDomain(dummyParams{err: errors.Errorf("boom")})
 	c.Check(d, jc.DeepEquals, Domain{})
@@ -171,6 +201,13 @@ type dummyDisk struct {
 func (d dummyDisk) Driver() string { return d.driver }
 func (d dummyDisk) Source() string { return d.source }
 
+type dummySerialDisk struct {
+	dummyDisk
+	serial string
+}
+
+func (d dummySerialDisk) Serial() string { return d.serial }
+
 type dummyInterface struct {
 	mac, parent, name string
 }
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package kvm
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/storage/provider"
+)
+
+// VolumeSerial returns the serial number of the virtio disk that backs
+// the volume with the given tag.
+func VolumeSerial(tag names.VolumeTag) string {
+	return "juju-" + strings.Replace(tag.Id(), "/", "-", -1)
+}
+
+// VolumeDeviceLink returns the link under which the disk backing the
+// volume with the given tag appears in the guest.
+func VolumeDeviceLink(tag names.VolumeTag) string {
+	return "/dev/disk/by-id/virtio-" + VolumeSerial(tag)
+}
+
+// volumeParams returns the parameters of the disks to attach to a
+// container for the hostloop volumes in the given storage config.
+func volumeParams(storageConfig *container.StorageConfig) ([]VolumeParams, error) {
+	if storageConfig == nil {
+		return nil, nil
+	}
+	var result []VolumeParams
+	for _, v := range storageConfig.Volumes {
+		if v.Provider != provider.HostLoopProviderType {
+			return nil, errors.NotSupportedf("%s volumes on kvm containers", v.Provider)
+		}
+		var hostDir string
+		if attr, ok := v.Attributes[provider.HostDirAttr]; ok {
+			hostDir, _ = attr.(string)
+		}
+		result = append(result, VolumeParams{
+			Serial:  VolumeSerial(v.Tag),
+			Size:    v.Size,
+			HostDir: hostDir,
+		})
+	}
+	return result, nil
+}
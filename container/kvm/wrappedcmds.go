@@ -67,6 +67,7 @@ type CreateMachineParams struct {
 	CpuCores      uint64
 	RootDisk      uint64
 	Interfaces    []libvirt.InterfaceInfo
+	Volumes       []VolumeParams
 
 	disks    []libvirt.DiskInfo
 	findPath func(string) (string, error)
@@ -150,7 +151,7 @@ func (p CreateMachineParams) ValidateDomainParams() error {
 
 // diskInfo is type for imlementing libvirt.DiskInfo.
 type diskInfo struct {
-	driver, source string
+	driver, source, serial string
 }
 
 // Driver implements libvirt.DiskInfo.
@@ -163,6 +164,11 @@ func (d diskInfo) Source() string {
 	return d.source
 }
 
+// Serial implements libvirt.SerialDiskInfo.
+func (d diskInfo) Serial() string {
+	return d.serial
+}
+
 // CreateMachine creates a virtual machine and starts it.
 func CreateMachine(params CreateMachineParams) error {
 	if params.Hostname == "" {
@@ -191,6 +197,14 @@ func CreateMachine(params CreateMachineParams) error {
 	params.disks = append(params.disks, diskInfo{source: imgPath, driver: "qcow2"})
 	params.disks = append(params.disks, diskInfo{source: dsPath, driver: "raw"})
 
+	for _, v := range params.Volumes {
+		volPath, err := writeVolumeDisk(params, v)
+		if err != nil {
+			return errors.Annotatef(err, "failed to write volume %q for %q", v.Serial, params.Host())
+		}
+		params.disks = append(params.disks, diskInfo{source: volPath, driver: "raw", serial: v.Serial})
+	}
+
 	domainPath, err := writeDomainXML(templateDir, params)
 	if err != nil {
 		return errors.Annotatef(err, "failed to write domain xml for %q", params.Host())
@@ -265,6 +279,7 @@ func DestroyMachine(c *kvmContainer) error {
 	if err != nil {
 		logger.Errorf("failed to remove cloud-init data disk for %q: %s", c.Name(), err)
 	}
+	removeVolumeDisks(guestBase, c.Name())
 
 	return nil
 }
@@ -463,6 +478,63 @@ func writeRootDisk(params CreateMachineParams) (string, error) {
 	return imgPath, nil
 }
 
+// writeVolumeDisk creates the raw image backing a volume attached to the
+// container. Images created outside of the guest directory are linked
+// into it, so that they can be found when the container is destroyed.
+func writeVolumeDisk(params CreateMachineParams, v VolumeParams) (string, error) {
+	guestBase, err := guestPath(params.findPath)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	name := fmt.Sprintf("%s-%s.img", params.Host(), v.Serial)
+	linkPath := filepath.Join(guestBase, name)
+	volPath := linkPath
+	if v.HostDir != "" {
+		if err := os.MkdirAll(v.HostDir, 0755); err != nil {
+			return "", errors.Trace(err)
+		}
+		volPath = filepath.Join(v.HostDir, name)
+	}
+
+	out, err := params.runCmd(
+		"qemu-img",
+		"create",
+		"-f", "raw",
+		volPath,
+		fmt.Sprintf("%dM", v.Size))
+	logger.Debugf("create volume image: %s", out)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if volPath != linkPath {
+		if err := os.Symlink(volPath, linkPath); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	return volPath, nil
+}
+
+// removeVolumeDisks removes the images backing the volumes of the named
+// container, following the links to any images created outside of the
+// guest directory.
+func removeVolumeDisks(guestBase, name string) {
+	paths, err := filepath.Glob(filepath.Join(guestBase, fmt.Sprintf("%s-juju-*.img", name)))
+	if err != nil {
+		logger.Errorf("failed to find volume disks for %q: %s", name, err)
+		return
+	}
+	for _, path := range paths {
+		if target, err := os.Readlink(path); err == nil {
+			if err := os.Remove(target); err != nil {
+				logger.Errorf("failed to remove volume disk %q for %q: %s", target, name, err)
+			}
+		}
+		if err := os.Remove(path); err != nil {
+			logger.Errorf("failed to remove volume disk %q for %q: %s", path, name, err)
+		}
+	}
+}
+
 // pool info parses and returns the output of `virsh pool-info <poolname>`.
 func poolInfo(runCmd runFunc) (*libvirtPool, error) {
 	output, err := runCmd("virsh", "pool-info", poolName)
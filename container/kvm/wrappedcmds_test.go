@@ -150,6 +150,89 @@ func (commandWrapperSuite) TestCreateMachineSuccess(c *gc.C) {
 	}
 }
 
+func (commandWrapperSuite) TestCreateMachineWithVolumes(c *gc.C) {
+	stub := NewRunStub("success", nil)
+
+	tmpDir := c.MkDir()
+	err := os.MkdirAll(filepath.Join(tmpDir, "kvm", "guests"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	cloudInitPath := filepath.Join(tmpDir, "cloud-init")
+	err = ioutil.WriteFile(cloudInitPath, []byte("#cloud-init\nEOF\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	hostDir := filepath.Join(tmpDir, "images")
+	pathfinder := func(s string) (string, error) {
+		return tmpDir, nil
+	}
+
+	params := CreateMachineParams{
+		Hostname:     "host00",
+		Series:       "precise",
+		UserDataFile: cloudInitPath,
+		CpuCores:     1,
+		RootDisk:     8,
+		Volumes: []VolumeParams{
+			{Serial: "juju-0", Size: 1024},
+			{Serial: "juju-1", Size: 2048, HostDir: hostDir},
+		},
+	}
+
+	MakeCreateMachineParamsTestable(&params, pathfinder, stub.Run, "amd64")
+	err = CreateMachine(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	guestBase := filepath.Join(tmpDir, "kvm", "guests")
+	c.Check(stub.Calls(), gc.HasLen, 6)
+	c.Check(stub.Calls()[2], gc.Equals, "qemu-img create -f raw "+filepath.Join(guestBase, "host00-juju-0.img")+" 1024M")
+	c.Check(stub.Calls()[3], gc.Equals, "qemu-img create -f raw "+filepath.Join(hostDir, "host00-juju-1.img")+" 2048M")
+
+	// Images created outside the guest directory are linked into it.
+	target, err := os.Readlink(filepath.Join(guestBase, "host00-juju-1.img"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, filepath.Join(hostDir, "host00-juju-1.img"))
+
+	domain, err := ioutil.ReadFile(filepath.Join(tmpDir, "host00.xml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(domain), jc.Contains, "<serial>juju-0</serial>")
+	c.Check(string(domain), jc.Contains, "<serial>juju-1</serial>")
+}
+
+func (commandWrapperSuite) TestDestroyMachineRemovesVolumes(c *gc.C) {
+	tmpDir := c.MkDir()
+	guestBase := filepath.Join(tmpDir, "kvm", "guests")
+	err := os.MkdirAll(guestBase, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	hostDir := filepath.Join(tmpDir, "images")
+	err = os.MkdirAll(hostDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	localVolume := filepath.Join(guestBase, "aname-juju-0.img")
+	err = ioutil.WriteFile(localVolume, []byte("diskcontents"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	hostVolume := filepath.Join(hostDir, "aname-juju-1.img")
+	err = ioutil.WriteFile(hostVolume, []byte("diskcontents"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink(hostVolume, filepath.Join(guestBase, "aname-juju-1.img"))
+	c.Assert(err, jc.ErrorIsNil)
+	otherVolume := filepath.Join(guestBase, "anothername-juju-0.img")
+	err = ioutil.WriteFile(otherVolume, []byte("diskcontents"), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	pathfinder := func(_ string) (string, error) {
+		return tmpDir, nil
+	}
+	stub := NewRunStub("success", nil)
+	container := NewTestContainer("aname", stub.Run, pathfinder)
+	err = DestroyMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, path := range []string{localVolume, hostVolume, filepath.Join(guestBase, "aname-juju-1.img")} {
+		_, err = os.Lstat(path)
+		c.Check(os.IsNotExist(err), jc.IsTrue, gc.Commentf("%s", path))
+	}
+	_, err = os.Stat(otherVolume)
+	c.Check(err, jc.ErrorIsNil)
+}
+
 func (commandWrapperSuite) TestDestroyMachineSuccess(c *gc.C) {
 	tmpDir, err := ioutil.TempDir("", "juju-libvirtSuite-")
 	c.Check(err, jc.ErrorIsNil)
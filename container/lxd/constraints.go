@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxd
+
+import (
+	"fmt"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/tools/lxdclient"
+)
+
+const rootDiskDeviceName = "root"
+
+// constraintsConfig translates the mem, cores and cpu-power
+// constraints into LXD container limits.
+func constraintsConfig(cons constraints.Value) map[string]string {
+	config := make(map[string]string)
+	if cons.HasMem() {
+		config["limits.memory"] = fmt.Sprintf("%dMiB", *cons.Mem)
+	}
+	if cons.HasCpuCores() {
+		config["limits.cpu"] = fmt.Sprint(*cons.CpuCores)
+	}
+	if cons.HasCpuPower() {
+		// A cpu-power of 100 corresponds to one full core, so
+		// allow the container that many milliseconds of CPU
+		// time in every 100ms period.
+		config["limits.cpu.allowance"] = fmt.Sprintf("%dms/100ms", *cons.CpuPower)
+	}
+	return config
+}
+
+// rootDiskDevice returns the root disk device for a container with
+// the given root-disk constraint, based on the root disk device of the
+// profile the container would otherwise use, or nil if there is no
+// root-disk constraint.
+func rootDiskDevice(cons constraints.Value, profileRoot lxdclient.Device) lxdclient.Device {
+	if cons.RootDisk == nil || *cons.RootDisk == 0 {
+		return nil
+	}
+	device := lxdclient.Device{
+		"type": "disk",
+		"path": "/",
+	}
+	for key, value := range profileRoot {
+		device[key] = value
+	}
+	device["size"] = fmt.Sprintf("%dMiB", *cons.RootDisk)
+	return device
+}
+
+// constraintsHardware returns the hardware characteristics described
+// by the constraints that were applied to a container.
+func constraintsHardware(cons constraints.Value, arch string) *instance.HardwareCharacteristics {
+	hc := &instance.HardwareCharacteristics{Arch: &arch}
+	if cons.HasMem() {
+		mem := *cons.Mem
+		hc.Mem = &mem
+	}
+	if cons.HasCpuCores() {
+		cores := *cons.CpuCores
+		hc.CpuCores = &cores
+	}
+	if cons.HasCpuPower() {
+		power := *cons.CpuPower
+		hc.CpuPower = &power
+	}
+	if cons.RootDisk != nil && *cons.RootDisk > 0 {
+		rootDisk := *cons.RootDisk
+		hc.RootDisk = &rootDisk
+	}
+	return hc
+}
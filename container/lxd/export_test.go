@@ -6,6 +6,10 @@
 package lxd
 
 var (
-	NICDevice      = nicDevice
-	NetworkDevices = networkDevices
+	NICDevice            = nicDevice
+	NetworkDevices       = networkDevices
+	ConstraintsConfig    = constraintsConfig
+	RootDiskDevice       = rootDiskDevice
+	ConstraintsHardware  = constraintsHardware
+	ReplaceCharmProfiles = replaceCharmProfiles
 )
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/utils/proxy"
 
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
 )
 
 type containerInitialiser struct {
@@ -19,7 +20,7 @@ type containerInitialiser struct {
 var _ container.Initialiser = (*containerInitialiser)(nil)
 
 // NewContainerInitialiser  - on anything but Linux this is a NOP
-func NewContainerInitialiser(series string) container.Initialiser {
+func NewContainerInitialiser(series string, family network.IPFamily) container.Initialiser {
 	return &containerInitialiser{}
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/lxc/lxd/shared"
 
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/tools/lxdclient"
 )
 
@@ -33,6 +34,7 @@ var requiredPackages = []string{
 
 type containerInitialiser struct {
 	series         string
+	family         network.IPFamily
 	getExecCommand func(string, ...string) *exec.Cmd
 }
 
@@ -40,10 +42,12 @@ type containerInitialiser struct {
 var _ container.Initialiser = (*containerInitialiser)(nil)
 
 // NewContainerInitialiser returns an instance used to perform the steps
-// required to allow a host machine to run a LXC container.
-func NewContainerInitialiser(series string) container.Initialiser {
+// required to allow a host machine to run a LXC container. The IP family
+// decides whether IPv6 is enabled on the default bridge.
+func NewContainerInitialiser(series string, family network.IPFamily) container.Initialiser {
 	return &containerInitialiser{
 		series,
+		family,
 		exec.Command,
 	}
 }
@@ -55,7 +59,7 @@ func (ci *containerInitialiser) Initialise() error {
 		return err
 	}
 
-	err = configureLXDBridge()
+	err = configureLXDBridge(ci.family)
 	if err != nil {
 		return err
 	}
@@ -152,7 +156,7 @@ var df = func(path string) (uint64, error) {
 	return uint64(statfs.Bsize) * statfs.Bfree, nil
 }
 
-var configureLXDBridge = func() error {
+var configureLXDBridge = func(family network.IPFamily) error {
 	client, err := ConnectLocal()
 	if err != nil {
 		return errors.Trace(err)
@@ -164,7 +168,10 @@ var configureLXDBridge = func() error {
 	}
 
 	if shared.StringInSlice("network", status.APIExtensions) {
-		return lxdclient.CreateDefaultBridgeInDefaultProfile(client)
+		return lxdclient.CreateDefaultBridgeInDefaultProfile(client, family)
+	}
+	if family != network.PreferIPv4 {
+		logger.Warningf("LXD has no network API; IPv6 will not be configured on %s for preferred-ip-family %q", lxdBridgeFile, family)
 	}
 
 	f, err := os.OpenFile(lxdBridgeFile, os.O_RDWR, 0777)
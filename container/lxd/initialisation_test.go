@@ -19,6 +19,7 @@ import (
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -79,7 +80,7 @@ func (s *InitialiserSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.calledCmds = []string{}
 	s.PatchValue(&manager.RunCommandWithRetry, getMockRunCommandWithRetry(&s.calledCmds))
-	s.PatchValue(&configureLXDBridge, func() error { return nil })
+	s.PatchValue(&configureLXDBridge, func(network.IPFamily) error { return nil })
 	s.PatchValue(&getLXDConfigSetter, func() (configSetter, error) {
 		return &mockConfigSetter{}, nil
 	})
@@ -95,7 +96,7 @@ func (s *InitialiserSuite) TestLTSSeriesPackages(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	s.PatchValue(&series.MustHostSeries, func() string { return "trusty" })
-	container := NewContainerInitialiser("trusty")
+	container := NewContainerInitialiser("trusty", network.PreferIPv4)
 
 	err = container.Initialise()
 	c.Assert(err, jc.ErrorIsNil)
@@ -112,7 +113,7 @@ func (s *InitialiserSuite) TestNoSeriesPackages(c *gc.C) {
 	paccmder, err := commands.NewPackageCommander("xenial")
 	c.Assert(err, jc.ErrorIsNil)
 
-	container := NewContainerInitialiser("")
+	container := NewContainerInitialiser("", network.PreferIPv4)
 
 	err = container.Initialise()
 	c.Assert(err, jc.ErrorIsNil)
@@ -129,7 +130,7 @@ func (s *InitialiserSuite) TestLXDInit(c *gc.C) {
 	}
 	s.PatchValue(&df, df100)
 
-	container := NewContainerInitialiser("xenial")
+	container := NewContainerInitialiser("xenial", network.PreferIPv4)
 	err := container.Initialise()
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -143,7 +144,7 @@ func (s *InitialiserSuite) TestLXDAlreadyInitialized(c *gc.C) {
 	}
 	s.PatchValue(&df, df100)
 
-	container := NewContainerInitialiser("xenial")
+	container := NewContainerInitialiser("xenial", network.PreferIPv4)
 	cont, ok := container.(*containerInitialiser)
 	if !ok {
 		c.Fatalf("Unexpected type of container initialized: %T", container)
@@ -210,7 +211,7 @@ func (s *InitialiserSuite) TestInitializeSetsProxies(c *gc.C) {
 	s.PatchEnvironment("https_proxy", "http://test.local/https/proxy")
 	s.PatchEnvironment("no_proxy", "test.local,localhost")
 
-	container := NewContainerInitialiser("")
+	container := NewContainerInitialiser("", network.PreferIPv4)
 	err := container.Initialise()
 	c.Assert(err, jc.ErrorIsNil)
 
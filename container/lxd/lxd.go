@@ -7,15 +7,19 @@ package lxd
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/arch"
+	"github.com/lxc/lxd/shared/api"
 
 	"github.com/juju/juju/cloudconfig/containerinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -40,8 +44,12 @@ type containerManager struct {
 	client *lxdclient.Client
 }
 
-// containerManager implements container.Manager.
-var _ container.Manager = (*containerManager)(nil)
+// containerManager implements container.Manager and
+// container.LXDProfileManager.
+var (
+	_ container.Manager           = (*containerManager)(nil)
+	_ container.LXDProfileManager = (*containerManager)(nil)
+)
 
 func ConnectLocal() (*lxdclient.Client, error) {
 	cfg := lxdclient.Config{
@@ -148,6 +156,9 @@ func (manager *containerManager) CreateContainer(
 		// Make sure these come back up on host reboot.
 		"boot.autostart": "true",
 	}
+	for key, value := range constraintsConfig(cons) {
+		metadata[key] = value
+	}
 
 	nics, err := networkDevices(networkConfig)
 	if err != nil {
@@ -164,6 +175,15 @@ func (manager *containerManager) CreateContainer(
 	} else {
 		logger.Infof("instance %q configured with %v network devices", name, nics)
 	}
+	if len(instanceConfig.Profiles) > 0 {
+		// LXD only applies the default profile implicitly when no
+		// profiles are named, so name it before the charm profiles.
+		if len(profiles) == 0 {
+			profiles = append(profiles, lxdDefaultProfileName)
+		}
+		logger.Infof("instance %q configured with charm profiles %v", name, instanceConfig.Profiles)
+		profiles = append(profiles, instanceConfig.Profiles...)
+	}
 
 	// Push the required /etc/network/interfaces file to the container.
 	// By pushing this file (which happens after LXD init, and before LXD
@@ -178,11 +198,24 @@ func (manager *containerManager) CreateContainer(
 		return
 	}
 
+	devices := nics
+	if cons.RootDisk != nil && *cons.RootDisk > 0 {
+		profileRoot, err := manager.defaultProfileRootDevice()
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		devices = make(lxdclient.Devices)
+		for name, device := range nics {
+			devices[name] = device
+		}
+		devices[rootDiskDeviceName] = rootDiskDevice(cons, profileRoot)
+	}
+
 	spec := lxdclient.InstanceSpec{
 		Name:     name,
 		Image:    imageName,
 		Metadata: metadata,
-		Devices:  nics,
+		Devices:  devices,
 		Profiles: profiles,
 		Files: lxdclient.Files{
 			lxdclient.File{
@@ -204,7 +237,18 @@ func (manager *containerManager) CreateContainer(
 
 	callback(status.Running, "Container started", nil)
 	inst = &lxdInstance{name, manager.client}
-	return
+	return inst, constraintsHardware(cons, hostArch), nil
+}
+
+// defaultProfileRootDevice returns the root disk device of the default
+// profile, so that a container-specific root disk device can keep the
+// profile's storage settings.
+func (manager *containerManager) defaultProfileRootDevice() (lxdclient.Device, error) {
+	profile, err := manager.client.ProfileConfig(lxdDefaultProfileName)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting %q profile", lxdDefaultProfileName)
+	}
+	return profile.Devices[rootDiskDeviceName], nil
 }
 
 func (manager *containerManager) DestroyContainer(id instance.Id) error {
@@ -218,6 +262,84 @@ func (manager *containerManager) DestroyContainer(id instance.Id) error {
 	return errors.Trace(manager.client.RemoveInstances(manager.namespace.Prefix(), string(id)))
 }
 
+// EnsureLXDProfile implements container.LXDProfileManager.
+func (manager *containerManager) EnsureLXDProfile(name string, profile *lxdprofile.Profile) error {
+	if err := manager.connect(); err != nil {
+		return errors.Trace(err)
+	}
+	put := api.ProfilePut{
+		Description: profile.Description,
+		Config:      profile.Config,
+		Devices:     profile.Devices,
+	}
+	return errors.Annotatef(manager.client.EnsureProfile(name, put), "writing profile %q", name)
+}
+
+// SetContainerLXDProfiles implements container.LXDProfileManager.
+// Profiles that were not created for charms are left in place.
+func (manager *containerManager) SetContainerLXDProfiles(id instance.Id, names []string) error {
+	if err := manager.connect(); err != nil {
+		return errors.Trace(err)
+	}
+	current, err := manager.client.InstanceProfiles(string(id))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	profiles := replaceCharmProfiles(current, names)
+	if reflect.DeepEqual(profiles, current) {
+		return nil
+	}
+	logger.Infof("instance %q configured with profiles %v", id, profiles)
+	return errors.Trace(manager.client.SetInstanceProfiles(string(id), profiles))
+}
+
+// replaceCharmProfiles returns the given profiles, with any charm
+// profiles replaced by the named ones.
+func replaceCharmProfiles(current, names []string) []string {
+	var profiles []string
+	for _, name := range current {
+		if !strings.HasPrefix(name, lxdprofile.Prefix) {
+			profiles = append(profiles, name)
+		}
+	}
+	if len(profiles) == 0 && len(names) > 0 {
+		profiles = append(profiles, lxdDefaultProfileName)
+	}
+	return append(profiles, names...)
+}
+
+// RemoveUnusedLXDProfiles implements container.LXDProfileManager.
+func (manager *containerManager) RemoveUnusedLXDProfiles() error {
+	if err := manager.connect(); err != nil {
+		return errors.Trace(err)
+	}
+	unused, err := manager.client.UnusedProfiles(lxdprofile.Prefix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range unused {
+		logger.Infof("removing unused profile %q", name)
+		if err := manager.client.ProfileDelete(name); err != nil {
+			return errors.Annotatef(err, "removing profile %q", name)
+		}
+	}
+	return nil
+}
+
+// connect connects to the local LXD, if the manager is not already
+// connected.
+func (manager *containerManager) connect() error {
+	if manager.client != nil {
+		return nil
+	}
+	client, err := ConnectLocal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	manager.client = client
+	return nil
+}
+
 func (manager *containerManager) ListContainers() (result []instance.Instance, err error) {
 	result = []instance.Instance{}
 	if manager.client == nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
 )
 
 var (
@@ -20,7 +21,7 @@ func NewContainerManager(conf container.ManagerConfig) (container.Manager, error
 	return nil, errors.Errorf("LXD containers not supported in go 1.2")
 }
 
-func NewContainerInitialiser(series string) container.Initialiser {
+func NewContainerInitialiser(series string, family network.IPFamily) container.Initialiser {
 	logger.Errorf("No LXD container initializer in go 1.2")
 	/* while it seems slightly impolite to return nil here, the return
 	 * value is never actually used, because it's never deref'd before
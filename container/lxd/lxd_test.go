@@ -201,3 +201,67 @@ func (t *LxdSuite) TestNetworkDevicesWithParentDevice(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, jc.DeepEquals, expected)
 }
+
+func (t *LxdSuite) TestConstraintsConfig(c *gc.C) {
+	cons := constraints.MustParse("mem=2G cores=2 cpu-power=150 root-disk=10G")
+	c.Assert(lxd.ConstraintsConfig(cons), jc.DeepEquals, map[string]string{
+		"limits.memory":        "2048MiB",
+		"limits.cpu":           "2",
+		"limits.cpu.allowance": "150ms/100ms",
+	})
+}
+
+func (t *LxdSuite) TestConstraintsConfigEmpty(c *gc.C) {
+	c.Assert(lxd.ConstraintsConfig(constraints.Value{}), gc.HasLen, 0)
+}
+
+func (t *LxdSuite) TestRootDiskDevice(c *gc.C) {
+	cons := constraints.MustParse("root-disk=10G")
+	device := lxd.RootDiskDevice(cons, lxdclient.Device{
+		"type": "disk",
+		"path": "/",
+		"pool": "default",
+	})
+	c.Assert(device, jc.DeepEquals, lxdclient.Device{
+		"type": "disk",
+		"path": "/",
+		"pool": "default",
+		"size": "10240MiB",
+	})
+}
+
+func (t *LxdSuite) TestRootDiskDeviceNoProfileRoot(c *gc.C) {
+	cons := constraints.MustParse("root-disk=10G")
+	device := lxd.RootDiskDevice(cons, nil)
+	c.Assert(device, jc.DeepEquals, lxdclient.Device{
+		"type": "disk",
+		"path": "/",
+		"size": "10240MiB",
+	})
+}
+
+func (t *LxdSuite) TestRootDiskDeviceNoConstraint(c *gc.C) {
+	c.Assert(lxd.RootDiskDevice(constraints.MustParse("mem=1G"), nil), gc.IsNil)
+}
+
+func (t *LxdSuite) TestConstraintsHardware(c *gc.C) {
+	cons := constraints.MustParse("mem=2G cores=2 cpu-power=150 root-disk=10G")
+	hc := lxd.ConstraintsHardware(cons, "amd64")
+	c.Assert(hc.String(), gc.Equals, "arch=amd64 cores=2 cpu-power=150 mem=2048M root-disk=10240M")
+}
+
+func (t *LxdSuite) TestReplaceCharmProfiles(c *gc.C) {
+	current := []string{"default", "juju-charm-foo-mysql", "custom"}
+	profiles := lxd.ReplaceCharmProfiles(current, []string{"juju-charm-foo-wordpress"})
+	c.Assert(profiles, jc.DeepEquals, []string{"default", "custom", "juju-charm-foo-wordpress"})
+}
+
+func (t *LxdSuite) TestReplaceCharmProfilesRemovesAll(c *gc.C) {
+	current := []string{"default", "juju-charm-foo-mysql"}
+	c.Assert(lxd.ReplaceCharmProfiles(current, nil), jc.DeepEquals, []string{"default"})
+}
+
+func (t *LxdSuite) TestReplaceCharmProfilesAddsDefault(c *gc.C) {
+	profiles := lxd.ReplaceCharmProfiles(nil, []string{"juju-charm-foo-mysql"})
+	c.Assert(profiles, jc.DeepEquals, []string{"default", "juju-charm-foo-mysql"})
+}
@@ -3,6 +3,10 @@
 
 package container
 
+import (
+	"github.com/juju/juju/storage"
+)
+
 // StorageConfig defines how the container will be configured to support
 // storage requirements.
 type StorageConfig struct {
@@ -10,4 +14,10 @@ type StorageConfig struct {
 	// AllowMount is true is the container is required to allow
 	// mounting block devices.
 	AllowMount bool
+
+	// Volumes holds the parameters of volumes that are backed by
+	// the host and attached to the container when it is created.
+	// Only container types that can attach host block devices,
+	// such as KVM, make use of them.
+	Volumes []storage.VolumeParams
 }
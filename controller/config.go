@@ -5,6 +5,9 @@ package controller
 
 import (
 	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -73,6 +76,42 @@ const (
 	// detault
 	MongoMemoryProfile = "mongo-memory-profile"
 
+	// APIUserRateLimitKey sets the number of API requests per second
+	// each user may make to each API server. Zero, the default, means
+	// that user requests are not rate limited.
+	APIUserRateLimitKey = "api-user-rate-limit"
+
+	// APIUserRateLimitBurstKey sets the number of API requests a user
+	// may make in a burst before api-user-rate-limit is enforced. By
+	// default it is the same as api-user-rate-limit.
+	APIUserRateLimitBurstKey = "api-user-rate-limit-burst"
+
+	// APIUserRateLimitOverridesKey overrides api-user-rate-limit and
+	// api-user-rate-limit-burst for particular users. It holds a
+	// space-separated list of user=rate or user=rate:burst entries,
+	// for example "ci-bot=50:100 admin=0".
+	APIUserRateLimitOverridesKey = "api-user-rate-limit-overrides"
+
+	// APIModelRateLimitKey sets the number of API requests per second
+	// that all users together may make to each model through each API
+	// server. Zero, the default, means that model requests are not
+	// rate limited.
+	APIModelRateLimitKey = "api-model-rate-limit"
+
+	// APIModelRateLimitBurstKey sets the number of API requests that
+	// may be made to a model in a burst before api-model-rate-limit is
+	// enforced. By default it is the same as api-model-rate-limit.
+	APIModelRateLimitBurstKey = "api-model-rate-limit-burst"
+
+	// TracingExportFileKey sets the path of a file on each controller
+	// machine to which traces of API requests are appended, one line
+	// of Jaeger JSON per request.
+	TracingExportFileKey = "tracing-export-file"
+
+	// TracingCollectorURLKey sets the URL of a collector endpoint to
+	// which traces of API requests are posted as Jaeger JSON.
+	TracingCollectorURLKey = "tracing-collector-url"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -107,6 +146,13 @@ var ControllerOnlyConfigAttributes = []string{
 	SetNUMAControlPolicyKey,
 	StatePort,
 	MongoMemoryProfile,
+	APIUserRateLimitKey,
+	APIUserRateLimitBurstKey,
+	APIUserRateLimitOverridesKey,
+	APIModelRateLimitKey,
+	APIModelRateLimitBurstKey,
+	TracingExportFileKey,
+	TracingCollectorURLKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -269,6 +315,114 @@ func (c Config) AllowModelAccess() bool {
 	return value
 }
 
+// APIRateLimit holds the token bucket parameters used to limit the
+// rate of API requests.
+type APIRateLimit struct {
+	// Rate is the number of requests allowed per second. Zero means
+	// that requests are not limited.
+	Rate int
+
+	// Burst is the number of requests that may be made at once
+	// before Rate is enforced.
+	Burst int
+}
+
+// Unlimited reports whether the limit allows any number of requests.
+func (l APIRateLimit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// APIUserRateLimit returns the rate limit applied to the API requests
+// of each user who does not have an override.
+func (c Config) APIUserRateLimit() APIRateLimit {
+	return c.apiRateLimit(APIUserRateLimitKey, APIUserRateLimitBurstKey)
+}
+
+// APIModelRateLimit returns the rate limit applied to the API
+// requests made by all users to each model.
+func (c Config) APIModelRateLimit() APIRateLimit {
+	return c.apiRateLimit(APIModelRateLimitKey, APIModelRateLimitBurstKey)
+}
+
+// APIUserRateLimitOverrides returns the rate limits for users whose
+// limits differ from APIUserRateLimit, keyed by user name.
+func (c Config) APIUserRateLimitOverrides() map[string]APIRateLimit {
+	overrides, err := parseAPIRateLimitOverrides(c.asString(APIUserRateLimitOverridesKey))
+	if err != nil {
+		// We check the overrides can be parsed in the
+		// Validate function, so we do not expect this to fail.
+		panic(err)
+	}
+	return overrides
+}
+
+// TracingExportFile returns the path of the file to which API request
+// traces are written, or the empty string if they are not written to
+// a file.
+func (c Config) TracingExportFile() string {
+	return c.asString(TracingExportFileKey)
+}
+
+// TracingCollectorURL returns the URL of the collector to which API
+// request traces are posted, or the empty string if they are not
+// posted to a collector.
+func (c Config) TracingCollectorURL() string {
+	return c.asString(TracingCollectorURLKey)
+}
+
+// TracingEnabled reports whether API requests should be traced.
+func (c Config) TracingEnabled() bool {
+	return c.TracingExportFile() != "" || c.TracingCollectorURL() != ""
+}
+
+func (c Config) apiRateLimit(rateKey, burstKey string) APIRateLimit {
+	limit := APIRateLimit{
+		Rate:  c.asInt(rateKey),
+		Burst: c.asInt(burstKey),
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = limit.Rate
+	}
+	return limit
+}
+
+// asInt returns the named attribute as an integer, returning 0
+// if it isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
+// parseAPIRateLimitOverrides parses a space-separated list of
+// user=rate or user=rate:burst entries.
+func parseAPIRateLimitOverrides(value string) (map[string]APIRateLimit, error) {
+	overrides := make(map[string]APIRateLimit)
+	for _, entry := range strings.Fields(value) {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expected user=rate[:burst], got %q", entry)
+		}
+		rateBurst := strings.SplitN(parts[1], ":", 2)
+		rate, err := strconv.Atoi(rateBurst[0])
+		if err != nil || rate < 0 {
+			return nil, errors.Errorf("invalid rate in %q", entry)
+		}
+		burst := rate
+		if len(rateBurst) == 2 {
+			burst, err = strconv.Atoi(rateBurst[1])
+			if err != nil || burst < 0 {
+				return nil, errors.Errorf("invalid burst in %q", entry)
+			}
+		}
+		overrides[parts[0]] = APIRateLimit{Rate: rate, Burst: burst}
+	}
+	return overrides, nil
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -310,6 +464,33 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, key := range []string{
+		APIUserRateLimitKey,
+		APIUserRateLimitBurstKey,
+		APIModelRateLimitKey,
+		APIModelRateLimitBurstKey,
+	} {
+		if c.asInt(key) < 0 {
+			return errors.Errorf("%s: expected non-negative value, got %d", key, c.asInt(key))
+		}
+	}
+	if _, err := parseAPIRateLimitOverrides(c.asString(APIUserRateLimitOverridesKey)); err != nil {
+		return errors.Annotate(err, APIUserRateLimitOverridesKey)
+	}
+
+	if v := c.TracingExportFile(); v != "" && !filepath.IsAbs(v) {
+		return errors.Errorf("%s: expected absolute path, got %q", TracingExportFileKey, v)
+	}
+	if v := c.TracingCollectorURL(); v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, TracingCollectorURLKey)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s: expected http or https URL, got %q", TracingCollectorURLKey, v)
+		}
+	}
+
 	return nil
 }
 
@@ -320,25 +501,39 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
+	AuditingEnabled:              schema.Bool(),
+	APIPort:                      schema.ForceInt(),
+	StatePort:                    schema.ForceInt(),
+	IdentityURL:                  schema.String(),
+	IdentityPublicKey:            schema.String(),
+	SetNUMAControlPolicyKey:      schema.Bool(),
+	AutocertURLKey:               schema.String(),
+	AutocertDNSNameKey:           schema.String(),
+	AllowModelAccessKey:          schema.Bool(),
+	MongoMemoryProfile:           schema.String(),
+	APIUserRateLimitKey:          schema.ForceInt(),
+	APIUserRateLimitBurstKey:     schema.ForceInt(),
+	APIUserRateLimitOverridesKey: schema.String(),
+	APIModelRateLimitKey:         schema.ForceInt(),
+	APIModelRateLimitBurstKey:    schema.ForceInt(),
+	TracingExportFileKey:         schema.String(),
+	TracingCollectorURLKey:       schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
+	APIPort:                      DefaultAPIPort,
+	AuditingEnabled:              DefaultAuditingEnabled,
+	StatePort:                    DefaultStatePort,
+	IdentityURL:                  schema.Omit,
+	IdentityPublicKey:            schema.Omit,
+	SetNUMAControlPolicyKey:      DefaultNUMAControlPolicy,
+	AutocertURLKey:               schema.Omit,
+	AutocertDNSNameKey:           schema.Omit,
+	AllowModelAccessKey:          schema.Omit,
+	MongoMemoryProfile:           schema.Omit,
+	APIUserRateLimitKey:          schema.Omit,
+	APIUserRateLimitBurstKey:     schema.Omit,
+	APIUserRateLimitOverridesKey: schema.Omit,
+	APIModelRateLimitKey:         schema.Omit,
+	APIModelRateLimitBurstKey:    schema.Omit,
+	TracingExportFileKey:         schema.Omit,
+	TracingCollectorURLKey:       schema.Omit,
 })
@@ -121,6 +121,41 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "negative API rate limit",
+	config: controller.Config{
+		controller.APIUserRateLimitKey: -1,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `api-user-rate-limit: expected non-negative value, got -1`,
+}, {
+	about: "invalid API rate limit override",
+	config: controller.Config{
+		controller.APIUserRateLimitOverridesKey: "bob=fast",
+		controller.CACertKey:                    testing.CACert,
+	},
+	expectError: `api-user-rate-limit-overrides: invalid rate in "bob=fast"`,
+}, {
+	about: "invalid API rate limit override format",
+	config: controller.Config{
+		controller.APIUserRateLimitOverridesKey: "bob",
+		controller.CACertKey:                    testing.CACert,
+	},
+	expectError: `api-user-rate-limit-overrides: expected user=rate\[:burst\], got "bob"`,
+}, {
+	about: "relative tracing export file",
+	config: controller.Config{
+		controller.TracingExportFileKey: "traces.json",
+		controller.CACertKey:            testing.CACert,
+	},
+	expectError: `tracing-export-file: expected absolute path, got "traces.json"`,
+}, {
+	about: "non-HTTP tracing collector URL",
+	config: controller.Config{
+		controller.TracingCollectorURLKey: "udp://10.0.0.1:6831",
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `tracing-collector-url: expected http or https URL, got "udp://10.0.0.1:6831"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -134,3 +169,44 @@ func (s *ConfigSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *ConfigSuite) TestAPIRateLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.APIUserRateLimitKey:          10,
+		controller.APIUserRateLimitOverridesKey: "ci-bot=50:100 admin=0",
+		controller.APIModelRateLimitKey:         100,
+		controller.APIModelRateLimitBurstKey:    200,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRateLimit(), jc.DeepEquals, controller.APIRateLimit{Rate: 10, Burst: 10})
+	c.Assert(cfg.APIModelRateLimit(), jc.DeepEquals, controller.APIRateLimit{Rate: 100, Burst: 200})
+	overrides := cfg.APIUserRateLimitOverrides()
+	c.Assert(overrides, jc.DeepEquals, map[string]controller.APIRateLimit{
+		"ci-bot": {Rate: 50, Burst: 100},
+		"admin":  {Rate: 0, Burst: 0},
+	})
+	c.Assert(overrides["admin"].Unlimited(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAPIRateLimitsDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIUserRateLimit().Unlimited(), jc.IsTrue)
+	c.Assert(cfg.APIModelRateLimit().Unlimited(), jc.IsTrue)
+	c.Assert(cfg.APIUserRateLimitOverrides(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestTracing(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingEnabled(), jc.IsFalse)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.TracingExportFileKey:   "/var/log/juju/traces.json",
+		controller.TracingCollectorURLKey: "http://10.0.0.1:14268/api/traces",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.TracingEnabled(), jc.IsTrue)
+	c.Assert(cfg.TracingExportFile(), gc.Equals, "/var/log/juju/traces.json")
+	c.Assert(cfg.TracingCollectorURL(), gc.Equals, "http://10.0.0.1:14268/api/traces")
+}
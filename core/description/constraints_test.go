@@ -34,6 +34,7 @@ func (s *ConstraintsSerializationSuite) allArgs() ConstraintsArgs {
 		RootDisk:     200 * gig,
 		Spaces:       []string{"my", "own"},
 		Tags:         []string{"much", "strong"},
+		Zones:        []string{"az1", "az2"},
 	}
 }
 
@@ -72,6 +73,13 @@ func (s *ConstraintsSerializationSuite) TestNewConstraintsWithVirt(c *gc.C) {
 	c.Assert(instance.VirtType(), gc.Equals, args.VirtType)
 }
 
+func (s *ConstraintsSerializationSuite) TestNewConstraintsZones(c *gc.C) {
+	args := s.allArgs()
+	instance := newConstraints(args)
+	args.Zones[0] = "weird"
+	c.Assert(instance.Zones(), jc.DeepEquals, []string{"az1", "az2"})
+}
+
 func (s *ConstraintsSerializationSuite) TestNewConstraintsEmpty(c *gc.C) {
 	instance := newConstraints(ConstraintsArgs{})
 	c.Assert(instance, gc.IsNil)
@@ -160,6 +160,7 @@ type Constraints interface {
 	Tags() []string
 
 	VirtType() string
+	Zones() []string
 }
 
 // Status represents an agent, application, or workload status.
@@ -0,0 +1,182 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile defines the LXD profiles that charms may supply
+// in an lxd-profile.yaml file, to be applied to the LXD containers
+// their units are deployed into.
+package lxdprofile
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+// FileName is the name of the file, in the root of a charm, that
+// holds the charm's LXD profile.
+const FileName = "lxd-profile.yaml"
+
+// Prefix is the prefix of the names of all LXD profiles created by
+// Juju for charms. It must not clash with the names of the profiles
+// the LXD provider creates for models.
+const Prefix = "juju-charm-"
+
+// allowedConfigKeys holds the LXD container config keys a charm
+// profile may set.
+var allowedConfigKeys = set(
+	"linux.kernel_modules",
+	"security.nesting",
+	"security.privileged",
+)
+
+// allowedConfigPrefixes holds the prefixes of LXD container config
+// keys a charm profile may set.
+var allowedConfigPrefixes = []string{
+	"environment.",
+	"linux.sysctl.",
+}
+
+// allowedDeviceTypes holds the LXD device types a charm profile may
+// add to a container. Network and disk devices are managed by Juju.
+var allowedDeviceTypes = set(
+	"gpu",
+	"unix-block",
+	"unix-char",
+	"usb",
+)
+
+// Profile holds the LXD profile supplied by a charm.
+type Profile struct {
+	Description string                       `yaml:"description,omitempty"`
+	Config      map[string]string            `yaml:"config,omitempty"`
+	Devices     map[string]map[string]string `yaml:"devices,omitempty"`
+}
+
+// Empty returns true if the profile neither sets any config nor adds
+// any devices.
+func (p *Profile) Empty() bool {
+	return p == nil || (len(p.Config) == 0 && len(p.Devices) == 0)
+}
+
+// Validate returns an error if the profile sets config keys or adds
+// device types that are not on the allow-list.
+func (p *Profile) Validate() error {
+	for _, key := range sortedKeys(p.Config) {
+		if !configKeyAllowed(key) {
+			return errors.NotValidf("lxd profile config key %q", key)
+		}
+	}
+	for name, device := range p.Devices {
+		deviceType := device["type"]
+		if deviceType == "" {
+			return errors.NotValidf("lxd profile device %q without type", name)
+		}
+		if !allowedDeviceTypes[deviceType] {
+			return errors.NotValidf("lxd profile device %q of type %q", name, deviceType)
+		}
+	}
+	return nil
+}
+
+func configKeyAllowed(key string) bool {
+	if allowedConfigKeys[key] {
+		return true
+	}
+	for _, prefix := range allowedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Read reads and validates a profile in the lxd-profile.yaml format.
+func Read(r io.Reader) (*Profile, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var profile Profile
+	if err := yaml.Unmarshal(data, &profile); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", FileName)
+	}
+	if err := profile.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &profile, nil
+}
+
+// ReadCharm reads and validates the profile supplied by the given
+// charm. It returns nil if the charm does not supply a profile, or if
+// the charm's files are not available.
+func ReadCharm(ch charm.Charm) (*Profile, error) {
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		f, err := os.Open(filepath.Join(ch.Path, FileName))
+		if os.IsNotExist(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer f.Close()
+		return Read(f)
+	case *charm.CharmArchive:
+		if ch.Path == "" {
+			return nil, nil
+		}
+		return readArchive(ch.Path)
+	}
+	return nil, nil
+}
+
+func readArchive(path string) (*Profile, error) {
+	zipr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if f.Name != FileName {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer r.Close()
+		return Read(r)
+	}
+	return nil, nil
+}
+
+// Name returns the name of the LXD profile created for the given
+// application in the given model.
+func Name(modelName, applicationName string) string {
+	return fmt.Sprintf("%s%s-%s", Prefix, modelName, applicationName)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func set(values ...string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type profileSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&profileSuite{})
+
+func (s *profileSuite) TestRead(c *gc.C) {
+	profile, err := lxdprofile.Read(strings.NewReader(`
+description: test
+config:
+  security.nesting: "true"
+  environment.http_proxy: ""
+devices:
+  tun:
+    type: unix-char
+    path: /dev/net/tun
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, jc.DeepEquals, &lxdprofile.Profile{
+		Description: "test",
+		Config: map[string]string{
+			"security.nesting":       "true",
+			"environment.http_proxy": "",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+		},
+	})
+	c.Assert(profile.Empty(), jc.IsFalse)
+}
+
+func (s *profileSuite) TestReadInvalidYAML(c *gc.C) {
+	_, err := lxdprofile.Read(strings.NewReader("config: [}"))
+	c.Assert(err, gc.ErrorMatches, "parsing lxd-profile.yaml: .*")
+}
+
+func (s *profileSuite) TestValidateConfigKeyNotAllowed(c *gc.C) {
+	for _, key := range []string{"boot.autostart", "limits.memory", "raw.lxc", "environment."} {
+		profile := &lxdprofile.Profile{Config: map[string]string{key: "x"}}
+		c.Check(profile.Validate(), gc.ErrorMatches, `lxd profile config key "`+key+`" not valid`)
+	}
+}
+
+func (s *profileSuite) TestValidateDeviceTypeNotAllowed(c *gc.C) {
+	profile := &lxdprofile.Profile{Devices: map[string]map[string]string{
+		"eth1": {"type": "nic", "nictype": "bridged"},
+	}}
+	c.Assert(profile.Validate(), gc.ErrorMatches, `lxd profile device "eth1" of type "nic" not valid`)
+}
+
+func (s *profileSuite) TestValidateDeviceWithoutType(c *gc.C) {
+	profile := &lxdprofile.Profile{Devices: map[string]map[string]string{
+		"tun": {"path": "/dev/net/tun"},
+	}}
+	c.Assert(profile.Validate(), gc.ErrorMatches, `lxd profile device "tun" without type not valid`)
+}
+
+func (s *profileSuite) TestEmpty(c *gc.C) {
+	var profile *lxdprofile.Profile
+	c.Assert(profile.Empty(), jc.IsTrue)
+	c.Assert((&lxdprofile.Profile{Description: "nothing"}).Empty(), jc.IsTrue)
+}
+
+func (s *profileSuite) TestReadCharmDir(c *gc.C) {
+	ch := testcharms.Repo.CharmDir("lxd-profile")
+	profile, err := lxdprofile.ReadCharm(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Config["security.nesting"], gc.Equals, "true")
+	c.Assert(profile.Devices["tun"]["type"], gc.Equals, "unix-char")
+}
+
+func (s *profileSuite) TestReadCharmArchive(c *gc.C) {
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "lxd-profile")
+	profile, err := lxdprofile.ReadCharm(ch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile.Config["linux.kernel_modules"], gc.Equals, "openvswitch,nbd,ip_tables")
+}
+
+func (s *profileSuite) TestReadCharmWithoutProfile(c *gc.C) {
+	profile, err := lxdprofile.ReadCharm(testcharms.Repo.CharmDir("dummy"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profile, gc.IsNil)
+}
+
+func (s *profileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "mysql"), gc.Equals, "juju-charm-default-mysql")
+}
@@ -6,6 +6,7 @@ package environs
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/instance"
@@ -73,6 +74,11 @@ type StartInstanceParams struct {
 	// that may be used to start this instance.
 	ImageMetadata []*imagemetadata.ImageMetadata
 
+	// CharmLXDProfiles holds the LXD profiles supplied by the charms of
+	// the units to be deployed to the instance, keyed by profile name.
+	// It is only populated when provisioning an LXD container.
+	CharmLXDProfiles map[string]*lxdprofile.Profile
+
 	// CleanupCallback is a callback to be used to clean up any residual
 	// status-reporting output from StatusCallback.
 	CleanupCallback func(info string) error
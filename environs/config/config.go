@@ -5,6 +5,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"strings"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/network"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -43,6 +45,16 @@ const (
 	FwNone = "none"
 )
 
+const (
+	// ZonePlacementSpread requests that new machines are started in
+	// the least populated of the allowed availability zones.
+	ZonePlacementSpread = "spread"
+
+	// ZonePlacementPack requests that new machines are started in
+	// the most populated of the allowed availability zones.
+	ZonePlacementPack = "pack"
+)
+
 // TODO(katco-): Please grow this over time.
 // Centralized place to store values of config keys. This transitions
 // mistakes in referencing key-values to a compile-time error.
@@ -96,6 +108,19 @@ const (
 	// NoProxyKey stores the key for this setting.
 	NoProxyKey = "no-proxy"
 
+	// JujuHTTPProxyKey stores the key for the HTTP proxy used only
+	// by Juju agents, and not passed to workloads.
+	JujuHTTPProxyKey = "juju-http-proxy"
+
+	// JujuHTTPSProxyKey stores the key for the HTTPS proxy used only
+	// by Juju agents, and not passed to workloads.
+	JujuHTTPSProxyKey = "juju-https-proxy"
+
+	// JujuNoProxyKey stores the key for the addresses that Juju
+	// agents do not reach through the juju-http-proxy and
+	// juju-https-proxy. Entries may be CIDRs.
+	JujuNoProxyKey = "juju-no-proxy"
+
 	// NetBondReconfigureDelay is the key to pass when bridging
 	// the network for containers.
 	NetBondReconfigureDelayKey = "net-bond-reconfigure-delay"
@@ -107,6 +132,11 @@ const (
 	// of k=v pairs, defining the tags for ResourceTags.
 	ResourceTagsKey = "resource-tags"
 
+	// CloudInitUserDataKey is the key to specify cloud-init yaml the user
+	// wants to add into the cloud-config data produced by Juju when
+	// provisioning machines.
+	CloudInitUserDataKey = "cloudinit-userdata"
+
 	// LogForwardEnabled determines whether the log forward functionality is enabled.
 	LogForwardEnabled = "logforward-enabled"
 
@@ -137,6 +167,15 @@ const (
 	// is stored against the model.
 	ExtraInfoKey = "extra-info"
 
+	// ZonePlacementKey is the key for the policy used to choose
+	// between the availability zones a new machine may be started in.
+	ZonePlacementKey = "zone-placement"
+
+	// PreferredIPFamilyKey is the key for the IP address family
+	// preferred when selecting addresses for machines, units and
+	// controllers in the model.
+	PreferredIPFamilyKey = "preferred-ip-family"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -478,6 +517,19 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if v, ok := cfg.defined[JujuNoProxyKey].(string); ok && v != "" {
+		if err := validateNoProxy(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", JujuNoProxyKey)
+		}
+	}
+
+	// If the cloud-init userdata is set, make sure it is valid.
+	if v, ok := cfg.defined[CloudInitUserDataKey].(string); ok && v != "" {
+		if _, err := parseCloudInitUserData(v); err != nil {
+			return errors.Annotate(err, "invalid cloudinit-userdata")
+		}
+	}
+
 	// Check the immutable config values.  These can't change
 	if old != nil {
 		for _, attr := range immutableAttributes {
@@ -583,6 +635,41 @@ func (c *Config) ProxySSH() bool {
 	return value
 }
 
+// CloudInitUserData returns the cloud-init config supplied by the
+// user to be merged into the cloud-config Juju generates when
+// provisioning machines, or nil if none is set.
+func (c *Config) CloudInitUserData() map[string]interface{} {
+	value, _ := c.defined[CloudInitUserDataKey].(string)
+	if value == "" {
+		return nil
+	}
+	userData, err := parseCloudInitUserData(value)
+	if err != nil {
+		logger.Errorf("invalid %s: %v", CloudInitUserDataKey, err)
+		return nil
+	}
+	return userData
+}
+
+// ZonePlacement returns the policy used to choose between the
+// availability zones a new machine may be started in; either
+// ZonePlacementSpread (the default) or ZonePlacementPack.
+func (c *Config) ZonePlacement() string {
+	if value, ok := c.defined[ZonePlacementKey].(string); ok && value != "" {
+		return value
+	}
+	return ZonePlacementSpread
+}
+
+// PreferredIPFamily returns the IP address family preferred when
+// selecting addresses in the model; network.PreferIPv4 by default.
+func (c *Config) PreferredIPFamily() network.IPFamily {
+	if value, ok := c.defined[PreferredIPFamilyKey].(string); ok && value != "" {
+		return network.IPFamily(value)
+	}
+	return network.PreferIPv4
+}
+
 // NetBondReconfigureDelay returns the duration in seconds that should be
 // passed to the bridge script when bridging bonded interfaces.
 func (c *Config) NetBondReconfigureDelay() int {
@@ -601,6 +688,31 @@ func (c *Config) ProxySettings() proxy.Settings {
 	}
 }
 
+// JujuProxySettings returns the proxy settings used only by Juju
+// agents, which are not passed to workloads.
+func (c *Config) JujuProxySettings() proxy.Settings {
+	return proxy.Settings{
+		Http:    c.asString(JujuHTTPProxyKey),
+		Https:   c.asString(JujuHTTPSProxyKey),
+		NoProxy: c.asString(JujuNoProxyKey),
+	}
+}
+
+// validateNoProxy checks that the entries of the comma-separated
+// no-proxy value that look like CIDRs are valid.
+func validateNoProxy(noProxy string) error {
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			return errors.Errorf("%q is not a valid CIDR", entry)
+		}
+	}
+	return nil
+}
+
 // HTTPProxy returns the http proxy for the environment.
 func (c *Config) HTTPProxy() string {
 	return c.asString(HTTPProxyKey)
@@ -997,6 +1109,12 @@ var alwaysOptional = schema.Defaults{
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
 	NetBondReconfigureDelayKey:   schema.Omit,
+	CloudInitUserDataKey:         schema.Omit,
+	JujuHTTPProxyKey:             schema.Omit,
+	JujuHTTPSProxyKey:            schema.Omit,
+	JujuNoProxyKey:               schema.Omit,
+	ZonePlacementKey:             schema.Omit,
+	PreferredIPFamilyKey:         schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1362,4 +1480,51 @@ data of the store. (default false)`,
 		Type:        environschema.Tint,
 		Group:       environschema.EnvironGroup,
 	},
+	JujuHTTPProxyKey: {
+		Description: "The HTTP proxy used by Juju agents only; unlike http-proxy, it is not passed to workloads. Agents use http-proxy if it is not set",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuHTTPSProxyKey: {
+		Description: "The HTTPS proxy used by Juju agents only; unlike https-proxy, it is not passed to workloads. Agents use https-proxy if it is not set",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	JujuNoProxyKey: {
+		Description: "List of domain addresses and CIDRs that Juju agents do not reach through a proxy (comma-separated). Agents use no-proxy if it is not set",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CloudInitUserDataKey: {
+		Description: `Cloud-init config, in YAML, to merge into the cloud-config Juju generates when provisioning machines. The preruncmd and postruncmd lists are run before and after Juju's own commands; packages and bootcmd are added to Juju's; other keys are added unless Juju sets them itself.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ZonePlacementKey: {
+		Description: `How to choose between the availability zones a new machine may be started in.
+
+'spread' starts the machine in the least populated zone, to spread
+instances of an application across zones.
+
+'pack' starts the machine in the most populated zone, to keep
+instances of an application together.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{ZonePlacementSpread, ZonePlacementPack},
+		Group:  environschema.EnvironGroup,
+	},
+	PreferredIPFamilyKey: {
+		Description: `The IP address family preferred when selecting addresses.
+
+'ipv4' prefers IPv4 addresses, falling back to IPv6 ones.
+
+'ipv6' prefers IPv6 addresses, falling back to IPv4 ones, and enables
+IPv6 on container bridges.
+
+'dual' prefers IPv4 where a single address is needed, but publishes an
+address of each family in ingress-addresses and enables IPv6 on
+container bridges.`,
+		Type:   environschema.Tstring,
+		Values: []interface{}{string(network.PreferIPv4), string(network.PreferIPv6), string(network.DualStack)},
+		Group:  environschema.EnvironGroup,
+	},
 }
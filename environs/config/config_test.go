@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -526,6 +527,38 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			config.NetBondReconfigureDelayKey: 1234,
 		}),
+	}, {
+		about:       "zone-placement pack",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.ZonePlacementKey: "pack",
+		}),
+	}, {
+		about:       "invalid zone-placement",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.ZonePlacementKey: "scatter",
+		}),
+		err: `zone-placement: expected one of \[spread pack\], got "scatter"`,
+	}, {
+		about:       "preferred-ip-family ipv6",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.PreferredIPFamilyKey: "ipv6",
+		}),
+	}, {
+		about:       "preferred-ip-family dual",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.PreferredIPFamilyKey: "dual",
+		}),
+	}, {
+		about:       "invalid preferred-ip-family",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.PreferredIPFamilyKey: "ipv5",
+		}),
+		err: `preferred-ip-family: expected one of \[ipv4 ipv6 dual\], got "ipv5"`,
 	}, {
 		about:       "transmit-vendor-metrics asserted with default value",
 		useDefaults: config.UseDefaults,
@@ -538,6 +571,47 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"transmit-vendor-metrics": false,
 		}),
+	}, {
+		about:       "Valid cloudinit-userdata",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.CloudInitUserDataKey: validCloudInitUserData,
+		}),
+	}, {
+		about:       "Invalid juju-no-proxy CIDR",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.JujuNoProxyKey: "localhost,10.0.0.0/33",
+		}),
+		err: `invalid juju-no-proxy: "10.0.0.0/33" is not a valid CIDR`,
+	}, {
+		about:       "Invalid cloudinit-userdata YAML",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.CloudInitUserDataKey: "packages: [",
+		}),
+		err: `invalid cloudinit-userdata: cannot parse YAML: .*`,
+	}, {
+		about:       "cloudinit-userdata not a map",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.CloudInitUserDataKey: "- foo",
+		}),
+		err: `invalid cloudinit-userdata: cannot parse YAML: .*`,
+	}, {
+		about:       "cloudinit-userdata with reserved key",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.CloudInitUserDataKey: "runcmd: [ls]",
+		}),
+		err: `invalid cloudinit-userdata: key "runcmd" not allowed: use preruncmd or postruncmd instead`,
+	}, {
+		about:       "cloudinit-userdata with invalid list",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			config.CloudInitUserDataKey: "packages: python",
+		}),
+		err: `invalid cloudinit-userdata: key "packages": expected list of strings, got string`,
 	}, {
 		about:       "Valid syslog config values",
 		useDefaults: config.UseDefaults,
@@ -730,6 +804,18 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	if val, ok := test.attrs[config.NetBondReconfigureDelayKey].(int); ok {
 		c.Assert(cfg.NetBondReconfigureDelay(), gc.Equals, val)
 	}
+
+	if val, ok := test.attrs[config.ZonePlacementKey].(string); ok {
+		c.Assert(cfg.ZonePlacement(), gc.Equals, val)
+	} else {
+		c.Assert(cfg.ZonePlacement(), gc.Equals, config.ZonePlacementSpread)
+	}
+
+	if val, ok := test.attrs[config.PreferredIPFamilyKey].(string); ok {
+		c.Assert(cfg.PreferredIPFamily(), gc.Equals, network.IPFamily(val))
+	} else {
+		c.Assert(cfg.PreferredIPFamily(), gc.Equals, network.PreferIPv4)
+	}
 }
 
 func (test configTest) assertDuration(c *gc.C, name string, actual time.Duration, defaultInSeconds int) {
@@ -982,6 +1068,37 @@ func (s *ConfigSuite) TestLoggingConfigFromEnvironment(c *gc.C) {
 	c.Assert(config.LoggingConfig(), gc.Equals, "<root>=INFO;unit=DEBUG")
 }
 
+var validCloudInitUserData = `
+packages:
+  - python-keystoneclient
+  - python-glanceclient
+preruncmd:
+  - mkdir /tmp/preruncmd
+postruncmd:
+  - mkdir /tmp/postruncmd
+write_files:
+  - path: /etc/motd
+    content: hello
+`[1:]
+
+func (s *ConfigSuite) TestCloudInitUserData(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		config.CloudInitUserDataKey: validCloudInitUserData})
+	c.Assert(config.CloudInitUserData(), jc.DeepEquals, map[string]interface{}{
+		"packages":   []string{"python-keystoneclient", "python-glanceclient"},
+		"preruncmd":  []string{"mkdir /tmp/preruncmd"},
+		"postruncmd": []string{"mkdir /tmp/postruncmd"},
+		"write_files": []interface{}{
+			map[string]interface{}{"path": "/etc/motd", "content": "hello"},
+		},
+	})
+}
+
+func (s *ConfigSuite) TestCloudInitUserDataNotSet(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.CloudInitUserData(), gc.IsNil)
+}
+
 func (s *ConfigSuite) TestAutoHookRetryDefault(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
@@ -1053,6 +1170,22 @@ func (s *ConfigSuite) TestProxyValues(c *gc.C) {
 	c.Assert(config.AptFTPProxy(), gc.Equals, "ftp://user@10.0.0.2")
 }
 
+func (s *ConfigSuite) TestJujuProxyValues(c *gc.C) {
+	s.addJujuFiles(c)
+	config := newTestConfig(c, testing.Attrs{
+		"http-proxy":       "http://user@10.0.0.1",
+		"juju-http-proxy":  "http://juju@10.0.0.2",
+		"juju-https-proxy": "https://juju@10.0.0.2",
+		"juju-no-proxy":    "localhost,10.0.0.0/8",
+	})
+	c.Assert(config.ProxySettings().Http, gc.Equals, "http://user@10.0.0.1")
+	c.Assert(config.JujuProxySettings(), jc.DeepEquals, proxy.Settings{
+		Http:    "http://juju@10.0.0.2",
+		Https:   "https://juju@10.0.0.2",
+		NoProxy: "localhost,10.0.0.0/8",
+	})
+}
+
 func (s *ConfigSuite) TestProxyValuesNotSet(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
@@ -0,0 +1,121 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+// cloudInitUserDataStringLists holds the cloudinit-userdata keys whose
+// values must be lists of strings. These are merged with the values
+// Juju generates, rather than replacing them.
+var cloudInitUserDataStringLists = []string{
+	"preruncmd",
+	"postruncmd",
+	"packages",
+	"bootcmd",
+}
+
+// cloudInitUserDataReservedKeys holds the cloudinit-userdata keys that
+// would conflict with the configuration Juju generates, along with the
+// reason they are rejected.
+var cloudInitUserDataReservedKeys = map[string]string{
+	"runcmd":              "use preruncmd or postruncmd instead",
+	"output":              "Juju manages cloud-init output logging",
+	"package_update":      "use the enable-os-refresh-update model config instead",
+	"package_upgrade":     "use the enable-os-upgrade model config instead",
+	"ssh_authorized_keys": "use the authorized-keys model config instead",
+}
+
+// parseCloudInitUserData parses and validates the YAML value of the
+// cloudinit-userdata model config. Nested maps in the result are
+// converted to have string keys.
+func parseCloudInitUserData(value string) (map[string]interface{}, error) {
+	var raw map[string]interface{}
+	if err := goyaml.Unmarshal([]byte(value), &raw); err != nil {
+		return nil, errors.Annotate(err, "cannot parse YAML")
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("expected a YAML map")
+	}
+	for key, reason := range cloudInitUserDataReservedKeys {
+		if _, ok := raw[key]; ok {
+			return nil, errors.Errorf("key %q not allowed: %s", key, reason)
+		}
+	}
+	userData := conformYAML(raw).(map[string]interface{})
+	for _, key := range cloudInitUserDataStringLists {
+		v, ok := userData[key]
+		if !ok {
+			continue
+		}
+		list, err := stringList(v)
+		if err != nil {
+			return nil, errors.Annotatef(err, "key %q", key)
+		}
+		userData[key] = list
+	}
+	if users, ok := userData["users"]; ok {
+		list, ok := users.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("key %q: expected list, got %T", "users", users)
+		}
+		for i, user := range list {
+			if _, ok := user.(map[string]interface{}); !ok {
+				return nil, errors.Errorf("key %q: expected map for entry %d, got %T", "users", i, user)
+			}
+		}
+	}
+	return userData, nil
+}
+
+// stringList returns the value as a list of strings, or an error if it
+// is not a list of strings.
+func stringList(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		result := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.Errorf("expected string for entry %d, got %T", i, item)
+			}
+			result[i] = s
+		}
+		return result, nil
+	}
+	return nil, errors.Errorf("expected list of strings, got %T", v)
+}
+
+// conformYAML ensures that all keys of any nested maps are strings,
+// so that the value can be serialized as JSON and BSON.
+func conformYAML(input interface{}) interface{} {
+	switch typedInput := input.(type) {
+	case map[interface{}]interface{}:
+		newMap := make(map[string]interface{})
+		for key, value := range typedInput {
+			newMap[fmt.Sprint(key)] = conformYAML(value)
+		}
+		return newMap
+	case map[string]interface{}:
+		newMap := make(map[string]interface{})
+		for key, value := range typedInput {
+			newMap[key] = conformYAML(value)
+		}
+		return newMap
+	case []interface{}:
+		newSlice := make([]interface{}, len(typedInput))
+		for i, elem := range typedInput {
+			newSlice[i] = conformYAML(elem)
+		}
+		return newSlice
+	default:
+		return input
+	}
+}
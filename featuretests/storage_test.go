@@ -240,6 +240,8 @@ environscoped:
   provider: environscoped
 environscoped-block:
   provider: environscoped-block
+hostloop:
+  provider: hostloop
 loop:
   provider: loop
 machinescoped:
@@ -262,6 +264,7 @@ Name                 Provider             Attrs
 block                loop                 it=works
 environscoped        environscoped        
 environscoped-block  environscoped-block  
+hostloop             hostloop             
 loop                 loop                 
 machinescoped        machinescoped        
 rootfs               rootfs               
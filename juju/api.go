@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/jujuclient"
@@ -52,6 +53,11 @@ type NewAPIConnectionParams struct {
 	// are zero, the login will be as an external user.
 	AccountDetails *jujuclient.AccountDetails
 
+	// Macaroons holds macaroons to present when logging in, such as
+	// those of an API token. If set, the local account details are
+	// not updated after logging in.
+	Macaroons []macaroon.Slice
+
 	// ModelUUID is an optional model UUID. If specified, the API connection
 	// will be scoped to the model with that UUID; otherwise it will be
 	// scoped to the controller.
@@ -124,7 +130,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (api.Connection, error) {
 	// Process the account details obtained from login.
 	var accountDetails *jujuclient.AccountDetails
 	user, ok := st.AuthTag().(names.UserTag)
-	if !apiInfo.SkipLogin {
+	if !apiInfo.SkipLogin && len(args.Macaroons) == 0 {
 		if ok {
 			if accountDetails, err = args.Store.AccountDetails(args.ControllerName); err != nil {
 				if !errors.IsNotFound(err) {
@@ -187,6 +193,7 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		// authenticate using macaroons.
 		apiInfo.Password = account.Password
 	}
+	apiInfo.Macaroons = args.Macaroons
 	return apiInfo, controller, nil
 }
 
@@ -14,6 +14,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/cloud"
@@ -187,6 +188,37 @@ func (s *NewAPIClientSuite) TestUpdatesLastKnownAccess(c *gc.C) {
 	)
 }
 
+func (s *NewAPIClientSuite) TestWithMacaroons(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+	m, err := macaroon.New([]byte("root-key"), "id", "juju")
+	c.Assert(err, jc.ErrorIsNil)
+	macaroons := []macaroon.Slice{{m}}
+
+	expectState := mockedAPIState(mockedHostPort | mockedModelTag)
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.Tag, gc.Equals, names.NewUserTag("fred"))
+		c.Check(apiInfo.Password, gc.Equals, "")
+		c.Check(apiInfo.Macaroons, jc.DeepEquals, macaroons)
+		return expectState, nil
+	}
+
+	stubStore := jujuclienttesting.WrapClientStore(store)
+	st, err := juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          stubStore,
+		ControllerName: "noconfig",
+		AccountDetails: &jujuclient.AccountDetails{User: "fred"},
+		Macaroons:      macaroons,
+		ModelUUID:      fakeUUID,
+		DialOpts:       api.DefaultDialOpts(),
+		OpenAPI:        apiOpen,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, gc.Equals, expectState)
+	// The local account is not updated when logging in with macaroons
+	// supplied by the caller.
+	stubStore.CheckCallNames(c, "ControllerByName", "UpdateController")
+}
+
 func (s *NewAPIClientSuite) TestWithInfoNoAddresses(c *gc.C) {
 	store := newClientStore(c, "noconfig")
 	err := store.UpdateController("noconfig", jujuclient.ControllerDetails{
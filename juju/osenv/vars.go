@@ -22,6 +22,11 @@ const (
 	JujuLoggingConfigEnvKey = "JUJU_LOGGING_CONFIG"
 	JujuFeatureFlagEnvKey   = "JUJU_DEV_FEATURE_FLAGS"
 
+	// JujuAPITokenEnvKey is the env var which, if set, holds an API
+	// token minted by "juju add-token" that is used to log in instead
+	// of the local account details.
+	JujuAPITokenEnvKey = "JUJU_API_TOKEN"
+
 	// JujuStartupLoggingConfigEnvKey if set is used to configure the initial
 	// logging before the command objects are even created to allow debugging
 	// of the command creation and initialisation process.
@@ -0,0 +1,54 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+)
+
+// APIToken contains the details printed by "juju add-token", that
+// are used to log in to a model without a local account.
+type APIToken struct {
+	// User is the name of the local user on whose behalf the
+	// token acts.
+	User string `json:"user"`
+
+	// Macaroons holds the macaroons that are presented to the
+	// controller when logging in.
+	Macaroons []macaroon.Slice `json:"macaroons"`
+}
+
+// EncodeAPIToken encodes the API token as a string that may be
+// passed around, for example in an environment variable.
+func EncodeAPIToken(token APIToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot encode API token")
+	}
+	return base64.URLEncoding.EncodeToString(data), nil
+}
+
+// DecodeAPIToken decodes an API token encoded with EncodeAPIToken.
+func DecodeAPIToken(s string) (*APIToken, error) {
+	data, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot decode API token")
+	}
+	var token APIToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, errors.Annotate(err, "cannot decode API token")
+	}
+	if !names.IsValidUserName(token.User) {
+		return nil, errors.NotValidf("API token user %q", token.User)
+	}
+	if len(token.Macaroons) == 0 {
+		return nil, errors.NotValidf("API token with no macaroons")
+	}
+	return &token, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"encoding/base64"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type APITokenSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&APITokenSuite{})
+
+func (s *APITokenSuite) TestEncodeDecode(c *gc.C) {
+	m, err := macaroon.New([]byte("root-key"), "id", "juju")
+	c.Assert(err, jc.ErrorIsNil)
+	encoded, err := jujuclient.EncodeAPIToken(jujuclient.APIToken{
+		User:      "fred",
+		Macaroons: []macaroon.Slice{{m}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	token, err := jujuclient.DecodeAPIToken(encoded)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.User, gc.Equals, "fred")
+	c.Assert(token.Macaroons, gc.HasLen, 1)
+	c.Assert(token.Macaroons[0][0].Id(), gc.Equals, "id")
+}
+
+func (s *APITokenSuite) TestDecodeInvalid(c *gc.C) {
+	_, err := jujuclient.DecodeAPIToken("!!!")
+	c.Assert(err, gc.ErrorMatches, "cannot decode API token: .*")
+
+	encoded := base64.URLEncoding.EncodeToString([]byte(`{"user":"fred"}`))
+	_, err = jujuclient.DecodeAPIToken(encoded)
+	c.Assert(err, gc.ErrorMatches, "API token with no macaroons not valid")
+
+	encoded = base64.URLEncoding.EncodeToString([]byte(`{"user":"not/valid"}`))
+	_, err = jujuclient.DecodeAPIToken(encoded)
+	c.Assert(err, gc.ErrorMatches, `API token user "not/valid" not valid`)
+}
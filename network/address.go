@@ -51,6 +51,42 @@ const (
 	IPv6Address AddressType = "ipv6"
 )
 
+// IPFamily determines which address family is preferred when selecting
+// and ordering addresses.
+type IPFamily string
+
+const (
+	// PreferIPv4 ranks IPv4 addresses ahead of IPv6 ones. This is the
+	// default.
+	PreferIPv4 IPFamily = "ipv4"
+
+	// PreferIPv6 ranks IPv6 addresses ahead of IPv4 ones.
+	PreferIPv6 IPFamily = "ipv6"
+
+	// DualStack ranks IPv4 addresses first where a single address is
+	// needed, but publishes an address of each family where several
+	// can be given.
+	DualStack IPFamily = "dual"
+)
+
+// Validate returns an error if the family is not one of the known
+// values.
+func (f IPFamily) Validate() error {
+	switch f {
+	case PreferIPv4, PreferIPv6, DualStack:
+		return nil
+	}
+	return errors.NotValidf("IP family %q", f)
+}
+
+// PreferredType returns the address type ranked first for the family.
+func (f IPFamily) PreferredType() AddressType {
+	if f == PreferIPv6 {
+		return IPv6Address
+	}
+	return IPv4Address
+}
+
 // Scope denotes the context a location may apply to. If a name or
 // address can be reached from the wider internet, it is considered
 // public. A private network address is either specific to the cloud
@@ -365,9 +401,15 @@ func SelectMongoHostPortsByScope(hostPorts []HostPort, machineLocal bool) []stri
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address is then ok is true.
 func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return SelectPublicAddressForFamily(addresses, PreferIPv4)
+}
+
+// SelectPublicAddressForFamily is like SelectPublicAddress, but ranks
+// addresses of the family's preferred type first.
+func SelectPublicAddressForFamily(addresses []Address, family IPFamily) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, publicMatcher(family))
 	if index < 0 {
 		return Address{}, false
 	}
@@ -380,7 +422,7 @@ func SelectPublicAddress(addresses []Address) (Address, bool) {
 func SelectPublicHostPort(hps []HostPort) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, publicMatch)
+	}, publicMatcher(PreferIPv4))
 	if index < 0 {
 		return ""
 	}
@@ -392,15 +434,48 @@ func SelectPublicHostPort(hps []HostPort) string {
 // are no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	return SelectInternalAddressForFamily(addresses, machineLocal, PreferIPv4)
+}
+
+// SelectInternalAddressForFamily is like SelectInternalAddress, but
+// ranks addresses of the family's preferred type first.
+func SelectInternalAddressForFamily(addresses []Address, machineLocal bool, family IPFamily) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family))
 	if index < 0 {
 		return Address{}, false
 	}
 	return addresses[index], true
 }
 
+// SelectIngressAddresses picks the addresses other units should use to
+// reach a unit with the given addresses. For DualStack the best internal
+// address of each IP family is returned, IPv4 first; for the other
+// families the result holds at most the address picked by
+// SelectInternalAddressForFamily.
+func SelectIngressAddresses(addresses []Address, machineLocal bool, family IPFamily) []Address {
+	var result []Address
+	if family != DualStack {
+		if addr, ok := SelectInternalAddressForFamily(addresses, machineLocal, family); ok {
+			result = append(result, addr)
+		}
+		return result
+	}
+	for _, addrType := range []AddressType{IPv4Address, IPv6Address} {
+		var candidates []Address
+		for _, addr := range addresses {
+			if addr.Type == addrType {
+				candidates = append(candidates, addr)
+			}
+		}
+		if addr, ok := SelectInternalAddressForFamily(candidates, machineLocal, family); ok {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
 // SelectInternalHostPort picks one HostPort from a slice that can be
 // used as an endpoint for juju internal communication and returns it
 // in its NetAddr form. If there are no suitable addresses, the empty
@@ -408,7 +483,7 @@ func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, boo
 func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 	index := bestAddressIndex(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, PreferIPv4))
 	if index < 0 {
 		return ""
 	}
@@ -422,7 +497,7 @@ func SelectInternalHostPort(hps []HostPort, machineLocal bool) string {
 func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	indexes := bestAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, PreferIPv4))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -436,9 +511,16 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 // returns them in NetAddr form. If there are no suitable addresses
 // then an empty slice is returned.
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	return PrioritizeInternalHostPortsForFamily(hps, machineLocal, PreferIPv4)
+}
+
+// PrioritizeInternalHostPortsForFamily is like
+// PrioritizeInternalHostPorts, but ranks addresses of the family's
+// preferred type first.
+func PrioritizeInternalHostPortsForFamily(hps []HostPort, machineLocal bool, family IPFamily) []string {
 	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
 		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
+	}, internalAddressMatcher(machineLocal, family))
 
 	out := make([]string, 0, len(indexes))
 	for _, index := range indexes {
@@ -447,62 +529,68 @@ func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 	return out
 }
 
-func publicMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopePublic:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
-		}
-		return exactScope
-	case ScopeCloudLocal, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
+func publicMatcher(family IPFamily) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		switch addr.Scope {
+		case ScopePublic:
+			return exactMatch(addr, family)
+		case ScopeCloudLocal, ScopeUnknown:
+			return fallbackMatch(addr, family)
 		}
-		return fallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func internalAddressMatcher(machineLocal bool) scopeMatchFunc {
+func internalAddressMatcher(machineLocal bool, family IPFamily) scopeMatchFunc {
 	if machineLocal {
-		return cloudOrMachineLocalMatch
+		return cloudOrMachineLocalMatcher(family)
 	}
-	return cloudLocalMatch
+	return cloudLocalMatcher(family)
 }
 
-func cloudLocalMatch(addr Address) scopeMatch {
-	switch addr.Scope {
-	case ScopeCloudLocal:
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+func cloudLocalMatcher(family IPFamily) scopeMatchFunc {
+	return func(addr Address) scopeMatch {
+		switch addr.Scope {
+		case ScopeCloudLocal:
+			return exactMatch(addr, family)
+		case ScopePublic, ScopeUnknown:
+			return fallbackMatch(addr, family)
 		}
-		return exactScope
-	case ScopePublic, ScopeUnknown:
-		if addr.Type == IPv4Address {
-			return fallbackScopeIPv4
-		}
-		return fallbackScope
+		return invalidScope
 	}
-	return invalidScope
 }
 
-func cloudOrMachineLocalMatch(addr Address) scopeMatch {
-	if addr.Scope == ScopeMachineLocal {
-		if addr.Type == IPv4Address {
-			return exactScopeIPv4
+func cloudOrMachineLocalMatcher(family IPFamily) scopeMatchFunc {
+	cloudLocalMatch := cloudLocalMatcher(family)
+	return func(addr Address) scopeMatch {
+		if addr.Scope == ScopeMachineLocal {
+			return exactMatch(addr, family)
 		}
-		return exactScope
+		return cloudLocalMatch(addr)
+	}
+}
+
+func exactMatch(addr Address, family IPFamily) scopeMatch {
+	if addr.Type == family.PreferredType() {
+		return exactScopePreferred
+	}
+	return exactScope
+}
+
+func fallbackMatch(addr Address, family IPFamily) scopeMatch {
+	if addr.Type == family.PreferredType() {
+		return fallbackScopePreferred
 	}
-	return cloudLocalMatch(addr)
+	return fallbackScope
 }
 
 type scopeMatch int
 
 const (
 	invalidScope scopeMatch = iota
-	exactScopeIPv4
+	exactScopePreferred
 	exactScope
-	fallbackScopeIPv4
+	fallbackScopePreferred
 	fallbackScope
 )
 
@@ -529,7 +617,7 @@ func bestAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matchFunc s
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
 		if ok && len(indexes) > 0 {
@@ -544,7 +632,7 @@ func prioritizedAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc, matc
 	matches := filterAndCollateAddressIndexes(numAddr, getAddrFunc, matchFunc)
 
 	// Retrieve the indexes of the addresses with the best scope and type match.
-	allowedMatchTypes := []scopeMatch{exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope}
+	allowedMatchTypes := []scopeMatch{exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope}
 	var prioritized []int
 	for _, matchType := range allowedMatchTypes {
 		indexes, ok := matches[matchType]
@@ -561,7 +649,7 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 	for i := 0; i < numAddr; i++ {
 		matchType := matchFunc(getAddrFunc(i))
 		switch matchType {
-		case exactScopeIPv4, exactScope, fallbackScopeIPv4, fallbackScope:
+		case exactScopePreferred, exactScope, fallbackScopePreferred, fallbackScope:
 			matches[matchType] = append(matches[matchType], i)
 		}
 	}
@@ -575,7 +663,8 @@ func filterAndCollateAddressIndexes(numAddr int, getAddrFunc addressByIndexFunc,
 // - machine-local next;
 // - link-local next;
 // - non-hostnames with unknown scope last.
-func (a Address) sortOrder() int {
+// Within each scope, addresses of the family's preferred type come first.
+func (a Address) sortOrder(family IPFamily) int {
 	order := 0xFF
 	switch a.Scope {
 	case ScopePublic:
@@ -593,23 +682,26 @@ func (a Address) sortOrder() int {
 		if a.Value == "localhost" {
 			order++
 		}
-	case IPv6Address:
-		// Prefer IPv4 over IPv6 addresses.
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		if a.Type != family.PreferredType() {
+			order++
+		}
 	}
 	return order
 }
 
-type addressesPreferringIPv4Slice []Address
+type addressesByFamily struct {
+	addrs  []Address
+	family IPFamily
+}
 
-func (a addressesPreferringIPv4Slice) Len() int      { return len(a) }
-func (a addressesPreferringIPv4Slice) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesByFamily) Len() int      { return len(a.addrs) }
+func (a addressesByFamily) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesByFamily) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrder(a.family)
+	order2 := addr2.sortOrder(a.family)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -617,9 +709,17 @@ func (a addressesPreferringIPv4Slice) Less(i, j int) bool {
 }
 
 // SortAddresses sorts the given Address slice according to the sortOrder of
-// each address. See Address.sortOrder() for more info.
+// each address, preferring IPv4 over IPv6. See Address.sortOrder() for more
+// info.
 func SortAddresses(addrs []Address) {
-	sort.Sort(addressesPreferringIPv4Slice(addrs))
+	SortAddressesForFamily(addrs, PreferIPv4)
+}
+
+// SortAddressesForFamily sorts the given Address slice according to the
+// sortOrder of each address, preferring addresses of the family's
+// preferred type.
+func SortAddressesForFamily(addrs []Address, family IPFamily) {
+	sort.Sort(addressesByFamily{addrs, family})
 }
 
 // DecimalToIPv4 converts a decimal to the dotted quad IP address format.
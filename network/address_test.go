@@ -368,6 +368,33 @@ func (s *AddressSuite) TestSelectPublicAddress(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestSelectPublicAddressForFamily(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	}
+	for family, expected := range map[network.IPFamily]network.Address{
+		network.PreferIPv4: addrs[0],
+		network.PreferIPv6: addrs[1],
+		network.DualStack:  addrs[0],
+	} {
+		c.Logf("family %q", family)
+		addr, ok := network.SelectPublicAddressForFamily(addrs, family)
+		c.Check(ok, jc.IsTrue)
+		c.Check(addr, gc.Equals, expected)
+	}
+}
+
+func (s *AddressSuite) TestSelectPublicAddressForFamilyFallsBack(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+	}
+	addr, ok := network.SelectPublicAddressForFamily(addrs, network.PreferIPv6)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addrs[0])
+}
+
 var selectInternalTests = []selectTest{{
 	"no addresses gives empty string result",
 	[]network.Address{},
@@ -434,6 +461,34 @@ func (s *AddressSuite) TestSelectInternalAddress(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestSelectInternalAddressForFamily(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+	}
+	addr, ok := network.SelectInternalAddressForFamily(addrs, false, network.PreferIPv4)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addrs[1])
+
+	addr, ok = network.SelectInternalAddressForFamily(addrs, false, network.PreferIPv6)
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, gc.Equals, addrs[2])
+}
+
+func (s *AddressSuite) TestSelectIngressAddresses(c *gc.C) {
+	addrs := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	}
+	c.Check(network.SelectIngressAddresses(addrs, false, network.PreferIPv4), jc.DeepEquals, []network.Address{addrs[2]})
+	c.Check(network.SelectIngressAddresses(addrs, false, network.PreferIPv6), jc.DeepEquals, []network.Address{addrs[1]})
+	c.Check(network.SelectIngressAddresses(addrs, false, network.DualStack), jc.DeepEquals, []network.Address{addrs[2], addrs[1]})
+	c.Check(network.SelectIngressAddresses(addrs[:1], false, network.DualStack), jc.DeepEquals, []network.Address{addrs[0]})
+	c.Check(network.SelectIngressAddresses(nil, false, network.DualStack), gc.HasLen, 0)
+}
+
 var selectInternalMachineTests = []selectTest{{
 	"first cloud local IPv4 address is selected",
 	[]network.Address{
@@ -624,6 +679,17 @@ func (s *AddressSuite) TestPrioritizeInternalHostPorts(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestPrioritizeInternalHostPortsForFamily(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("2001:db8::1", network.ScopePublic), 123},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 123},
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 4444},
+	}
+	prioritized := network.PrioritizeInternalHostPortsForFamily(hps, false, network.PreferIPv6)
+	c.Check(prioritized, gc.DeepEquals, []string{"[fc00::1]:123", "10.0.0.1:4444", "[2001:db8::1]:123", "8.8.8.8:123"})
+}
+
 var stringTests = []struct {
 	addr network.Address
 	str  string
@@ -734,6 +800,47 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	))
 }
 
+func (*AddressSuite) TestSortAddressesForFamily(c *gc.C) {
+	addrs := network.NewAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"example.com",
+		"8.8.8.8",
+		"2001:db8::1",
+		"172.16.0.1",
+	)
+	network.SortAddressesForFamily(addrs, network.PreferIPv6)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses(
+		// Public IPv6 addresses on top.
+		"2001:db8::1",
+		// After that public IPv4 addresses.
+		"8.8.8.8",
+		// Then hostnames.
+		"example.com",
+		// Then cloud-local addresses, IPv6 first.
+		"fc00::1",
+		"172.16.0.1",
+		// Finally, machine-local addresses, IPv6 first.
+		"::1",
+		"127.0.0.1",
+	))
+}
+
+func (*AddressSuite) TestIPFamilyValidate(c *gc.C) {
+	for _, family := range []network.IPFamily{network.PreferIPv4, network.PreferIPv6, network.DualStack} {
+		c.Check(family.Validate(), jc.ErrorIsNil)
+	}
+	err := network.IPFamily("ipv5").Validate()
+	c.Check(err, gc.ErrorMatches, `IP family "ipv5" not valid`)
+}
+
+func (*AddressSuite) TestIPFamilyPreferredType(c *gc.C) {
+	c.Check(network.PreferIPv4.PreferredType(), gc.Equals, network.IPv4Address)
+	c.Check(network.PreferIPv6.PreferredType(), gc.Equals, network.IPv6Address)
+	c.Check(network.DualStack.PreferredType(), gc.Equals, network.IPv4Address)
+}
+
 func (*AddressSuite) TestIPv4ToDecimal(c *gc.C) {
 	zeroIP, err := network.IPv4ToDecimal(net.ParseIP("0.0.0.0"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -188,6 +188,13 @@ var skippedDeviceNames = set.NewStrings(
 	network.DefaultKVMBridge,
 )
 
+// localBridgeForType holds the local-only bridge that guests of each
+// container type are attached to when UseLocalBridges is set.
+var localBridgeForType = map[instance.ContainerType]string{
+	instance.LXD: network.DefaultLXDBridge,
+	instance.KVM: network.DefaultKVMBridge,
+}
+
 // FindMissingBridgesForContainer looks at the spaces that the container
 // wants to be in, and sees if there are any host devices that should be
 // bridged.
@@ -202,11 +209,15 @@ func (b *BridgePolicy) FindMissingBridgesForContainer(m Machine, containerMachin
 	logger.Debugf("FindMissingBridgesForContainer(%q) spaces %s devices %v",
 		containerMachine.Id(), network.QuoteSpaceSet(containerSpaces),
 		formatDeviceMap(devicesPerSpace))
+	// A local bridge is only of use to guests of the matching type;
+	// KVM guests can't be attached to lxdbr0, nor LXD guests to virbr0.
+	localBridgeName := localBridgeForType[containerMachine.ContainerType()]
 	spacesFound := set.NewStrings()
 	for spaceName, devices := range devicesPerSpace {
 		for _, device := range devices {
 			if device.Type() == state.BridgeDevice {
-				if !b.UseLocalBridges && skippedDeviceNames.Contains(device.Name()) {
+				if skippedDeviceNames.Contains(device.Name()) &&
+					(!b.UseLocalBridges || device.Name() != localBridgeName) {
 					continue
 				}
 				spacesFound.Add(spaceName)
@@ -303,10 +314,6 @@ func (p *BridgePolicy) PopulateContainerLinkLayerDevices(m Machine, containerMac
 	logger.Debugf("for container %q, found host devices spaces: %s",
 		containerMachine.Id(), formatDeviceMap(devicesPerSpace))
 
-	localBridgeForType := map[instance.ContainerType]string{
-		instance.LXD: network.DefaultLXDBridge,
-		instance.KVM: network.DefaultKVMBridge,
-	}
 	spacesFound := set.NewStrings()
 	devicesByName := make(map[string]*state.LinkLayerDevice)
 	bridgeDeviceNames := make([]string, 0)
@@ -80,12 +80,16 @@ func (s *bridgePolicyStateSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *bridgePolicyStateSuite) addContainerMachine(c *gc.C) {
+	s.addContainerMachineOfType(c, instance.LXD)
+}
+
+func (s *bridgePolicyStateSuite) addContainerMachineOfType(c *gc.C, containerType instance.ContainerType) {
 	// Add a container machine with s.machine as its host.
 	containerTemplate := state.MachineTemplate{
 		Series: "quantal",
 		Jobs:   []state.MachineJob{state.JobHostUnits},
 	}
-	container, err := s.State.AddMachineInsideMachine(containerTemplate, s.machine.Id(), instance.LXD)
+	container, err := s.State.AddMachineInsideMachine(containerTemplate, s.machine.Id(), containerType)
 	c.Assert(err, jc.ErrorIsNil)
 	s.containerMachine = container
 }
@@ -1088,6 +1092,82 @@ func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerVLANOnBond(c
 	c.Check(reconfigureDelay, gc.Equals, 13)
 }
 
-// TODO(jam): 2017-01-31 Make sure KVM guests default to virbr0, and LXD guests use lxdbr0
-// Add tests for UseLocal = True, but we have named spaces
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesUseLocalKVM(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "ens3", "172.12.1.10/24")
+	s.createAllDefaultDevices(c, s.machine)
+	s.addContainerMachineOfType(c, instance.KVM)
+	bridgePolicy := &containerizer.BridgePolicy{
+		NetBondReconfigureDelay: 13,
+		UseLocalBridges:         true,
+	}
+	err := bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 1)
+	c.Check(containerDevices[0].Name(), gc.Equals, "eth0")
+	c.Check(containerDevices[0].ParentName(), gc.Equals, `m#0#d#virbr0`)
+}
+
+func (s *bridgePolicyStateSuite) TestPopulateContainerLinkLayerDevicesKVMTwoSpaces(c *gc.C) {
+	// A KVM guest in two spaces gets a NIC on the host's bridge in
+	// each of them.
+	s.setupMachineInTwoSpaces(c)
+	s.createAllDefaultDevices(c, s.machine)
+	s.addContainerMachineOfType(c, instance.KVM)
+	err := s.containerMachine.SetConstraints(constraints.Value{
+		Spaces: &[]string{"default", "dmz"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.bridgePolicy.PopulateContainerLinkLayerDevices(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	containerDevices, err := s.containerMachine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(containerDevices, gc.HasLen, 2)
+	c.Check(containerDevices[0].Name(), gc.Equals, "eth0")
+	c.Check(containerDevices[0].ParentName(), gc.Equals, `m#0#d#br-ens0p10`)
+	c.Check(containerDevices[1].Name(), gc.Equals, "eth1")
+	c.Check(containerDevices[1].ParentName(), gc.Equals, `m#0#d#br-ens33`)
+}
+
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerUseLocalBridgesKVM(c *gc.C) {
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "ens3", "172.12.0.10/24")
+	s.createAllDefaultDevices(c, s.machine)
+	s.addContainerMachineOfType(c, instance.KVM)
+	bridgePolicy := &containerizer.BridgePolicy{
+		NetBondReconfigureDelay: 13,
+		UseLocalBridges:         true,
+	}
+	missing, reconfigureDelay, err := bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(missing, jc.DeepEquals, []network.DeviceToBridge{})
+	c.Check(reconfigureDelay, gc.Equals, 0)
+}
+
+func (s *bridgePolicyStateSuite) TestFindMissingBridgesForContainerUseLocalBridgesKVMNoVirbr0(c *gc.C) {
+	// lxdbr0 is no use to a KVM guest, so the host device is bridged
+	// instead.
+	s.setupTwoSpaces(c)
+	s.createNICWithIP(c, s.machine, "ens3", "172.12.0.10/24")
+	s.createBridgeWithIP(c, s.machine, "lxdbr0", "10.0.4.1/24")
+	s.addContainerMachineOfType(c, instance.KVM)
+	bridgePolicy := &containerizer.BridgePolicy{
+		NetBondReconfigureDelay: 13,
+		UseLocalBridges:         true,
+	}
+	missing, reconfigureDelay, err := bridgePolicy.FindMissingBridgesForContainer(s.machine, s.containerMachine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(missing, gc.DeepEquals, []network.DeviceToBridge{{
+		DeviceName: "ens3",
+		BridgeName: "br-ens3",
+	}})
+	c.Check(reconfigureDelay, gc.Equals, 0)
+}
+
+// TODO(jam): 2017-01-31 Add tests for UseLocal = True, but we have named spaces
 // Add tests for UseLocal = True, but the host device is bridged
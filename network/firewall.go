@@ -4,7 +4,6 @@
 package network
 
 import (
-	"fmt"
 	"net"
 	"sort"
 	"strings"
@@ -72,10 +71,7 @@ func (r IngressRule) String() string {
 	if from != "" && from != "0.0.0.0/0" {
 		source = " from " + from
 	}
-	if r.FromPort == r.ToPort {
-		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), source)
-	}
-	return fmt.Sprintf("%d-%d/%s%s", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), source)
+	return r.PortRange.String() + source
 }
 
 // GoString is used to print values passed as an operand to a %#v format.
@@ -83,6 +79,30 @@ func (r IngressRule) GoString() string {
 	return r.String()
 }
 
+// CheckPortlessProtocols returns a NotSupported error if any of the
+// rules is for a port-less protocol other than the supported ones.
+// Providers use it to reject the port-less protocols their firewalls
+// cannot express.
+func CheckPortlessProtocols(rules []IngressRule, supported ...string) error {
+	for _, rule := range rules {
+		if !rule.IsPortless() {
+			continue
+		}
+		protocol := strings.ToLower(rule.Protocol)
+		found := false
+		for _, s := range supported {
+			if protocol == s {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.NotSupportedf("ingress rules for protocol %q", protocol)
+		}
+	}
+	return nil
+}
+
 type IngressRuleSlice []IngressRule
 
 func (p IngressRuleSlice) Len() int      { return len(p) }
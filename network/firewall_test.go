@@ -4,6 +4,7 @@
 package network_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -35,6 +36,26 @@ func (*FirewallSuite) TestStrings(c *gc.C) {
 	c.Assert(rule.GoString(), gc.Equals, "80-100/tcp from 0.0.0.0/0,192.168.1.0/24")
 }
 
+func (*FirewallSuite) TestPortlessStrings(c *gc.C) {
+	rule := network.MustNewIngressRule("icmp", -1, -1)
+	c.Assert(rule.String(), gc.Equals, "icmp")
+
+	rule = network.MustNewIngressRule("gre", -1, -1, "10.0.0.0/8")
+	c.Assert(rule.String(), gc.Equals, "gre from 10.0.0.0/8")
+}
+
+func (*FirewallSuite) TestCheckPortlessProtocols(c *gc.C) {
+	rules := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80),
+		network.MustNewIngressRule("icmp", -1, -1),
+	}
+	c.Assert(network.CheckPortlessProtocols(rules, "icmp"), jc.ErrorIsNil)
+
+	err := network.CheckPortlessProtocols(rules)
+	c.Assert(err, gc.ErrorMatches, `ingress rules for protocol "icmp" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (*FirewallSuite) TestSortIngressRules(c *gc.C) {
 	rule1, err := network.NewIngressRule("udp", 10, 100, "0.0.0.0/0", "192.168.1.0/24")
 	c.Assert(err, jc.ErrorIsNil)
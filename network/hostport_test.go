@@ -399,6 +399,26 @@ func (*HostPortSuite) TestNetAddrAndString(c *gc.C) {
 	}
 }
 
+func (s *HostPortSuite) TestSortHostPortsForFamily(c *gc.C) {
+	hps := network.NewHostPorts(1234,
+		"127.0.0.1",
+		"::1",
+		"10.0.0.1",
+		"fc00::1",
+		"8.8.8.8",
+		"2001:db8::1",
+	)
+	network.SortHostPortsForFamily(hps, network.PreferIPv6)
+	s.assertHostPorts(c, hps,
+		"[2001:db8::1]:1234",
+		"8.8.8.8:1234",
+		"[fc00::1]:1234",
+		"10.0.0.1:1234",
+		"[::1]:1234",
+		"127.0.0.1:1234",
+	)
+}
+
 func (s *HostPortSuite) TestHostPortsToStrings(c *gc.C) {
 	hps := s.makeHostPorts()
 	strHPs := network.HostPortsToStrings(hps)
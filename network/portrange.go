@@ -12,18 +12,62 @@ import (
 	"github.com/juju/errors"
 )
 
-// PortRange represents a single range of ports.
+// PortRange represents a single range of ports. The traffic of a
+// port-less protocol, such as icmp, is represented by a PortRange
+// whose FromPort and ToPort are both NoPort.
 type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
 }
 
+// NoPort is the FromPort and ToPort of a port-less protocol's
+// PortRange.
+const NoPort = -1
+
+// portlessProtocols holds the supported protocols whose traffic is
+// not addressed to ports.
+var portlessProtocols = []string{"icmp", "gre", "esp", "ah"}
+
+// IsPortlessProtocol returns whether the traffic of the given
+// protocol is not addressed to ports.
+func IsPortlessProtocol(protocol string) bool {
+	protocol = strings.ToLower(protocol)
+	for _, portless := range portlessProtocols {
+		if protocol == portless {
+			return true
+		}
+	}
+	return false
+}
+
+// NewPortlessRange returns the PortRange representing all traffic of
+// the given port-less protocol.
+func NewPortlessRange(protocol string) PortRange {
+	return PortRange{
+		FromPort: NoPort,
+		ToPort:   NoPort,
+		Protocol: strings.ToLower(protocol),
+	}
+}
+
+// IsPortless returns whether the port range is that of a port-less
+// protocol.
+func (p PortRange) IsPortless() bool {
+	return IsPortlessProtocol(p.Protocol)
+}
+
 // IsValid determines if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
+	if IsPortlessProtocol(proto) {
+		if p.FromPort != NoPort || p.ToPort != NoPort {
+			return errors.Errorf("invalid port range %d-%d/%s, protocol %q does not use ports", p.FromPort, p.ToPort, proto, proto)
+		}
+		return nil
+	}
 	if proto != "tcp" && proto != "udp" {
-		return errors.Errorf(`invalid protocol %q, expected "tcp" or "udp"`, proto)
+		return errors.Errorf(`invalid protocol %q, expected "tcp", "udp", "icmp", "gre", "esp" or "ah"`, proto)
 	}
 	err := errors.Errorf(
 		"invalid port range %d-%d/%s",
@@ -42,15 +86,22 @@ func (p PortRange) Validate() error {
 	return nil
 }
 
-// ConflictsWith determines if the two port ranges conflict.
+// ConflictsWith determines if the two port ranges conflict. Port-less
+// protocols are not exclusive, so their ranges never conflict.
 func (a PortRange) ConflictsWith(b PortRange) bool {
 	if a.Protocol != b.Protocol {
 		return false
 	}
+	if a.IsPortless() {
+		return false
+	}
 	return a.ToPort >= b.FromPort && b.ToPort >= a.FromPort
 }
 
 func (p PortRange) String() string {
+	if p.IsPortless() {
+		return strings.ToLower(p.Protocol)
+	}
 	if p.FromPort == p.ToPort {
 		return fmt.Sprintf("%d/%s", p.FromPort, strings.ToLower(p.Protocol))
 	}
@@ -132,11 +183,16 @@ func CollapsePorts(ports []Port) (result []PortRange) {
 }
 
 // ParsePortRange builds a PortRange from the provided string. If the
-// string does not include a protocol then "tcp" is used. Validate()
-// gets called on the result before returning. If validation fails the
-// invalid PortRange is still returned.
-// Example strings: "80/tcp", "443", "12345-12349/udp".
+// string does not include a protocol then "tcp" is used. A port-less
+// protocol is given on its own. Validate() gets called on the result
+// before returning. If validation fails the invalid PortRange is still
+// returned.
+// Example strings: "80/tcp", "443", "12345-12349/udp", "icmp".
 func ParsePortRange(inPortRange string) (PortRange, error) {
+	if IsPortlessProtocol(inPortRange) {
+		return NewPortlessRange(inPortRange), nil
+	}
+
 	// Extract the protocol.
 	protocol := "tcp"
 	parts := strings.SplitN(inPortRange, "/", 2)
@@ -63,6 +63,11 @@ func (*PortRangeSuite) TestConflictsWith(c *gc.C) {
 		network.PortRange{100, 200, "TCP"},
 		network.PortRange{120, 140, "TCP"},
 		true,
+	}, {
+		"identical port-less ranges",
+		network.NewPortlessRange("icmp"),
+		network.NewPortlessRange("icmp"),
+		false,
 	}}
 
 	for i, t := range testCases {
@@ -93,6 +98,11 @@ func (*PortRangeSuite) TestStrings(c *gc.C) {
 		gc.Equals,
 		"80-100/tcp",
 	)
+	c.Assert(
+		network.NewPortlessRange("ICMP").String(),
+		gc.Equals,
+		"icmp",
+	)
 }
 
 func (*PortRangeSuite) TestValidate(c *gc.C) {
@@ -139,7 +149,15 @@ func (*PortRangeSuite) TestValidate(c *gc.C) {
 	}, {
 		"invalid protocol",
 		network.PortRange{80, 80, "some protocol"},
-		`invalid protocol "some protocol", expected "tcp" or "udp"`,
+		`invalid protocol "some protocol", expected "tcp", "udp", "icmp", "gre", "esp" or "ah"`,
+	}, {
+		"valid icmp range",
+		network.PortRange{-1, -1, "ICMP"},
+		"",
+	}, {
+		"icmp range with ports",
+		network.PortRange{80, 80, "icmp"},
+		`invalid port range 80-80/icmp, protocol "icmp" does not use ports`,
 	}}
 
 	for i, t := range testCases {
@@ -250,6 +268,16 @@ func (*PortRangeSuite) TestParsePortRangeRoundTrip(c *gc.C) {
 	c.Check(portRangeStr, gc.Equals, "8000-8099/tcp")
 }
 
+func (*PortRangeSuite) TestParsePortRangePortless(c *gc.C) {
+	for _, protocol := range []string{"icmp", "gre", "esp", "ah"} {
+		portRange, err := network.ParsePortRange(protocol)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(portRange, gc.Equals, network.PortRange{network.NoPort, network.NoPort, protocol})
+		c.Check(portRange.IsPortless(), jc.IsTrue)
+		c.Check(portRange.String(), gc.Equals, protocol)
+	}
+}
+
 func (*PortRangeSuite) TestParsePortRangeMultiRange(c *gc.C) {
 	_, err := network.ParsePortRange("10-55-100")
 
@@ -37,13 +37,20 @@ const (
 
 	// SuperuserAccess allows user unrestricted permissions in the subject.
 	SuperuserAccess Access = "superuser"
+
+	// Offer permissions
+
+	// ConsumeAccess allows a user to consume an application offer.
+	// Offers also use ReadAccess, allowing a user to see an offer, and
+	// AdminAccess, allowing a user to manage an offer and its users.
+	ConsumeAccess Access = "consume"
 )
 
 // Validate returns error if the current is not a valid access level.
 func (a Access) Validate() error {
 	switch a {
 	case NoAccess, AdminAccess, ReadAccess, WriteAccess,
-		LoginAccess, AddModelAccess, SuperuserAccess:
+		LoginAccess, AddModelAccess, SuperuserAccess, ConsumeAccess:
 		return nil
 	}
 	return errors.NotValidf("access level %s", a)
@@ -69,6 +76,16 @@ func ValidateControllerAccess(access Access) error {
 	return errors.NotValidf("%q controller access", access)
 }
 
+// ValidateOfferAccess returns error if the passed access is not a valid
+// application offer access level.
+func ValidateOfferAccess(access Access) error {
+	switch access {
+	case ReadAccess, ConsumeAccess, AdminAccess:
+		return nil
+	}
+	return errors.NotValidf("%q offer access", access)
+}
+
 func (a Access) controllerValue() int {
 	switch a {
 	case NoAccess:
@@ -99,6 +116,21 @@ func (a Access) modelValue() int {
 	}
 }
 
+func (a Access) offerValue() int {
+	switch a {
+	case NoAccess:
+		return 0
+	case ReadAccess:
+		return 1
+	case ConsumeAccess:
+		return 2
+	case AdminAccess:
+		return 3
+	default:
+		return -1
+	}
+}
+
 // EqualOrGreaterModelAccessThan returns true if the current access is equal
 // or greater than the passed in access level.
 func (a Access) EqualOrGreaterModelAccessThan(access Access) bool {
@@ -139,6 +171,26 @@ func (a Access) GreaterControllerAccessThan(access Access) bool {
 	return v1 > v2
 }
 
+// EqualOrGreaterOfferAccessThan returns true if the current access is
+// equal or greater than the passed in access level.
+func (a Access) EqualOrGreaterOfferAccessThan(access Access) bool {
+	v1, v2 := a.offerValue(), access.offerValue()
+	if v1 < 0 || v2 < 0 {
+		return false
+	}
+	return v1 >= v2
+}
+
+// GreaterOfferAccessThan returns true if the current access is
+// greater than the passed in access level.
+func (a Access) GreaterOfferAccessThan(access Access) bool {
+	v1, v2 := a.offerValue(), access.offerValue()
+	if v1 < 0 || v2 < 0 {
+		return false
+	}
+	return v1 > v2
+}
+
 // accessField returns a Checker that accepts a string value only
 // and returns a valid Access or an error.
 func accessField() schema.Checker {
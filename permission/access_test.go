@@ -199,3 +199,65 @@ func (*accessSuite) TestGreaterControllerAccessThan(c *gc.C) {
 	c.Check(superuser.GreaterControllerAccessThan(addmodel), jc.IsTrue)
 	c.Check(superuser.GreaterControllerAccessThan(superuser), jc.IsFalse)
 }
+
+func (*accessSuite) TestEqualOrGreaterOfferAccessThan(c *gc.C) {
+	var (
+		undefined = permission.NoAccess
+		read      = permission.ReadAccess
+		consume   = permission.ConsumeAccess
+		admin     = permission.AdminAccess
+	)
+	// None of the controller or model-only permissions return true for any comparison.
+	for _, value := range []permission.Access{permission.WriteAccess, permission.LoginAccess, permission.SuperuserAccess} {
+		c.Check(value.EqualOrGreaterOfferAccessThan(undefined), jc.IsFalse)
+		c.Check(value.EqualOrGreaterOfferAccessThan(read), jc.IsFalse)
+		c.Check(value.EqualOrGreaterOfferAccessThan(consume), jc.IsFalse)
+		c.Check(value.EqualOrGreaterOfferAccessThan(admin), jc.IsFalse)
+	}
+
+	c.Check(undefined.EqualOrGreaterOfferAccessThan(undefined), jc.IsTrue)
+	c.Check(undefined.EqualOrGreaterOfferAccessThan(read), jc.IsFalse)
+	c.Check(undefined.EqualOrGreaterOfferAccessThan(consume), jc.IsFalse)
+	c.Check(undefined.EqualOrGreaterOfferAccessThan(admin), jc.IsFalse)
+
+	c.Check(read.EqualOrGreaterOfferAccessThan(undefined), jc.IsTrue)
+	c.Check(read.EqualOrGreaterOfferAccessThan(read), jc.IsTrue)
+	c.Check(read.EqualOrGreaterOfferAccessThan(consume), jc.IsFalse)
+	c.Check(read.EqualOrGreaterOfferAccessThan(admin), jc.IsFalse)
+
+	c.Check(consume.EqualOrGreaterOfferAccessThan(undefined), jc.IsTrue)
+	c.Check(consume.EqualOrGreaterOfferAccessThan(read), jc.IsTrue)
+	c.Check(consume.EqualOrGreaterOfferAccessThan(consume), jc.IsTrue)
+	c.Check(consume.EqualOrGreaterOfferAccessThan(admin), jc.IsFalse)
+
+	c.Check(admin.EqualOrGreaterOfferAccessThan(undefined), jc.IsTrue)
+	c.Check(admin.EqualOrGreaterOfferAccessThan(read), jc.IsTrue)
+	c.Check(admin.EqualOrGreaterOfferAccessThan(consume), jc.IsTrue)
+	c.Check(admin.EqualOrGreaterOfferAccessThan(admin), jc.IsTrue)
+}
+
+func (*accessSuite) TestGreaterOfferAccessThan(c *gc.C) {
+	var (
+		undefined = permission.NoAccess
+		read      = permission.ReadAccess
+		consume   = permission.ConsumeAccess
+		admin     = permission.AdminAccess
+	)
+	c.Check(undefined.GreaterOfferAccessThan(undefined), jc.IsFalse)
+	c.Check(read.GreaterOfferAccessThan(undefined), jc.IsTrue)
+	c.Check(read.GreaterOfferAccessThan(read), jc.IsFalse)
+	c.Check(consume.GreaterOfferAccessThan(read), jc.IsTrue)
+	c.Check(consume.GreaterOfferAccessThan(consume), jc.IsFalse)
+	c.Check(admin.GreaterOfferAccessThan(consume), jc.IsTrue)
+	c.Check(admin.GreaterOfferAccessThan(admin), jc.IsFalse)
+	c.Check(permission.WriteAccess.GreaterOfferAccessThan(read), jc.IsFalse)
+}
+
+func (*accessSuite) TestValidateOfferAccess(c *gc.C) {
+	for _, value := range []permission.Access{permission.ReadAccess, permission.ConsumeAccess, permission.AdminAccess} {
+		c.Check(permission.ValidateOfferAccess(value), jc.ErrorIsNil)
+	}
+	for _, value := range []permission.Access{permission.NoAccess, permission.WriteAccess, permission.LoginAccess} {
+		c.Check(permission.ValidateOfferAccess(value), gc.ErrorMatches, `".*" offer access not valid`)
+	}
+}
@@ -370,6 +370,7 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Zones,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
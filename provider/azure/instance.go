@@ -211,6 +211,9 @@ func (inst *azureInstance) primaryNetworkAddress() (jujunetwork.Address, error)
 
 // OpenPorts is specified in the Instance interface.
 func (inst *azureInstance) OpenPorts(machineId string, rules []jujunetwork.IngressRule) error {
+	if err := jujunetwork.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	nsgClient := network.SecurityGroupsClient{inst.env.network}
 	securityRuleClient := network.SecurityRulesClient{inst.env.network}
 	primaryNetworkAddress, err := inst.primaryNetworkAddress()
@@ -5,8 +5,13 @@ package common
 
 import (
 	"sort"
+	"strings"
 
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 )
 
@@ -133,6 +138,89 @@ func AvailabilityZoneAllocations(env ZonedEnviron, group []instance.Id) ([]Avail
 
 var internalAvailabilityZoneAllocations = AvailabilityZoneAllocations
 
+type byPopulationDescendingThenName []AvailabilityZoneInstances
+
+func (b byPopulationDescendingThenName) Len() int {
+	return len(b)
+}
+
+func (b byPopulationDescendingThenName) Less(i, j int) bool {
+	switch {
+	case len(b[i].Instances) > len(b[j].Instances):
+		return true
+	case len(b[i].Instances) == len(b[j].Instances):
+		return b[i].ZoneName < b[j].ZoneName
+	}
+	return false
+}
+
+func (b byPopulationDescendingThenName) Swap(i, j int) {
+	b[i], b[j] = b[j], b[i]
+}
+
+// PlacementZoneNames returns the names of the availability zones in
+// zoneInstances that a new instance may be started in, in the order in
+// which they should be tried. Zones not permitted by the zones
+// constraint are dropped. The remaining zones are ordered by ascending
+// population for config.ZonePlacementSpread, and by descending
+// population for config.ZonePlacementPack.
+//
+// An error is returned if the zones constraint is set but none of the
+// zones it names are available.
+func PlacementZoneNames(
+	zoneInstances []AvailabilityZoneInstances,
+	cons constraints.Value,
+	placement string,
+) ([]string, error) {
+	allowed := zoneInstances
+	if cons.HasZones() {
+		allowed = nil
+		for _, z := range zoneInstances {
+			for _, name := range *cons.Zones {
+				if z.ZoneName == name {
+					allowed = append(allowed, z)
+					break
+				}
+			}
+		}
+		if len(allowed) == 0 {
+			return nil, errors.Errorf(
+				"no available zones match constraint zones=%s",
+				strings.Join(*cons.Zones, ","),
+			)
+		}
+	}
+	sorted := make([]AvailabilityZoneInstances, len(allowed))
+	copy(sorted, allowed)
+	if placement == config.ZonePlacementPack {
+		sort.Sort(byPopulationDescendingThenName(sorted))
+	} else {
+		sort.Sort(byPopulationThenName(sorted))
+	}
+	names := make([]string, len(sorted))
+	for i, z := range sorted {
+		names[i] = z.ZoneName
+	}
+	return names, nil
+}
+
+// ValidatePlacementZone returns an error if the zone named in a
+// placement directive is not permitted by the zones constraint.
+func ValidatePlacementZone(zone string, cons constraints.Value) error {
+	if !cons.HasZones() {
+		return nil
+	}
+	for _, name := range *cons.Zones {
+		if name == zone {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"availability zone %q does not match constraint zones=%s",
+		zone, strings.Join(*cons.Zones, ","),
+	)
+}
+
 // DistributeInstances is a common function for implement the
 // state.InstanceDistributor policy based on availability zone
 // spread.
@@ -9,7 +9,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
 	coretesting "github.com/juju/juju/testing"
@@ -254,3 +256,42 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestPlacementZoneNames(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{
+		{ZoneName: "az1"},
+		{ZoneName: "az3", Instances: []instance.Id{"inst0"}},
+		{ZoneName: "az2", Instances: []instance.Id{"inst1", "inst2"}},
+	}
+	names, err := common.PlacementZoneNames(zoneInstances, constraints.Value{}, config.ZonePlacementSpread)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"az1", "az3", "az2"})
+
+	names, err = common.PlacementZoneNames(zoneInstances, constraints.Value{}, config.ZonePlacementPack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"az2", "az3", "az1"})
+
+	cons := constraints.MustParse("zones=az1,az3")
+	names, err = common.PlacementZoneNames(zoneInstances, cons, config.ZonePlacementSpread)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"az1", "az3"})
+
+	names, err = common.PlacementZoneNames(zoneInstances, cons, config.ZonePlacementPack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"az3", "az1"})
+}
+
+func (s *AvailabilityZoneSuite) TestPlacementZoneNamesNoMatch(c *gc.C) {
+	zoneInstances := []common.AvailabilityZoneInstances{{ZoneName: "az1"}}
+	_, err := common.PlacementZoneNames(zoneInstances, constraints.MustParse("zones=az0"), config.ZonePlacementSpread)
+	c.Assert(err, gc.ErrorMatches, "no available zones match constraint zones=az0")
+}
+
+func (s *AvailabilityZoneSuite) TestValidatePlacementZone(c *gc.C) {
+	err := common.ValidatePlacementZone("az1", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = common.ValidatePlacementZone("az1", constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = common.ValidatePlacementZone("az3", constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, gc.ErrorMatches, `availability zone "az3" does not match constraint zones=az1,az2`)
+}
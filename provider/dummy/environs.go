@@ -1397,10 +1397,17 @@ func (e *environ) AllInstances() ([]instance.Instance, error) {
 	return insts, nil
 }
 
+// supportedPortlessProtocols holds the port-less protocols that the
+// dummy provider's firewall supports.
+var supportedPortlessProtocols = []string{"icmp"}
+
 func (e *environ) OpenPorts(rules []network.IngressRule) error {
 	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", mode)
 	}
+	if err := network.CheckPortlessProtocols(rules, supportedPortlessProtocols...); err != nil {
+		return errors.Trace(err)
+	}
 	estate, err := e.state()
 	if err != nil {
 		return err
@@ -1560,6 +1567,9 @@ func (inst *dummyInstance) OpenPorts(machineId string, rules []network.IngressRu
 	if inst.machineId != machineId {
 		panic(fmt.Errorf("OpenPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
 	}
+	if err := network.CheckPortlessProtocols(rules, supportedPortlessProtocols...); err != nil {
+		return errors.Trace(err)
+	}
 	inst.state.mu.Lock()
 	defer inst.state.mu.Unlock()
 	if err := inst.checkBroken("OpenPorts"); err != nil {
@@ -82,6 +82,32 @@ func (s *liveSuite) TearDownTest(c *gc.C) {
 	s.BaseSuite.TearDownTest(c)
 }
 
+func (s *liveSuite) TestPortlessProtocols(c *gc.C) {
+	s.BootstrapOnce(c)
+
+	inst, _ := jujutesting.AssertStartInstance(c, s.Env, s.ControllerUUID, "1")
+	c.Assert(inst, gc.NotNil)
+	defer s.Env.StopInstances(inst.Id())
+
+	// The dummy firewall supports icmp...
+	err := inst.OpenPorts("1", []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := inst.IngressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("icmp", -1, -1, "0.0.0.0/0"),
+	})
+
+	// ...but rejects other port-less protocols.
+	err = inst.OpenPorts("1", []network.IngressRule{
+		network.MustNewIngressRule("gre", -1, -1),
+	})
+	c.Assert(err, gc.ErrorMatches, `ingress rules for protocol "gre" not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type suite struct {
 	testing.BaseSuite
 	gitjujutesting.MgoSuite
@@ -270,9 +270,13 @@ func (e *environ) parsePlacement(placement string) (*ec2Placement, error) {
 // PrecheckInstance is defined on the state.Prechecker interface.
 func (e *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
-		if _, err := e.parsePlacement(placement); err != nil {
+		p, err := e.parsePlacement(placement)
+		if err != nil {
 			return err
 		}
+		if err := common.ValidatePlacementZone(p.availabilityZone.Name, cons); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if !cons.HasInstanceType() {
 		return nil
@@ -376,13 +380,16 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if placement.availabilityZone.State != availableState {
 			return nil, errors.Errorf("availability zone %q is %s", placement.availabilityZone.Name, placement.availabilityZone.State)
 		}
+		if err := common.ValidatePlacementZone(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
 	callback(status.Allocating, "Determining availability zones", nil)
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then automatically spread
+	// across (or pack into) the known zones permitted by the zones
+	// constraint, according to the instance distribution group.
 	var zoneInstances []common.AvailabilityZoneInstances
 	if len(availabilityZones) == 0 {
 		var err error
@@ -397,8 +404,11 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if err != nil {
 			return nil, err
 		}
-		for _, z := range zoneInstances {
-			availabilityZones = append(availabilityZones, z.ZoneName)
+		availabilityZones, err = common.PlacementZoneNames(
+			zoneInstances, args.Constraints, e.Config().ZonePlacement(),
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
 		if len(availabilityZones) == 0 {
 			return nil, errors.New("failed to determine availability zones")
@@ -1159,11 +1169,38 @@ func (e *environ) allModelVolumes(includeRootDisks bool) ([]string, error) {
 	return listVolumes(e.ec2, filter, includeRootDisks)
 }
 
+// ipProtocolNumbers maps the port-less protocols that EC2 security
+// groups only accept by IP protocol number to those numbers. EC2
+// accepts icmp by name.
+var ipProtocolNumbers = map[string]string{
+	"gre": "47",
+	"esp": "50",
+	"ah":  "51",
+}
+
+// ec2Protocol returns the name EC2 uses for the given protocol.
+func ec2Protocol(protocol string) string {
+	if number, ok := ipProtocolNumbers[protocol]; ok {
+		return number
+	}
+	return protocol
+}
+
+// jujuProtocol returns the protocol named by EC2 as given.
+func jujuProtocol(ec2Protocol string) string {
+	for protocol, number := range ipProtocolNumbers {
+		if ec2Protocol == number {
+			return protocol
+		}
+	}
+	return ec2Protocol
+}
+
 func rulesToIPPerms(rules []network.IngressRule) []ec2.IPPerm {
 	ipPerms := make([]ec2.IPPerm, len(rules))
 	for i, r := range rules {
 		ipPerms[i] = ec2.IPPerm{
-			Protocol: r.Protocol,
+			Protocol: ec2Protocol(strings.ToLower(r.Protocol)),
 			FromPort: r.FromPort,
 			ToPort:   r.ToPort,
 		}
@@ -1238,7 +1275,7 @@ func (e *environ) ingressRulesInGroup(name string) (rules []network.IngressRule,
 		if len(ips) == 0 {
 			ips = []string{defaultRouteCIDRBlock}
 		}
-		rule, err := network.NewIngressRule(p.Protocol, p.FromPort, p.ToPort, ips...)
+		rule, err := network.NewIngressRule(jujuProtocol(p.Protocol), p.FromPort, p.ToPort, ips...)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
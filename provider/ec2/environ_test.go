@@ -157,6 +157,23 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 			ToPort:    82,
 			SourceIPs: []string{"192.168.1.0/24", "0.0.0.0/0"},
 		}},
+	}, {
+		about: "port-less protocols",
+		rules: []network.IngressRule{
+			network.MustNewIngressRule("icmp", -1, -1),
+			network.MustNewIngressRule("esp", -1, -1, "10.0.0.0/8"),
+		},
+		expected: []amzec2.IPPerm{{
+			Protocol:  "icmp",
+			FromPort:  -1,
+			ToPort:    -1,
+			SourceIPs: []string{"0.0.0.0/0"},
+		}, {
+			Protocol:  "50",
+			FromPort:  -1,
+			ToPort:    -1,
+			SourceIPs: []string{"10.0.0.0/8"},
+		}},
 	}}
 
 	for i, t := range testCases {
@@ -166,6 +183,14 @@ func (*Suite) TestPortsToIPPerms(c *gc.C) {
 	}
 }
 
+func (*Suite) TestProtocolNames(c *gc.C) {
+	for _, protocol := range []string{"tcp", "udp", "icmp", "gre", "esp", "ah"} {
+		c.Check(jujuProtocol(ec2Protocol(protocol)), gc.Equals, protocol)
+	}
+	c.Check(ec2Protocol("gre"), gc.Equals, "47")
+	c.Check(jujuProtocol("51"), gc.Equals, "ah")
+}
+
 // These Support checks are currently valid with a 'nil' environ pointer. If
 // that changes, the tests will need to be updated. (we know statically what is
 // supported.)
@@ -965,6 +965,52 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"},
+			{ZoneName: "az2", Instances: []instance.Id{"i-1"}},
+			{ZoneName: "az3", Instances: []instance.Id{"i-2", "i-3"}},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	var azArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		azArgs = append(azArgs, ri.AvailZone)
+		return nil, azConstrainedErr
+	})
+	cons := constraints.MustParse("zones=az2,az3")
+	_, _, _, err := testing.StartInstanceWithConstraints(env, t.ControllerUUID, "1", cons)
+	c.Assert(err, gc.NotNil)
+	c.Assert(azArgs, gc.DeepEquals, []string{"az2", "az3"})
+
+	cfg, err := env.Config().Apply(map[string]interface{}{
+		"zone-placement": "pack",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = env.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	azArgs = nil
+	_, _, _, err = testing.StartInstanceWithConstraints(env, t.ControllerUUID, "1", cons)
+	c.Assert(err, gc.NotNil)
+	c.Assert(azArgs, gc.DeepEquals, []string{"az3", "az2"})
+
+	cons = constraints.MustParse("zones=az4")
+	_, _, _, err = testing.StartInstanceWithConstraints(env, t.ControllerUUID, "1", cons)
+	c.Assert(err, gc.ErrorMatches, "no available zones match constraint zones=az4")
+}
+
+func (t *localServerSuite) TestPrecheckInstanceAvailZoneNotInConstraint(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("zones=test-unavailable")
+	err := env.PrecheckInstance(series.LatestLts(), cons, "zone=test-available")
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" does not match constraint zones=test-unavailable`)
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets.
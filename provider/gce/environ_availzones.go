@@ -93,12 +93,15 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 			return nil, errors.Trace(err)
 		}
 		// TODO(ericsnow) Fail if placement.Zone is not in the env's configured region?
+		if err := common.ValidatePlacementZone(placement.Zone.Name(), args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return []string{placement.Zone.Name()}, nil
 	}
 
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then automatically spread
+	// across (or pack into) the known zones permitted by the zones
+	// constraint, according to the instance distribution group.
 	var group []instance.Id
 	var err error
 	if args.DistributionGroup != nil {
@@ -113,9 +116,9 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 	}
 	logger.Infof("found %d zones: %v", len(zoneInstances), zoneInstances)
 
-	var zoneNames []string
-	for _, z := range zoneInstances {
-		zoneNames = append(zoneNames, z.ZoneName)
+	zoneNames, err := common.PlacementZoneNames(zoneInstances, args.Constraints, env.Config().ZonePlacement())
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	if len(zoneNames) == 0 {
@@ -19,6 +19,9 @@ func (env *environ) globalFirewallName() string {
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
 func (env *environ) OpenPorts(rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	err := env.gce.OpenPorts(env.globalFirewallName(), rules...)
 	return errors.Trace(err)
 }
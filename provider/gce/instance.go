@@ -70,6 +70,9 @@ func findInst(id instance.Id, instances []instance.Instance) instance.Instance {
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
 func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(ericsnow) Make sure machineId matches inst.Id()?
 	name, err := inst.env.namespace.Hostname(machineID)
 	if err != nil {
@@ -96,6 +96,9 @@ func getRules(envName string, fwrules []cloudapi.FirewallRule) ([]network.Ingres
 }
 
 func (env *joyentEnviron) OpenPorts(ports []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(ports); err != nil {
+		return errors.Trace(err)
+	}
 	if env.Config().FirewallMode() != config.FwGlobal {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on model", env.Config().FirewallMode())
 	}
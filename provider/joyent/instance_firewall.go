@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/joyent/gosdc/cloudapi"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -33,6 +34,9 @@ func createFirewallRuleVm(envName string, machineId string, portRange network.In
 }
 
 func (inst *joyentInstance) OpenPorts(machineId string, ports []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(ports); err != nil {
+		return errors.Trace(err)
+	}
 	if inst.env.Config().FirewallMode() != config.FwInstance {
 		return fmt.Errorf("invalid firewall mode %q for opening ports on instance", inst.env.Config().FirewallMode())
 	}
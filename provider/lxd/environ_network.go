@@ -21,6 +21,9 @@ func (env *environ) globalFirewallName() string {
 // Must only be used if the environment was setup with the
 // FwGlobal firewall mode.
 func (env *environ) OpenPorts(rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	err := env.raw.OpenPorts(env.globalFirewallName(), rules...)
 	if errors.IsNotImplemented(err) {
 		// TODO(ericsnow) for now...
@@ -64,6 +64,9 @@ func (inst *environInstance) Addresses() ([]network.Address, error) {
 // OpenPorts opens the given ports on the instance, which
 // should have been started with the given machine id.
 func (inst *environInstance) OpenPorts(machineID string, rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(ericsnow) Make sure machineId matches inst.Id()?
 	name, err := inst.env.namespace.Hostname(machineID)
 	if err != nil {
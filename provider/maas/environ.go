@@ -886,6 +886,9 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		}
 		switch {
 		case placement.zoneName != "":
+			if err := common.ValidatePlacementZone(placement.zoneName, args.Constraints); err != nil {
+				return nil, errors.Trace(err)
+			}
 			availabilityZones = append(availabilityZones, placement.zoneName)
 		default:
 			nodeName = placement.nodeName
@@ -893,8 +896,8 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 	}
 
 	// If no placement is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// (or pack into) the known zones permitted by the zones constraint,
+	// according to the instance distribution group.
 	if args.Placement == "" {
 		var group []instance.Id
 		var err error
@@ -913,8 +916,11 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		} else if err != nil {
 			return nil, errors.Annotate(err, "cannot get availability zone allocations")
 		} else if len(zoneInstances) > 0 {
-			for _, z := range zoneInstances {
-				availabilityZones = append(availabilityZones, z.ZoneName)
+			availabilityZones, err = common.PlacementZoneNames(
+				zoneInstances, args.Constraints, environ.Config().ZonePlacement(),
+			)
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
 		}
 	}
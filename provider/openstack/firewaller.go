@@ -122,6 +122,9 @@ func (f *switchingFirewaller) initFirewaller() error {
 }
 
 func (f *switchingFirewaller) OpenPorts(rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
@@ -185,6 +188,9 @@ func (f *switchingFirewaller) SetUpGroups(controllerUUID, machineId string, apiP
 }
 
 func (f *switchingFirewaller) OpenInstancePorts(inst instance.Instance, machineId string, rules []network.IngressRule) error {
+	if err := network.CheckPortlessProtocols(rules); err != nil {
+		return errors.Trace(err)
+	}
 	if err := f.initFirewaller(); err != nil {
 		return errors.Trace(err)
 	}
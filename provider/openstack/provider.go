@@ -581,9 +581,13 @@ func (e *Environ) parsePlacement(placement string) (*openstackPlacement, error)
 // PrecheckInstance is defined on the state.Prechecker interface.
 func (e *Environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
-		if _, err := e.parsePlacement(placement); err != nil {
+		p, err := e.parsePlacement(placement)
+		if err != nil {
 			return err
 		}
+		if err := common.ValidatePlacementZone(p.availabilityZone.Name, cons); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	if !cons.HasInstanceType() {
 		return nil
@@ -933,12 +937,15 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		if !placement.availabilityZone.State.Available {
 			return nil, errors.Errorf("availability zone %q is unavailable", placement.availabilityZone.Name)
 		}
+		if err := common.ValidatePlacementZone(placement.availabilityZone.Name, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = append(availabilityZones, placement.availabilityZone.Name)
 	}
 
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then automatically spread
+	// across (or pack into) the known zones permitted by the zones
+	// constraint, according to the instance distribution group.
 	if len(availabilityZones) == 0 {
 		var group []instance.Id
 		var err error
@@ -955,8 +962,11 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		} else if err != nil {
 			return nil, err
 		} else {
-			for _, zone := range zoneInstances {
-				availabilityZones = append(availabilityZones, zone.ZoneName)
+			availabilityZones, err = common.PlacementZoneNames(
+				zoneInstances, args.Constraints, e.Config().ZonePlacement(),
+			)
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
 		}
 		if len(availabilityZones) == 0 {
@@ -102,12 +102,15 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := common.ValidatePlacementZone(placement.Name(), args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return []string{placement.Name()}, nil
 	}
 
-	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// If no availability zone is specified, then automatically spread
+	// across (or pack into) the known zones permitted by the zones
+	// constraint, according to the instance distribution group.
 	var group []instance.Id
 	var err error
 	if args.DistributionGroup != nil {
@@ -116,26 +119,29 @@ func (env *environ) parseAvailabilityZones(args environs.StartInstanceParams) ([
 			return nil, errors.Trace(err)
 		}
 	}
-	var zoneNames []string
+	var zoneInstances []common.AvailabilityZoneInstances
 	// Vsphere will misbehave if we call AvailabilityZoneAllocations with empty
 	// groups, in this case all zones shouhld be returned.
 	if len(group) != 0 {
-		zoneInstances, err := AvailabilityZoneAllocations(env, group)
+		zoneInstances, err = AvailabilityZoneAllocations(env, group)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		for _, z := range zoneInstances {
-			zoneNames = append(zoneNames, z.ZoneName)
-		}
 	} else {
 		zones, err := AllAvailabilityZones(env)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		for _, z := range zones {
-			zoneNames = append(zoneNames, z.Name())
+			zoneInstances = append(zoneInstances, common.AvailabilityZoneInstances{
+				ZoneName: z.Name(),
+			})
 		}
 	}
+	zoneNames, err := common.PlacementZoneNames(zoneInstances, args.Constraints, env.Config().ZonePlacement())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	logger.Infof("found %d zones: %v", len(zoneNames), zoneNames)
 
 	if len(zoneNames) == 0 {
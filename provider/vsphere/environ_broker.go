@@ -148,6 +148,8 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, img *OvaFi
 			logger.Warningf("Error while trying to create instance in %s availability zone: %s", zone, err)
 			continue
 		}
+		zone := zone
+		hwc.AvailabilityZone = &zone
 		break
 	}
 	if err != nil {
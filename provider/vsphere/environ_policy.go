@@ -13,15 +13,20 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
 // are valid for use in creating an instance in this environment.
 func (env *environ) PrecheckInstance(series string, cons constraints.Value, placement string) error {
 	if placement != "" {
-		if _, err := env.parsePlacement(placement); err != nil {
+		zone, err := env.parsePlacement(placement)
+		if err != nil {
 			return err
 		}
+		if err := common.ValidatePlacementZone(zone.Name(), cons); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -66,6 +66,7 @@ func (conn *Conn) send(call *Call) {
 		RequestId: reqId,
 		Request:   call.Request,
 		Version:   1,
+		TraceID:   conn.traceID,
 	}
 	params := call.Params
 	if params == nil {
@@ -132,6 +133,15 @@ func (call *Call) done() {
 	}
 }
 
+// SetTraceID sets the ID of the distributed trace that subsequent
+// client requests are sent as part of, so that the server's records
+// of the requests can be correlated with the client.
+func (conn *Conn) SetTraceID(traceID string) {
+	conn.sending.Lock()
+	defer conn.sending.Unlock()
+	conn.traceID = traceID
+}
+
 // Call invokes the named action on the object of the given type with the given
 // id. The returned values will be stored in response, which should be a pointer.
 // If the action fails remotely, the error will have a cause of type RequestError.
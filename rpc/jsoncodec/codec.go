@@ -72,6 +72,7 @@ type inMsgV1 struct {
 	Error     string          `json:"error"`
 	ErrorCode string          `json:"error-code"`
 	Response  json.RawMessage `json:"response"`
+	TraceID   string          `json:"trace-id,omitempty"`
 }
 
 // outMsg holds an outgoing message.
@@ -97,6 +98,7 @@ type outMsgV1 struct {
 	Error     string      `json:"error,omitempty"`
 	ErrorCode string      `json:"error-code,omitempty"`
 	Response  interface{} `json:"response,omitempty"`
+	TraceID   string      `json:"trace-id,omitempty"`
 }
 
 func (c *Codec) Close() error {
@@ -140,6 +142,7 @@ func (c *Codec) ReadHeader(hdr *rpc.Header) error {
 	hdr.Error = c.msg.Error
 	hdr.ErrorCode = c.msg.ErrorCode
 	hdr.Version = version
+	hdr.TraceID = c.msg.TraceID
 	return nil
 }
 
@@ -272,6 +275,7 @@ func newOutMsgV1(hdr *rpc.Header, body interface{}) outMsgV1 {
 		Request:   hdr.Request.Action,
 		Error:     hdr.Error,
 		ErrorCode: hdr.ErrorCode,
+		TraceID:   hdr.TraceID,
 	}
 	if hdr.IsRequest() {
 		result.Params = body
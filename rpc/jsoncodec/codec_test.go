@@ -115,6 +115,18 @@ func (*suite) TestRead(c *gc.C) {
 			Version: 1,
 		},
 		expectBody: &value{X: "param"},
+	}, {
+		msg: `{"request-id": 5, "type": "foo", "request": "frob", "params": {"X": "param"}, "trace-id": "abc"}`,
+		expectHdr: rpc.Header{
+			RequestId: 5,
+			Request: rpc.Request{
+				Type:   "foo",
+				Action: "frob",
+			},
+			Version: 1,
+			TraceID: "abc",
+		},
+		expectBody: &value{X: "param"},
 	}} {
 		c.Logf("test %d", i)
 		codec := jsoncodec.New(&testConn{
@@ -236,6 +248,14 @@ func (*suite) TestWrite(c *gc.C) {
 		},
 		body:   &value{X: "param"},
 		expect: `{"request-id": 4, "type": "foo", "version": 2, "request": "frob", "params": {"X": "param"}}`,
+	}, {
+		hdr: &rpc.Header{
+			RequestId: 5,
+			Version:   1,
+			TraceID:   "abc",
+		},
+		body:   &value{X: "result"},
+		expect: `{"request-id": 5, "response": {"X": "result"}, "trace-id": "abc"}`,
 	}} {
 		c.Logf("test %d", i)
 		var conn testConn
@@ -4,34 +4,251 @@
 package jsoncodec
 
 import (
+	"bytes"
+	"compress/flate"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net"
+	"sync"
 
+	"github.com/juju/errors"
 	"golang.org/x/net/websocket"
 )
 
+const (
+	// CompressionHeader is the HTTP header with which a client
+	// opening a websocket tells the server which message compression
+	// it accepts. Servers that do not recognise it send uncompressed
+	// messages, as do clients that do not send it, so peers that
+	// predate compression continue to work.
+	CompressionHeader = "X-Juju-Accept-Compression"
+
+	// DeflateCompression is the CompressionHeader value indicating
+	// that messages compressed with DEFLATE (RFC 1951) are accepted.
+	DeflateCompression = "deflate"
+
+	// compressionThreshold is the size of the JSON encoding of a
+	// message, in bytes, above which it is worth compressing.
+	compressionThreshold = 1024
+
+	// maxInflatedSize limits the size of the decompressed messages
+	// that will be accepted.
+	maxInflatedSize = 256 << 20
+)
+
+// WebsocketOptions holds options for codecs created with
+// NewWebsocketWithOptions.
+type WebsocketOptions struct {
+	// Compress holds whether large messages are sent compressed.
+	// It must only be set when the peer is known to accept
+	// compressed messages. Compressed messages are always accepted
+	// when received.
+	Compress bool
+
+	// Stats, if non-nil, is used to record the messages sent and
+	// received by the codec.
+	Stats *Stats
+}
+
 // NewWebsocket returns an rpc codec that uses the given websocket
-// connection to send and receive messages.
+// connection to send and receive messages. It sends uncompressed
+// messages.
 func NewWebsocket(conn *websocket.Conn) *Codec {
-	return New(wsJSONConn{conn})
+	return NewWebsocketWithOptions(conn, WebsocketOptions{})
+}
+
+// NewWebsocketWithOptions returns an rpc codec that uses the given
+// websocket connection to send and receive messages, with the given
+// options.
+func NewWebsocketWithOptions(conn *websocket.Conn, opts WebsocketOptions) *Codec {
+	return New(&wsJSONConn{
+		conn: conn,
+		opts: opts,
+	})
 }
 
+// wsJSONConn sends messages as JSON in websocket text frames, or,
+// when compressing them, as compressed JSON in binary frames.
 type wsJSONConn struct {
 	conn *websocket.Conn
+	opts WebsocketOptions
+}
+
+// wsFrame holds the payload of a websocket frame.
+type wsFrame struct {
+	data       []byte
+	compressed bool
 }
 
-func (conn wsJSONConn) Send(msg interface{}) error {
-	return websocket.JSON.Send(conn.conn, msg)
+// wsFrameCodec sends and receives wsFrames, using the frame type to
+// distinguish compressed payloads.
+var wsFrameCodec = websocket.Codec{
+	Marshal: func(v interface{}) ([]byte, byte, error) {
+		f := v.(*wsFrame)
+		if f.compressed {
+			return f.data, websocket.BinaryFrame, nil
+		}
+		return f.data, websocket.TextFrame, nil
+	},
+	Unmarshal: func(data []byte, payloadType byte, v interface{}) error {
+		f := v.(*wsFrame)
+		f.data = data
+		f.compressed = payloadType == websocket.BinaryFrame
+		return nil
+	},
 }
 
-func (conn wsJSONConn) Receive(msg interface{}) error {
-	return websocket.JSON.Receive(conn.conn, msg)
+func (conn *wsJSONConn) Send(msg interface{}) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	frame := wsFrame{data: payload}
+	if conn.opts.Compress && len(payload) > compressionThreshold {
+		frame.data, err = deflate(payload)
+		if err != nil {
+			return errors.Annotate(err, "cannot compress message")
+		}
+		frame.compressed = true
+	}
+	if err := wsFrameCodec.Send(conn.conn, &frame); err != nil {
+		return err
+	}
+	conn.opts.Stats.recordSent(frame.compressed, len(payload), len(frame.data))
+	return nil
 }
 
-func (conn wsJSONConn) Close() error {
+func (conn *wsJSONConn) Receive(msg interface{}) error {
+	var frame wsFrame
+	if err := wsFrameCodec.Receive(conn.conn, &frame); err != nil {
+		return err
+	}
+	payload := frame.data
+	if frame.compressed {
+		var err error
+		payload, err = inflate(frame.data)
+		if err != nil {
+			return errors.Annotate(err, "cannot decompress message")
+		}
+	}
+	conn.opts.Stats.recordReceived(frame.compressed, len(payload), len(frame.data))
+	return json.Unmarshal(payload, msg)
+}
+
+func (conn *wsJSONConn) Close() error {
 	return conn.conn.Close()
 }
 
+// flateWriters holds compressors for reuse, as each one allocates
+// a large amount of memory.
+var flateWriters = sync.Pool{
+	New: func() interface{} {
+		w, err := flate.NewWriter(nil, flate.BestSpeed)
+		if err != nil {
+			// NewWriter only fails for invalid levels.
+			panic(err)
+		}
+		return w
+	},
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := flateWriters.Get().(*flate.Writer)
+	defer flateWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func inflate(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	payload, err := ioutil.ReadAll(io.LimitReader(r, maxInflatedSize+1))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(payload) > maxInflatedSize {
+		return nil, errors.Errorf("message exceeds %d bytes", maxInflatedSize)
+	}
+	return payload, nil
+}
+
+// Traffic holds counts of the messages passing in one direction
+// through websocket codecs.
+type Traffic struct {
+	// Messages holds the number of messages.
+	Messages uint64
+
+	// CompressedMessages holds the number of the messages
+	// that were compressed.
+	CompressedMessages uint64
+
+	// PayloadBytes holds the total size of the JSON encoding
+	// of the messages.
+	PayloadBytes uint64
+
+	// WireBytes holds the total size of the messages as sent
+	// over websockets, after any compression.
+	WireBytes uint64
+}
+
+func (t *Traffic) record(compressed bool, payloadBytes, wireBytes int) {
+	t.Messages++
+	if compressed {
+		t.CompressedMessages++
+	}
+	t.PayloadBytes += uint64(payloadBytes)
+	t.WireBytes += uint64(wireBytes)
+}
+
+// Stats records the traffic through the websocket codecs that share
+// it. The zero value is ready to use, and a Stats is safe for
+// concurrent use.
+type Stats struct {
+	mu       sync.Mutex
+	sent     Traffic
+	received Traffic
+}
+
+// Sent returns the traffic sent so far.
+func (s *Stats) Sent() Traffic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
+
+// Received returns the traffic received so far.
+func (s *Stats) Received() Traffic {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
+
+func (s *Stats) recordSent(compressed bool, payloadBytes, wireBytes int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent.record(compressed, payloadBytes, wireBytes)
+}
+
+func (s *Stats) recordReceived(compressed bool, payloadBytes, wireBytes int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.received.record(compressed, payloadBytes, wireBytes)
+}
+
 // NewNet returns an rpc codec that uses the given net
 // connection to send and receive messages.
 func NewNet(conn net.Conn) *Codec {
@@ -0,0 +1,89 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsoncodec_test
+
+import (
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/websocket"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+type websocketSuite struct {
+	testing.LoggingSuite
+}
+
+var _ = gc.Suite(&websocketSuite{})
+
+// serve starts a websocket server that writes the given bodies to
+// each connection using a codec with the given options, and returns
+// a client connection to it.
+func (s *websocketSuite) serve(c *gc.C, opts jsoncodec.WebsocketOptions, bodies ...interface{}) *websocket.Conn {
+	srv := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		codec := jsoncodec.NewWebsocketWithOptions(conn, opts)
+		for i, body := range bodies {
+			err := codec.WriteMessage(&rpc.Header{RequestId: uint64(i + 1), Version: 1}, body)
+			c.Check(err, jc.ErrorIsNil)
+		}
+		codec.Close()
+	}))
+	s.AddCleanup(func(*gc.C) { srv.Close() })
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", "http://localhost/")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn
+}
+
+func (s *websocketSuite) TestCompressedMessages(c *gc.C) {
+	var stats jsoncodec.Stats
+	large := value{X: strings.Repeat("status ", 1000)}
+	small := value{X: "ok"}
+	conn := s.serve(c, jsoncodec.WebsocketOptions{
+		Compress: true,
+		Stats:    &stats,
+	}, large, small)
+
+	var clientStats jsoncodec.Stats
+	codec := jsoncodec.NewWebsocketWithOptions(conn, jsoncodec.WebsocketOptions{
+		Stats: &clientStats,
+	})
+	for _, expect := range []value{large, small} {
+		var hdr rpc.Header
+		err := codec.ReadHeader(&hdr)
+		c.Assert(err, jc.ErrorIsNil)
+		var body value
+		err = codec.ReadBody(&body, false)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(body, jc.DeepEquals, expect)
+	}
+
+	sent := stats.Sent()
+	c.Assert(sent.Messages, gc.Equals, uint64(2))
+	c.Assert(sent.CompressedMessages, gc.Equals, uint64(1))
+	c.Assert(sent.WireBytes < sent.PayloadBytes/10, jc.IsTrue)
+	c.Assert(clientStats.Received(), jc.DeepEquals, sent)
+}
+
+func (s *websocketSuite) TestUncompressedMessagesAreJSON(c *gc.C) {
+	// Peers that predate compression read messages with
+	// websocket.JSON, which must continue to work.
+	large := value{X: strings.Repeat("status ", 1000)}
+	conn := s.serve(c, jsoncodec.WebsocketOptions{}, large)
+
+	var msg struct {
+		RequestId uint64 `json:"request-id"`
+		Response  value  `json:"response"`
+	}
+	err := websocket.JSON.Receive(conn, &msg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg.RequestId, gc.Equals, uint64(1))
+	c.Assert(msg.Response, jc.DeepEquals, large)
+}
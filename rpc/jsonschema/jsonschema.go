@@ -0,0 +1,202 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package jsonschema generates JSON schemas describing the RPC
+// methods of API objects, as they appear when encoded by the
+// rpc/jsoncodec package.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// Schema holds a JSON schema document, in the form that encodes to
+// JSON directly.
+type Schema map[string]interface{}
+
+const definitionsPrefix = "#/definitions/"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	byteSliceType     = reflect.TypeOf([]byte(nil))
+)
+
+// ForObjType returns a JSON schema describing the RPC methods of
+// the given object type. The schema has a property for each method,
+// which in turn has "Params" and "Result" properties describing the
+// method's argument and return value, if it has them. Named struct
+// types are described once, under "definitions", and referred to
+// from elsewhere in the schema.
+func ForObjType(objType *rpcreflect.ObjType) Schema {
+	g := &generator{
+		definitions: make(map[string]Schema),
+		names:       make(map[reflect.Type]string),
+	}
+	methods := make(Schema)
+	for _, name := range objType.MethodNames() {
+		m, err := objType.Method(name)
+		if err != nil {
+			// MethodNames only returns known methods.
+			panic(err)
+		}
+		properties := make(Schema)
+		if m.Params != nil {
+			properties["Params"] = g.schema(m.Params)
+		}
+		if m.Result != nil {
+			properties["Result"] = g.schema(m.Result)
+		}
+		methods[name] = Schema{
+			"type":       "object",
+			"properties": properties,
+		}
+	}
+	schema := Schema{
+		"type":       "object",
+		"properties": methods,
+	}
+	if len(g.definitions) > 0 {
+		schema["definitions"] = g.definitions
+	}
+	return schema
+}
+
+// generator generates the schemas of Go types, recording the schemas
+// of named struct types as definitions.
+type generator struct {
+	definitions map[string]Schema
+	names       map[reflect.Type]string
+}
+
+func (g *generator) schema(t reflect.Type) Schema {
+	switch {
+	case t == timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case t == durationType:
+		return Schema{"type": "integer"}
+	case t == byteSliceType:
+		return Schema{"type": "string", "contentEncoding": "base64"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// The encoding is determined by the type's own
+		// MarshalJSON method, so we cannot describe it.
+		return Schema{}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return Schema{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Ptr:
+		return g.schema(t.Elem())
+	case reflect.Slice, reflect.Array:
+		return Schema{
+			"type":  "array",
+			"items": g.schema(t.Elem()),
+		}
+	case reflect.Map:
+		return Schema{
+			"type":                 "object",
+			"patternProperties":    Schema{".*": g.schema(t.Elem())},
+			"additionalProperties": false,
+		}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return Schema{"$ref": definitionsPrefix + g.define(t)}
+	}
+	// Interfaces, and anything else, may hold any value.
+	return Schema{}
+}
+
+// define records the schema of the named struct type t as a
+// definition, if it has not already been recorded, and returns the
+// definition's name.
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, ok := g.definitions[name]; ok {
+		// Another type has the same name, so qualify this
+		// one with its package name.
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	g.names[t] = name
+	// Record a placeholder first, so that recursive types
+	// refer to the definition rather than recursing forever.
+	g.definitions[name] = Schema{}
+	g.definitions[name] = g.structSchema(t)
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) Schema {
+	properties := make(Schema)
+	var required []string
+	g.addFields(t, properties, &required)
+	schema := Schema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+// addFields adds the schemas of the fields of the struct type t
+// to properties, following the rules used by encoding/json.
+func (g *generator) addFields(t reflect.Type, properties Schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i:]
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(ft, properties, required)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			// Unexported fields are not encoded.
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = g.schema(f.Type)
+		if !strings.Contains(opts, ",omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
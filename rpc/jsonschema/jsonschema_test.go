@@ -0,0 +1,143 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/rpc/jsonschema"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+type schemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+type Entity struct {
+	Tag string `json:"tag"`
+}
+
+type Entities struct {
+	Entities []Entity `json:"entities"`
+}
+
+type Common struct {
+	Error *Error `json:"error,omitempty"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+type Result struct {
+	Common
+	Life     string                 `json:"life"`
+	Since    time.Time              `json:"since"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+	Children []Result               `json:"children,omitempty"`
+	Hidden   string                 `json:"-"`
+	ignored  string
+}
+
+type Results struct {
+	Results []Result `json:"results"`
+}
+
+type facade struct{}
+
+func (facade) Life(Entities) (Results, error) { return Results{}, nil }
+func (facade) Ping()                          {}
+
+func (s *schemaSuite) TestForObjType(c *gc.C) {
+	schema := jsonschema.ForObjType(rpcreflect.ObjTypeOf(reflect.TypeOf(facade{})))
+
+	// Compare the JSON encoding, as that is what clients see.
+	data, err := json.Marshal(schema)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), jc.JSONEquals, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"Life": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"Params": map[string]interface{}{"$ref": "#/definitions/Entities"},
+					"Result": map[string]interface{}{"$ref": "#/definitions/Results"},
+				},
+			},
+			"Ping": map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		"definitions": map[string]interface{}{
+			"Entities": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"entities": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"$ref": "#/definitions/Entity"},
+					},
+				},
+				"additionalProperties": false,
+				"required":             []string{"entities"},
+			},
+			"Entity": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"tag": map[string]interface{}{"type": "string"},
+				},
+				"additionalProperties": false,
+				"required":             []string{"tag"},
+			},
+			"Error": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"message": map[string]interface{}{"type": "string"},
+					"code":    map[string]interface{}{"type": "string"},
+				},
+				"additionalProperties": false,
+				"required":             []string{"code", "message"},
+			},
+			"Result": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"error": map[string]interface{}{"$ref": "#/definitions/Error"},
+					"life":  map[string]interface{}{"type": "string"},
+					"since": map[string]interface{}{"type": "string", "format": "date-time"},
+					"config": map[string]interface{}{
+						"type":                 "object",
+						"patternProperties":    map[string]interface{}{".*": map[string]interface{}{}},
+						"additionalProperties": false,
+					},
+					"children": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"$ref": "#/definitions/Result"},
+					},
+				},
+				"additionalProperties": false,
+				"required":             []string{"life", "since"},
+			},
+			"Results": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"results": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"$ref": "#/definitions/Result"},
+					},
+				},
+				"additionalProperties": false,
+				"required":             []string{"results"},
+			},
+		},
+	})
+}
@@ -3,7 +3,11 @@
 
 package rpc
 
-import "sync"
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
 
 // Observer can be implemented to find out about requests occurring in
 // an RPC conn, for example to print requests for logging
@@ -28,6 +32,26 @@ type Observer interface {
 	ServerReply(req Request, hdr *Header, body interface{})
 }
 
+// RequestContexter may be implemented by an Observer that needs to
+// pass information, such as the tracing span recording a request, to
+// the server method that handles the request.
+type RequestContexter interface {
+	// RequestContext returns the context in which the server
+	// method for the request most recently passed to
+	// ServerRequest is called, derived from ctx.
+	RequestContext(ctx context.Context) context.Context
+}
+
+// RequestContext returns the context in which the server method for
+// the request most recently observed by o should be called.
+func RequestContext(o Observer) context.Context {
+	ctx := context.Background()
+	if contexter, ok := o.(RequestContexter); ok {
+		ctx = contexter.RequestContext(ctx)
+	}
+	return ctx
+}
+
 // NewObserverMultiplexer returns a new ObserverMultiplexer
 // with the provided RequestNotifiers.
 func NewObserverMultiplexer(rpcObservers ...Observer) *ObserverMultiplexer {
@@ -52,6 +76,16 @@ func (m *ObserverMultiplexer) ServerRequest(hdr *Header, body interface{}) {
 	mapConcurrent(func(n Observer) { n.ServerRequest(hdr, body) }, m.rpcObservers)
 }
 
+// RequestContext implements RequestContexter.
+func (m *ObserverMultiplexer) RequestContext(ctx context.Context) context.Context {
+	for _, o := range m.rpcObservers {
+		if contexter, ok := o.(RequestContexter); ok {
+			ctx = contexter.RequestContext(ctx)
+		}
+	}
+	return ctx
+}
+
 // mapConcurrent calls fn on all observers concurrently and then waits
 // for all calls to exit before returning.
 func mapConcurrent(fn func(Observer), requestNotifiers []Observer) {
@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -130,7 +131,7 @@ func (*reflectSuite) TestFindMethod(c *gc.C) {
 	c.Assert(m.ParamsType(), gc.Equals, reflect.TypeOf(stringVal{}))
 	c.Assert(m.ResultType(), gc.Equals, reflect.TypeOf(stringVal{}))
 
-	ret, err := m.Call("a99", reflect.ValueOf(stringVal{"foo"}))
+	ret, err := m.Call(context.Background(), "a99", reflect.ValueOf(stringVal{"foo"}))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ret.Interface(), gc.Equals, stringVal{"Call1r1e ret"})
 }
@@ -151,3 +152,31 @@ func (*reflectSuite) TestFindMethodRefusesVersionsNot0(c *gc.C) {
 	c.Assert(err, gc.FitsTypeOf, (*rpcreflect.CallNotImplementedError)(nil))
 	c.Assert(err, gc.ErrorMatches, `unknown version \(1\) of interface "SimpleMethods"`)
 }
+
+type contextKey struct{}
+
+type ContextMethods struct{}
+
+func (ContextMethods) WithContext(ctx context.Context, arg stringVal) stringVal {
+	v, _ := ctx.Value(contextKey{}).(string)
+	return stringVal{arg.Val + " " + v}
+}
+
+func (*reflectSuite) TestObjTypeOfContextMethod(c *gc.C) {
+	objType := rpcreflect.ObjTypeOf(reflect.TypeOf(ContextMethods{}))
+	c.Assert(objType.DiscardedMethods(), gc.HasLen, 0)
+	m, err := objType.Method("WithContext")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Params, gc.Equals, reflect.TypeOf(stringVal{}))
+	c.Assert(m.Result, gc.Equals, reflect.TypeOf(stringVal{}))
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "ctx")
+	ret, err := m.Call(ctx, reflect.ValueOf(ContextMethods{}), reflect.ValueOf(stringVal{"arg"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ret.Interface(), gc.Equals, stringVal{"arg ctx"})
+
+	// A nil context is passed as the background context.
+	ret, err = m.Call(nil, reflect.ValueOf(ContextMethods{}), reflect.ValueOf(stringVal{"arg"}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ret.Interface(), gc.Equals, stringVal{"arg "})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/params"
@@ -297,7 +298,7 @@ func (c customMethodCaller) ResultType() reflect.Type {
 	return c.objMethod.Result
 }
 
-func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (c customMethodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	sm, err := c.root.SimpleMethods(objId)
 	if err != nil {
 		return reflect.Value{}, err
@@ -307,7 +308,7 @@ func (c customMethodCaller) Call(objId string, arg reflect.Value) (reflect.Value
 		logger.Errorf("got the wrong type back, expected %s got %T", c.expectedType, obj)
 	}
 	logger.Debugf("calling: %T %v %#v", obj, obj, c.objMethod)
-	return c.objMethod.Call(obj, arg)
+	return c.objMethod.Call(ctx, obj, arg)
 }
 
 func (cc *CustomRoot) Kill() {
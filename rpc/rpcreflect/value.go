@@ -6,6 +6,8 @@ package rpcreflect
 import (
 	"fmt"
 	"reflect"
+
+	"golang.org/x/net/context"
 )
 
 // CallNotImplementedError is the error returned when an attempt to call to
@@ -121,12 +123,12 @@ func (v Value) Kill() {
 	}
 }
 
-func (caller methodCaller) Call(objId string, arg reflect.Value) (reflect.Value, error) {
+func (caller methodCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
 	obj, err := caller.rootMethod.Call(caller.rootValue, objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
-	return caller.objMethod.Call(obj, arg)
+	return caller.objMethod.Call(ctx, obj, arg)
 }
 
 func (caller methodCaller) ParamsType() reflect.Type {
@@ -145,6 +147,7 @@ type MethodCaller interface {
 	ResultType() reflect.Type
 
 	// Call is actually placing a call to instantiate an given instance and
-	// call the method on that instance.
-	Call(objId string, arg reflect.Value) (reflect.Value, error)
+	// call the method on that instance. The context is that of the
+	// request being served.
+	Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error)
 }
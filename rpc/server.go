@@ -64,6 +64,11 @@ type Header struct {
 
 	// Version defines the wire format of the request and response structure.
 	Version int
+
+	// TraceID holds the ID of the distributed trace that the request
+	// is part of, if any. Replies hold the trace ID of the request
+	// being replied to.
+	TraceID string
 }
 
 // Request represents an RPC to be performed, absent its parameters.
@@ -148,6 +153,9 @@ type Conn struct {
 	inputLoopError error
 
 	observerFactory ObserverFactory
+
+	// traceID holds the trace ID sent with client requests.
+	traceID string
 }
 
 // NewConn creates a new connection that uses the given codec for
@@ -433,6 +441,7 @@ func (conn *Conn) writeErrorResponse(reqHdr *Header, err error, observer Observe
 	hdr := &Header{
 		RequestId: reqHdr.RequestId,
 		Version:   reqHdr.Version,
+		TraceID:   reqHdr.TraceID,
 	}
 	if err, ok := err.(ErrorCoder); ok {
 		hdr.ErrorCode = err.ErrorCode()
@@ -487,13 +496,14 @@ func (conn *Conn) bindRequest(hdr *Header) (boundRequest, error) {
 // runRequest runs the given request and sends the reply.
 func (conn *Conn) runRequest(req boundRequest, arg reflect.Value, version int, observer Observer) {
 	defer conn.srvPending.Done()
-	rv, err := req.Call(req.hdr.Request.Id, arg)
+	rv, err := req.Call(RequestContext(observer), req.hdr.Request.Id, arg)
 	if err != nil {
 		err = conn.writeErrorResponse(&req.hdr, req.transformErrors(err), observer)
 	} else {
 		hdr := &Header{
 			RequestId: req.hdr.RequestId,
 			Version:   version,
+			TraceID:   req.hdr.TraceID,
 		}
 		var rvi interface{}
 		if rv.IsValid() {
@@ -91,6 +91,10 @@ type MachineTemplate struct {
 	// with the machine.
 	Placement string
 
+	// Pool holds the id of the machine pool that the new machine
+	// will be reserved for, if any.
+	Pool string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -114,7 +118,11 @@ type MachineFilesystemParams struct {
 // of the given type inside another new machine. The two given templates
 // specify the form of the child and parent respectively.
 func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType)
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add a new machine")
+	}
+	mdoc, ops, err := st.addMachineInsideNewMachineOps(template, parentTemplate, containerType, family)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
@@ -124,7 +132,11 @@ func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemp
 // AddMachineInsideMachine adds a machine inside a container of the
 // given type on the existing machine with id=parentId.
 func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId string, containerType instance.ContainerType) (*Machine, error) {
-	mdoc, ops, err := st.addMachineInsideMachineOps(template, parentId, containerType)
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add a new machine")
+	}
+	mdoc, ops, err := st.addMachineInsideMachineOps(template, parentId, containerType, family)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
@@ -161,8 +173,12 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 	var ms []*Machine
 	var ops []txn.Op
 	var mdocs []*machineDoc
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	for _, template := range templates {
-		mdoc, addOps, err := st.addMachineOps(template)
+		mdoc, addOps, err := st.addMachineOps(template, family)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -257,7 +273,7 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 // addMachineOps returns operations to add a new top level machine
 // based on the given template. It also returns the machine document
 // that will be inserted.
-func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op, error) {
+func (st *State) addMachineOps(template MachineTemplate, family network.IPFamily) (*machineDoc, []txn.Op, error) {
 	template, err := st.effectiveMachineTemplate(template, st.IsController())
 	if err != nil {
 		return nil, nil, err
@@ -271,7 +287,7 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq))
+	mdoc := st.machineDocForTemplate(template, strconv.Itoa(seq), family)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -323,7 +339,7 @@ func (m *Machine) supportsContainerType(ctype instance.ContainerType) bool {
 
 // addMachineInsideMachineOps returns operations to add a machine inside
 // a container of the given type on an existing machine.
-func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId string, containerType instance.ContainerType) (*machineDoc, []txn.Op, error) {
+func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId string, containerType instance.ContainerType, family network.IPFamily) (*machineDoc, []txn.Op, error) {
 	if template.InstanceId != "" {
 		return nil, nil, errors.New("cannot specify instance id for a new container")
 	}
@@ -349,7 +365,7 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc := st.machineDocForTemplate(template, newId, family)
 	mdoc.ContainerType = string(containerType)
 	prereqOps, machineOp, err := st.insertNewMachineOps(mdoc, template)
 	if err != nil {
@@ -378,7 +394,7 @@ func (st *State) newContainerId(parentId string, containerType instance.Containe
 // machine within a container of the given type inside another
 // new machine. The two given templates specify the form
 // of the child and parent respectively.
-func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineTemplate, containerType instance.ContainerType) (*machineDoc, []txn.Op, error) {
+func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineTemplate, containerType instance.ContainerType, family network.IPFamily) (*machineDoc, []txn.Op, error) {
 	if template.InstanceId != "" || parentTemplate.InstanceId != "" {
 		return nil, nil, errors.New("cannot specify instance id for a new container")
 	}
@@ -399,7 +415,7 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 		}
 	}
 
-	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq))
+	parentDoc := st.machineDocForTemplate(parentTemplate, strconv.Itoa(seq), family)
 	newId, err := st.newContainerId(parentDoc.Id, containerType)
 	if err != nil {
 		return nil, nil, err
@@ -408,7 +424,7 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if err != nil {
 		return nil, nil, err
 	}
-	mdoc := st.machineDocForTemplate(template, newId)
+	mdoc := st.machineDocForTemplate(template, newId, family)
 	mdoc.ContainerType = string(containerType)
 	parentPrereqOps, parentOp, err := st.insertNewMachineOps(parentDoc, parentTemplate)
 	if err != nil {
@@ -428,13 +444,13 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	return mdoc, append(prereqOps, parentOp, machineOp), nil
 }
 
-func (st *State) machineDocForTemplate(template MachineTemplate, id string) *machineDoc {
+func (st *State) machineDocForTemplate(template MachineTemplate, id string, family network.IPFamily) *machineDoc {
 	// We ignore the error from Select*Address as an error indicates
 	// no address is available, in which case the empty address is returned
 	// and setting the preferred address to an empty one is the correct
 	// thing to do when none is available.
-	privateAddr, _ := network.SelectInternalAddress(template.Addresses, false)
-	publicAddr, _ := network.SelectPublicAddress(template.Addresses)
+	privateAddr, _ := network.SelectInternalAddressForFamily(template.Addresses, false, family)
+	publicAddr, _ := network.SelectPublicAddressForFamily(template.Addresses, family)
 	logger.Infof(
 		"new machine %q has preferred addresses: private %q, public %q",
 		id, privateAddr, publicAddr,
@@ -454,6 +470,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		Pool:                    template.Pool,
 	}
 }
 
@@ -480,14 +497,21 @@ func (st *State) insertNewMachineOps(mdoc *machineDoc, template MachineTemplate)
 		template.Constraints,
 	)
 
-	storageOps, volumeAttachments, filesystemAttachments, err := st.machineStorageOps(
-		mdoc, &machineStorageParams{
-			filesystems:           template.Filesystems,
-			filesystemAttachments: template.FilesystemAttachments,
-			volumes:               template.Volumes,
-			volumeAttachments:     template.VolumeAttachments,
-		},
-	)
+	storageParams := &machineStorageParams{
+		filesystems:           template.Filesystems,
+		filesystemAttachments: template.FilesystemAttachments,
+		volumes:               template.Volumes,
+		volumeAttachments:     template.VolumeAttachments,
+	}
+	storagePools, err := machineStoragePools(st, storageParams)
+	if err != nil {
+		return nil, txn.Op{}, errors.Trace(err)
+	}
+	containerType := instance.ContainerType(mdoc.ContainerType)
+	if err := validateHostLoopStoragePools(st, storagePools, containerType); err != nil {
+		return nil, txn.Op{}, errors.Trace(err)
+	}
+	storageOps, volumeAttachments, filesystemAttachments, err := st.machineStorageOps(mdoc, storageParams)
 	if err != nil {
 		return nil, txn.Op{}, errors.Trace(err)
 	}
@@ -755,6 +779,10 @@ func (st *State) EnableHA(
 	if numControllers > replicaset.MaxPeers {
 		return ControllersChanges{}, errors.Errorf("controller count is too large (allowed %d)", replicaset.MaxPeers)
 	}
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return ControllersChanges{}, errors.Annotate(err, "failed to create new controller machines")
+	}
 	var change ControllersChanges
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		currentInfo, err := st.ControllerInfo()
@@ -801,7 +829,7 @@ func (st *State) EnableHA(
 		logger.Infof("%d new machines; promoting %v; converting %v", intent.newCount, intent.promote, intent.convert)
 
 		var ops []txn.Op
-		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series)
+		ops, change, err = st.enableHAIntentionOps(intent, currentInfo, cons, series, family)
 		return ops, err
 	}
 	if err := st.run(buildTxn); err != nil {
@@ -827,6 +855,7 @@ func (st *State) enableHAIntentionOps(
 	currentInfo *ControllerInfo,
 	cons constraints.Value,
 	series string,
+	family network.IPFamily,
 ) ([]txn.Op, ControllersChanges, error) {
 	var ops []txn.Op
 	var change ControllersChanges
@@ -866,7 +895,7 @@ func (st *State) enableHAIntentionOps(
 			Constraints: cons,
 			Placement:   getPlacement(),
 		}
-		mdoc, addOps, err := st.addMachineOps(template)
+		mdoc, addOps, err := st.addMachineOps(template, family)
 		if err != nil {
 			return nil, ControllersChanges{}, err
 		}
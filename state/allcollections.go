@@ -136,6 +136,20 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds the controller local groups of users,
+		// and their members.
+		groupsC: {
+			global: true,
+		},
+
+		// This collection holds the API tokens minted for users, which
+		// grant limited access to a single model until they expire or
+		// are removed.
+		apiTokensC: {
+			global:  true,
+			indexes: []mgo.Index{{Key: []string{"user"}}},
+		},
+
 		// This collection holds users that are relative to controllers.
 		controllerUsersC: {
 			global: true,
@@ -271,6 +285,18 @@ func allCollections() collectionSchema {
 		},
 		relationScopesC: {},
 
+		// This collection holds the progress of charm upgrades that
+		// are applied to an application's units in batches.
+		rollingUpgradesC: {},
+
+		// This collection holds the charms that applications ran
+		// before their most recent upgrades.
+		charmHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application", "seq"},
+			}},
+		},
+
 		// -----
 
 		// These collections hold information associated with machines.
@@ -280,6 +306,10 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the pools of machines that are
+		// provisioned ahead of demand for unit placement.
+		machinePoolsC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -440,12 +470,14 @@ const (
 	actionresultsC           = "actionresults"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
+	apiTokensC               = "apitokens"
 	autocertCacheC           = "autocertCache"
 	assignUnitC              = "assignUnits"
 	auditingC                = "audit.log"
 	bakeryStorageItemsC      = "bakeryStorageItems"
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
+	charmHistoryC            = "charmhistory"
 	charmsC                  = "charms"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
@@ -458,12 +490,14 @@ const (
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
 	globalSettingsC          = "globalSettings"
+	groupsC                  = "groups"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
 	machineRemovalsC         = "machineremovals"
+	machinePoolsC            = "machinepools"
 	meterStatusC             = "meterStatus"
 	metricsC                 = "metrics"
 	metricsManagerC          = "metricsmanager"
@@ -484,6 +518,7 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	rollingUpgradesC         = "rollingupgrades"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// APIToken represents a token that allows its user to access a single
+// model with limited access until the token expires or is removed.
+// The token itself is a macaroon held by the client; the record kept
+// here is what determines whether the token is still valid.
+type APIToken struct {
+	st  *State
+	doc apiTokenDoc
+}
+
+type apiTokenDoc struct {
+	DocID       string    `bson:"_id"`
+	User        string    `bson:"user"`
+	Model       string    `bson:"model"`
+	Access      string    `bson:"access"`
+	Facades     []string  `bson:"facades,omitempty"`
+	Expires     time.Time `bson:"expires"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+// AddAPITokenArgs holds the parameters for adding an API token.
+type AddAPITokenArgs struct {
+	// User is the user on whose behalf the token acts.
+	User names.UserTag
+
+	// Model is the only model the token may be used with.
+	Model names.ModelTag
+
+	// Access is the greatest access to the model that the token
+	// grants. The user's own access to the model still applies.
+	Access permission.Access
+
+	// Facades, if non-empty, holds the names of the only facades
+	// that may be used with the token.
+	Facades []string
+
+	// Expires holds the time at which the token expires.
+	Expires time.Time
+
+	// CreatedBy is the user that created the token.
+	CreatedBy names.UserTag
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args AddAPITokenArgs) Validate() error {
+	if !args.User.IsLocal() {
+		return errors.NotValidf("non-local user %q", args.User.Id())
+	}
+	if args.Model.Id() == "" {
+		return errors.NotValidf("empty model")
+	}
+	if err := permission.ValidateModelAccess(args.Access); err != nil {
+		return errors.Trace(err)
+	}
+	if args.Expires.IsZero() {
+		return errors.NotValidf("zero expiry time")
+	}
+	return nil
+}
+
+// AddAPIToken adds a record for a new API token with the specified
+// parameters, and returns it.
+func (st *State) AddAPIToken(args AddAPITokenArgs) (*APIToken, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot add API token")
+	}
+	now := st.NowToTheSecond()
+	if !args.Expires.After(now) {
+		return nil, errors.Errorf("cannot add API token: expiry time %v is in the past", args.Expires)
+	}
+	if _, err := st.User(args.User); err != nil {
+		return nil, errors.Annotate(err, "cannot add API token")
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	token := &APIToken{
+		st: st,
+		doc: apiTokenDoc{
+			DocID:       uuid.String(),
+			User:        userAccessID(args.User),
+			Model:       args.Model.Id(),
+			Access:      string(args.Access),
+			Facades:     args.Facades,
+			Expires:     args.Expires.Round(time.Second).UTC(),
+			CreatedBy:   args.CreatedBy.Id(),
+			DateCreated: now,
+		},
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     userAccessID(args.User),
+		Assert: txn.DocExists,
+	}, {
+		C:      apiTokensC,
+		Id:     token.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &token.doc,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("user %q", args.User.Id())
+		}
+		return nil, errors.Annotate(err, "cannot add API token")
+	}
+	return token, nil
+}
+
+// APIToken returns the API token with the specified ID.
+func (st *State) APIToken(id string) (*APIToken, error) {
+	tokens, closer := st.getCollection(apiTokensC)
+	defer closer()
+
+	token := &APIToken{st: st}
+	err := tokens.FindId(id).One(&token.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("API token %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get API token %q", id)
+	}
+	token.fixTimes()
+	return token, nil
+}
+
+// UserAPITokens returns the API tokens of the specified user, ordered
+// by expiry time.
+func (st *State) UserAPITokens(user names.UserTag) ([]*APIToken, error) {
+	tokens, closer := st.getCollection(apiTokensC)
+	defer closer()
+
+	var docs []apiTokenDoc
+	query := bson.D{{"user", userAccessID(user)}}
+	if err := tokens.Find(query).Sort("expires").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get API tokens for %q", user.Id())
+	}
+	result := make([]*APIToken, len(docs))
+	for i, doc := range docs {
+		result[i] = &APIToken{st: st, doc: doc}
+		result[i].fixTimes()
+	}
+	return result, nil
+}
+
+// RemoveAPIToken removes the API token with the specified ID, so that
+// it can no longer be used.
+func (st *State) RemoveAPIToken(id string) error {
+	ops := []txn.Op{{
+		C:      apiTokensC,
+		Id:     id,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("API token %q", id)
+	}
+	return errors.Trace(err)
+}
+
+func (t *APIToken) fixTimes() {
+	t.doc.Expires = t.doc.Expires.UTC()
+	t.doc.DateCreated = t.doc.DateCreated.UTC()
+}
+
+// ID returns the unique identifier of the token.
+func (t *APIToken) ID() string {
+	return t.doc.DocID
+}
+
+// User returns the tag of the user on whose behalf the token acts.
+func (t *APIToken) User() names.UserTag {
+	return names.NewUserTag(t.doc.User)
+}
+
+// ModelTag returns the tag of the model the token may be used with.
+func (t *APIToken) ModelTag() names.ModelTag {
+	return names.NewModelTag(t.doc.Model)
+}
+
+// Access returns the greatest access to the model the token grants.
+func (t *APIToken) Access() permission.Access {
+	return permission.Access(t.doc.Access)
+}
+
+// Facades returns the names of the only facades that may be used with
+// the token. If empty, the token is not restricted by facade.
+func (t *APIToken) Facades() []string {
+	return t.doc.Facades
+}
+
+// Expires returns the time at which the token expires, in UTC.
+func (t *APIToken) Expires() time.Time {
+	return t.doc.Expires
+}
+
+// Expired reports whether the token had expired at the specified time.
+func (t *APIToken) Expired(now time.Time) bool {
+	return !now.Before(t.doc.Expires)
+}
+
+// CreatedBy returns the name of the user that created the token.
+func (t *APIToken) CreatedBy() string {
+	return t.doc.CreatedBy
+}
+
+// DateCreated returns when the token was created, in UTC.
+func (t *APIToken) DateCreated() time.Time {
+	return t.doc.DateCreated
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type apiTokenSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&apiTokenSuite{})
+
+func (s *apiTokenSuite) addTokenArgs(c *gc.C) state.AddAPITokenArgs {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred"})
+	return state.AddAPITokenArgs{
+		User:      user.UserTag(),
+		Model:     s.State.ModelTag(),
+		Access:    permission.ReadAccess,
+		Facades:   []string{"Client"},
+		Expires:   time.Now().Add(time.Hour).Round(time.Second).UTC(),
+		CreatedBy: s.Owner,
+	}
+}
+
+func (s *apiTokenSuite) TestAddAPIToken(c *gc.C) {
+	args := s.addTokenArgs(c)
+	token, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.ID(), gc.Not(gc.Equals), "")
+	c.Assert(token.User(), gc.Equals, names.NewUserTag("fred"))
+	c.Assert(token.ModelTag(), gc.Equals, s.State.ModelTag())
+	c.Assert(token.Access(), gc.Equals, permission.ReadAccess)
+	c.Assert(token.Facades(), jc.DeepEquals, []string{"Client"})
+	c.Assert(token.CreatedBy(), gc.Equals, s.Owner.Id())
+	c.Assert(token.Expired(time.Now()), jc.IsFalse)
+	c.Assert(token.Expired(args.Expires), jc.IsTrue)
+
+	token, err = s.State.APIToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(token.User(), gc.Equals, names.NewUserTag("fred"))
+	c.Assert(token.Expires(), gc.Equals, args.Expires)
+}
+
+func (s *apiTokenSuite) TestAddAPITokenInvalidAccess(c *gc.C) {
+	args := s.addTokenArgs(c)
+	args.Access = permission.SuperuserAccess
+	_, err := s.State.AddAPIToken(args)
+	c.Assert(err, gc.ErrorMatches, `cannot add API token: "superuser" model access not valid`)
+}
+
+func (s *apiTokenSuite) TestAddAPITokenExpired(c *gc.C) {
+	args := s.addTokenArgs(c)
+	args.Expires = time.Now().Add(-time.Hour)
+	_, err := s.State.AddAPIToken(args)
+	c.Assert(err, gc.ErrorMatches, `cannot add API token: expiry time .* is in the past`)
+}
+
+func (s *apiTokenSuite) TestAddAPITokenNoUser(c *gc.C) {
+	args := s.addTokenArgs(c)
+	args.User = names.NewUserTag("bob")
+	_, err := s.State.AddAPIToken(args)
+	c.Assert(err, gc.ErrorMatches, `cannot add API token: user "bob" not found`)
+}
+
+func (s *apiTokenSuite) TestUserAPITokens(c *gc.C) {
+	args := s.addTokenArgs(c)
+	later := args
+	later.Expires = args.Expires.Add(time.Hour)
+	token1, err := s.State.AddAPIToken(later)
+	c.Assert(err, jc.ErrorIsNil)
+	token2, err := s.State.AddAPIToken(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tokens, err := s.State.UserAPITokens(names.NewUserTag("fred"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 2)
+	c.Assert(tokens[0].ID(), gc.Equals, token2.ID())
+	c.Assert(tokens[1].ID(), gc.Equals, token1.ID())
+
+	tokens, err = s.State.UserAPITokens(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tokens, gc.HasLen, 0)
+}
+
+func (s *apiTokenSuite) TestRemoveAPIToken(c *gc.C) {
+	token, err := s.State.AddAPIToken(s.addTokenArgs(c))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveAPIToken(token.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.APIToken(token.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveAPIToken(token.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
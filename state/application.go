@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -52,6 +53,13 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+	RollingUpgradeBatch  int        `bson:"rollingupgradebatch,omitempty"`
+
+	// ExposedEndpoints restricts the sources that may access the
+	// ports opened by the application's units while it is exposed.
+	// The wildcard endpoint is stored under exposedWildcardKey, as
+	// MongoDB does not allow empty field names.
+	ExposedEndpoints map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -300,10 +308,42 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeLeadershipSettingsOp(name),
 		removeStatusOp(a.st, globalKey),
 		removeModelApplicationRefOp(a.st, name),
+		removeRollingUpgradeOp(a.st, name),
 	)
+	historyOps, err := removeAllCharmHistoryOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
 	return ops, nil
 }
 
+// WildcardEndpoint is the name used to refer to all of an
+// application's endpoints in its expose settings.
+const WildcardEndpoint = ""
+
+// exposedWildcardKey is the key under which the expose settings of
+// the wildcard endpoint are stored.
+const exposedWildcardKey = "*"
+
+// ExposedEndpoint holds the expose settings of an application
+// endpoint. An endpoint exposed without any spaces or CIDRs may be
+// accessed from anywhere.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may access the endpoint's ports.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs that may access the endpoint's
+	// ports.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllowsAll returns true if the endpoint may be accessed from anywhere.
+func (e ExposedEndpoint) AllowsAll() bool {
+	return len(e.ExposeToSpaces) == 0 && len(e.ExposeToCIDRs) == 0
+}
+
 // IsExposed returns whether this application is exposed. The explicitly open
 // ports (with open-port) for exposed applications may be accessed from machines
 // outside of the local deployment network. See SetExposed and ClearExposed.
@@ -311,29 +351,122 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
+// ExposedEndpoints returns the expose settings of the application's
+// endpoints, keyed by endpoint name, with WildcardEndpoint standing
+// for all endpoints. An exposed application without expose settings
+// may be accessed from anywhere.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for key, settings := range a.doc.ExposedEndpoints {
+		if key == exposedWildcardKey {
+			key = WildcardEndpoint
+		}
+		result[key] = settings
+	}
+	return result
+}
+
 // SetExposed marks the application as exposed.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag from the application, along
+// with its expose settings.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.runTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// MergeExposeSettings marks the application as exposed, and replaces
+// the expose settings of the given endpoints, leaving those of other
+// endpoints unchanged. Only settings for WildcardEndpoint are
+// currently supported.
+func (a *Application) MergeExposeSettings(exposed map[string]ExposedEndpoint) error {
+	if err := a.validateExposeSettings(exposed); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	merged := make(map[string]ExposedEndpoint)
+	for key, settings := range a.doc.ExposedEndpoints {
+		merged[key] = settings
+	}
+	for endpoint, settings := range exposed {
+		if endpoint == WildcardEndpoint {
+			endpoint = exposedWildcardKey
+		}
+		merged[endpoint] = settings
+	}
+	if err := a.setExposeSettings(true, merged); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", a)
+	}
+	return nil
+}
+
+func (a *Application) setExposeSettings(exposed bool, settings map[string]ExposedEndpoint) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{
+			{"exposed", exposed},
+			{"exposed-endpoints", settings},
+		}}},
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = settings
+	return nil
+}
+
+// validateExposeSettings checks that the expose settings are for the
+// wildcard endpoint only, and that the spaces and CIDRs they name exist
+// and are well formed. Opened ports are not associated with endpoints,
+// so settings for individual endpoints could not be honoured.
+func (a *Application) validateExposeSettings(exposed map[string]ExposedEndpoint) error {
+	for endpoint, settings := range exposed {
+		if endpoint != WildcardEndpoint {
+			return errors.NewNotSupported(nil, fmt.Sprintf(
+				"exposing endpoint %q on its own not supported: opened ports are not associated with endpoints", endpoint,
+			))
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+		for _, spaceName := range settings.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -768,6 +901,14 @@ func (a *Application) changeCharmOps(
 		return nil, errors.Trace(err)
 	}
 
+	// Record the charm being replaced, so that the application can
+	// be rolled back to it.
+	historyOps, err := recordCharmHistoryOps(a, oldSettings)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, historyOps...)
+
 	// And finally, decrement the old charm and settings.
 	return append(ops, decOps...), nil
 }
@@ -855,6 +996,10 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// RollingUpgrade, if its batch size is non-zero, causes existing
+	// units to be upgraded in batches rather than all at once.
+	RollingUpgrade RollingUpgradeArgs
 }
 
 // SetCharm changes the charm for the application.
@@ -918,6 +1063,9 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	if err != nil {
 		return errors.Annotate(err, "validating config settings")
 	}
+	if err := cfg.RollingUpgrade.Validate(); err != nil {
+		return errors.Trace(err)
+	}
 
 	var newCharmModifiedVersion int
 	channel := string(cfg.Channel)
@@ -977,6 +1125,12 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
+
+			rollingOps, err := setRollingUpgradeOps(a, cfg.Charm.URL(), cfg.RollingUpgrade)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, rollingOps...)
 		}
 
 		return ops, nil
@@ -1536,6 +1690,185 @@ func (a *Application) EndpointBindings() (map[string]string, error) {
 	return bindings, nil
 }
 
+// SetEndpointBindings binds the given endpoints of the application to
+// the given spaces, leaving the bindings of other endpoints unchanged.
+// Every machine hosting a unit of the application, including containers
+// that are already running, must have an address in each newly bound
+// space.
+//
+// The application's units run the config-changed hook, and the ingress
+// addresses each unit publishes in the settings of relations on a
+// rebound endpoint are updated to its address in the new space, so that
+// related units run the relation-changed hook.
+func (a *Application) SetEndpointBindings(bindings map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set endpoint bindings for application %q", a)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		current, err := a.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changed := make(map[string]string)
+		for endpoint, space := range bindings {
+			if old, ok := current[endpoint]; !ok || old != space {
+				changed[endpoint] = space
+			}
+		}
+		if len(changed) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		ch, _, err := a.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bindingsOp, err := updateEndpointBindingsOp(a.st, a.globalKey(), changed, ch.Meta())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}, bindingsOp, touchSettingsOp(settingsC, a.settingsKey())}
+
+		units, err := a.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		relations, err := a.Relations()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			addresses, err := unitAddressesInSpaces(unit, changed)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			relationOps, err := rebindRelationSettingsOps(unit, relations, changed, addresses)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, relationOps...)
+		}
+		return ops, nil
+	}
+	return a.st.run(buildTxn)
+}
+
+// unitAddressesInSpaces returns the address of the unit's machine in
+// each of the spaces the given endpoints are bound to. An error is
+// returned if the machine has no address in one of the spaces, as the
+// unit would have no address to publish in it. Units not yet assigned
+// to a machine are not checked.
+func unitAddressesInSpaces(unit *Unit, bindings map[string]string) (map[string]string, error) {
+	addresses := make(map[string]string)
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return addresses, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := unit.st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if _, ok := addresses[space]; ok {
+			continue
+		}
+		address, err := machineAddressInSpace(machine, space)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if address == "" {
+			return nil, errors.Errorf("machine %s of unit %q has no address in space %q", machineId, unit, space)
+		}
+		addresses[space] = address
+	}
+	return addresses, nil
+}
+
+// machineAddressInSpace returns the first of the machine's addresses
+// in the named space, or "" if it has none.
+func machineAddressInSpace(m *Machine, space string) (string, error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", errors.Trace(err)
+		}
+		if subnet.SpaceName() == space {
+			return addr.Value(), nil
+		}
+	}
+	return "", nil
+}
+
+// ingressAddressKeys holds the keys of the relation settings, other
+// than private-address, in which a unit may publish the address related
+// units should use to reach it.
+var ingressAddressKeys = []string{"ingress-address"}
+
+// rebindRelationSettingsOps returns the operations needed to publish the
+// unit's address in the newly bound space, in the settings of each
+// relation the unit is in on one of the rebound endpoints.
+func rebindRelationSettingsOps(unit *Unit, relations []*Relation, bindings map[string]string, addresses map[string]string) ([]txn.Op, error) {
+	var ops []txn.Op
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(unit.ApplicationName())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		space, ok := bindings[ep.Name]
+		if !ok {
+			continue
+		}
+		address := addresses[space]
+		if address == "" {
+			continue
+		}
+		ru, err := rel.Unit(unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err := readSettings(unit.st, settingsC, ru.key())
+		if errors.IsNotFound(err) {
+			// The unit has not entered the relation scope.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Juju always publishes private-address; the other ingress
+		// addresses are refreshed if the unit has published them.
+		settings.Set("private-address", address)
+		for _, key := range ingressAddressKeys {
+			if _, ok := settings.Get(key); ok {
+				settings.Set(key, address)
+			}
+		}
+		_, settingsOps := settings.settingsUpdateOps()
+		ops = append(ops, settingsOps...)
+	}
+	return ops, nil
+}
+
 // defaultEndpointBindings returns a map with each endpoint from the current
 // charm metadata bound to an empty space. If no charm URL is set yet, it
 // returns an empty map.
@@ -1704,6 +2037,7 @@ func (a *Application) deriveStatus(units []*Unit) (status.StatusInfo, error) {
 // Status values with higher severity are used in preference to others.
 var statusServerities = map[status.Status]int{
 	status.Error:       100,
+	status.Unhealthy:   95,
 	status.Blocked:     90,
 	status.Waiting:     80,
 	status.Maintenance: 70,
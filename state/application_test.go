@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -1380,6 +1381,68 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("admin", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToSpaces: []string{"admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToSpaces: []string{"admin"}},
+	}
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, expected)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": exposing endpoint "server" on its own not supported: opened ports are not associated with endpoints`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": CIDR "10.0.0.0" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToSpaces: []string{"missing"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot expose application "mysql": space "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestClearExposedRemovesExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit()
@@ -2132,6 +2195,103 @@ func (s *ApplicationSuite) TestWatchService(c *gc.C) {
 	testing.NewNotifyWatcherC(c, s.State, w).AssertOneChange()
 }
 
+func (s *ApplicationSuite) TestWatchApplicationCharms(c *gc.C) {
+	w := s.State.WatchApplicationCharms()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	// Upgrading the charm triggers an event.
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:      sch,
+		ForceUnits: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+
+	// Other changes to the application do not.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// Adding and removing an application do.
+	app := s.AddTestingService(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	wc.AssertChange("lxd-profile")
+	wc.AssertNoChange()
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("lxd-profile")
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
+func (s *ApplicationSuite) TestWatchUnitAssignments(c *gc.C) {
+	m0, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchUnitAssignments()
+	defer testing.AssertStop(c, w)
+
+	// Initial event.
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(m0.Id())
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine triggers an event.
+	m1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	unit1, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	err = unit1.AssignToMachine(m1)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(m1.Id())
+	wc.AssertNoChange()
+
+	// Other changes to the unit do not.
+	err = unit1.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// A subordinate joining the unit does.
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("mysql", "logging")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(m1.Id())
+	wc.AssertNoChange()
+
+	// And so does the removal of a unit.
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(m0.Id())
+	wc.AssertNoChange()
+
+	testing.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *ApplicationSuite) TestMetricCredentials(c *gc.C) {
 	err := s.mysql.SetMetricCredentials([]byte("hello there"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -2614,3 +2774,128 @@ func (s *ApplicationSuite) TestSetCharmHandlesMissingBindingsAsDefaults(c *gc.C)
 
 	s.assertApplicationRemovedWithItsBindings(c, service)
 }
+
+func (s *ApplicationSuite) addUnitWithAddress(c *gc.C, address string) *state.Unit {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  address,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *ApplicationSuite) addSpacesForBindings(c *gc.C) {
+	for name, cidr := range map[string]string{"db": "10.0.0.0/24", "public": "10.1.0.0/24"} {
+		_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: cidr})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = s.State.AddSpace(name, "", []string{cidr}, false)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindings(c *gc.C) {
+	s.addSpacesForBindings(c)
+	s.addUnitWithAddress(c, "10.0.0.5/24")
+
+	err := s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "db")
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsNoAddressInSpace(c *gc.C) {
+	s.addSpacesForBindings(c)
+	s.addUnitWithAddress(c, "10.0.0.5/24")
+
+	err := s.mysql.SetEndpointBindings(map[string]string{"server": "public"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": `+
+		`machine 0 of unit "mysql/0" has no address in space "public"`)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsNoAddressInSpaceContainer(c *gc.C) {
+	s.addSpacesForBindings(c)
+	host := s.addUnitWithAddress(c, "10.0.0.5/24")
+	hostId, err := host.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, hostId, instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.mysql.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The host has an address in the space, but the running
+	// container would not be given one, so the rebind is refused.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": `+
+		`machine 0/lxd/0 of unit "mysql/1" has no address in space "db"`)
+	bindings, err := s.mysql.EndpointBindings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bindings["server"], gc.Equals, "")
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsUnknownEndpoint(c *gc.C) {
+	s.addSpacesForBindings(c)
+
+	err := s.mysql.SetEndpointBindings(map[string]string{"missing": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot set endpoint bindings for application "mysql": unknown endpoint "missing" not valid`)
+}
+
+func (s *ApplicationSuite) TestSetEndpointBindingsRefreshesRelationSettings(c *gc.C) {
+	s.addSpacesForBindings(c)
+	unit := s.addUnitWithAddress(c, "10.0.0.5/24")
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(map[string]interface{}{
+		"private-address": "192.168.0.5",
+		"ingress-address": "192.168.0.5",
+		"hostname":        "mysql-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := unit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	settings, err := ru.ReadSettings("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"private-address": "10.0.0.5",
+		"ingress-address": "10.0.0.5",
+		"hostname":        "mysql-0",
+	})
+
+	// Rebinding to the same space changes nothing.
+	err = s.mysql.SetEndpointBindings(map[string]string{"server": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -85,7 +85,12 @@ func (s *applicationDirectory) offerAtURL(url string) (*applicationOfferDoc, err
 // Remove deletes the application offer at url immediately.
 func (s *applicationDirectory) Remove(url string) (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot delete application offer %q", url)
-	err = s.st.runTransaction(s.removeOps(url))
+	ops := s.removeOps(url)
+	accessOps, err := s.st.removeOfferAccessOps(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = s.st.runTransaction(append(ops, accessOps...))
 	if err == txn.ErrAborted {
 		// Already deleted.
 		return nil
@@ -253,6 +258,8 @@ func (s *applicationDirectory) makeFilterTerm(filterTerm crossmodel.ApplicationO
 }
 
 // ListOffers returns the application offers matching any one of the filter terms.
+// If any of the filter terms specify allowed users, only those offers which
+// at least one of the allowed users has permission to read are returned.
 func (s *applicationDirectory) ListOffers(filter ...crossmodel.ApplicationOfferFilter) ([]crossmodel.ApplicationOffer, error) {
 	applicationOffersCollection, closer := s.st.getCollection(localApplicationDirectoryC)
 	defer closer()
@@ -276,13 +283,41 @@ func (s *applicationDirectory) ListOffers(filter ...crossmodel.ApplicationOfferF
 		return nil, errors.Annotate(err, "cannot find application offers")
 	}
 	sort.Sort(srSlice(docs))
-	offers := make([]crossmodel.ApplicationOffer, len(docs))
-	for i, doc := range docs {
-		offers[i] = s.makeApplicationOffer(doc)
+	allowedUsers, err := allowedUserTags(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offers := make([]crossmodel.ApplicationOffer, 0, len(docs))
+	for _, doc := range docs {
+		if len(allowedUsers) > 0 {
+			ok, err := s.st.offerAccessibleBy(doc.URL, allowedUsers)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !ok {
+				continue
+			}
+		}
+		offers = append(offers, s.makeApplicationOffer(doc))
 	}
 	return offers, nil
 }
 
+// allowedUserTags returns the tags of the allowed users
+// specified in any of the filter terms.
+func allowedUserTags(filter []crossmodel.ApplicationOfferFilter) ([]names.UserTag, error) {
+	var users []names.UserTag
+	for _, term := range filter {
+		for _, user := range term.AllowedUsers {
+			if !names.IsValidUser(user) {
+				return nil, errors.NotValidf("user name %q", user)
+			}
+			users = append(users, names.NewUserTag(user))
+		}
+	}
+	return users, nil
+}
+
 func (s *applicationDirectory) makeApplicationOffer(doc applicationOfferDoc) crossmodel.ApplicationOffer {
 	offer := crossmodel.ApplicationOffer{
 		ApplicationURL:         doc.URL,
@@ -10,7 +10,9 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
 )
 
 type applicationDirectorySuite struct {
@@ -312,3 +314,37 @@ func (s *remoteApplicationSuite) TestUpdateApplicationOfferRemovedAfterInitial(c
 	})
 	c.Assert(err, gc.ErrorMatches, `cannot update application offer "mysql": application offer "local:/u/me/application" not found`)
 }
+
+func (s *applicationDirectorySuite) TestListOffersAllowedUsers(c *gc.C) {
+	sd := state.NewApplicationDirectory(s.State)
+	offer := s.createOffer(c, "offer1", "description for offer1", "uuid-1", "label")
+	s.createOffer(c, "offer2", "description for offer2", "uuid-2", "label")
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true})
+	err := s.State.CreateOfferAccess(offer.ApplicationURL, user.UserTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	offers, err := sd.ListOffers(crossmodel.ApplicationOfferFilter{
+		AllowedUsers: []string{"fred"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, jc.DeepEquals, []crossmodel.ApplicationOffer{offer})
+
+	offers, err = sd.ListOffers(crossmodel.ApplicationOfferFilter{
+		AllowedUsers: []string{"mary"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(offers, gc.HasLen, 0)
+}
+
+func (s *applicationDirectorySuite) TestRemoveRemovesOfferAccess(c *gc.C) {
+	offer := s.createDefaultOffer(c)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", NoModelUser: true})
+	err := s.State.CreateOfferAccess(offer.ApplicationURL, user.UserTag(), permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sd := state.NewApplicationDirectory(s.State)
+	err = sd.Remove(offer.ApplicationURL)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.GetOfferAccess(offer.ApplicationURL, user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -75,14 +75,18 @@ func (st *State) strictLocalID(ID string) (string, error) {
 // If the collection stores documents for multiple models, the
 // returned collection will automatically perform model
 // filtering where possible. See modelStateCollection below.
+//
+// If the state is used on behalf of a traced request, the queries
+// run on the collection are traced as well.
 func (st *State) getCollection(name string) (mongo.Collection, func()) {
-	return st.database.GetCollection(name)
+	collection, closer := st.database.GetCollection(name)
+	return st.traceCollection(collection), closer
 }
 
 func (st *State) getCollectionFor(modelUUID, name string) (mongo.Collection, func()) {
 	database, dbcloser := st.database.CopyForModel(modelUUID)
 	collection, closer := database.GetCollection(name)
-	return collection, func() {
+	return st.traceCollection(collection), func() {
 		closer()
 		dbcloser()
 	}
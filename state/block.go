@@ -5,10 +5,11 @@ package state
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
-	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
@@ -33,7 +34,53 @@ type Block interface {
 	// Message returns explanation that accompanies this block.
 	Message() string
 
-	updateMessageOp(string) ([]txn.Op, error)
+	// Targets returns the tags of the applications and machines
+	// that the block applies to. If there are none, the block
+	// applies to the whole model.
+	Targets() ([]names.Tag, error)
+
+	// Expiry returns the time after which the block no longer
+	// applies. The zero time means the block never expires.
+	Expiry() time.Time
+
+	// CreatedBy returns the name of the user that switched the
+	// block on, if known.
+	CreatedBy() string
+
+	updateOps(BlockArgs) ([]txn.Op, error)
+}
+
+// BlockArgs holds the parameters for switching a block on.
+type BlockArgs struct {
+	// Type is the type of the block.
+	Type BlockType
+
+	// Message is an explanation that accompanies the block.
+	Message string
+
+	// Targets holds the tags of the applications and machines the
+	// block applies to. If empty, the block applies to the whole
+	// model.
+	Targets []names.Tag
+
+	// Expiry, if non-zero, holds the time after which the block
+	// no longer applies.
+	Expiry time.Time
+
+	// CreatedBy holds the name of the user switching the block on.
+	CreatedBy string
+}
+
+// Validate returns an error if the arguments are not valid.
+func (a BlockArgs) Validate() error {
+	for _, tag := range a.Targets {
+		switch tag.(type) {
+		case names.ApplicationTag, names.MachineTag:
+		default:
+			return errors.NotValidf("block target %q", tag)
+		}
+	}
+	return nil
 }
 
 // BlockType specifies block type for enum benefit.
@@ -117,14 +164,56 @@ type blockDoc struct {
 	Tag       string    `bson:"tag"`
 	Type      BlockType `bson:"type"`
 	Message   string    `bson:"message,omitempty"`
+	Targets   []string  `bson:"targets,omitempty"`
+	Expiry    time.Time `bson:"expiry,omitempty"`
+	CreatedBy string    `bson:"created-by,omitempty"`
+}
+
+// activeAt reports whether the block applies at the given time.
+func (doc *blockDoc) activeAt(now time.Time) bool {
+	return doc.Expiry.IsZero() || now.Before(doc.Expiry)
+}
+
+// appliesTo reports whether the block applies to any of the
+// given targets. Model-wide blocks apply to everything.
+func (doc *blockDoc) appliesTo(targets []string) bool {
+	if len(doc.Targets) == 0 {
+		return true
+	}
+	for _, target := range targets {
+		for _, blocked := range doc.Targets {
+			if target == blocked {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasTargets reports whether the block applies to exactly the
+// given sorted targets.
+func (doc *blockDoc) hasTargets(targets []string) bool {
+	if len(doc.Targets) != len(targets) {
+		return false
+	}
+	for i, target := range targets {
+		if doc.Targets[i] != target {
+			return false
+		}
+	}
+	return true
 }
 
-func (b *block) updateMessageOp(message string) ([]txn.Op, error) {
+func (b *block) updateOps(args BlockArgs) ([]txn.Op, error) {
 	return []txn.Op{{
 		C:      blocksC,
 		Id:     b.doc.DocID,
 		Assert: txn.DocExists,
-		Update: bson.D{{"$set", bson.D{{"message", message}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"message", args.Message},
+			{"expiry", args.Expiry},
+			{"created-by", args.CreatedBy},
+		}}},
 	}}, nil
 }
 
@@ -157,10 +246,46 @@ func (b *block) Type() BlockType {
 	return b.doc.Type
 }
 
+// Targets is part of the state.Block interface.
+func (b *block) Targets() ([]names.Tag, error) {
+	if len(b.doc.Targets) == 0 {
+		return nil, nil
+	}
+	targets := make([]names.Tag, len(b.doc.Targets))
+	for i, target := range b.doc.Targets {
+		tag, err := names.ParseTag(target)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting block targets")
+		}
+		targets[i] = tag
+	}
+	return targets, nil
+}
+
+// Expiry is part of the state.Block interface.
+func (b *block) Expiry() time.Time {
+	return b.doc.Expiry
+}
+
+// CreatedBy is part of the state.Block interface.
+func (b *block) CreatedBy() string {
+	return b.doc.CreatedBy
+}
+
 // SwitchBlockOn enables block of specified type for the
 // current model.
 func (st *State) SwitchBlockOn(t BlockType, msg string) error {
-	return setModelBlock(st, t, msg)
+	return st.SwitchBlockOnWithArgs(BlockArgs{Type: t, Message: msg})
+}
+
+// SwitchBlockOnWithArgs enables a block described by the given
+// arguments. If a block of the same type with the same targets
+// already exists, it is updated.
+func (st *State) SwitchBlockOnWithArgs(args BlockArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return setModelBlock(st, args)
 }
 
 // SwitchBlockOff disables block of specified type for the
@@ -169,29 +294,51 @@ func (st *State) SwitchBlockOff(t BlockType) error {
 	return RemoveModelBlock(st, t)
 }
 
-// GetBlockForType returns the Block of the specified type for the current model
-// where
+// SwitchBlockOffForTargets disables the block of the specified type
+// that applies to exactly the given targets.
+func (st *State) SwitchBlockOffForTargets(t BlockType, targets []names.Tag) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		return removeBlockOps(st, t, targets)
+	}
+	return st.run(buildTxn)
+}
+
+// GetBlockForType returns the model-wide Block of the specified type for
+// the current model, if it has not expired, where
 //     not found -> nil, false, nil
 //     found -> block, true, nil
 //     error -> nil, false, err
 func (st *State) GetBlockForType(t BlockType) (Block, bool, error) {
-	all, closer := st.getCollection(blocksC)
-	defer closer()
-
-	doc := blockDoc{}
-	err := all.Find(bson.D{{"type", t}}).One(&doc)
-
-	switch err {
-	case nil:
-		return &block{doc}, true, nil
-	case mgo.ErrNotFound:
+	doc, err := findBlockDoc(st, t, nil)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if doc == nil || !doc.activeAt(st.clock.Now()) {
 		return nil, false, nil
-	default:
-		return nil, false, errors.Annotatef(err, "cannot get block of type %v", t.String())
 	}
+	return &block{*doc}, true, nil
 }
 
-// AllBlocks returns all blocks in the model.
+// GetBlockForTargets returns an unexpired Block of the specified type
+// that applies to any of the given applications or machines, including
+// any model-wide block of that type. The results are as for
+// GetBlockForType.
+func (st *State) GetBlockForTargets(t BlockType, targets []names.Tag) (Block, bool, error) {
+	docs, err := blockDocsForType(st, t)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	targetStrings := tagStrings(targets)
+	now := st.clock.Now()
+	for _, doc := range docs {
+		if doc.activeAt(now) && doc.appliesTo(targetStrings) {
+			return &block{doc}, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// AllBlocks returns all unexpired blocks in the model.
 func (st *State) AllBlocks() ([]Block, error) {
 	blocksCollection, closer := st.getCollection(blocksC)
 	defer closer()
@@ -201,16 +348,62 @@ func (st *State) AllBlocks() ([]Block, error) {
 	if err != nil {
 		return nil, errors.Annotatef(err, "cannot get all blocks")
 	}
-	blocks := make([]Block, len(bdocs))
-	for i, doc := range bdocs {
-		blocks[i] = &block{doc}
+	now := st.clock.Now()
+	blocks := make([]Block, 0, len(bdocs))
+	for _, doc := range bdocs {
+		if doc.activeAt(now) {
+			blocks = append(blocks, &block{doc})
+		}
 	}
 	return blocks, nil
 }
 
-// AllBlocksForController returns all blocks in any models on
-// the controller.
-func (st *State) AllBlocksForController() ([]Block, error) {
+// blockDocsForType returns all the blocks of the given type in the
+// model, including expired ones.
+func blockDocsForType(st *State, t BlockType) ([]blockDoc, error) {
+	blocks, closer := st.getCollection(blocksC)
+	defer closer()
+
+	var docs []blockDoc
+	if err := blocks.Find(bson.D{{"type", t}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get blocks of type %v", t.String())
+	}
+	return docs, nil
+}
+
+// findBlockDoc returns the block of the given type that applies to
+// exactly the given targets, or nil if there is none. The block
+// may have expired.
+func findBlockDoc(st *State, t BlockType, targets []names.Tag) (*blockDoc, error) {
+	docs, err := blockDocsForType(st, t)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetStrings := tagStrings(targets)
+	for _, doc := range docs {
+		if doc.hasTargets(targetStrings) {
+			return &doc, nil
+		}
+	}
+	return nil, nil
+}
+
+// tagStrings returns the sorted string forms of the given tags.
+func tagStrings(tags []names.Tag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	result := make([]string, len(tags))
+	for i, tag := range tags {
+		result[i] = tag.String()
+	}
+	sort.Strings(result)
+	return result
+}
+
+// allBlockDocsForController returns the documents of all blocks in
+// any models on the controller, including blocks that have expired.
+func (st *State) allBlockDocsForController() ([]blockDoc, error) {
 	blocksCollection, closer := st.getRawCollection(blocksC)
 	defer closer()
 
@@ -219,28 +412,40 @@ func (st *State) AllBlocksForController() ([]Block, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot get all blocks")
 	}
-	blocks := make([]Block, len(bdocs))
-	for i, doc := range bdocs {
-		blocks[i] = &block{doc}
-	}
+	return bdocs, nil
+}
 
+// AllBlocksForController returns all blocks in any models on
+// the controller. Blocks that have expired are not included.
+func (st *State) AllBlocksForController() ([]Block, error) {
+	bdocs, err := st.allBlockDocsForController()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := st.clock.Now()
+	var blocks []Block
+	for _, doc := range bdocs {
+		if doc.activeAt(now) {
+			blocks = append(blocks, &block{doc})
+		}
+	}
 	return blocks, nil
 }
 
-// RemoveAllBlocksForController removes all the blocks for the controller.
-// It does not prevent new blocks from being added during / after
-// removal.
+// RemoveAllBlocksForController removes all the blocks for the controller,
+// including any that have expired. It does not prevent new blocks from
+// being added during / after removal.
 func (st *State) RemoveAllBlocksForController() error {
-	blocks, err := st.AllBlocksForController()
+	bdocs, err := st.allBlockDocsForController()
 	if err != nil {
 		return errors.Trace(err)
 	}
 
 	ops := []txn.Op{}
-	for _, blk := range blocks {
+	for _, doc := range bdocs {
 		ops = append(ops, txn.Op{
 			C:      blocksC,
-			Id:     blk.Id(),
+			Id:     doc.DocID,
 			Remove: true,
 		})
 	}
@@ -252,19 +457,21 @@ func (st *State) RemoveAllBlocksForController() error {
 
 // setModelBlock updates the blocks collection with the
 // specified block.
-// Only one instance of each block type can exist in model.
-func setModelBlock(st *State, t BlockType, msg string) error {
+// Only one instance of each block type can exist in model for
+// any set of targets.
+func setModelBlock(st *State, args BlockArgs) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
-		block, exists, err := st.GetBlockForType(t)
+		doc, err := findBlockDoc(st, args.Type, args.Targets)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		// Cannot create blocks of the same type more than once per model.
-		// Cannot update current blocks.
-		if exists {
-			return block.updateMessageOp(msg)
+		// Cannot create blocks of the same type and targets more
+		// than once per model; update the existing block instead.
+		if doc != nil {
+			existing := &block{*doc}
+			return existing.updateOps(args)
 		}
-		return createModelBlockOps(st, t, msg)
+		return createModelBlockOps(st, args)
 	}
 	return st.run(buildTxn)
 }
@@ -278,7 +485,7 @@ func newBlockId(st *State) (string, error) {
 	return fmt.Sprint(seq), nil
 }
 
-func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
+func createModelBlockOps(st *State, args BlockArgs) ([]txn.Op, error) {
 	id, err := newBlockId(st)
 	if err != nil {
 		return nil, errors.Annotatef(err, "getting new block id")
@@ -290,8 +497,11 @@ func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
 		DocID:     st.docID(id),
 		ModelUUID: st.ModelUUID(),
 		Tag:       st.ModelTag().String(),
-		Type:      t,
-		Message:   msg,
+		Type:      args.Type,
+		Message:   args.Message,
+		Targets:   tagStrings(args.Targets),
+		Expiry:    args.Expiry,
+		CreatedBy: args.CreatedBy,
 	}
 	insertOp := txn.Op{
 		C:      blocksC,
@@ -302,6 +512,43 @@ func createModelBlockOps(st *State, t BlockType, msg string) ([]txn.Op, error) {
 	return []txn.Op{insertOp}, nil
 }
 
+// removeExpiredBlocks removes the blocks in the model that have
+// expired. They no longer apply, so removing them only stops them
+// accumulating.
+func (st *State) removeExpiredBlocks() error {
+	blocks, closer := st.getCollection(blocksC)
+	defer closer()
+
+	var docs []blockDoc
+	if err := blocks.Find(nil).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	now := st.clock.Now()
+	var ops []txn.Op
+	for _, doc := range docs {
+		if doc.Expiry.IsZero() || doc.activeAt(now) {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:  blocksC,
+			Id: doc.DocID,
+			// The block may have been switched on again
+			// with a new expiry since it was read.
+			Assert: bson.D{{"expiry", doc.Expiry}},
+			Remove: true,
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		// Another block was changed, so try again next time.
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func RemoveModelBlock(st *State, t BlockType) error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		return RemoveModelBlockOps(st, t)
@@ -310,14 +557,18 @@ func RemoveModelBlock(st *State, t BlockType) error {
 }
 
 func RemoveModelBlockOps(st *State, t BlockType) ([]txn.Op, error) {
-	tBlock, exists, err := st.GetBlockForType(t)
+	return removeBlockOps(st, t, nil)
+}
+
+func removeBlockOps(st *State, t BlockType, targets []names.Tag) ([]txn.Op, error) {
+	doc, err := findBlockDoc(st, t, targets)
 	if err != nil {
 		return nil, errors.Annotatef(err, "removing block %v", t.String())
 	}
-	if exists {
+	if doc != nil {
 		return []txn.Op{txn.Op{
 			C:      blocksC,
-			Id:     tBlock.Id(),
+			Id:     doc.DocID,
 			Remove: true,
 		}}, nil
 	}
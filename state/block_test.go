@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"strings"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -202,3 +203,192 @@ func (s *blockSuite) TestConcurrentBlocked(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertModelHasBlock(c, s.State, t, msg)
 }
+
+func (s *blockSuite) TestSwitchOnBlockWithArgs(c *gc.C) {
+	expiry := s.Clock.Now().Add(time.Hour).Round(time.Second).UTC()
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:      state.RemoveBlock,
+		Message:   "keep mysql",
+		Targets:   []names.Tag{names.NewApplicationTag("mysql"), names.NewMachineTag("0")},
+		Expiry:    expiry,
+		CreatedBy: "bob",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	blocks, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+	block := blocks[0]
+	c.Assert(block.Type(), gc.Equals, state.RemoveBlock)
+	c.Assert(block.Message(), gc.Equals, "keep mysql")
+	c.Assert(block.CreatedBy(), gc.Equals, "bob")
+	c.Assert(block.Expiry().Equal(expiry), jc.IsTrue)
+	targets, err := block.Targets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(targets, jc.DeepEquals, []names.Tag{
+		names.NewApplicationTag("mysql"),
+		names.NewMachineTag("0"),
+	})
+
+	// A targeted block is not a model-wide block.
+	s.assertNoTypedBlock(c, state.RemoveBlock)
+}
+
+func (s *blockSuite) TestSwitchOnBlockWithArgsInvalidTarget(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Targets: []names.Tag{names.NewUnitTag("mysql/0")},
+	})
+	c.Assert(err, gc.ErrorMatches, `block target "unit-mysql-0" not valid`)
+	assertNoEnvBlock(c, s.State)
+}
+
+func (s *blockSuite) TestGetBlockForTargets(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Message: "freeze mysql",
+		Targets: []names.Tag{names.NewApplicationTag("mysql")},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	block, found, err := s.State.GetBlockForTargets(state.ChangeBlock, []names.Tag{
+		names.NewApplicationTag("mysql"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Message(), gc.Equals, "freeze mysql")
+
+	_, found, err = s.State.GetBlockForTargets(state.ChangeBlock, []names.Tag{
+		names.NewApplicationTag("wordpress"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsFalse)
+
+	// A model-wide block applies to every target.
+	err = s.State.SwitchBlockOn(state.ChangeBlock, "freeze everything")
+	c.Assert(err, jc.ErrorIsNil)
+	block, found, err = s.State.GetBlockForTargets(state.ChangeBlock, []names.Tag{
+		names.NewApplicationTag("wordpress"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.IsTrue)
+	c.Assert(block.Message(), gc.Equals, "freeze everything")
+}
+
+func (s *blockSuite) TestSwitchBlockOffForTargets(c *gc.C) {
+	targets := []names.Tag{names.NewApplicationTag("mysql")}
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Targets: targets,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOn(state.RemoveBlock, "model-wide")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SwitchBlockOffForTargets(state.RemoveBlock, targets)
+	c.Assert(err, jc.ErrorIsNil)
+
+	blocks, err := s.State.AllBlocks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+	s.assertModelHasBlock(c, s.State, state.RemoveBlock, "model-wide")
+}
+
+func (s *blockSuite) TestBlockExpires(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Message: "no changes until Monday",
+		Expiry:  s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertModelHasBlock(c, s.State, state.ChangeBlock, "no changes until Monday")
+
+	s.Clock.Advance(time.Hour)
+	s.assertNoTypedBlock(c, state.ChangeBlock)
+	assertNoEnvBlock(c, s.State)
+
+	// Switching the block on again replaces the expired block.
+	err = s.State.SwitchBlockOn(state.ChangeBlock, "again")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertModelHasBlock(c, s.State, state.ChangeBlock, "again")
+	blocks, err := s.State.AllBlocksForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+}
+
+func (s *blockSuite) TestCleanupRemovesExpiredBlocks(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Message: "no changes until Monday",
+		Expiry:  s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Message: "no removals this year",
+		Expiry:  s.Clock.Now().Add(365 * 24 * time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.switchOnBlock(c, state.DestroyBlock, "never")
+
+	// Blocks that have not expired are kept.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	blocks, err := s.State.AllBlocksForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 3)
+
+	s.Clock.Advance(time.Hour)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.blockDocCount(c), gc.Equals, 2)
+	s.assertModelHasBlock(c, s.State, state.RemoveBlock, "no removals this year")
+	s.assertModelHasBlock(c, s.State, state.DestroyBlock, "never")
+}
+
+// blockDocCount returns the number of block documents on the
+// controller, including those of blocks that have expired.
+func (s *blockSuite) blockDocCount(c *gc.C) int {
+	coll, closer := state.GetRawCollection(s.State, "blocks")
+	defer closer()
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return count
+}
+
+func (s *blockSuite) TestAllBlocksForControllerOmitsExpiredBlocks(c *gc.C) {
+	_, st2 := s.createTestModel(c)
+	defer st2.Close()
+
+	err := st2.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.DestroyBlock,
+		Message: "frozen until Monday",
+		Expiry:  s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.switchOnBlock(c, state.ChangeBlock, "block test")
+
+	blocks, err := s.State.AllBlocksForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 2)
+
+	s.Clock.Advance(time.Hour)
+	blocks, err = s.State.AllBlocksForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(blocks, gc.HasLen, 1)
+	c.Assert(blocks[0].Message(), gc.Equals, "block test")
+}
+
+func (s *blockSuite) TestRemoveAllBlocksForControllerRemovesExpiredBlocks(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.DestroyBlock,
+		Message: "frozen until Monday",
+		Expiry:  s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Hour)
+
+	err = s.State.RemoveAllBlocksForController()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.blockDocCount(c), gc.Equals, 0)
+}
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -81,6 +82,70 @@ type charmDoc struct {
 	Config  *charm.Config  `bson:"config"`
 	Actions *charm.Actions `bson:"actions"`
 	Metrics *charm.Metrics `bson:"metrics"`
+
+	// LXDProfile holds the LXD profile supplied by the charm, if any,
+	// with its config keys escaped.
+	LXDProfile *charmLXDProfileDoc `bson:"lxd-profile,omitempty"`
+}
+
+// charmLXDProfileDoc represents a charm's LXD profile in MongoDB. The
+// LXD config keys and device properties contain dots, so they are
+// escaped before being stored.
+type charmLXDProfileDoc struct {
+	Description string                       `bson:"description,omitempty"`
+	Config      map[string]string            `bson:"config,omitempty"`
+	Devices     map[string]map[string]string `bson:"devices,omitempty"`
+}
+
+// newCharmLXDProfileDoc reads the LXD profile supplied by the charm,
+// and returns it ready for storage. It returns nil if the charm does
+// not supply a profile.
+func newCharmLXDProfileDoc(ch charm.Charm) (*charmLXDProfileDoc, error) {
+	profile, err := lxdprofile.ReadCharm(ch)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid lxd profile")
+	}
+	if profile.Empty() {
+		return nil, nil
+	}
+	doc := &charmLXDProfileDoc{
+		Description: profile.Description,
+		Config:      replaceStringMapKeys(profile.Config, escapeReplacer.Replace),
+	}
+	if len(profile.Devices) > 0 {
+		doc.Devices = make(map[string]map[string]string)
+		for name, device := range profile.Devices {
+			doc.Devices[escapeReplacer.Replace(name)] = replaceStringMapKeys(device, escapeReplacer.Replace)
+		}
+	}
+	return doc, nil
+}
+
+// profile returns the LXD profile held by the document, with its
+// keys unescaped.
+func (doc *charmLXDProfileDoc) profile() *lxdprofile.Profile {
+	profile := &lxdprofile.Profile{
+		Description: doc.Description,
+		Config:      replaceStringMapKeys(doc.Config, unescapeReplacer.Replace),
+	}
+	if len(doc.Devices) > 0 {
+		profile.Devices = make(map[string]map[string]string)
+		for name, device := range doc.Devices {
+			profile.Devices[unescapeReplacer.Replace(name)] = replaceStringMapKeys(device, unescapeReplacer.Replace)
+		}
+	}
+	return profile
+}
+
+func replaceStringMapKeys(in map[string]string, replace func(string) string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for key, value := range in {
+		out[replace(key)] = value
+	}
+	return out
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -109,6 +174,10 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 		BundleSha256: info.SHA256,
 		StoragePath:  info.StoragePath,
 	}
+	var err error
+	if doc.LXDProfile, err = newCharmLXDProfileDoc(info.Charm); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := checkCharmDataIsStorable(doc); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -209,11 +278,17 @@ func updateCharmOps(
 	}
 	op.Assert = append(lifeAssert, assert...)
 
+	lxdProfile, err := newCharmLXDProfileDoc(info.Charm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	data := bson.D{
 		{"meta", info.Charm.Meta()},
 		{"config", safeConfig(info.Charm)},
 		{"actions", info.Charm.Actions()},
 		{"metrics", info.Charm.Metrics()},
+		{"lxd-profile", lxdProfile},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
 		{"pendingupload", false},
@@ -458,6 +533,15 @@ func (c *Charm) Actions() *charm.Actions {
 	return c.doc.Actions
 }
 
+// LXDProfile returns the LXD profile supplied by the charm, or nil
+// if the charm does not supply one.
+func (c *Charm) LXDProfile() *lxdprofile.Profile {
+	if c.doc.LXDProfile == nil {
+		return nil
+	}
+	return c.doc.LXDProfile.profile()
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
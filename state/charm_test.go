@@ -6,6 +6,7 @@ package state_test
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 
@@ -111,6 +112,45 @@ func (s *CharmSuite) testCharm(c *gc.C) {
 		})
 }
 
+func (s *CharmSuite) TestLXDProfile(c *gc.C) {
+	ch := s.AddTestingCharm(c, "lxd-profile")
+	profile := ch.LXDProfile()
+	c.Assert(profile, gc.NotNil)
+	c.Assert(profile.Description, gc.Equals, "lxd profile for testing")
+	c.Assert(profile.Config, jc.DeepEquals, map[string]string{
+		"security.nesting":     "true",
+		"linux.kernel_modules": "openvswitch,nbd,ip_tables",
+	})
+	c.Assert(profile.Devices, jc.DeepEquals, map[string]map[string]string{
+		"tun": {"path": "/dev/net/tun", "type": "unix-char"},
+	})
+
+	// The profile survives a round trip through the database.
+	ch, err := s.State.Charm(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.LXDProfile().Config["security.nesting"], gc.Equals, "true")
+}
+
+func (s *CharmSuite) TestNoLXDProfile(c *gc.C) {
+	c.Assert(s.charm.LXDProfile(), gc.IsNil)
+}
+
+func (s *CharmSuite) TestAddCharmInvalidLXDProfile(c *gc.C) {
+	path := testcharms.Repo.ClonedDirPath(c.MkDir(), "lxd-profile")
+	err := ioutil.WriteFile(filepath.Join(path, "lxd-profile.yaml"), []byte("config:\n  boot.autostart: \"true\"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmDir(path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddCharm(state.CharmInfo{
+		Charm:       ch,
+		ID:          charm.MustParseURL("local:quantal/lxd-profile-1"),
+		StoragePath: "lxd-profile-path",
+		SHA256:      "lxd-profile-sha256",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid lxd profile: lxd profile config key "boot.autostart" not valid`)
+}
+
 func (s *CharmSuite) TestRemovedCharmNotFound(c *gc.C) {
 	s.remove(c)
 	s.checkRemoved(c)
@@ -248,6 +288,14 @@ func (s *CharmSuite) TestDestroyUnitReferencedCharm(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "charm in use")
 	err = unit.SetCharmURL(info.ID)
 	c.Assert(err, jc.ErrorIsNil)
+
+	// the application's charm history still references the original
+	// charm, until the application is removed
+	err = s.charm.Destroy()
+	c.Assert(err, gc.ErrorMatches, "charm in use")
+	removeUnit(c, unit)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
 	err = s.charm.Destroy()
 	c.Assert(err, jc.ErrorIsNil)
 }
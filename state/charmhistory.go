@@ -0,0 +1,283 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/resource"
+)
+
+// CharmHistoryLimit is the number of charms recorded in each
+// application's charm history. When an application's charm is
+// changed beyond this many times, the oldest entries are discarded.
+const CharmHistoryLimit = 10
+
+// CharmHistoryEntry records a charm that an application ran before its
+// charm was changed, along with the config and resources that were in
+// use at the time. The charm is not garbage collected while it is
+// recorded in an application's history.
+type CharmHistoryEntry struct {
+	doc charmHistoryDoc
+}
+
+type charmHistoryDoc struct {
+	DocID       string                    `bson:"_id"`
+	ModelUUID   string                    `bson:"model-uuid"`
+	Application string                    `bson:"application"`
+	Seq         int                       `bson:"seq"`
+	CharmURL    *charm.URL                `bson:"charmurl"`
+	Channel     string                    `bson:"cs-channel,omitempty"`
+	Config      map[string]interface{}    `bson:"config,omitempty"`
+	Resources   []charmHistoryResourceDoc `bson:"resources,omitempty"`
+	Replaced    time.Time                 `bson:"replaced"`
+}
+
+type charmHistoryResourceDoc struct {
+	Name        string `bson:"name"`
+	Type        string `bson:"type"`
+	Path        string `bson:"path"`
+	Description string `bson:"description,omitempty"`
+	Origin      string `bson:"origin"`
+	Revision    int    `bson:"revision"`
+	Fingerprint []byte `bson:"fingerprint,omitempty"`
+	Size        int64  `bson:"size"`
+}
+
+func charmHistoryDocID(st *State, application string, seq int) string {
+	return st.docID(fmt.Sprintf("%s#%d", application, seq))
+}
+
+// Application returns the name of the application that ran the charm.
+func (e *CharmHistoryEntry) Application() string {
+	return e.doc.Application
+}
+
+// Sequence returns the position of the entry in the application's
+// history. Later entries have higher sequence numbers.
+func (e *CharmHistoryEntry) Sequence() int {
+	return e.doc.Seq
+}
+
+// CharmURL returns the URL of the charm the application ran.
+func (e *CharmHistoryEntry) CharmURL() *charm.URL {
+	return e.doc.CharmURL
+}
+
+// Channel returns the charm store channel the charm was pulled from.
+func (e *CharmHistoryEntry) Channel() csparams.Channel {
+	return csparams.Channel(e.doc.Channel)
+}
+
+// Config returns the application's config settings at the time the
+// charm was replaced.
+func (e *CharmHistoryEntry) Config() charm.Settings {
+	return copyMap(e.doc.Config, unescapeReplacer.Replace)
+}
+
+// Resources returns the application's resources at the time the charm
+// was replaced.
+func (e *CharmHistoryEntry) Resources() ([]charmresource.Resource, error) {
+	result := make([]charmresource.Resource, len(e.doc.Resources))
+	for i, doc := range e.doc.Resources {
+		res, err := doc.resource()
+		if err != nil {
+			return nil, errors.Annotatef(err, "resource %q", doc.Name)
+		}
+		result[i] = res
+	}
+	return result, nil
+}
+
+// Replaced returns the time at which the charm was replaced.
+func (e *CharmHistoryEntry) Replaced() time.Time {
+	return e.doc.Replaced
+}
+
+func (doc charmHistoryResourceDoc) resource() (charmresource.Resource, error) {
+	rtype, err := charmresource.ParseType(doc.Type)
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(doc.Origin)
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	fp, err := resource.DeserializeFingerprint(doc.Fingerprint)
+	if err != nil {
+		return charmresource.Resource{}, errors.Trace(err)
+	}
+	return charmresource.Resource{
+		Meta: charmresource.Meta{
+			Name:        doc.Name,
+			Type:        rtype,
+			Path:        doc.Path,
+			Description: doc.Description,
+		},
+		Origin:      origin,
+		Revision:    doc.Revision,
+		Fingerprint: fp,
+		Size:        doc.Size,
+	}, nil
+}
+
+// CharmHistory returns the charms the application ran before its most
+// recent charm changes, most recent first.
+func (a *Application) CharmHistory() ([]*CharmHistoryEntry, error) {
+	docs, err := readCharmHistory(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm history for application %q", a)
+	}
+	entries := make([]*CharmHistoryEntry, len(docs))
+	for i, doc := range docs {
+		doc.Replaced = doc.Replaced.UTC()
+		entries[len(docs)-1-i] = &CharmHistoryEntry{doc: doc}
+	}
+	return entries, nil
+}
+
+// readCharmHistory returns the application's charm history documents,
+// oldest first.
+func readCharmHistory(st *State, application string) ([]charmHistoryDoc, error) {
+	coll, closer := st.getCollection(charmHistoryC)
+	defer closer()
+
+	var docs []charmHistoryDoc
+	err := coll.Find(bson.D{{"application", application}}).Sort("seq").All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
+// recordCharmHistoryOps returns the operations necessary to add the
+// application's current charm, with the supplied config settings, to
+// its charm history. Entries beyond CharmHistoryLimit are discarded.
+func recordCharmHistoryOps(a *Application, settings *Settings) ([]txn.Op, error) {
+	docs, err := readCharmHistory(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq := 1
+	if len(docs) > 0 {
+		seq = docs[len(docs)-1].Seq + 1
+	}
+	resources, err := charmHistoryResources(a)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var config map[string]interface{}
+	if settings != nil {
+		config = copyMap(settings.Map(), escapeReplacer.Replace)
+	}
+	doc := charmHistoryDoc{
+		DocID:       charmHistoryDocID(a.st, a.doc.Name, seq),
+		ModelUUID:   a.st.ModelUUID(),
+		Application: a.doc.Name,
+		Seq:         seq,
+		CharmURL:    a.doc.CharmURL,
+		Channel:     a.doc.Channel,
+		Config:      config,
+		Resources:   resources,
+		Replaced:    a.st.NowToTheSecond(),
+	}
+
+	refcounts, closer := a.st.getCollection(refcountsC)
+	defer closer()
+
+	// Holding a reference to the charm stops it from being
+	// removed while it is recorded in the history.
+	incOp, err := nsRefcounts.CreateOrIncRefOp(refcounts, charmGlobalKey(doc.CharmURL), 1)
+	if err != nil {
+		return nil, errors.Annotate(err, "charm reference")
+	}
+	ops := []txn.Op{{
+		C:      charmHistoryC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, incOp}
+
+	if excess := len(docs) + 1 - CharmHistoryLimit; excess > 0 {
+		pruneOps, err := removeCharmHistoryOps(a.st, docs[:excess])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, pruneOps...)
+	}
+	return ops, nil
+}
+
+// charmHistoryResources returns the application's current resources,
+// for recording in its charm history.
+func charmHistoryResources(a *Application) ([]charmHistoryResourceDoc, error) {
+	resources, err := a.st.Resources()
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appResources, err := resources.ListResources(a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var docs []charmHistoryResourceDoc
+	for _, res := range appResources.Resources {
+		if res.IsPlaceholder() {
+			continue
+		}
+		docs = append(docs, charmHistoryResourceDoc{
+			Name:        res.Name,
+			Type:        res.Type.String(),
+			Path:        res.Path,
+			Description: res.Description,
+			Origin:      res.Origin.String(),
+			Revision:    res.Revision,
+			Fingerprint: res.Fingerprint.Bytes(),
+			Size:        res.Size,
+		})
+	}
+	return docs, nil
+}
+
+// removeAllCharmHistoryOps returns the operations necessary to remove
+// the application's charm history.
+func removeAllCharmHistoryOps(st *State, application string) ([]txn.Op, error) {
+	docs, err := readCharmHistory(st, application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return removeCharmHistoryOps(st, docs)
+}
+
+// removeCharmHistoryOps returns the operations necessary to remove the
+// supplied charm history entries, release their references to their
+// charms, and schedule cleanups for any charms no longer in use.
+func removeCharmHistoryOps(st *State, docs []charmHistoryDoc) ([]txn.Op, error) {
+	refcounts, closer := st.getCollection(refcountsC)
+	defer closer()
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		decOp, err := nsRefcounts.AliveDecRefOp(refcounts, charmGlobalKey(doc.CharmURL))
+		if err != nil {
+			return nil, errors.Annotatef(err, "charm reference %s", doc.CharmURL)
+		}
+		ops = append(ops, txn.Op{
+			C:      charmHistoryC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}, decOp, newCleanupOp(cleanupCharm, doc.CharmURL.String()))
+	}
+	return ops, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmHistorySuite struct {
+	ConnSuite
+	charm *state.Charm
+	mysql *state.Application
+}
+
+var _ = gc.Suite(&CharmHistorySuite{})
+
+func (s *CharmHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddConfigCharm(c, "mysql", stringConfig, 1)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+}
+
+func (s *CharmHistorySuite) upgrade(c *gc.C, revision int) *state.Charm {
+	ch := s.AddConfigCharm(c, "mysql", newStringConfig, revision)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *CharmHistorySuite) TestNoHistory(c *gc.C) {
+	history, err := s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *CharmHistorySuite) TestSetCharmRecordsHistory(c *gc.C) {
+	err := s.mysql.UpdateConfigSettings(charm.Settings{"key": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.upgrade(c, 2)
+
+	history, err := s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	entry := history[0]
+	c.Assert(entry.Application(), gc.Equals, "mysql")
+	c.Assert(entry.Sequence(), gc.Equals, 1)
+	c.Assert(entry.CharmURL(), jc.DeepEquals, s.charm.URL())
+	c.Assert(entry.Config(), jc.DeepEquals, charm.Settings{"key": "value"})
+	c.Assert(entry.Replaced().IsZero(), jc.IsFalse)
+	resources, err := entry.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+
+	// Setting the same charm again does not add to the history.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err = s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+}
+
+func (s *CharmHistorySuite) TestHistoryKeepsCharms(c *gc.C) {
+	s.upgrade(c, 2)
+	err := s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.charm.Destroy()
+	c.Assert(err, gc.ErrorMatches, "charm in use")
+	_, err = s.State.Charm(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmHistorySuite) TestHistoryIsLimited(c *gc.C) {
+	last := state.CharmHistoryLimit + 2
+	for revision := 2; revision <= last; revision++ {
+		s.upgrade(c, revision)
+	}
+
+	history, err := s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.CharmHistoryLimit)
+	c.Assert(history[0].CharmURL().Revision, gc.Equals, last-1)
+	c.Assert(history[0].Sequence(), gc.Equals, last-1)
+	c.Assert(history[len(history)-1].CharmURL().Revision, gc.Equals, 2)
+
+	// The charm that dropped out of the history is cleaned up.
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Charm(s.charm.URL())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.Charm(history[0].CharmURL())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmHistorySuite) TestRollBackThroughHistory(c *gc.C) {
+	s.upgrade(c, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].CharmURL().Revision, gc.Equals, 2)
+	c.Assert(history[1].CharmURL().Revision, gc.Equals, 1)
+}
+
+func (s *CharmHistorySuite) TestRemoveApplicationRemovesHistory(c *gc.C) {
+	s.upgrade(c, 2)
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Application("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	history, err := s.mysql.CharmHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Charm(s.charm.URL())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -31,6 +31,7 @@ const (
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
 	cleanupMachinesForDyingModel         cleanupKind = "modelMachines"
+	cleanupPreferredAddresses            cleanupKind = "preferredAddresses"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -107,6 +108,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupModelsForDyingController()
 		case cleanupMachinesForDyingModel:
 			err = st.cleanupMachinesForDyingModel()
+		case cleanupPreferredAddresses:
+			err = st.cleanupPreferredAddresses()
 		default:
 			handler, ok := cleanupHandlers[doc.Kind]
 			if !ok {
@@ -129,6 +132,9 @@ func (st *State) Cleanup() (err error) {
 			return errors.Annotate(err, "cannot remove empty cleanup document")
 		}
 	}
+	if err := st.removeExpiredBlocks(); err != nil {
+		return errors.Annotate(err, "cannot remove expired blocks")
+	}
 	return nil
 }
 
@@ -212,6 +218,26 @@ func (st *State) cleanupMachinesForDyingModel() (err error) {
 	return nil
 }
 
+// cleanupPreferredAddresses re-evaluates the preferred addresses of all
+// the model's machines under the model's preferred IP family. It's
+// expected to be used when the model's preferred-ip-family changes.
+func (st *State) cleanupPreferredAddresses() error {
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if err := m.updatePreferredAddresses(family); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // cleanupServicesForDyingModel sets all services to Dying, if they are
 // not already Dying or Dead. It's expected to be used when a model is
 // destroyed.
@@ -4,11 +4,15 @@
 package state
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"golang.org/x/net/context"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/tracing"
 )
 
 // getRawCollection returns the named mgo Collection. As no automatic
@@ -142,3 +146,183 @@ func (c *modelStateCollection) mungeQuery(inq interface{}) bson.D {
 	}
 	return outq
 }
+
+// traceCollection returns a collection that records the queries run on
+// the given collection as children of the tracing span carried by the
+// state's context. If there is no such span, the collection is returned
+// unchanged.
+func (st *State) traceCollection(collection mongo.Collection) mongo.Collection {
+	if tracing.SpanFromContext(st.ctx) == nil {
+		return collection
+	}
+	return &tracingCollection{Collection: collection, ctx: st.ctx}
+}
+
+// tracingCollection is a mongo.Collection that records each query run
+// on it as a tracing span. Writes are made in transactions, which are
+// traced by tracingRunner, so Writeable returns the collection as is.
+type tracingCollection struct {
+	mongo.Collection
+	ctx context.Context
+}
+
+// Count is part of the mongo.Collection interface.
+func (c *tracingCollection) Count() (int, error) {
+	span := c.startSpan("count")
+	n, err := c.Collection.Count()
+	finishQuerySpan(span, err)
+	return n, err
+}
+
+// Find is part of the mongo.Collection interface.
+func (c *tracingCollection) Find(query interface{}) mongo.Query {
+	return &tracingQuery{Query: c.Collection.Find(query), collection: c}
+}
+
+// FindId is part of the mongo.Collection interface.
+func (c *tracingCollection) FindId(id interface{}) mongo.Query {
+	return &tracingQuery{Query: c.Collection.FindId(id), collection: c}
+}
+
+func (c *tracingCollection) startSpan(operation string) *tracing.ActiveSpan {
+	span := tracing.StartChildSpan(c.ctx, "query")
+	span.SetTag("component", "state")
+	span.SetTag("collection", c.Name())
+	span.SetTag("operation", operation)
+	return span
+}
+
+func finishQuerySpan(span *tracing.ActiveSpan, err error) {
+	if err != nil && err != mgo.ErrNotFound {
+		span.SetTag("error", err.Error())
+	}
+	span.Finish()
+}
+
+// tracingQuery is a mongo.Query that records the operations which
+// read from the database as tracing spans. Iterators are returned
+// untraced, as they outlive the call that creates them.
+type tracingQuery struct {
+	mongo.Query
+	collection *tracingCollection
+}
+
+func (q *tracingQuery) wrap(query mongo.Query) mongo.Query {
+	return &tracingQuery{Query: query, collection: q.collection}
+}
+
+// All is part of the mongo.Query interface.
+func (q *tracingQuery) All(result interface{}) error {
+	span := q.collection.startSpan("all")
+	err := q.Query.All(result)
+	finishQuerySpan(span, err)
+	return err
+}
+
+// Apply is part of the mongo.Query interface.
+func (q *tracingQuery) Apply(change mgo.Change, result interface{}) (*mgo.ChangeInfo, error) {
+	span := q.collection.startSpan("apply")
+	info, err := q.Query.Apply(change, result)
+	finishQuerySpan(span, err)
+	return info, err
+}
+
+// Count is part of the mongo.Query interface.
+func (q *tracingQuery) Count() (int, error) {
+	span := q.collection.startSpan("count")
+	n, err := q.Query.Count()
+	finishQuerySpan(span, err)
+	return n, err
+}
+
+// Distinct is part of the mongo.Query interface.
+func (q *tracingQuery) Distinct(key string, result interface{}) error {
+	span := q.collection.startSpan("distinct")
+	err := q.Query.Distinct(key, result)
+	finishQuerySpan(span, err)
+	return err
+}
+
+// For is part of the mongo.Query interface.
+func (q *tracingQuery) For(result interface{}, f func() error) error {
+	span := q.collection.startSpan("for")
+	err := q.Query.For(result, f)
+	finishQuerySpan(span, err)
+	return err
+}
+
+// MapReduce is part of the mongo.Query interface.
+func (q *tracingQuery) MapReduce(job *mgo.MapReduce, result interface{}) (*mgo.MapReduceInfo, error) {
+	span := q.collection.startSpan("mapreduce")
+	info, err := q.Query.MapReduce(job, result)
+	finishQuerySpan(span, err)
+	return info, err
+}
+
+// One is part of the mongo.Query interface.
+func (q *tracingQuery) One(result interface{}) error {
+	span := q.collection.startSpan("one")
+	err := q.Query.One(result)
+	finishQuerySpan(span, err)
+	return err
+}
+
+// Batch is part of the mongo.Query interface.
+func (q *tracingQuery) Batch(n int) mongo.Query {
+	return q.wrap(q.Query.Batch(n))
+}
+
+// Comment is part of the mongo.Query interface.
+func (q *tracingQuery) Comment(comment string) mongo.Query {
+	return q.wrap(q.Query.Comment(comment))
+}
+
+// Hint is part of the mongo.Query interface.
+func (q *tracingQuery) Hint(indexKey ...string) mongo.Query {
+	return q.wrap(q.Query.Hint(indexKey...))
+}
+
+// Limit is part of the mongo.Query interface.
+func (q *tracingQuery) Limit(n int) mongo.Query {
+	return q.wrap(q.Query.Limit(n))
+}
+
+// LogReplay is part of the mongo.Query interface.
+func (q *tracingQuery) LogReplay() mongo.Query {
+	return q.wrap(q.Query.LogReplay())
+}
+
+// Prefetch is part of the mongo.Query interface.
+func (q *tracingQuery) Prefetch(p float64) mongo.Query {
+	return q.wrap(q.Query.Prefetch(p))
+}
+
+// Select is part of the mongo.Query interface.
+func (q *tracingQuery) Select(selector interface{}) mongo.Query {
+	return q.wrap(q.Query.Select(selector))
+}
+
+// SetMaxScan is part of the mongo.Query interface.
+func (q *tracingQuery) SetMaxScan(n int) mongo.Query {
+	return q.wrap(q.Query.SetMaxScan(n))
+}
+
+// SetMaxTime is part of the mongo.Query interface.
+func (q *tracingQuery) SetMaxTime(d time.Duration) mongo.Query {
+	return q.wrap(q.Query.SetMaxTime(d))
+}
+
+// Skip is part of the mongo.Query interface.
+func (q *tracingQuery) Skip(n int) mongo.Query {
+	return q.wrap(q.Query.Skip(n))
+}
+
+// Snapshot is part of the mongo.Query interface.
+func (q *tracingQuery) Snapshot() mongo.Query {
+	return q.wrap(q.Query.Snapshot())
+}
+
+// Sort is part of the mongo.Query interface.
+func (q *tracingQuery) Sort(fields ...string) mongo.Query {
+	return q.wrap(q.Query.Sort(fields...))
+}
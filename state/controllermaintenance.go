@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// controllerMaintenanceKey is the key for the document recording
+// whether the controller is in maintenance mode.
+const controllerMaintenanceKey = "controllerMaintenance"
+
+// ControllerMaintenance describes the maintenance mode of the
+// controller. While the controller is in maintenance mode, the API
+// server rejects changes to the models it hosts.
+type ControllerMaintenance struct {
+	// Enabled holds whether the controller is in maintenance mode.
+	Enabled bool
+
+	// Message holds the message supplied when maintenance mode
+	// was enabled, describing the reason for the maintenance.
+	Message string
+}
+
+type controllerMaintenanceDoc struct {
+	Enabled bool   `bson:"enabled"`
+	Message string `bson:"message"`
+}
+
+// ControllerMaintenance returns the current maintenance mode of the
+// controller.
+func (st *State) ControllerMaintenance() (ControllerMaintenance, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var doc controllerMaintenanceDoc
+	err := controllers.FindId(controllerMaintenanceKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		// Controllers created before maintenance mode existed
+		// have no document; they are not in maintenance.
+		return ControllerMaintenance{}, nil
+	} else if err != nil {
+		return ControllerMaintenance{}, errors.Annotate(err, "cannot get controller maintenance")
+	}
+	return ControllerMaintenance{
+		Enabled: doc.Enabled,
+		Message: doc.Message,
+	}, nil
+}
+
+// SetControllerMaintenance enables or disables maintenance mode for
+// the controller. The message is ignored if maintenance is being
+// disabled.
+func (st *State) SetControllerMaintenance(enabled bool, message string) error {
+	if !enabled {
+		message = ""
+	}
+	doc := controllerMaintenanceDoc{
+		Enabled: enabled,
+		Message: message,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		controllers, closer := st.getCollection(controllersC)
+		defer closer()
+		n, err := controllers.FindId(controllerMaintenanceKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     controllerMaintenanceKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     controllerMaintenanceKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"enabled", doc.Enabled},
+				{"message", doc.Message},
+			}}},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set controller maintenance")
+	}
+	return nil
+}
+
+// WatchControllerMaintenance returns a NotifyWatcher that notifies
+// when the maintenance mode of the controller changes.
+func (st *State) WatchControllerMaintenance() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, controllerMaintenanceKey)
+}
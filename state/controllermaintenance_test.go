@@ -0,0 +1,72 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ControllerMaintenanceSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerMaintenanceSuite{})
+
+func (s *ControllerMaintenanceSuite) TestDefault(c *gc.C) {
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance, jc.DeepEquals, state.ControllerMaintenance{})
+}
+
+func (s *ControllerMaintenanceSuite) TestSetControllerMaintenance(c *gc.C) {
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	maintenance, err := s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance, jc.DeepEquals, state.ControllerMaintenance{
+		Enabled: true,
+		Message: "upgrading mongo",
+	})
+
+	err = s.State.SetControllerMaintenance(false, "ignored")
+	c.Assert(err, jc.ErrorIsNil)
+	maintenance, err = s.State.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance, jc.DeepEquals, state.ControllerMaintenance{})
+}
+
+func (s *ControllerMaintenanceSuite) TestSharedByModels(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	maintenance, err := st.ControllerMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(maintenance.Enabled, jc.IsTrue)
+}
+
+func (s *ControllerMaintenanceSuite) TestWatchControllerMaintenance(c *gc.C) {
+	w := s.State.WatchControllerMaintenance()
+	defer statetesting.AssertStop(c, w)
+
+	// Initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetControllerMaintenance(true, "upgrading mongo")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.SetControllerMaintenance(false, "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
@@ -0,0 +1,452 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+const groupGlobalKeyPrefix = "gr"
+
+// groupGlobalKey returns the global database key for the named group,
+// used as the subject of the group's permissions.
+func groupGlobalKey(name string) string {
+	return fmt.Sprintf("%s#%s", groupGlobalKeyPrefix, strings.ToLower(name))
+}
+
+// Group represents a controller local group of users. Access granted
+// to a group applies to all of its members.
+type Group struct {
+	st  *State
+	doc groupDoc
+}
+
+type groupDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+// AddGroup adds a group with the specified name to the controller.
+// Group names share the namespace of local user names, so that they
+// can be used interchangeably when granting access.
+func (st *State) AddGroup(name, creator string) (*Group, error) {
+	if !names.IsValidUserName(name) {
+		return nil, errors.Errorf("invalid group name %q", name)
+	}
+	nameToLower := strings.ToLower(name)
+	if exists, err := st.checkUserExists(nameToLower); err != nil {
+		return nil, errors.Trace(err)
+	} else if exists {
+		return nil, errors.AlreadyExistsf("user with name %q", name)
+	}
+
+	group := &Group{
+		st: st,
+		doc: groupDoc{
+			DocID:       nameToLower,
+			Name:        name,
+			Members:     []string{},
+			CreatedBy:   creator,
+			DateCreated: st.NowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     nameToLower,
+		Assert: txn.DocMissing,
+	}, {
+		C:      groupsC,
+		Id:     nameToLower,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// Group returns the group with the specified name.
+func (st *State) Group(name string) (*Group, error) {
+	groups, closer := st.getCollection(groupsC)
+	defer closer()
+
+	group := &Group{st: st}
+	err := groups.FindId(strings.ToLower(name)).One(&group.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get group %q", name)
+	}
+	group.doc.DateCreated = group.doc.DateCreated.UTC()
+	return group, nil
+}
+
+// AllGroups returns all the groups on the controller, sorted by name.
+func (st *State) AllGroups() ([]*Group, error) {
+	return st.findGroups(nil)
+}
+
+// UserGroups returns the groups of which the specified user is a
+// member, sorted by name.
+func (st *State) UserGroups(user names.UserTag) ([]*Group, error) {
+	return st.findGroups(bson.D{{"members", userAccessID(user)}})
+}
+
+func (st *State) findGroups(query bson.D) ([]*Group, error) {
+	groups, closer := st.getCollection(groupsC)
+	defer closer()
+
+	var docs []groupDoc
+	if err := groups.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get groups")
+	}
+	result := make([]*Group, len(docs))
+	for i, doc := range docs {
+		doc.DateCreated = doc.DateCreated.UTC()
+		result[i] = &Group{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveGroup removes the named group, along with all the access
+// granted to it.
+func (st *State) RemoveGroup(name string) error {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	query := bson.D{{"subject-global-key", groupGlobalKey(name)}}
+	if err := permissions.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Annotatef(err, "reading permissions for group %q", name)
+	}
+	ops := []txn.Op{{
+		C:      groupsC,
+		Id:     strings.ToLower(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		})
+	}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("group %q", name)
+	}
+	return errors.Trace(err)
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.doc.Name
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *Group) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created in UTC.
+func (g *Group) DateCreated() time.Time {
+	return g.doc.DateCreated
+}
+
+// Members returns the tags of the users that are members of the group.
+func (g *Group) Members() []names.UserTag {
+	members := make([]names.UserTag, len(g.doc.Members))
+	for i, member := range g.doc.Members {
+		members[i] = names.NewUserTag(member)
+	}
+	return members
+}
+
+// AddMember adds the specified user to the group. Adding a user that
+// is already a member is not an error.
+func (g *Group) AddMember(user names.UserTag) error {
+	if user.IsLocal() {
+		if _, err := g.st.User(user); err != nil {
+			return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+		}
+	}
+	ops := []txn.Op{{
+		C:      groupsC,
+		Id:     g.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"members", userAccessID(user)}}}},
+	}}
+	if err := g.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("group %q", g.doc.Name)
+		}
+		return errors.Annotatef(err, "cannot add %q to group %q", user.Id(), g.doc.Name)
+	}
+	return g.Refresh()
+}
+
+// RemoveMember removes the specified user from the group.
+func (g *Group) RemoveMember(user names.UserTag) error {
+	ops := []txn.Op{{
+		C:      groupsC,
+		Id:     g.doc.DocID,
+		Assert: bson.D{{"members", userAccessID(user)}},
+		Update: bson.D{{"$pull", bson.D{{"members", userAccessID(user)}}}},
+	}}
+	if err := g.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.NotFoundf("member %q of group %q", user.Id(), g.doc.Name)
+		}
+		return errors.Trace(err)
+	}
+	return g.Refresh()
+}
+
+// Refresh refreshes information about the group from the state.
+func (g *Group) Refresh() error {
+	group, err := g.st.Group(g.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g.doc = group.doc
+	return nil
+}
+
+// groupObjectGlobalKey returns the global key of the object of a
+// group permission for the specified target.
+func (st *State) groupObjectGlobalKey(target names.Tag) (string, error) {
+	switch target.Kind() {
+	case names.ModelTagKind:
+		return modelKey(target.Id()), nil
+	case names.ControllerTagKind:
+		return controllerKey(st.ControllerUUID()), nil
+	}
+	return "", errors.NotValidf("%q as a target", target.Kind())
+}
+
+// validateTargetAccess returns an error if the specified access is not
+// valid for the kind of target.
+func validateTargetAccess(target names.Tag, access permission.Access) error {
+	if target.Kind() == names.ControllerTagKind {
+		return permission.ValidateControllerAccess(access)
+	}
+	return permission.ValidateModelAccess(access)
+}
+
+// GroupAccess returns the access the named group has been granted on
+// the specified model or controller.
+func (st *State) GroupAccess(name string, target names.Tag) (permission.Access, error) {
+	objectKey, err := st.groupObjectGlobalKey(target)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	perm, err := st.userPermission(objectKey, groupGlobalKey(name))
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// SetGroupAccess grants the named group the specified access on the
+// model or controller, replacing any access it had previously.
+func (st *State) SetGroupAccess(name string, target names.Tag, access permission.Access) error {
+	if err := validateTargetAccess(target, access); err != nil {
+		return errors.Trace(err)
+	}
+	objectKey, err := st.groupObjectGlobalKey(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := st.Group(name); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.GroupAccess(name, target)
+		if errors.IsNotFound(err) {
+			return []txn.Op{createPermissionOp(objectKey, groupGlobalKey(name), access)}, nil
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{updatePermissionOp(objectKey, groupGlobalKey(name), access)}, nil
+	}
+	return errors.Trace(st.run(buildTxn))
+}
+
+// RemoveGroupAccess removes the access the named group has been granted
+// on the specified model or controller.
+func (st *State) RemoveGroupAccess(name string, target names.Tag) error {
+	objectKey, err := st.groupObjectGlobalKey(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	op := removePermissionOp(objectKey, groupGlobalKey(name))
+	err = st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("access for group %q", name)
+	}
+	return errors.Trace(err)
+}
+
+// inheritedAccess returns the greatest access granted on the target to
+// any of the groups of which the specified user is a member. It
+// returns permission.NoAccess if no group access applies.
+func (st *State) inheritedAccess(user names.UserTag, target names.Tag) (permission.Access, error) {
+	groups, err := st.UserGroups(user)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return permission.NoAccess, nil
+	}
+	objectKey, err := st.groupObjectGlobalKey(target)
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	ids := make([]string, len(groups))
+	for i, group := range groups {
+		ids[i] = permissionID(objectKey, groupGlobalKey(group.Name()))
+	}
+
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	query := bson.D{{"_id", bson.D{{"$in", ids}}}}
+	if err := permissions.Find(query).All(&docs); err != nil {
+		return permission.NoAccess, errors.Annotate(err, "cannot get group access")
+	}
+	result := permission.NoAccess
+	for _, doc := range docs {
+		access := stringToAccess(doc.Access)
+		if greaterAccess(target, access, result) {
+			result = access
+		}
+	}
+	return result, nil
+}
+
+// groupModelUUIDs returns the UUIDs of the models on which access has
+// been granted to any of the groups of which the specified user is a
+// member.
+func (st *State) groupModelUUIDs(user names.UserTag) ([]string, error) {
+	groups, err := st.UserGroups(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	subjectKeys := make([]string, len(groups))
+	for i, group := range groups {
+		subjectKeys[i] = groupGlobalKey(group.Name())
+	}
+
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	prefix := modelKey("")
+	query := bson.D{
+		{"subject-global-key", bson.D{{"$in", subjectKeys}}},
+		{"object-global-key", bson.D{{"$regex", "^" + regexp.QuoteMeta(prefix)}}},
+	}
+	if err := permissions.Find(query).Select(bson.D{{"object-global-key", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get group model access")
+	}
+	uuids := make([]string, len(docs))
+	for i, doc := range docs {
+		uuids[i] = strings.TrimPrefix(doc.ObjectGlobalKey, prefix)
+	}
+	return uuids, nil
+}
+
+// greaterAccess reports whether access a is greater than access b for
+// the kind of target.
+func greaterAccess(target names.Tag, a, b permission.Access) bool {
+	if target.Kind() == names.ControllerTagKind {
+		return a.GreaterControllerAccessThan(b)
+	}
+	return a.GreaterModelAccessThan(b)
+}
+
+// maximumAccess returns the greatest access that can be granted on
+// the kind of target.
+func maximumAccess(target names.Tag) permission.Access {
+	if target.Kind() == names.ControllerTagKind {
+		return permission.SuperuserAccess
+	}
+	return permission.AdminAccess
+}
+
+// EffectiveUserAccess returns the access the subject has on the target,
+// being the greater of the access granted directly to the user and the
+// access granted to any of the groups of which the user is a member.
+func (st *State) EffectiveUserAccess(subject names.UserTag, target names.Tag) (permission.UserAccess, error) {
+	userAccess, directErr := st.UserAccess(subject, target)
+	if directErr != nil && !errors.IsNotFound(directErr) {
+		return permission.UserAccess{}, errors.Trace(directErr)
+	}
+	if directErr == nil && !greaterAccess(target, maximumAccess(target), userAccess.Access) {
+		// No group can grant more than the user already has.
+		return userAccess, nil
+	}
+	inherited, err := st.inheritedAccess(subject, target)
+	if err != nil {
+		return permission.UserAccess{}, errors.Trace(err)
+	}
+	if inherited != permission.NoAccess && subject.IsLocal() {
+		// Make sure we don't hand out group access to users that no
+		// longer exist.
+		_, err := st.User(subject)
+		if errors.IsUserNotFound(err) {
+			if directErr != nil {
+				return permission.UserAccess{}, errors.NotFoundf("user %q", subject.Id())
+			}
+			return userAccess, nil
+		}
+		if err != nil {
+			return permission.UserAccess{}, errors.Trace(err)
+		}
+	}
+	if directErr != nil {
+		if inherited == permission.NoAccess {
+			return permission.UserAccess{}, errors.Trace(directErr)
+		}
+		// The user has no direct access, so create a stand-in to hold
+		// the access inherited from their groups.
+		return permission.UserAccess{
+			UserID:   userAccessID(subject),
+			UserTag:  subject,
+			Object:   target,
+			Access:   inherited,
+			UserName: subject.Id(),
+		}, nil
+	}
+	if greaterAccess(target, inherited, userAccess.Access) {
+		userAccess.Access = inherited
+	}
+	return userAccess, nil
+}
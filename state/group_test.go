@@ -0,0 +1,240 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+type groupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&groupSuite{})
+
+func (s *groupSuite) makeUser(c *gc.C, name string) names.UserTag {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: name, NoModelUser: true})
+	return user.UserTag()
+}
+
+func (s *groupSuite) TestAddGroup(c *gc.C) {
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+	c.Assert(group.CreatedBy(), gc.Equals, "admin")
+	c.Assert(group.Members(), gc.HasLen, 0)
+
+	group, err = s.State.Group("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+}
+
+func (s *groupSuite) TestAddGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddGroup("not/valid", "admin")
+	c.Assert(err, gc.ErrorMatches, `invalid group name "not/valid"`)
+}
+
+func (s *groupSuite) TestAddGroupAlreadyExists(c *gc.C) {
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *groupSuite) TestAddGroupSameNameAsUser(c *gc.C) {
+	s.makeUser(c, "fred")
+	_, err := s.State.AddGroup("fred", "admin")
+	c.Assert(err, gc.ErrorMatches, `user with name "fred" already exists`)
+}
+
+func (s *groupSuite) TestAddUserSameNameAsGroup(c *gc.C) {
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddUser("devs", "", "secret", "admin")
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *groupSuite) TestGroupNotFound(c *gc.C) {
+	_, err := s.State.Group("devs")
+	c.Assert(err, gc.ErrorMatches, `group "devs" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *groupSuite) TestAllGroups(c *gc.C) {
+	for _, name := range []string{"ops", "devs"} {
+		_, err := s.State.AddGroup(name, "admin")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	groups, err := s.State.AllGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 2)
+	c.Assert(groups[0].Name(), gc.Equals, "devs")
+	c.Assert(groups[1].Name(), gc.Equals, "ops")
+}
+
+func (s *groupSuite) TestAddAndRemoveMember(c *gc.C) {
+	fred := s.makeUser(c, "fred")
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	// Adding an existing member is a no-op.
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), jc.DeepEquals, []names.UserTag{fred})
+
+	groups, err := s.State.UserGroups(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name(), gc.Equals, "devs")
+
+	err = group.RemoveMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+
+	err = group.RemoveMember(fred)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *groupSuite) TestAddMemberNoUser(c *gc.C) {
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(names.NewUserTag("nobody"))
+	c.Assert(err, gc.ErrorMatches, `user "nobody" does not exist locally: user "nobody" not found`)
+}
+
+func (s *groupSuite) TestSetGroupAccess(c *gc.C) {
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ReadAccess)
+
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.WriteAccess)
+
+	err = s.State.RemoveGroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.GroupAccess("devs", s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *groupSuite) TestSetGroupAccessInvalidAccess(c *gc.C) {
+	_, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.SuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `"superuser" model access not valid`)
+}
+
+func (s *groupSuite) TestSetGroupAccessNoGroup(c *gc.C) {
+	err := s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *groupSuite) TestEffectiveUserAccessFromGroup(c *gc.C) {
+	fred := s.makeUser(c, "fred")
+	_, err := s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.UserTag, gc.Equals, fred)
+	c.Assert(access.Object, gc.Equals, names.Tag(s.State.ModelTag()))
+	c.Assert(access.Access, gc.Equals, permission.WriteAccess)
+}
+
+func (s *groupSuite) TestEffectiveUserAccessUnion(c *gc.C) {
+	fred := s.Factory.MakeUser(c, &factory.UserParams{Name: "fred", Access: permission.AdminAccess}).UserTag()
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Direct access is greater than the group access.
+	access, err := s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+
+	// Group access is greater than the direct access.
+	err = s.State.SetGroupAccess("devs", s.State.ControllerTag(), permission.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.EffectiveUserAccess(fred, s.State.ControllerTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *groupSuite) TestEffectiveUserAccessGreatestGroup(c *gc.C) {
+	fred := s.makeUser(c, "fred")
+	for name, access := range map[string]permission.Access{
+		"devs": permission.ReadAccess,
+		"ops":  permission.AdminAccess,
+		"qa":   permission.WriteAccess,
+	} {
+		group, err := s.State.AddGroup(name, "admin")
+		c.Assert(err, jc.ErrorIsNil)
+		err = group.AddMember(fred)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.State.SetGroupAccess(name, s.State.ModelTag(), access)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	access, err := s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access.Access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *groupSuite) TestEffectiveUserAccessRemovedUser(c *gc.C) {
+	fred := s.makeUser(c, "fred")
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUser(fred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *groupSuite) TestRemoveGroup(c *gc.C) {
+	fred := s.makeUser(c, "fred")
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(fred)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", s.State.ModelTag(), permission.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Group("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.EffectiveUserAccess(fred, s.State.ModelTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -148,6 +148,10 @@ type machineDoc struct {
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
+
+	// Pool holds the id of the machine pool the machine is reserved
+	// for. It is cleared when a unit claims the machine.
+	Pool string `bson:"pool,omitempty"`
 }
 
 func newMachine(st *State, doc *machineDoc) *Machine {
@@ -654,10 +658,23 @@ func (original *Machine) advanceLifecycle(life Life) (err error) {
 			if m.doc.Life == Dead {
 				return nil, jujutxn.ErrNoOperations
 			}
-			advanceAsserts = append(advanceAsserts, notDeadDoc...)
+			if m.doc.Life == Alive && m.doc.Pool != "" {
+				// The pool's machine count depends on the
+				// machine leaving the pool only once.
+				advanceAsserts = append(advanceAsserts, isAliveDoc...)
+			} else {
+				advanceAsserts = append(advanceAsserts, notDeadDoc...)
+			}
 		default:
 			panic(fmt.Errorf("cannot advance lifecycle to %v", life))
 		}
+		// A pooled machine leaves its pool once it is no longer alive,
+		// so that the pool is topped up again.
+		var poolOps []txn.Op
+		if m.doc.Life == Alive && m.doc.Pool != "" {
+			advanceAsserts = append(advanceAsserts, bson.DocElem{"pool", m.doc.Pool})
+			poolOps = append(poolOps, leaveMachinePoolOp(m.st, m.doc.Pool))
+		}
 		// Check that the machine does not have any responsibilities that
 		// prevent a lifecycle change.
 		if hasJob(m.doc.Jobs, JobManageModel) {
@@ -715,7 +732,7 @@ func (original *Machine) advanceLifecycle(life Life) (err error) {
 						{{"children", bson.D{{"$exists", false}}}},
 					}}},
 				}
-				return []txn.Op{op, containerCheck, cleanupOp}, nil
+				return append([]txn.Op{op, containerCheck, cleanupOp}, poolOps...), nil
 			}
 		}
 
@@ -739,7 +756,7 @@ func (original *Machine) advanceLifecycle(life Life) (err error) {
 
 		// Add the additional asserts needed for this transaction.
 		op.Assert = advanceAsserts
-		return []txn.Op{op, cleanupOp}, nil
+		return append([]txn.Op{op, cleanupOp}, poolOps...), nil
 	}
 	if err = m.st.run(buildTxn); err == jujutxn.ErrExcessiveContention {
 		err = errors.Annotatef(err, "machine %s cannot advance lifecycle", m)
@@ -1344,16 +1361,24 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+// familyMatch reports whether addr can be kept as a preferred address
+// under the given IP family. Only PreferIPv6 asks for a stored IPv4
+// address to be replaced; the other families keep any exact scope match.
+func familyMatch(addr network.Address, family network.IPFamily) bool {
+	return family != network.PreferIPv6 || addr.Type != network.IPv4Address
+}
+
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, family network.IPFamily) ([]txn.Op, address, bool) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef("machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v", m.Id(), publicAddress, providerAddresses, machineAddresses)
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
+		netAddr := addr.networkAddress()
+		return network.ExactScopeMatch(netAddr, network.ScopePublic) && familyMatch(netAddr, family)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := network.SelectPublicAddressForFamily(networkAddresses(addresses), family)
 		return addr
 	}
 
@@ -1367,15 +1392,16 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, newAddr, true
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, address, bool) {
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, family network.IPFamily) ([]txn.Op, address, bool) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
-		return network.ExactScopeMatch(addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal)
+		netAddr := addr.networkAddress()
+		return network.ExactScopeMatch(netAddr, network.ScopeMachineLocal, network.ScopeCloudLocal) && familyMatch(netAddr, family)
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := network.SelectInternalAddressForFamily(networkAddresses(addresses), false, family)
 		return addr
 	}
 
@@ -1395,7 +1421,7 @@ func (m *Machine) SetProviderAddresses(addresses ...network.Address) (err error)
 	if err != nil {
 		return errors.Annotatef(err, "cannot refresh provider addresses for machine %s", m)
 	}
-	if err = m.setAddresses(addresses, &mdoc.Addresses, "addresses"); err != nil {
+	if err = m.setAddresses(addresses, &mdoc.Addresses, "addresses", m.st.addressIPFamily()); err != nil {
 		return fmt.Errorf("cannot set addresses of machine %v: %v", m, err)
 	}
 	m.doc.Addresses = mdoc.Addresses
@@ -1427,7 +1453,7 @@ func (m *Machine) SetMachineAddresses(addresses ...network.Address) (err error)
 	if err != nil {
 		return errors.Annotatef(err, "cannot refresh machine addresses for machine %s", m)
 	}
-	if err = m.setAddresses(addresses, &mdoc.MachineAddresses, "machineaddresses"); err != nil {
+	if err = m.setAddresses(addresses, &mdoc.MachineAddresses, "machineaddresses", m.st.addressIPFamily()); err != nil {
 		return fmt.Errorf("cannot set machine addresses of machine %v: %v", m, err)
 	}
 	m.doc.MachineAddresses = mdoc.MachineAddresses
@@ -1435,15 +1461,16 @@ func (m *Machine) SetMachineAddresses(addresses ...network.Address) (err error)
 }
 
 // setAddresses updates the machine's addresses (either Addresses or
-// MachineAddresses, depending on the field argument). Changes are
-// only predicated on the machine not being Dead; concurrent address
+// MachineAddresses, depending on the field argument), choosing the
+// preferred addresses by the given IP family. Changes are only
+// predicated on the machine not being Dead; concurrent address
 // changes are ignored.
-func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fieldName string) error {
+func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fieldName string, family network.IPFamily) error {
 	addressesToSet := make([]network.Address, len(addresses))
 	copy(addressesToSet, addresses)
 
 	// Update addresses now.
-	network.SortAddresses(addressesToSet)
+	network.SortAddressesForFamily(addressesToSet, family)
 	origin := OriginProvider
 	if fieldName == "machineaddresses" {
 		origin = OriginMachine
@@ -1483,8 +1510,8 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 		}
 
 		var setPrivateAddressOps, setPublicAddressOps []txn.Op
-		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses)
-		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses)
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(providerAddresses, machineAddresses, family)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(providerAddresses, machineAddresses, family)
 		ops = append(ops, setPrivateAddressOps...)
 		ops = append(ops, setPublicAddressOps...)
 		return ops, nil
@@ -1497,6 +1524,13 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 	}
 
 	*field = stateAddresses
+	m.recordPreferredAddresses(newPrivate, changedPrivate, newPublic, changedPublic)
+	return nil
+}
+
+// recordPreferredAddresses updates the machine's cached preferred
+// addresses after they have been changed in the database.
+func (m *Machine) recordPreferredAddresses(newPrivate address, changedPrivate bool, newPublic address, changedPublic bool) {
 	if changedPrivate {
 		oldPrivate := m.doc.PreferredPrivateAddress.networkAddress()
 		m.doc.PreferredPrivateAddress = newPrivate
@@ -1513,6 +1547,44 @@ func (m *Machine) setAddresses(addresses []network.Address, field *[]address, fi
 			m.Id(), oldPublic, newPublic.networkAddress(),
 		)
 	}
+}
+
+// updatePreferredAddresses re-evaluates the machine's preferred
+// addresses against its current addresses under the given IP family.
+// It is called by the cleanup that follows a change to the model's
+// preferred-ip-family.
+func (m *Machine) updatePreferredAddresses(family network.IPFamily) error {
+	var (
+		newPrivate, newPublic         address
+		changedPrivate, changedPublic bool
+		err                           error
+	)
+	machine := m
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		changedPrivate, changedPublic = false, false
+		if attempt != 0 {
+			if machine, err = machine.st.Machine(machine.doc.Id); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if machine.doc.Life == Dead {
+			return nil, jujutxn.ErrNoOperations
+		}
+		var setPrivateAddressOps, setPublicAddressOps []txn.Op
+		setPrivateAddressOps, newPrivate, changedPrivate = machine.setPrivateAddressOps(machine.doc.Addresses, machine.doc.MachineAddresses, family)
+		setPublicAddressOps, newPublic, changedPublic = machine.setPublicAddressOps(machine.doc.Addresses, machine.doc.MachineAddresses, family)
+		ops := append(setPrivateAddressOps, setPublicAddressOps...)
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := m.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update preferred addresses of machine %v", m)
+	}
+	m.recordPreferredAddresses(newPrivate, changedPrivate, newPublic, changedPublic)
 	return nil
 }
 
@@ -1532,6 +1604,12 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// Pool returns the id of the machine pool the machine is reserved for,
+// or the empty string if the machine is not pooled.
+func (m *Machine) Pool() string {
+	return m.doc.Pool
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
@@ -1627,6 +1705,23 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 	})
 }
 
+// SetStatusData sets the given keys in the data of the machine's status
+// without changing the status itself, so that workers can report on the
+// machine without taking over its status. Keys with nil values are
+// removed.
+func (m *Machine) SetStatusData(data map[string]interface{}) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set status data")
+	if len(data) == 0 {
+		return nil
+	}
+	ops := []txn.Op{setStatusDataOp(m.st, m.globalKey(), data)}
+	err = m.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("machine")
+	}
+	return errors.Trace(err)
+}
+
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
 // or items as old as filter.Date or items newer than now - filter.Delta time
 // representing past statuses for this machine.
@@ -0,0 +1,362 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/constraints"
+)
+
+// machinePoolDoc represents a pool of machines that are provisioned
+// ahead of demand, so that units can be placed on them without
+// waiting for a new instance to start.
+type machinePoolDoc struct {
+	DocID     string `bson:"_id"`
+	Id        string `bson:"poolid"`
+	ModelUUID string `bson:"model-uuid"`
+	Series    string `bson:"series"`
+
+	// Size is the number of unclaimed machines the pool should hold.
+	Size int `bson:"size"`
+
+	// Machines counts the alive machines reserved for the pool that
+	// have not been claimed by a unit. It changes whenever a machine
+	// joins or leaves the pool, so that watchers of the pool are
+	// notified that it may need topping up.
+	Machines int `bson:"machines"`
+}
+
+// MachinePool represents a pool of pre-provisioned machines.
+type MachinePool struct {
+	st  *State
+	doc machinePoolDoc
+}
+
+func newMachinePool(st *State, doc *machinePoolDoc) *MachinePool {
+	return &MachinePool{st: st, doc: *doc}
+}
+
+// Id returns the machine pool id.
+func (p *MachinePool) Id() string {
+	return p.doc.Id
+}
+
+// Series returns the series of the machines in the pool.
+func (p *MachinePool) Series() string {
+	return p.doc.Series
+}
+
+// Size returns the number of unclaimed machines the pool should hold.
+func (p *MachinePool) Size() int {
+	return p.doc.Size
+}
+
+// Refresh refreshes the contents of the machine pool from the
+// underlying state.
+func (p *MachinePool) Refresh() error {
+	pool, err := p.st.MachinePool(p.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p.doc = pool.doc
+	return nil
+}
+
+// Constraints returns the constraints used for machines added to
+// the pool.
+func (p *MachinePool) Constraints() (constraints.Value, error) {
+	return readConstraints(p.st, machinePoolGlobalKey(p.doc.Id))
+}
+
+// Machines returns the alive machines that are currently reserved
+// for the pool and have not yet been claimed by a unit.
+func (p *MachinePool) Machines() ([]*Machine, error) {
+	machinesCollection, closer := p.st.getCollection(machinesC)
+	defer closer()
+
+	var docs []machineDoc
+	err := machinesCollection.Find(bson.D{
+		{"pool", p.doc.Id},
+		{"life", Alive},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machines for machine pool %q", p.doc.Id)
+	}
+	machines := make([]*Machine, len(docs))
+	for i := range docs {
+		machines[i] = newMachine(p.st, &docs[i])
+	}
+	return machines, nil
+}
+
+// SetSize changes the number of unclaimed machines the pool should
+// hold. When the pool shrinks, its surplus machines are destroyed;
+// machines claimed by a unit while the pool is being resized are left
+// alone.
+func (p *MachinePool) SetSize(size int) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size of machine pool %q", p.doc.Id)
+	if size <= 0 {
+		return errors.NotValidf("pool size %d", size)
+	}
+	ops := []txn.Op{{
+		C:      machinePoolsC,
+		Id:     p.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"size", size}}}},
+	}}
+	if err := p.st.runTransaction(ops); errors.Cause(err) == txn.ErrAborted {
+		return errors.NotFoundf("machine pool %q", p.doc.Id)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	p.doc.Size = size
+
+	machines, err := p.Machines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := size; i < len(machines); i++ {
+		if err := machines[i].Destroy(); err != nil && !IsHasAssignedUnitsError(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Remove removes the machine pool and destroys the machines it holds.
+// Machines claimed by a unit while the pool is being removed are left
+// alone.
+func (p *MachinePool) Remove() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove machine pool %q", p.doc.Id)
+	machinesCollection, closer := p.st.getCollection(machinesC)
+	defer closer()
+
+	var machines []*Machine
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		machines = nil
+		if attempt > 0 {
+			if _, err := p.st.MachinePool(p.doc.Id); errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		var docs []machineDoc
+		if err := machinesCollection.Find(bson.D{{"pool", p.doc.Id}}).All(&docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      machinePoolsC,
+			Id:     p.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}, removeConstraintsOp(p.st, machinePoolGlobalKey(p.doc.Id))}
+		// The machines are taken out of the pool along with the pool
+		// itself, so that they can't be claimed once it has gone.
+		for i := range docs {
+			ops = append(ops, txn.Op{
+				C:      machinesC,
+				Id:     docs[i].DocID,
+				Assert: bson.D{{"pool", p.doc.Id}},
+				Update: bson.D{{"$unset", bson.D{{"pool", nil}}}},
+			})
+			if docs[i].Life == Alive {
+				machines = append(machines, newMachine(p.st, &docs[i]))
+			}
+		}
+		return ops, nil
+	}
+	if err := p.st.run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if err := m.Destroy(); err != nil && !IsHasAssignedUnitsError(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// machinePoolGlobalKey returns the global database key for the
+// machine pool with the given id.
+func machinePoolGlobalKey(id string) string {
+	return "mp#" + id
+}
+
+// AddMachinePool adds a pool that holds size machines of the given
+// series and constraints. The machines themselves are added by
+// TopUpMachinePools.
+func (st *State) AddMachinePool(size int, series string, cons constraints.Value) (_ *MachinePool, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add machine pool")
+	if size <= 0 {
+		return nil, errors.NotValidf("pool size %d", size)
+	}
+	if series == "" {
+		return nil, errors.New("no series specified")
+	}
+	unsupported, err := st.validateConstraints(cons)
+	if len(unsupported) > 0 {
+		logger.Warningf(
+			"adding machine pool: unsupported constraints: %v", unsupported)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := st.sequence("machinepool")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := &machinePoolDoc{
+		DocID:     st.docID(id),
+		Id:        id,
+		ModelUUID: st.ModelUUID(),
+		Series:    series,
+		Size:      size,
+	}
+	ops := []txn.Op{
+		assertModelActiveOp(st.ModelUUID()),
+		createConstraintsOp(st, machinePoolGlobalKey(id), cons),
+		{
+			C:      machinePoolsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: doc,
+		},
+	}
+	if err := st.runTransaction(ops); err != nil {
+		if errors.Cause(err) == txn.ErrAborted {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return nil, errors.Trace(err)
+	}
+	return newMachinePool(st, doc), nil
+}
+
+// MachinePool returns the machine pool with the given id.
+func (st *State) MachinePool(id string) (*MachinePool, error) {
+	machinePools, closer := st.getCollection(machinePoolsC)
+	defer closer()
+
+	var doc machinePoolDoc
+	err := machinePools.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("machine pool %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get machine pool %q", id)
+	}
+	return newMachinePool(st, &doc), nil
+}
+
+// AllMachinePools returns all the machine pools in the model.
+func (st *State) AllMachinePools() ([]*MachinePool, error) {
+	machinePools, closer := st.getCollection(machinePoolsC)
+	defer closer()
+
+	var docs []machinePoolDoc
+	if err := machinePools.Find(nil).Sort("poolid").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get all machine pools")
+	}
+	pools := make([]*MachinePool, len(docs))
+	for i := range docs {
+		pools[i] = newMachinePool(st, &docs[i])
+	}
+	return pools, nil
+}
+
+// TopUpMachinePools adds machines to every machine pool in the model
+// that holds fewer unclaimed machines than its size, and returns the
+// machines that were added.
+func (st *State) TopUpMachinePools() (_ []*Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot top up machine pools")
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var added []*Machine
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		added = nil
+		if attempt > 0 {
+			if err := checkModelActive(st); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		pools, err := st.AllMachinePools()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, pool := range pools {
+			missing := pool.doc.Size - pool.doc.Machines
+			if missing <= 0 {
+				continue
+			}
+			cons, err := pool.Constraints()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			template := MachineTemplate{
+				Series:      pool.doc.Series,
+				Constraints: cons,
+				Jobs:        []MachineJob{JobHostUnits},
+				Pool:        pool.doc.Id,
+			}
+			for i := 0; i < missing; i++ {
+				mdoc, addOps, err := st.addMachineOps(template, family)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				added = append(added, newMachine(st, mdoc))
+				ops = append(ops, addOps...)
+			}
+			// Asserting the count guards against topping up a pool
+			// that has been topped up or claimed from concurrently.
+			ops = append(ops, txn.Op{
+				C:      machinePoolsC,
+				Id:     pool.doc.DocID,
+				Assert: bson.D{{"machines", pool.doc.Machines}},
+				Update: bson.D{{"$inc", bson.D{{"machines", missing}}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return added, nil
+}
+
+// claimPooledMachineOps returns the operations to take the given
+// machine out of its pool.
+func claimPooledMachineOps(m *Machine) []txn.Op {
+	leaveOp := leaveMachinePoolOp(m.st, m.doc.Pool)
+	leaveOp.Assert = txn.DocExists
+	return []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: bson.D{{"pool", m.doc.Pool}},
+		Update: bson.D{{"$unset", bson.D{{"pool", nil}}}},
+	}, leaveOp}
+}
+
+// leaveMachinePoolOp returns the operation to count a machine out of
+// the given pool, when it is claimed or stops being alive.
+func leaveMachinePoolOp(st *State, poolId string) txn.Op {
+	return txn.Op{
+		C:      machinePoolsC,
+		Id:     st.docID(poolId),
+		Update: bson.D{{"$inc", bson.D{{"machines", -1}}}},
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type MachinePoolSuite struct {
+	ConnSuite
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&MachinePoolSuite{})
+
+func (s *MachinePoolSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *MachinePoolSuite) TestAddMachinePool(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	pool, err := s.State.AddMachinePool(2, "quantal", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Id(), gc.Equals, "0")
+	c.Assert(pool.Size(), gc.Equals, 2)
+	c.Assert(pool.Series(), gc.Equals, "quantal")
+
+	pool, err = s.State.MachinePool("0")
+	c.Assert(err, jc.ErrorIsNil)
+	poolCons, err := pool.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(poolCons, jc.DeepEquals, cons)
+
+	pools, err := s.State.AllMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pools, gc.HasLen, 1)
+	c.Assert(pools[0].Id(), gc.Equals, "0")
+}
+
+func (s *MachinePoolSuite) TestAddMachinePoolInvalid(c *gc.C) {
+	_, err := s.State.AddMachinePool(0, "quantal", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "cannot add machine pool: pool size 0 not valid")
+	_, err = s.State.AddMachinePool(1, "", constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "cannot add machine pool: no series specified")
+}
+
+func (s *MachinePoolSuite) TestMachinePoolNotFound(c *gc.C) {
+	_, err := s.State.MachinePool("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachinePoolSuite) TestTopUpMachinePools(c *gc.C) {
+	pool, err := s.State.AddMachinePool(2, "quantal", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 2)
+	for _, m := range added {
+		c.Assert(m.Pool(), gc.Equals, pool.Id())
+		c.Assert(m.Series(), gc.Equals, "quantal")
+		c.Assert(m.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+		cons, err := m.Constraints()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(*cons.Mem, gc.Equals, uint64(4096))
+	}
+
+	// A full pool is left alone.
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 0)
+
+	machines, err := pool.Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+}
+
+func (s *MachinePoolSuite) TestAssignUnitPrefersPooledMachine(c *gc.C) {
+	// A clean machine outside the pool is not used while a
+	// pooled machine is available.
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 1)
+
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, added[0].Id())
+
+	// The claimed machine has left the pool, so the pool
+	// is topped up again.
+	m, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Pool(), gc.Equals, "")
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 1)
+}
+
+func (s *MachinePoolSuite) TestAssignUnitPooledMachineConstraints(c *gc.C) {
+	_, err := s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware("mem=2G")
+	err = added[0].SetProvisioned("inst-0", "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.wordpress.SetConstraints(constraints.MustParse("mem=8G"))
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignToPooledMachine()
+	c.Assert(err, gc.ErrorMatches, "no eligible pooled machines available")
+
+	// Pooled machines are not handed out as ordinary clean machines.
+	err = s.State.AssignUnit(unit, state.AssignClean)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Not(gc.Equals), added[0].Id())
+}
+
+func (s *MachinePoolSuite) TestWatchMachinePools(c *gc.C) {
+	w := s.State.WatchMachinePools()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	_, err := s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	unit, err := s.wordpress.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = unit.AssignToPooledMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// A pooled machine that is destroyed leaves the pool.
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = added[0].Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *MachinePoolSuite) TestTopUpAfterPooledMachineDies(c *gc.C) {
+	pool, err := s.State.AddMachinePool(2, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 2)
+
+	err = added[0].Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = added[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	machines, err := pool.Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 2)
+}
+
+func (s *MachinePoolSuite) TestTopUpMachinePoolsConcurrently(c *gc.C) {
+	pool, err := s.State.AddMachinePool(2, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		added, err := s.State.TopUpMachinePools()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(added, gc.HasLen, 2)
+	}).Check()
+
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 0)
+	machines, err := pool.Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+}
+
+func (s *MachinePoolSuite) TestSetSize(c *gc.C) {
+	pool, err := s.State.AddMachinePool(1, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = pool.SetSize(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Size(), gc.Equals, 3)
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 2)
+
+	// Shrinking the pool destroys its surplus machines.
+	err = pool.SetSize(1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = pool.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pool.Size(), gc.Equals, 1)
+	machines, err := pool.Machines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 0)
+
+	err = pool.SetSize(0)
+	c.Assert(err, gc.ErrorMatches, `cannot set size of machine pool "0": pool size 0 not valid`)
+}
+
+func (s *MachinePoolSuite) TestRemove(c *gc.C) {
+	pool, err := s.State.AddMachinePool(2, "quantal", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 2)
+
+	err = pool.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.MachinePool(pool.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	for _, m := range added {
+		err := m.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(m.Pool(), gc.Equals, "")
+		c.Assert(m.Life(), gc.Equals, state.Dying)
+	}
+
+	// The machines are not replaced, and removing the pool
+	// again does nothing.
+	added, err = s.State.TopUpMachinePools()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(added, gc.HasLen, 0)
+	err = pool.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = pool.SetSize(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set size of machine pool "0": machine pool "0" not found`)
+}
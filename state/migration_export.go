@@ -186,7 +186,22 @@ func (e *exporter) readBlocks() (map[string]string, error) {
 	}
 
 	result := make(map[string]string)
+	now := e.st.clock.Now()
 	for _, doc := range docs {
+		// Expired blocks no longer apply, and are removed by the
+		// next cleanup.
+		if !doc.activeAt(now) {
+			continue
+		}
+		// The model description can only hold model-wide blocks
+		// that never expire, and dropping the targets or expiry
+		// of a block would change what it blocks.
+		if len(doc.Targets) > 0 {
+			return nil, errors.NotSupportedf("migrating %s block with targets", doc.Type.MigrationValue())
+		}
+		if !doc.Expiry.IsZero() {
+			return nil, errors.NotSupportedf("migrating %s block with expiry", doc.Type.MigrationValue())
+		}
 		// We don't care about the id, uuid, or tag.
 		// The uuid and tag both refer to the model uuid, and the
 		// id is opaque - even though it is sequence generated.
@@ -597,6 +612,11 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	if !found {
 		return errors.Errorf("missing leadership settings for application %q", appName)
 	}
+	// The model description cannot hold expose settings, and
+	// dropping them would expose the application to everyone.
+	if len(application.doc.ExposedEndpoints) > 0 {
+		return errors.NotSupportedf("migrating application %q with expose settings", appName)
+	}
 
 	args := description.ApplicationArgs{
 		Tag:                  application.ApplicationTag(),
@@ -1312,6 +1332,7 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		Spaces:       optionalStringSlice("spaces"),
 		Tags:         optionalStringSlice("tags"),
 		VirtType:     optionalString("virttype"),
+		Zones:        optionalStringSlice("zones"),
 	}
 	if optionalErr != nil {
 		return description.ConstraintsArgs{}, errors.Trace(optionalErr)
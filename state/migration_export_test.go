@@ -9,6 +9,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -184,6 +185,46 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestExpiredBlocksNotExported(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Message: "no changes until yesterday",
+		Expiry:  time.Now().Add(-time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Blocks(), gc.HasLen, 0)
+}
+
+func (s *MigrationExportSuite) TestExpiringBlockNotSupported(c *gc.C) {
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.ChangeBlock,
+		Message: "no changes until Monday",
+		Expiry:  time.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "migrating all-changes block with expiry not supported")
+}
+
+func (s *MigrationExportSuite) TestTargetedBlockNotSupported(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := s.State.SwitchBlockOnWithArgs(state.BlockArgs{
+		Type:    state.RemoveBlock,
+		Message: "keep it",
+		Targets: []names.Tag{application.ApplicationTag()},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, "migrating remove-object block with targets not supported")
+}
+
 func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	// Make sure we have some last connection times for the admin user,
 	// and create a few other users.
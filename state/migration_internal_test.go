@@ -97,6 +97,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guisettingsC,
 		// Users aren't migrated.
 		usersC,
+		// Groups are controller global, and aren't migrated either.
+		groupsC,
+		// API tokens are tied to the controller that minted them.
+		apiTokensC,
 		userLastLoginC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
@@ -110,6 +114,12 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// reference counts are implementation details that should be
 		// reconstructed on the other side.
 		refcountsC,
+		// Rolling upgrade progress isn't migrated; units that were
+		// not yet released are upgraded on the target controller.
+		rollingUpgradesC,
+		// Charm history isn't migrated, as only the charms that
+		// applications are currently using are exported.
+		charmHistoryC,
 		// upgradeInfoC is used to coordinate upgrades and schema migrations,
 		// and aren't needed for model migrations.
 		upgradeInfoC,
@@ -142,6 +152,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// This is a transitory collection of units that need to be assigned
 		// to machines.
 		assignUnitC,
+		// Machine pools are not migrated; pooled machines are exported
+		// as ordinary clean machines.
+		machinePoolsC,
 
 		// The model entity references collection will be repopulated
 		// after importing the model. It does not need to be migrated
@@ -289,6 +302,8 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Machine pools are not migrated.
+		"Pool",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -350,6 +365,12 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// RollingUpgradeBatch only serves to notify watchers of the
+		// application when a rolling upgrade releases units.
+		"RollingUpgradeBatch",
+		// The model description cannot hold expose settings, so
+		// applications with expose settings cannot be migrated.
+		"ExposedEndpoints",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -490,6 +511,13 @@ func (s *MigrationSuite) TestBlockDocFields(c *gc.C) {
 		// Tag is just string representation of the model tag,
 		// which also contains the model-uuid.
 		"Tag",
+		// The model description only holds model-wide blocks
+		// that never expire; models with targeted or expiring
+		// blocks cannot be migrated. Who created the block is
+		// not migrated.
+		"Targets",
+		"Expiry",
+		"CreatedBy",
 	)
 	migrated := set.NewStrings(
 		"Type",
@@ -526,6 +554,7 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		"Zones",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -6,10 +6,13 @@ package state
 import (
 	"github.com/juju/errors"
 	"github.com/juju/schema"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
 )
 
 type attrValues map[string]interface{}
@@ -29,6 +32,42 @@ func (st *State) ModelConfig() (*config.Config, error) {
 	return config.New(config.NoDefaults, modelSettings.Map())
 }
 
+// preferredIPFamily returns the IP address family the model's
+// preferred-ip-family setting asks for. Only that setting is read
+// from the model's settings, as addresses are set frequently and
+// don't need the rest of the model config.
+func (st *State) preferredIPFamily() (network.IPFamily, error) {
+	settings, closer := st.getCollection(settingsC)
+	defer closer()
+
+	var doc struct {
+		Settings settingsMap `bson:"settings"`
+	}
+	field := "settings." + config.PreferredIPFamilyKey
+	err := settings.FindId(modelGlobalKey).Select(bson.D{{field, 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return "", errors.NotFoundf("model settings")
+	} else if err != nil {
+		return "", errors.Annotate(err, "cannot read preferred IP family")
+	}
+	if value, ok := doc.Settings[config.PreferredIPFamilyKey].(string); ok && value != "" {
+		return network.IPFamily(value), nil
+	}
+	return network.PreferIPv4, nil
+}
+
+// addressIPFamily returns the model's preferred IP family for choosing
+// machine addresses. Address updates must not fail because the model
+// settings can't be read, so the default family is used in that case.
+func (st *State) addressIPFamily() network.IPFamily {
+	family, err := st.preferredIPFamily()
+	if err != nil {
+		logger.Warningf("cannot read preferred IP family, using %q: %v", network.PreferIPv4, err)
+		return network.PreferIPv4
+	}
+	return family
+}
+
 // checkModelConfig returns an error if the config is definitely invalid.
 func checkModelConfig(cfg *config.Config) error {
 	allAttrs := cfg.AllAttrs()
@@ -345,7 +384,12 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if validCfg.PreferredIPFamily() != oldConfig.PreferredIPFamily() {
+		// The machines' preferred addresses are re-evaluated by
+		// the cleanup, so that failures are retried.
+		ops = append(ops, newCleanupOp(cleanupPreferredAddresses, ""))
+	}
+	return errors.Trace(modelSettings.write(ops))
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
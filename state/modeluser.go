@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -207,23 +208,22 @@ func (st *State) allUserModels() ([]*UserModel, error) {
 }
 
 // ModelsForUser returns a list of models that the user
-// is able to access.
+// is able to access, either directly or through one of their groups.
 func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	// Consider the controller permissions overriding Model permission, for
 	// this case the only relevant one is superuser.
 	// The mgo query below wont work for superuser case because it needs at
 	// least one model user per model.
-	access, err := st.UserAccess(user, st.controllerTag)
+	access, err := st.EffectiveUserAccess(user, st.controllerTag)
 	if err == nil && access.Access == permission.SuperuserAccess {
 		return st.allUserModels()
 	}
 	if err != nil && !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
-	// Since there are no groups at this stage, the simplest way to get all
-	// the models that a particular user can see is to look through the
-	// model user collection. A raw collection is required to support
-	// queries across multiple models.
+	// The models a user can see directly are found through the model
+	// user collection. A raw collection is required to support queries
+	// across multiple models.
 	modelUsers, userCloser := st.getRawCollection(modelUsersC)
 	defer userCloser()
 
@@ -232,10 +232,20 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	if err != nil {
 		return nil, err
 	}
+	modelUUIDs := set.NewStrings()
+	for _, doc := range userSlice {
+		modelUUIDs.Add(doc.ObjectUUID)
+	}
+	// Add the models the user can see through their groups.
+	groupModelUUIDs, err := st.groupModelUUIDs(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelUUIDs = modelUUIDs.Union(set.NewStrings(groupModelUUIDs...))
 
 	var result []*UserModel
-	for _, doc := range userSlice {
-		modelTag := names.NewModelTag(doc.ObjectUUID)
+	for _, modelUUID := range modelUUIDs.SortedValues() {
+		modelTag := names.NewModelTag(modelUUID)
 		env, err := st.GetModel(modelTag)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -247,9 +257,10 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	return result, nil
 }
 
-// IsControllerAdmin returns true if the user specified has Super User Access.
+// IsControllerAdmin returns true if the user specified has Super User Access,
+// either directly or through one of their groups.
 func (st *State) IsControllerAdmin(user names.UserTag) (bool, error) {
-	ua, err := st.UserAccess(user, st.ControllerTag())
+	ua, err := st.EffectiveUserAccess(user, st.ControllerTag())
 	if errors.IsNotFound(err) {
 		return false, nil
 	}
@@ -472,6 +472,59 @@ func (s *ModelUserSuite) TestModelsForUserMultiple(c *gc.C) {
 	}
 }
 
+func (s *ModelUserSuite) addGroupMember(c *gc.C, user names.UserTag, target names.Tag, access permission.Access) {
+	group, err := s.State.AddGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(user)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetGroupAccess("devs", target, access)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ModelUserSuite) TestModelsForUserThroughGroup(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	envState := s.Factory.MakeModel(c, &factory.ModelParams{Name: "group-model"})
+	defer envState.Close()
+	model, err := envState.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.addGroupMember(c, user.UserTag(), model.ModelTag(), permission.ReadAccess)
+
+	models, err := s.State.ModelsForUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	s.checkSameModel(c, models[0].Model, model)
+}
+
+func (s *ModelUserSuite) TestModelsForUserDirectAndThroughGroup(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	s.addGroupMember(c, user.UserTag(), model.ModelTag(), permission.WriteAccess)
+
+	// The model is only listed once.
+	models, err := s.State.ModelsForUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	s.checkSameModel(c, models[0].Model, model)
+}
+
+func (s *ModelUserSuite) TestModelsForUserSuperuserThroughGroup(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	envState := s.Factory.MakeModel(c, nil)
+	defer envState.Close()
+	s.addGroupMember(c, user.UserTag(), s.State.ControllerTag(), permission.SuperuserAccess)
+
+	all, err := s.State.AllModels()
+	c.Assert(err, jc.ErrorIsNil)
+	models, err := s.State.ModelsForUser(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, len(all))
+
+	isAdmin, err := s.State.IsControllerAdmin(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
 func (s *ModelUserSuite) TestIsControllerAdmin(c *gc.C) {
 	isAdmin, err := s.State.IsControllerAdmin(s.Owner)
 	c.Assert(err, jc.ErrorIsNil)
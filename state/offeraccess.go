@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/permission"
+)
+
+// offerGlobalKeyPrefix is the prefix of the global key used as the
+// object of application offer permissions.
+const offerGlobalKeyPrefix = "ao"
+
+// offerGlobalKey returns the global database key for the application
+// offer at the specified URL.
+func offerGlobalKey(offerURL string) string {
+	return fmt.Sprintf("%s#%s", offerGlobalKeyPrefix, offerURL)
+}
+
+// GetOfferAccess gets the access permission for the specified user
+// on the application offer at the specified URL.
+func (st *State) GetOfferAccess(offerURL string, user names.UserTag) (permission.Access, error) {
+	perm, err := st.userPermission(offerGlobalKey(offerURL), userGlobalKey(userAccessID(user)))
+	if err != nil {
+		return permission.NoAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// CreateOfferAccess creates a new access permission for the specified
+// user on the application offer at the specified URL.
+func (st *State) CreateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error {
+	if err := permission.ValidateOfferAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	// Ensure local user exists in state before granting them access.
+	if user.IsLocal() {
+		if _, err := st.User(user); err != nil {
+			return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+		}
+	}
+	op := createPermissionOp(offerGlobalKey(offerURL), userGlobalKey(userAccessID(user)), access)
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("permission for user %q for offer %q", user.Id(), offerURL)
+	}
+	return errors.Trace(err)
+}
+
+// UpdateOfferAccess changes the access permission for the specified
+// user on the application offer at the specified URL.
+func (st *State) UpdateOfferAccess(offerURL string, user names.UserTag, access permission.Access) error {
+	if err := permission.ValidateOfferAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	op := updatePermissionOp(offerGlobalKey(offerURL), userGlobalKey(userAccessID(user)), access)
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("existing permissions")
+	}
+	return errors.Trace(err)
+}
+
+// RemoveOfferAccess removes the access permission for the specified
+// user on the application offer at the specified URL.
+func (st *State) RemoveOfferAccess(offerURL string, user names.UserTag) error {
+	op := removePermissionOp(offerGlobalKey(offerURL), userGlobalKey(userAccessID(user)))
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NewNotFound(nil, fmt.Sprintf("offer user %q does not exist", user.Id()))
+	}
+	return errors.Trace(err)
+}
+
+// removeOfferAccessOps returns the operations required to remove all
+// access permissions for the application offer at the specified URL.
+func (st *State) removeOfferAccessOps(offerURL string) ([]txn.Op, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	query := bson.D{{"object-global-key", offerGlobalKey(offerURL)}}
+	if err := permissions.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading permissions for offer %q", offerURL)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// offerAccessibleBy returns whether any of the specified users has at
+// least read access to the application offer at the specified URL.
+func (st *State) offerAccessibleBy(offerURL string, users []names.UserTag) (bool, error) {
+	for _, user := range users {
+		access, err := st.GetOfferAccess(offerURL, user)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if access.EqualOrGreaterOfferAccessThan(permission.ReadAccess) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
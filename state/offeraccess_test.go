@@ -0,0 +1,93 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/testing/factory"
+)
+
+type offerAccessSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&offerAccessSuite{})
+
+const offerURL = "local:/u/me/mysql"
+
+func (s *offerAccessSuite) makeUser(c *gc.C, name string) names.UserTag {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: name, NoModelUser: true})
+	return user.UserTag()
+}
+
+func (s *offerAccessSuite) TestCreateAndGetOfferAccess(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.CreateOfferAccess(offerURL, user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetOfferAccess(offerURL, user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.ConsumeAccess)
+}
+
+func (s *offerAccessSuite) TestCreateOfferAccessAlreadyExists(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.CreateOfferAccess(offerURL, user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CreateOfferAccess(offerURL, user, permission.ConsumeAccess)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *offerAccessSuite) TestCreateOfferAccessInvalidAccess(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.CreateOfferAccess(offerURL, user, permission.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `"write" offer access not valid`)
+}
+
+func (s *offerAccessSuite) TestCreateOfferAccessNoUser(c *gc.C) {
+	err := s.State.CreateOfferAccess(offerURL, names.NewUserTag("nobody"), permission.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, `user "nobody" does not exist locally: user "nobody" not found`)
+}
+
+func (s *offerAccessSuite) TestGetOfferAccessNotFound(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	_, err := s.State.GetOfferAccess(offerURL, user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerAccessSuite) TestUpdateOfferAccess(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.CreateOfferAccess(offerURL, user, permission.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateOfferAccess(offerURL, user, permission.AdminAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.GetOfferAccess(offerURL, user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, permission.AdminAccess)
+}
+
+func (s *offerAccessSuite) TestUpdateOfferAccessNotFound(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.UpdateOfferAccess(offerURL, user, permission.AdminAccess)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *offerAccessSuite) TestRemoveOfferAccess(c *gc.C) {
+	user := s.makeUser(c, "fred")
+	err := s.State.CreateOfferAccess(offerURL, user, permission.ConsumeAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveOfferAccess(offerURL, user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.GetOfferAccess(offerURL, user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = s.State.RemoveOfferAccess(offerURL, user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
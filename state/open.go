@@ -548,6 +548,7 @@ func newState(
 		database:               database,
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
+		watchers:               &allWatchers{},
 	}
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
@@ -581,18 +582,19 @@ func (st *State) Close() (err error) {
 		handle("standard workers", worker.Stop(st.workers))
 	}
 
-	st.mu.Lock()
-	if st.allManager != nil {
-		handle("allwatcher manager", st.allManager.Stop())
+	w := st.watchers
+	w.mu.Lock()
+	if w.allManager != nil {
+		handle("allwatcher manager", w.allManager.Stop())
 	}
-	if st.allModelManager != nil {
-		handle("allModelWatcher manager", st.allModelManager.Stop())
+	if w.allModelManager != nil {
+		handle("allModelWatcher manager", w.allModelManager.Stop())
 	}
-	if st.allModelWatcherBacking != nil {
-		handle("allModelWatcher backing", st.allModelWatcherBacking.Release())
+	if w.allModelWatcherBacking != nil {
+		handle("allModelWatcher backing", w.allModelWatcherBacking.Release())
 	}
 	st.session.Close()
-	st.mu.Unlock()
+	w.mu.Unlock()
 
 	if len(errs) > 0 {
 		for _, err := range errs[1:] {
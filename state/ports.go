@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -62,12 +63,19 @@ func PortRangeFromNetworkPortRange(unitName string, portRange network.PortRange)
 // Validate checks if the port range is valid.
 func (p PortRange) Validate() error {
 	proto := strings.ToLower(p.Protocol)
-	if proto != "tcp" && proto != "udp" {
+	portless := network.IsPortlessProtocol(proto)
+	if !portless && proto != "tcp" && proto != "udp" {
 		return errors.Errorf("invalid protocol %q", proto)
 	}
 	if !names.IsValidUnit(p.UnitName) {
 		return errors.Errorf("invalid unit %q", p.UnitName)
 	}
+	if portless {
+		if p.FromPort != network.NoPort || p.ToPort != network.NoPort {
+			return errors.Errorf("protocol %q does not use ports, got %d-%d", proto, p.FromPort, p.ToPort)
+		}
+		return nil
+	}
 	if p.FromPort > p.ToPort {
 		return errors.Errorf("invalid port range %d-%d", p.FromPort, p.ToPort)
 	}
@@ -78,21 +86,28 @@ func (p PortRange) Validate() error {
 	return nil
 }
 
-// Length returns the number of ports in the range.
-// If the range is not valid, it returns 0.
+// Length returns the number of ports in the range, which is 1 for
+// a port-less protocol. If the range is not valid, it returns 0.
 func (a PortRange) Length() int {
 	if err := a.Validate(); err != nil {
 		// Invalid range (from > to or something equally bad)
 		return 0
 	}
+	if network.IsPortlessProtocol(a.Protocol) {
+		return 1
+	}
 	return (a.ToPort - a.FromPort) + 1
 }
 
 // Sanitize returns a copy of the port range, which is guaranteed to
 // have FromPort >= ToPort and both FromPort and ToPort fit into the
-// valid range from 1 to 65535, inclusive.
+// valid range from 1 to 65535, inclusive. The bounds of a port-less
+// protocol's range are left alone.
 func (a PortRange) SanitizeBounds() PortRange {
 	b := a
+	if network.IsPortlessProtocol(b.Protocol) {
+		return b
+	}
 	if b.FromPort > b.ToPort {
 		b.FromPort, b.ToPort = b.ToPort, b.FromPort
 	}
@@ -107,7 +122,8 @@ func (a PortRange) SanitizeBounds() PortRange {
 	return b
 }
 
-// CheckConflicts determines if the two port ranges conflict.
+// CheckConflicts determines if the two port ranges conflict. Port-less
+// protocols are not exclusive, so several units may open the same one.
 func (prA PortRange) CheckConflicts(prB PortRange) error {
 	if err := prA.Validate(); err != nil {
 		return err
@@ -125,6 +141,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	if prA.Protocol != prB.Protocol {
 		return nil
 	}
+	if network.IsPortlessProtocol(prA.Protocol) {
+		return nil
+	}
 	if prA.ToPort >= prB.FromPort && prB.ToPort >= prA.FromPort {
 		return errors.Errorf("port ranges %v and %v conflict", prA, prB)
 	}
@@ -133,6 +152,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	if network.IsPortlessProtocol(p.Protocol) {
+		return fmt.Sprintf("%s (%q)", strings.ToLower(p.Protocol), p.UnitName)
+	}
 	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, strings.ToLower(p.Protocol), p.UnitName)
 }
 
@@ -347,17 +369,21 @@ func (p *Ports) Refresh() error {
 	return nil
 }
 
-// AllPortRanges returns a map with network.PortRange as keys and unit
-// names as values.
-func (p *Ports) AllPortRanges() map[network.PortRange]string {
-	result := make(map[network.PortRange]string)
+// AllPortRanges returns a map with network.PortRange as keys and the
+// sorted names of the units which opened them as values. Only ranges
+// of port-less protocols can be opened by more than one unit.
+func (p *Ports) AllPortRanges() map[network.PortRange][]string {
+	result := make(map[network.PortRange][]string)
 	for _, portRange := range p.doc.Ports {
 		rawRange := network.PortRange{
 			FromPort: portRange.FromPort,
 			ToPort:   portRange.ToPort,
 			Protocol: portRange.Protocol,
 		}
-		result[rawRange] = portRange.UnitName
+		result[rawRange] = append(result[rawRange], portRange.UnitName)
+	}
+	for _, unitNames := range result {
+		sort.Strings(unitNames)
 	}
 	return result
 }
@@ -505,17 +531,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
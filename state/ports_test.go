@@ -296,7 +296,39 @@ func (s *PortsDocSuite) TestAllPortRanges(c *gc.C) {
 	ranges := s.portsWithoutSubnet.AllPortRanges()
 	c.Assert(ranges, gc.HasLen, 1)
 
-	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], gc.Equals, s.unit1.Name())
+	c.Assert(ranges[network.PortRange{100, 200, "TCP"}], jc.DeepEquals, []string{s.unit1.Name()})
+}
+
+func (s *PortsDocSuite) TestOpenAndClosePortlessProtocol(c *gc.C) {
+	portRange := state.PortRange{
+		FromPort: network.NoPort,
+		ToPort:   network.NoPort,
+		UnitName: s.unit1.Name(),
+		Protocol: "icmp",
+	}
+	err := s.portsWithoutSubnet.OpenPorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Port-less protocols are not exclusive, so another unit can open
+	// the same one.
+	otherRange := state.PortRange{
+		FromPort: network.NoPort,
+		ToPort:   network.NoPort,
+		UnitName: s.unit2.Name(),
+		Protocol: "icmp",
+	}
+	err = s.portsWithoutSubnet.OpenPorts(otherRange)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ranges := s.portsWithoutSubnet.AllPortRanges()
+	c.Assert(ranges, jc.DeepEquals, map[network.PortRange][]string{
+		network.NewPortlessRange("icmp"): {s.unit1.Name(), s.unit2.Name()},
+	})
+
+	err = s.portsWithoutSubnet.ClosePorts(portRange)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit1.Name()), gc.HasLen, 0)
+	c.Assert(s.portsWithoutSubnet.PortsForUnit(s.unit2.Name()), jc.DeepEquals, []state.PortRange{otherRange})
 }
 
 func (s *PortsDocSuite) TestOpenInvalidRange(c *gc.C) {
@@ -588,6 +620,10 @@ func (p *PortRangeSuite) TestPortRangeString(c *gc.C) {
 		gc.Equals,
 		`80-100/tcp ("wordpress/0")`,
 	)
+	c.Assert(state.PortRange{"wordpress/0", -1, -1, "ICMP"}.String(),
+		gc.Equals,
+		`icmp ("wordpress/0")`,
+	)
 }
 
 func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
@@ -661,6 +697,16 @@ func (p *PortRangeSuite) TestPortRangeValidityAndLength(c *gc.C) {
 		state.PortRange{"wordpress/0", 1, 65535, "tcp"},
 		65535,
 		"",
+	}, {
+		"valid icmp range",
+		state.PortRange{"wordpress/0", -1, -1, "icmp"},
+		1,
+		"",
+	}, {
+		"gre range with ports",
+		state.PortRange{"wordpress/0", 80, 80, "gre"},
+		0,
+		`protocol "gre" does not use ports, got 80-80`,
 	}}
 
 	for i, t := range testCases {
@@ -0,0 +1,440 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/status"
+)
+
+// RollingUpgradeStatus describes the progress of a rolling upgrade.
+type RollingUpgradeStatus string
+
+const (
+	// RollingUpgradeRunning indicates that units are being released
+	// to the new charm in batches.
+	RollingUpgradeRunning RollingUpgradeStatus = "running"
+
+	// RollingUpgradePaused indicates that a released unit went into
+	// error, and no further units will be released until the
+	// upgrade is resumed.
+	RollingUpgradePaused RollingUpgradeStatus = "paused"
+
+	// RollingUpgradeAborted indicates that the upgrade was stopped.
+	// Units that were not released keep their current charm.
+	RollingUpgradeAborted RollingUpgradeStatus = "aborted"
+
+	// RollingUpgradeCompleted indicates that all units were released
+	// and reached the target status.
+	RollingUpgradeCompleted RollingUpgradeStatus = "completed"
+)
+
+// RollingUpgrade records the progress of an upgrade of an application's
+// charm that is applied to its units in batches. Units that have not
+// been released to the new charm continue to see the charm they are
+// currently running.
+type RollingUpgrade struct {
+	st  *State
+	doc rollingUpgradeDoc
+}
+
+type rollingUpgradeDoc struct {
+	DocID       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+
+	FromCharmURL         *charm.URL `bson:"fromcharmurl"`
+	FromCharmModifiedVer int        `bson:"fromcharmmodifiedversion"`
+	ToCharmURL           *charm.URL `bson:"tocharmurl"`
+
+	BatchSize int      `bson:"batchsize"`
+	WaitFor   string   `bson:"waitfor,omitempty"`
+	Released  []string `bson:"released"`
+
+	Status  RollingUpgradeStatus `bson:"status"`
+	Message string               `bson:"message,omitempty"`
+	Started time.Time            `bson:"started"`
+	Updated time.Time            `bson:"updated"`
+}
+
+// RollingUpgradeArgs holds the parameters for upgrading an
+// application's units in batches.
+type RollingUpgradeArgs struct {
+	// BatchSize is the number of units released to the new charm at
+	// a time. A value of zero means that all units are upgraded at
+	// once, and no rolling upgrade is recorded.
+	BatchSize int
+
+	// WaitFor is the workload status that the units of a batch must
+	// reach before the next batch is released. If empty, the next
+	// batch is released once the units of the previous batch are
+	// running the new charm.
+	WaitFor status.Status
+}
+
+// Validate returns an error if the arguments are not valid.
+func (args RollingUpgradeArgs) Validate() error {
+	if args.BatchSize < 0 {
+		return errors.NotValidf("negative batch size")
+	}
+	if args.WaitFor != "" {
+		if args.BatchSize == 0 {
+			return errors.NotValidf("wait-for status without batch size")
+		}
+		if !args.WaitFor.KnownWorkloadStatus() {
+			return errors.NotValidf("wait-for status %q", args.WaitFor)
+		}
+	}
+	return nil
+}
+
+func rollingUpgradeDocID(st *State, application string) string {
+	return st.docID(application)
+}
+
+// RollingUpgrade returns the most recent rolling upgrade of the
+// application. An error satisfying errors.IsNotFound is returned if
+// the application's charm has never been upgraded in batches, or was
+// last upgraded without batches.
+func (a *Application) RollingUpgrade() (*RollingUpgrade, error) {
+	return getRollingUpgrade(a.st, a.doc.Name)
+}
+
+func getRollingUpgrade(st *State, application string) (*RollingUpgrade, error) {
+	coll, closer := st.getCollection(rollingUpgradesC)
+	defer closer()
+
+	upgrade := &RollingUpgrade{st: st}
+	err := coll.FindId(rollingUpgradeDocID(st, application)).One(&upgrade.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("rolling upgrade of application %q", application)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get rolling upgrade of application %q", application)
+	}
+	upgrade.fixTimes()
+	return upgrade, nil
+}
+
+// setRollingUpgradeOps returns the operations necessary to record, or
+// clear, the rolling upgrade of the application to the specified charm.
+func setRollingUpgradeOps(a *Application, curl *charm.URL, args RollingUpgradeArgs) ([]txn.Op, error) {
+	existing, err := a.RollingUpgrade()
+	if errors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if existing != nil {
+		switch existing.Status() {
+		case RollingUpgradeRunning, RollingUpgradePaused:
+			return nil, errors.Errorf(
+				"rolling upgrade to %q is %s; resume or abort it first",
+				existing.ToCharmURL(), existing.Status(),
+			)
+		}
+	}
+	docID := rollingUpgradeDocID(a.st, a.doc.Name)
+	if args.BatchSize == 0 {
+		if existing == nil {
+			return nil, nil
+		}
+		return []txn.Op{{
+			C:      rollingUpgradesC,
+			Id:     docID,
+			Assert: bson.D{{"status", existing.Status()}},
+			Remove: true,
+		}}, nil
+	}
+
+	now := a.st.NowToTheSecond()
+	doc := rollingUpgradeDoc{
+		DocID:                docID,
+		ModelUUID:            a.st.ModelUUID(),
+		Application:          a.doc.Name,
+		FromCharmURL:         a.doc.CharmURL,
+		FromCharmModifiedVer: a.doc.CharmModifiedVersion,
+		ToCharmURL:           curl,
+		BatchSize:            args.BatchSize,
+		WaitFor:              string(args.WaitFor),
+		Released:             []string{},
+		Status:               RollingUpgradeRunning,
+		Started:              now,
+		Updated:              now,
+	}
+	if existing == nil {
+		return []txn.Op{{
+			C:      rollingUpgradesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      rollingUpgradesC,
+		Id:     docID,
+		Assert: bson.D{{"status", existing.Status()}},
+		Update: bson.D{{"$set", bson.D{
+			{"fromcharmurl", doc.FromCharmURL},
+			{"fromcharmmodifiedversion", doc.FromCharmModifiedVer},
+			{"tocharmurl", doc.ToCharmURL},
+			{"batchsize", doc.BatchSize},
+			{"waitfor", doc.WaitFor},
+			{"released", doc.Released},
+			{"status", doc.Status},
+			{"message", ""},
+			{"started", doc.Started},
+			{"updated", doc.Updated},
+		}}},
+	}}, nil
+}
+
+func removeRollingUpgradeOp(st *State, application string) txn.Op {
+	return txn.Op{
+		C:      rollingUpgradesC,
+		Id:     rollingUpgradeDocID(st, application),
+		Remove: true,
+	}
+}
+
+// touchApplicationOp returns an operation that changes the
+// application's document, without otherwise changing the
+// application, so that units watching the application are
+// notified that a rolling upgrade has released them.
+func touchApplicationOp(st *State, application string) txn.Op {
+	return txn.Op{
+		C:      applicationsC,
+		Id:     st.docID(application),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"rollingupgradebatch", 1}}}},
+	}
+}
+
+func (u *RollingUpgrade) fixTimes() {
+	u.doc.Started = u.doc.Started.UTC()
+	u.doc.Updated = u.doc.Updated.UTC()
+}
+
+// ApplicationName returns the name of the application being upgraded.
+func (u *RollingUpgrade) ApplicationName() string {
+	return u.doc.Application
+}
+
+// FromCharmURL returns the URL of the charm the application ran before
+// the upgrade.
+func (u *RollingUpgrade) FromCharmURL() *charm.URL {
+	return u.doc.FromCharmURL
+}
+
+// ToCharmURL returns the URL of the charm the application is being
+// upgraded to.
+func (u *RollingUpgrade) ToCharmURL() *charm.URL {
+	return u.doc.ToCharmURL
+}
+
+// FromCharmModifiedVersion returns the application's charm modified
+// version before the upgrade.
+func (u *RollingUpgrade) FromCharmModifiedVersion() int {
+	return u.doc.FromCharmModifiedVer
+}
+
+// BatchSize returns the number of units released at a time.
+func (u *RollingUpgrade) BatchSize() int {
+	return u.doc.BatchSize
+}
+
+// WaitFor returns the workload status that the units of each batch
+// must reach before the next batch is released.
+func (u *RollingUpgrade) WaitFor() status.Status {
+	return status.Status(u.doc.WaitFor)
+}
+
+// Released returns the names of the units that have been released to
+// the new charm, in the order they were released.
+func (u *RollingUpgrade) Released() []string {
+	return u.doc.Released
+}
+
+// Status returns the status of the upgrade.
+func (u *RollingUpgrade) Status() RollingUpgradeStatus {
+	return u.doc.Status
+}
+
+// Message returns a message describing why the upgrade was paused, if
+// it was.
+func (u *RollingUpgrade) Message() string {
+	return u.doc.Message
+}
+
+// Started returns when the upgrade was started, in UTC.
+func (u *RollingUpgrade) Started() time.Time {
+	return u.doc.Started
+}
+
+// Updated returns when the upgrade last changed, in UTC.
+func (u *RollingUpgrade) Updated() time.Time {
+	return u.doc.Updated
+}
+
+// Holds reports whether the upgrade holds the named unit back on the
+// charm it is currently running.
+func (u *RollingUpgrade) Holds(unitName string) bool {
+	if u.doc.Status == RollingUpgradeCompleted {
+		return false
+	}
+	for _, name := range u.doc.Released {
+		if name == unitName {
+			return false
+		}
+	}
+	return true
+}
+
+// Refresh refreshes the contents of the RollingUpgrade from the
+// underlying state.
+func (u *RollingUpgrade) Refresh() error {
+	other, err := getRollingUpgrade(u.st, u.doc.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	u.doc = other.doc
+	return nil
+}
+
+// Advance checks the progress of a running upgrade. If any released
+// unit is in error, the upgrade is paused. Otherwise, once all released
+// units run the new charm and have reached the target workload status,
+// the next batch of units is released; when there are no units left to
+// release, the upgrade is completed.
+func (u *RollingUpgrade) Advance() error {
+	if u.doc.Status != RollingUpgradeRunning {
+		return nil
+	}
+	units, err := allUnits(u.st, u.doc.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	released := set.NewStrings(u.doc.Released...)
+	var pending []string
+	for _, unit := range units {
+		if !released.Contains(unit.Name()) {
+			pending = append(pending, unit.Name())
+			continue
+		}
+		if unit.Life() != Alive {
+			continue
+		}
+		info, err := unit.Status()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.Status == status.Error {
+			msg := fmt.Sprintf("unit %s is in error: %s", unit.Name(), info.Message)
+			return errors.Trace(u.setStatus(RollingUpgradeRunning, RollingUpgradePaused, msg, nil))
+		}
+		curl, _ := unit.CharmURL()
+		if curl == nil || *curl != *u.doc.ToCharmURL {
+			return nil
+		}
+		if u.doc.WaitFor != "" && info.Status != status.Status(u.doc.WaitFor) {
+			return nil
+		}
+	}
+	if len(pending) == 0 {
+		return errors.Trace(u.setStatus(RollingUpgradeRunning, RollingUpgradeCompleted, "", nil))
+	}
+	sort.Strings(pending)
+	if len(pending) > u.doc.BatchSize {
+		pending = pending[:u.doc.BatchSize]
+	}
+	return errors.Trace(u.setStatus(RollingUpgradeRunning, RollingUpgradeRunning, "", pending))
+}
+
+// Resume resumes a paused upgrade.
+func (u *RollingUpgrade) Resume() error {
+	if u.doc.Status != RollingUpgradePaused {
+		return errors.Errorf("cannot resume %s rolling upgrade", u.doc.Status)
+	}
+	return errors.Trace(u.setStatus(RollingUpgradePaused, RollingUpgradeRunning, "", nil))
+}
+
+// Abort stops a running or paused upgrade. Units that have not been
+// released keep the charm they are running until the application's
+// charm is next set.
+func (u *RollingUpgrade) Abort() error {
+	switch u.doc.Status {
+	case RollingUpgradeRunning, RollingUpgradePaused:
+	default:
+		return errors.Errorf("cannot abort %s rolling upgrade", u.doc.Status)
+	}
+	return errors.Trace(u.setStatus(u.doc.Status, RollingUpgradeAborted, "", nil))
+}
+
+// setStatus changes the status of the upgrade from the expected status
+// to the new one, releasing the specified units.
+func (u *RollingUpgrade) setStatus(from, to RollingUpgradeStatus, message string, release []string) error {
+	now := u.st.NowToTheSecond()
+	update := bson.D{{"$set", bson.D{
+		{"status", to},
+		{"message", message},
+		{"updated", now},
+	}}}
+	if len(release) > 0 {
+		update = append(update, bson.DocElem{
+			"$push", bson.D{{"released", bson.D{{"$each", release}}}},
+		})
+	}
+	ops := []txn.Op{{
+		C:  rollingUpgradesC,
+		Id: u.doc.DocID,
+		Assert: bson.D{
+			{"status", from},
+			{"tocharmurl", u.doc.ToCharmURL},
+			{"released", bson.D{{"$size", len(u.doc.Released)}}},
+		},
+		Update: update,
+	}}
+	if len(release) > 0 {
+		ops = append(ops, touchApplicationOp(u.st, u.doc.Application))
+	}
+	if err := u.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("rolling upgrade of application %q changed concurrently", u.doc.Application)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update rolling upgrade of application %q", u.doc.Application)
+	}
+	u.doc.Status = to
+	u.doc.Message = message
+	u.doc.Updated = now
+	u.doc.Released = append(u.doc.Released, release...)
+	return nil
+}
+
+// AdvanceRollingUpgrades advances all the running rolling upgrades in
+// the model. See RollingUpgrade.Advance.
+func (st *State) AdvanceRollingUpgrades() error {
+	coll, closer := st.getCollection(rollingUpgradesC)
+	defer closer()
+
+	var docs []rollingUpgradeDoc
+	if err := coll.Find(bson.D{{"status", RollingUpgradeRunning}}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get rolling upgrades")
+	}
+	for _, doc := range docs {
+		upgrade := &RollingUpgrade{st: st, doc: doc}
+		if err := upgrade.Advance(); err != nil {
+			return errors.Annotatef(err, "cannot advance rolling upgrade of application %q", doc.Application)
+		}
+	}
+	return nil
+}
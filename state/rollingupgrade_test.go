@@ -0,0 +1,229 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RollingUpgradeSuite struct {
+	ConnSuite
+	charm    *state.Charm
+	newCharm *state.Charm
+	mysql    *state.Application
+	units    []*state.Unit
+}
+
+var _ = gc.Suite(&RollingUpgradeSuite{})
+
+func (s *RollingUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.charm = s.AddTestingCharm(c, "mysql")
+	s.newCharm = s.AddMetaCharm(c, "mysql", metaBase, 2)
+	s.mysql = s.AddTestingService(c, "mysql", s.charm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.mysql.AddUnit()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.charm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *RollingUpgradeSuite) setCharm(c *gc.C, batchSize int, waitFor status.Status) *state.RollingUpgrade {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		RollingUpgrade: state.RollingUpgradeArgs{
+			BatchSize: batchSize,
+			WaitFor:   waitFor,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.mysql.RollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	return upgrade
+}
+
+func (s *RollingUpgradeSuite) upgradeUnit(c *gc.C, unit *state.Unit, workload status.Status) {
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = unit.SetStatus(status.StatusInfo{Status: workload, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmWithoutBatchSize(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: s.newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.RollingUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmInvalidArgs(c *gc.C) {
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		RollingUpgrade: state.RollingUpgradeArgs{BatchSize: 1, WaitFor: "bouncing"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-2": wait-for status "bouncing" not valid`)
+	err = s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		RollingUpgrade: state.RollingUpgradeArgs{WaitFor: status.Active},
+	})
+	c.Assert(err, gc.ErrorMatches, `.*wait-for status without batch size not valid`)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRecordsUpgrade(c *gc.C) {
+	upgrade := s.setCharm(c, 2, status.Active)
+	c.Assert(upgrade.ApplicationName(), gc.Equals, "mysql")
+	c.Assert(upgrade.FromCharmURL(), jc.DeepEquals, s.charm.URL())
+	c.Assert(upgrade.ToCharmURL(), jc.DeepEquals, s.newCharm.URL())
+	c.Assert(upgrade.BatchSize(), gc.Equals, 2)
+	c.Assert(upgrade.WaitFor(), gc.Equals, status.Active)
+	c.Assert(upgrade.Released(), gc.HasLen, 0)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+	for _, unit := range s.units {
+		c.Check(upgrade.Holds(unit.Name()), jc.IsTrue)
+	}
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceReleasesBatches(c *gc.C) {
+	upgrade := s.setCharm(c, 2, status.Active)
+
+	err := upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	c.Assert(upgrade.Holds("mysql/1"), jc.IsFalse)
+	c.Assert(upgrade.Holds("mysql/2"), jc.IsTrue)
+
+	// Nothing more is released until the batch reaches the target status.
+	s.upgradeUnit(c, s.units[0], status.Active)
+	s.upgradeUnit(c, s.units[1], status.Maintenance)
+	err = s.State.AdvanceRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Released(), gc.HasLen, 2)
+
+	s.upgradeUnit(c, s.units[1], status.Active)
+	err = s.State.AdvanceRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1", "mysql/2"})
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+
+	s.upgradeUnit(c, s.units[2], status.Active)
+	err = upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeCompleted)
+	c.Assert(upgrade.Holds("mysql/3"), jc.IsFalse)
+}
+
+func (s *RollingUpgradeSuite) TestAdvanceNotifiesApplicationWatchers(c *gc.C) {
+	upgrade := s.setCharm(c, 1, "")
+	w := s.mysql.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *RollingUpgradeSuite) TestAdvancePausesOnError(c *gc.C) {
+	upgrade := s.setCharm(c, 1, status.Active)
+	err := upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.units[0].SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	now := coretesting.NonZeroTime()
+	err = s.units[0].SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed: \"upgrade-charm\"",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradePaused)
+	c.Assert(upgrade.Message(), gc.Equals, `unit mysql/0 is in error: hook failed: "upgrade-charm"`)
+	c.Assert(upgrade.Released(), gc.HasLen, 1)
+
+	// A paused upgrade is left alone.
+	err = s.State.AdvanceRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradePaused)
+
+	s.upgradeUnit(c, s.units[0], status.Active)
+	err = s.units[0].SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Resume()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+	c.Assert(upgrade.Message(), gc.Equals, "")
+	err = upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *RollingUpgradeSuite) TestResumeNotPaused(c *gc.C) {
+	upgrade := s.setCharm(c, 1, "")
+	err := upgrade.Resume()
+	c.Assert(err, gc.ErrorMatches, "cannot resume running rolling upgrade")
+}
+
+func (s *RollingUpgradeSuite) TestAbort(c *gc.C) {
+	upgrade := s.setCharm(c, 1, "")
+	err := upgrade.Advance()
+	c.Assert(err, jc.ErrorIsNil)
+	err = upgrade.Abort()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrade.Status(), gc.Equals, state.RollingUpgradeAborted)
+	c.Assert(upgrade.Holds("mysql/0"), jc.IsFalse)
+	c.Assert(upgrade.Holds("mysql/1"), jc.IsTrue)
+
+	err = upgrade.Abort()
+	c.Assert(err, gc.ErrorMatches, "cannot abort aborted rolling upgrade")
+
+	// Setting the charm again without batches clears the upgrade.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.mysql.RollingUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmWhileInProgress(c *gc.C) {
+	s.setCharm(c, 1, "")
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, gc.ErrorMatches, `.*rolling upgrade to "local:quantal/quantal-mysql-2" is running; resume or abort it first`)
+}
+
+func (s *RollingUpgradeSuite) TestRemoveApplicationRemovesUpgrade(c *gc.C) {
+	s.setCharm(c, 1, "")
+	for _, unit := range s.units {
+		err := unit.EnsureDead()
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.Remove()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Application("mysql")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.mysql.RollingUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -365,6 +365,18 @@ func removeSettingsOp(collection, key string) txn.Op {
 	}
 }
 
+// touchSettingsOp returns an operation that increments the version of
+// a settings document without changing the settings, so that watchers
+// of the document are notified.
+func touchSettingsOp(collection, key string) txn.Op {
+	return txn.Op{
+		C:      collection,
+		Id:     key,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"version", 1}}}},
+	}
+}
+
 // listSettings returns all the settings with the specified key prefix.
 func listSettings(st *State, collection, keyPrefix string) (map[string]map[string]interface{}, error) {
 	settings, closer := st.getRawCollection(collection)
@@ -4,7 +4,13 @@
 package state
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -236,3 +242,192 @@ func (s *Space) Refresh() error {
 	s.doc = doc
 	return nil
 }
+
+// MoveSubnetsToSpace moves the subnets with the given CIDRs from their
+// current spaces into the named space. Unless force is true, the move is
+// refused if an application endpoint bound to a space would be left
+// unsatisfiable, because a machine hosting one of the application's units
+// would no longer have an address in that space.
+//
+// The units of every application deployed to a machine with an address
+// in a moved subnet run the config-changed hook, so that charms pick up
+// their new network-get results. The names of those applications are
+// returned.
+func (st *State) MoveSubnetsToSpace(spaceName string, cidrs []string, force bool) (_ []string, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot move subnets to space %q", spaceName)
+
+	var affected []string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		space, err := st.Space(spaceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if space.Life() != Alive {
+			return nil, errors.Errorf("space is not alive")
+		}
+		ops := []txn.Op{{
+			C:      spacesC,
+			Id:     spaceName,
+			Assert: isAliveDoc,
+		}}
+		moved := make(map[string]bool)
+		for _, cidr := range cidrs {
+			subnet, err := st.Subnet(cidr)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if subnet.Life() != Alive {
+				return nil, errors.Errorf("subnet %q is not alive", cidr)
+			}
+			if subnet.SpaceName() == spaceName {
+				continue
+			}
+			moved[cidr] = true
+			ops = append(ops, txn.Op{
+				C:      subnetsC,
+				Id:     cidr,
+				Assert: bson.D{{"life", Alive}, subnetSpaceAssert(subnet.SpaceName())},
+				Update: bson.D{{"$set", bson.D{{"space-name", spaceName}}}},
+			})
+		}
+		if len(moved) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		applications, err := st.checkSubnetsMove(moved, spaceName, force)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, app := range applications {
+			ops = append(ops, touchSettingsOp(settingsC, app.settingsKey()))
+		}
+		affected = make([]string, len(applications))
+		for i, app := range applications {
+			affected[i] = app.Name()
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return affected, nil
+}
+
+// subnetSpaceAssert returns the assertion that a subnet is in the named
+// space. Subnets in no space have no space-name field.
+func subnetSpaceAssert(spaceName string) bson.DocElem {
+	if spaceName == "" {
+		return bson.DocElem{"space-name", bson.D{{"$in", []interface{}{"", nil}}}}
+	}
+	return bson.DocElem{"space-name", spaceName}
+}
+
+// checkSubnetsMove checks the endpoint bindings of the applications with
+// units on machines that have addresses in the moved subnets, and returns
+// those applications sorted by name. An error is returned if a binding
+// satisfied before the move would not be satisfied after it, unless
+// force is true.
+func (st *State) checkSubnetsMove(moved map[string]bool, spaceName string, force bool) ([]*Application, error) {
+	movedCIDRs := make([]string, 0, len(moved))
+	for cidr := range moved {
+		movedCIDRs = append(movedCIDRs, cidr)
+	}
+	machineIds := set.NewStrings()
+	err := st.forEachIPAddressDoc(bson.D{{"subnet-cidr", bson.D{{"$in", movedCIDRs}}}}, func(doc *ipAddressDoc) {
+		machineIds.Add(doc.MachineID)
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	applications := make(map[string]*Application)
+	bindings := make(map[string]map[string]string)
+	var unsatisfied []string
+	for _, id := range machineIds.SortedValues() {
+		m, err := st.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		before, after, err := machineSpacesForMove(m, moved, spaceName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		units, err := m.Units()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			appName := unit.ApplicationName()
+			if _, ok := applications[appName]; !ok {
+				app, err := unit.Application()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				appBindings, err := app.EndpointBindings()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				applications[appName] = app
+				bindings[appName] = appBindings
+			}
+			for endpoint, bound := range bindings[appName] {
+				if bound == "" || !before.Contains(bound) || after.Contains(bound) {
+					continue
+				}
+				unsatisfied = append(unsatisfied, fmt.Sprintf(
+					"%s endpoint %q bound to space %q (machine %s)",
+					unit.Name(), endpoint, bound, m.Id(),
+				))
+			}
+		}
+	}
+	if len(unsatisfied) > 0 {
+		sort.Strings(unsatisfied)
+		if !force {
+			return nil, errors.Errorf(
+				"bindings would be left unsatisfiable: %s", strings.Join(unsatisfied, ", "),
+			)
+		}
+		logger.Warningf("forcing subnet move leaves bindings unsatisfiable: %s", strings.Join(unsatisfied, ", "))
+	}
+
+	names := make([]string, 0, len(applications))
+	for name := range applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*Application, len(names))
+	for i, name := range names {
+		result[i] = applications[name]
+	}
+	return result, nil
+}
+
+// machineSpacesForMove returns the spaces the machine has addresses in
+// before and after the given subnets are moved to the named space.
+func machineSpacesForMove(m *Machine, moved map[string]bool, spaceName string) (before, after set.Strings, err error) {
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	before = set.NewStrings()
+	after = set.NewStrings()
+	for _, addr := range addresses {
+		subnet, err := addr.Subnet()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if subnet.SpaceName() != "" {
+			before.Add(subnet.SpaceName())
+		}
+		if moved[addr.SubnetCIDR()] {
+			after.Add(spaceName)
+		} else if subnet.SpaceName() != "" {
+			after.Add(subnet.SpaceName())
+		}
+	}
+	return before, after, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
 )
 
 type SpacesSuite struct {
@@ -559,3 +560,108 @@ func (s *SpacesSuite) TestRefreshFailsWithNotFoundWhenRemoved(c *gc.C) {
 	err := space.Refresh()
 	s.assertSpaceNotFoundError(c, err, "soon-removed")
 }
+
+func (s *SpacesSuite) addMachineInSubnet(c *gc.C, address string) *state.Machine {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  address,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return machine
+}
+
+func (s *SpacesSuite) addBoundUnit(c *gc.C, machine *state.Machine) *state.Application {
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:             "mysql",
+		Charm:            s.AddTestingCharm(c, "mysql"),
+		EndpointBindings: map[string]string{"server": "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	return app
+}
+
+func (s *SpacesSuite) TestMoveSubnetsToSpace(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{Name: "db", SubnetCIDRs: []string{"10.0.0.0/24"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addSubnets(c, []string{"10.1.0.0/24"})
+
+	affected, err := s.State.MoveSubnetsToSpace("db", []string{"10.0.0.0/24", "10.1.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(affected, gc.HasLen, 0)
+
+	subnet, err := s.State.Subnet("10.1.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "db")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsToSpaceNotFound(c *gc.C) {
+	_, err := s.State.MoveSubnetsToSpace("missing", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, gc.ErrorMatches, `cannot move subnets to space "missing": space "missing" not found`)
+
+	s.addAliveSpace(c, "db")
+	_, err = s.State.MoveSubnetsToSpace("db", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, gc.ErrorMatches, `cannot move subnets to space "db": subnet "10.0.0.0/24" not found`)
+}
+
+func (s *SpacesSuite) TestMoveSubnetsToSpaceUnsatisfiableBinding(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{Name: "db", SubnetCIDRs: []string{"10.0.0.0/24"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addAliveSpace(c, "public")
+	s.addBoundUnit(c, s.addMachineInSubnet(c, "10.0.0.5/24"))
+
+	_, err = s.State.MoveSubnetsToSpace("public", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, gc.ErrorMatches, `cannot move subnets to space "public": bindings would be left unsatisfiable: `+
+		`mysql/0 endpoint "server" bound to space "db" \(machine 0\)`)
+	subnet, err := s.State.Subnet("10.0.0.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "db")
+
+	affected, err := s.State.MoveSubnetsToSpace("public", []string{"10.0.0.0/24"}, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(affected, jc.DeepEquals, []string{"mysql"})
+	err = subnet.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnet.SpaceName(), gc.Equals, "public")
+}
+
+func (s *SpacesSuite) TestMoveSubnetsToSpaceTriggersConfigChanged(c *gc.C) {
+	_, err := s.addSpaceWithSubnets(c, addSpaceArgs{Name: "db", SubnetCIDRs: []string{"10.0.0.0/24"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.addSubnets(c, []string{"10.1.0.0/24"})
+	machine := s.addMachineInSubnet(c, "10.1.0.5/24")
+	app := s.addBoundUnit(c, s.addMachineInSubnet(c, "10.0.0.5/24"))
+	unit, err := app.AddUnit()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := unit.WatchConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Moving the subnet into the bound space satisfies the binding
+	// on the second unit's machine, and the units are told about it.
+	affected, err := s.State.MoveSubnetsToSpace("db", []string{"10.1.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(affected, jc.DeepEquals, []string{"mysql"})
+	wc.AssertOneChange()
+
+	// Moving it again is a no-op.
+	_, err = s.State.MoveSubnetsToSpace("db", []string{"10.1.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/utils/series"
 	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"golang.org/x/net/context"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -104,18 +105,41 @@ type State struct {
 	// that yet, but having a type that collects them together is the
 	// first step.
 	//
-	// note that the allManager stuff below probably ought to be
-	// folded in as well, but that feels like its own task.
+	// note that the multiwatcher managers held in watchers probably
+	// ought to be folded in as well, but that feels like its own task.
 	workers workers.Workers
 
+	// watchers holds the managers behind the multiwatchers returned
+	// by Watch and WatchAllModels.
+	watchers *allWatchers
+
+	// ctx holds the context of the request on whose behalf the
+	// state is used, if any; see WithContext.
+	ctx context.Context
+
+	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
+	CloudImageMetadataStorage cloudimagemetadata.Storage
+}
+
+// allWatchers holds the managers behind a State's multiwatchers. It
+// is shared by the copies of the State made by WithContext.
+type allWatchers struct {
 	// mu guards allManager, allModelManager & allModelWatcherBacking
 	mu                     sync.Mutex
 	allManager             *storeManager
 	allModelManager        *storeManager
 	allModelWatcherBacking Backing
+}
 
-	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
-	CloudImageMetadataStorage cloudimagemetadata.Storage
+// WithContext returns a copy of st that performs operations on behalf
+// of the request with the given context. If the context carries a
+// tracing span, the transactions run by the copy are recorded as its
+// children. The copy shares st's session and workers, and must not be
+// closed.
+func (st *State) WithContext(ctx context.Context) *State {
+	stCopy := *st
+	stCopy.ctx = ctx
+	return &stCopy
 }
 
 // StateServingInfo holds information needed by a controller.
@@ -547,22 +571,24 @@ func (st *State) MongoSession() *mgo.Session {
 }
 
 func (st *State) Watch() *Multiwatcher {
-	st.mu.Lock()
-	if st.allManager == nil {
-		st.allManager = newStoreManager(newAllWatcherStateBacking(st))
+	w := st.watchers
+	w.mu.Lock()
+	if w.allManager == nil {
+		w.allManager = newStoreManager(newAllWatcherStateBacking(st))
 	}
-	st.mu.Unlock()
-	return NewMultiwatcher(st.allManager)
+	w.mu.Unlock()
+	return NewMultiwatcher(w.allManager)
 }
 
 func (st *State) WatchAllModels(pool *StatePool) *Multiwatcher {
-	st.mu.Lock()
-	if st.allModelManager == nil {
-		st.allModelWatcherBacking = NewAllModelWatcherStateBacking(st, pool)
-		st.allModelManager = newStoreManager(st.allModelWatcherBacking)
+	w := st.watchers
+	w.mu.Lock()
+	if w.allModelManager == nil {
+		w.allModelWatcherBacking = NewAllModelWatcherStateBacking(st, pool)
+		w.allModelManager = newStoreManager(w.allModelWatcherBacking)
 	}
-	st.mu.Unlock()
-	return NewMultiwatcher(st.allModelManager)
+	w.mu.Unlock()
+	return NewMultiwatcher(w.allModelManager)
 }
 
 // versionInconsistentError indicates one or more agents have a
@@ -1073,16 +1099,32 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	// Ignore constraints that result from this call as
 	// these would be accumulation of model and application constraints
 	// but we only want application constraints to be persisted here.
-	_, err = st.resolveConstraints(args.Constraints)
+	cons, err := st.resolveConstraints(args.Constraints)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
+	// Units can only use hostloop storage if they are given new KVM
+	// containers, either by a container placement directive or, for
+	// units without a placement, by the container constraint.
+	if len(args.Placement) == 0 || len(args.Placement) < args.NumUnits {
+		var containerType instance.ContainerType
+		if cons.HasContainer() {
+			containerType = *cons.Container
+		}
+		if err := validateHostLoopStoragePools(st, storagePools, containerType); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	for _, placement := range args.Placement {
 		data, err := st.parsePlacement(placement)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := validateHostLoopStoragePools(st, storagePools, data.containerType); err != nil {
+			return nil, errors.Trace(err)
+		}
 		switch data.placementType() {
 		case machinePlacement:
 			// Ensure that the machine and charm series match.
@@ -1853,6 +1895,13 @@ func (st *State) AssignUnit(u *Unit, policy AssignmentPolicy) (err error) {
 		return errors.Errorf("subordinate unit %q cannot be assigned directly to a machine", u)
 	}
 	defer errors.DeferredAnnotatef(&err, "cannot assign unit %q to machine", u)
+	if policy != AssignLocal {
+		// Machines reserved in a pool are preferred over any
+		// other placement, as they are already provisioned.
+		if _, err = u.AssignToPooledMachine(); errors.Cause(err) != noPooledMachines {
+			return errors.Trace(err)
+		}
+	}
 	var m *Machine
 	switch policy {
 	case AssignLocal:
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
 	"github.com/juju/version"
+	"golang.org/x/net/context"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
@@ -46,6 +47,7 @@ import (
 	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/tracing"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -205,6 +207,69 @@ func (s *StateSuite) TestMongoSession(c *gc.C) {
 	c.Assert(session.Ping(), gc.IsNil)
 }
 
+type recordingExporter struct {
+	spans []tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (s *StateSuite) TestWithContextTracesTransactions(c *gc.C) {
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(clock.WallClock, &exporter)
+	span := tracer.StartSpan("", "", "Client.AddMachines")
+	st := s.State.WithContext(tracing.ContextWithSpan(context.Background(), span))
+
+	_, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	traced := len(exporter.spans)
+	var txnSpan *tracing.Span
+	for i := range exporter.spans {
+		if exporter.spans[i].Name == "txn" {
+			txnSpan = &exporter.spans[i]
+			break
+		}
+	}
+	c.Assert(txnSpan, gc.NotNil)
+	c.Assert(txnSpan.TraceID, gc.Equals, span.TraceID())
+	c.Assert(txnSpan.ParentID, gc.Equals, span.SpanID())
+	c.Assert(txnSpan.Tags["model"], gc.Equals, s.State.ModelUUID())
+	c.Assert(txnSpan.Tags["attempts"], gc.Equals, "1")
+
+	// The original state does not trace transactions.
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.spans, gc.HasLen, traced)
+}
+
+func (s *StateSuite) TestWithContextTracesQueries(c *gc.C) {
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(clock.WallClock, &exporter)
+	span := tracer.StartSpan("", "", "Client.FullStatus")
+	st := s.State.WithContext(tracing.ContextWithSpan(context.Background(), span))
+
+	_, err = st.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.spans, gc.HasLen, 1)
+	querySpan := exporter.spans[0]
+	c.Assert(querySpan.Name, gc.Equals, "query")
+	c.Assert(querySpan.TraceID, gc.Equals, span.TraceID())
+	c.Assert(querySpan.ParentID, gc.Equals, span.SpanID())
+	c.Assert(querySpan.Tags["collection"], gc.Equals, "machines")
+	c.Assert(querySpan.Tags["operation"], gc.Equals, "one")
+	c.Assert(querySpan.Tags["error"], gc.Equals, "")
+
+	// The original state does not trace queries.
+	_, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exporter.spans, gc.HasLen, 1)
+}
+
 func (s *StateSuite) TestWatch(c *gc.C) {
 	// The allWatcher infrastructure is comprehensively tested
 	// elsewhere. This just ensures things are hooked up correctly in
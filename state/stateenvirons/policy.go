@@ -5,16 +5,20 @@ package stateenvirons
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
 )
 
+var logger = loggo.GetLogger("juju.state.stateenvirons")
+
 // environStatePolicy implements state.Policy in
 // terms of environs.Environ and related types.
 type environStatePolicy struct {
@@ -61,7 +65,50 @@ func (p environStatePolicy) ConstraintsValidator() (constraints.Validator, error
 	if err != nil {
 		return nil, err
 	}
-	return env.ConstraintsValidator()
+	validator, err := env.ConstraintsValidator()
+	if err != nil {
+		return nil, err
+	}
+	if zonedEnv, ok := env.(common.ZonedEnviron); ok {
+		return zonesValidator{validator, zonedEnv}, nil
+	}
+	return validator, nil
+}
+
+// zonesValidator is a constraints.Validator that restricts the zones
+// constraint to the zones the environ reports. The zones are only
+// looked up when validating constraints that specify zones.
+type zonesValidator struct {
+	constraints.Validator
+	env common.ZonedEnviron
+}
+
+// Validate is part of the constraints.Validator interface.
+func (v zonesValidator) Validate(cons constraints.Value) ([]string, error) {
+	if cons.HasZones() {
+		v.updateZones()
+	}
+	return v.Validator.Validate(cons)
+}
+
+// updateZones adds the environ's availability zones to the vocabulary
+// of the zones constraint. If the zones cannot be listed, the zones
+// constraint is left for the provider to check when starting instances.
+func (v zonesValidator) updateZones() {
+	zones, err := v.env.AvailabilityZones()
+	if errors.IsNotImplemented(err) {
+		return
+	} else if err != nil {
+		logger.Warningf("not validating zones constraint: getting availability zones: %v", err)
+		return
+	}
+	var zoneNames []string
+	for _, zone := range zones {
+		zoneNames = append(zoneNames, zone.Name())
+	}
+	if len(zoneNames) > 0 {
+		v.Validator.UpdateVocabulary(constraints.Zones, zoneNames)
+	}
 }
 
 // InstanceDistributor implements state.Policy.
@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateenvirons_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type policySuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&policySuite{})
+
+func (s *policySuite) TestConstraintsValidatorZones(c *gc.C) {
+	env := &zonedEnviron{zones: []common.AvailabilityZone{
+		availabilityZone("az1"), availabilityZone("az2"),
+	}}
+	policy := stateenvirons.GetNewPolicyFunc(func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})(nil)
+
+	validator, err := policy.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("zones=az1,az3"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: zones=az3\nvalid values are: \\[az1 az2\\]")
+	c.Assert(env.calls, gc.Equals, 2)
+}
+
+func (s *policySuite) TestConstraintsValidatorWithoutZones(c *gc.C) {
+	env := &zonedEnviron{err: errors.New("boom")}
+	policy := stateenvirons.GetNewPolicyFunc(func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})(nil)
+
+	validator, err := policy.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.calls, gc.Equals, 0)
+}
+
+func (s *policySuite) TestConstraintsValidatorZonesError(c *gc.C) {
+	env := &zonedEnviron{err: errors.New("boom")}
+	policy := stateenvirons.GetNewPolicyFunc(func(*state.State) (environs.Environ, error) {
+		return env, nil
+	})(nil)
+
+	// The zones are left for the provider to check.
+	validator, err := policy.ConstraintsValidator()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("mem=4G zones=az1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(env.calls, gc.Equals, 1)
+}
+
+type zonedEnviron struct {
+	environs.Environ
+	zones []common.AvailabilityZone
+	err   error
+	calls int
+}
+
+func (e *zonedEnviron) ConstraintsValidator() (constraints.Validator, error) {
+	return constraints.NewValidator(), nil
+}
+
+func (e *zonedEnviron) AvailabilityZones() ([]common.AvailabilityZone, error) {
+	e.calls++
+	return e.zones, e.err
+}
+
+func (e *zonedEnviron) InstanceAvailabilityZoneNames(ids []instance.Id) ([]string, error) {
+	return make([]string, len(ids)), nil
+}
+
+type availabilityZone string
+
+func (z availabilityZone) Name() string {
+	return string(z)
+}
+
+func (z availabilityZone) Available() bool {
+	return true
+}
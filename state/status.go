@@ -139,6 +139,35 @@ func statusSetOps(st *State, doc statusDoc, globalKey string) ([]txn.Op, error)
 	}}, nil
 }
 
+// setStatusDataOp returns the operation needed to set the given keys in
+// the data of the status document associated with the given globalKey,
+// leaving its status, message and other data alone. Keys with nil values
+// are removed.
+func setStatusDataOp(st *State, globalKey string, data map[string]interface{}) txn.Op {
+	var set, unset bson.D
+	for key, value := range utils.EscapeKeys(data) {
+		field := "statusdata." + key
+		if value == nil {
+			unset = append(unset, bson.DocElem{field, nil})
+		} else {
+			set = append(set, bson.DocElem{field, value})
+		}
+	}
+	var update bson.D
+	if len(set) > 0 {
+		update = append(update, bson.DocElem{"$set", set})
+	}
+	if len(unset) > 0 {
+		update = append(update, bson.DocElem{"$unset", unset})
+	}
+	return txn.Op{
+		C:      statusesC,
+		Id:     st.docID(globalKey),
+		Assert: txn.DocExists,
+		Update: update,
+	}
+}
+
 // createStatusOp returns the operation needed to create the given status
 // document associated with the given globalKey.
 func createStatusOp(st *State, globalKey string, doc statusDoc) txn.Op {
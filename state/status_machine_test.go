@@ -163,6 +163,53 @@ func (s *MachineStatusSuite) TestGetSetStatusGone(c *gc.C) {
 	c.Check(statusInfo, gc.DeepEquals, status.StatusInfo{})
 }
 
+func (s *MachineStatusSuite) TestSetStatusData(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Started,
+		Message: "blah",
+		Data: map[string]interface{}{
+			"foo": "bar",
+		},
+		Since: &now,
+	}
+	err := s.machine.SetStatus(sInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetStatusData(map[string]interface{}{"pew.pew": "zap"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Started)
+	c.Check(statusInfo.Message, gc.Equals, "blah")
+	c.Check(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"foo":     "bar",
+		"pew.pew": "zap",
+	})
+
+	// A nil value removes the key.
+	err = s.machine.SetStatusData(map[string]interface{}{"pew.pew": nil})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err = s.machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Started)
+	c.Check(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"foo": "bar",
+	})
+}
+
+func (s *MachineStatusSuite) TestSetStatusDataGone(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetStatusData(map[string]interface{}{"foo": "bar"})
+	c.Check(err, gc.ErrorMatches, `cannot set status data: machine not found`)
+}
+
 func (s *MachineStatusSuite) TestSetStatusPendingProvisioned(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
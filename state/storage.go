@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/storage/provider"
@@ -1025,6 +1026,27 @@ func poolStorageProvider(st *State, poolName string) (storage.ProviderType, stor
 	return providerType, provider, nil
 }
 
+// validateHostLoopStoragePools returns an IsNotSupported error if any of
+// the specified pools uses the hostloop provider, and the machine the
+// storage is created with is not a KVM container. Hostloop volumes are
+// created by the KVM container broker when it starts the container, so
+// they can't be provisioned anywhere else.
+func validateHostLoopStoragePools(st *State, pools set.Strings, containerType instance.ContainerType) error {
+	if containerType == instance.KVM {
+		return nil
+	}
+	for pool := range pools {
+		providerType, _, err := poolStorageProvider(st, pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if providerType == provider.HostLoopProviderType {
+			return errors.NotSupportedf("%q storage outside a KVM container", pool)
+		}
+	}
+	return nil
+}
+
 // ErrNoDefaultStoragePool is returned when a storage pool is required but none
 // is specified nor available as a default.
 var ErrNoDefaultStoragePool = fmt.Errorf("no storage pool specifed and no default available")
@@ -1222,6 +1244,22 @@ func (st *State) addStorageForUnitOps(
 	if cons.Count == 0 {
 		return nil, errors.NotValidf("adding storage where instance count is 0")
 	}
+	if u.doc.MachineId == "" {
+		// The storage will be created along with the unit's machine,
+		// which is a container only if the unit's constraints say so.
+		unitCons, err := u.Constraints()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var containerType instance.ContainerType
+		if unitCons.HasContainer() {
+			containerType = *unitCons.Container
+		}
+		pools := set.NewStrings(completeCons.Pool)
+		if err := validateHostLoopStoragePools(st, pools, containerType); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	addUnitStorageOps, err := st.addUnitStorageOps(charmMeta, u, storageName, completeCons, -1)
 	if err != nil {
@@ -79,6 +79,14 @@ func (s *storageAddSuite) assertFileSystemCount(c *gc.C, count int) {
 	c.Assert(all, gc.HasLen, count)
 }
 
+func (s *storageAddSuite) TestAddHostLoopStorageToUnitOutsideKVM(c *gc.C) {
+	s.setupMultipleStoragesForAdd(c)
+	err := s.State.AddStorageForUnit(s.unitTag, "multi1to10", makeStorageCons("hostloop", 1024, 1))
+	c.Assert(err, gc.ErrorMatches, `adding storage to unit storage-block2/0: "hostloop" storage outside a KVM container not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+	s.assertStorageCount(c, s.originalStorageCount)
+}
+
 func (s *storageAddSuite) TestAddStorageToUnit(c *gc.C) {
 	u := s.setupMultipleStoragesForAdd(c)
 	s.assignUnit(c, u)
@@ -15,6 +15,8 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -395,6 +397,69 @@ func (s *StorageStateSuite) TestAddServiceStorageConstraintsValidation(c *gc.C)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *StorageStateSuite) TestAddServiceHostLoopStorageRequiresKVM(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storageCons := map[string]state.StorageConstraints{
+		"data": makeStorageCons("hostloop", 1024, 1),
+	}
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name: "storage-block", Charm: ch, Storage: storageCons,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": "hostloop" storage outside a KVM container not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name: "storage-block", Charm: ch, Storage: storageCons,
+		Constraints: constraints.MustParse("container=kvm"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StorageStateSuite) TestAddServiceHostLoopStorageWithKVMPlacement(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storageCons := map[string]state.StorageConstraints{
+		"data": makeStorageCons("hostloop", 1024, 1),
+	}
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Placing the unit in a new KVM container allows hostloop storage.
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name: "storage-block", Charm: ch, Storage: storageCons, NumUnits: 1,
+		Placement: []*instance.Placement{{Scope: string(instance.KVM), Directive: m.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Placing the unit directly on a machine does not.
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name: "storage-block2", Charm: ch, Storage: storageCons, NumUnits: 1,
+		Placement: []*instance.Placement{{Scope: instance.MachineScope, Directive: m.Id()}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block2": "hostloop" storage outside a KVM container not supported`)
+}
+
+func (s *StorageStateSuite) TestAddMachineHostLoopVolumeRequiresKVM(c *gc.C) {
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.MachineVolumeParams{{
+			Volume: state.VolumeParams{Pool: "hostloop", Size: 1024},
+		}},
+	}
+	_, err := s.State.AddOneMachine(template)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: "hostloop" storage outside a KVM container not supported`)
+
+	parentTemplate := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	m, err := s.State.AddMachineInsideNewMachine(template, parentTemplate, instance.KVM)
+	c.Assert(err, jc.ErrorIsNil)
+	attachments, err := s.State.MachineVolumeAttachments(m.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+}
+
 func (s *StorageStateSuite) assertAddServiceStorageConstraintsDefaults(c *gc.C, pool string, cons, expect map[string]state.StorageConstraints) {
 	if pool != "" {
 		err := s.State.UpdateModelConfig(map[string]interface{}{
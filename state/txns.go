@@ -4,10 +4,15 @@
 package state
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
+	"golang.org/x/net/context"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/tracing"
 )
 
 // readTxnRevno is a convenience method delegating to the state's Database.
@@ -26,7 +31,7 @@ func (st *State) readTxnRevno(collectionName string, id interface{}) (int64, err
 func (st *State) runTransaction(ops []txn.Op) error {
 	runner, closer := st.database.TransactionRunner()
 	defer closer()
-	return runner.RunTransaction(ops)
+	return st.traceRunner(runner, st.ModelUUID()).RunTransaction(ops)
 }
 
 // runTransaction is a convenience method delegating to the state's Database
@@ -36,7 +41,7 @@ func (st *State) runTransactionFor(modelUUID string, ops []txn.Op) error {
 	defer dbcloser()
 	runner, closer := database.TransactionRunner()
 	defer closer()
-	return runner.RunTransaction(ops)
+	return st.traceRunner(runner, modelUUID).RunTransaction(ops)
 }
 
 // runRawTransaction is a convenience method that will run a single
@@ -48,14 +53,14 @@ func (st *State) runRawTransaction(ops []txn.Op) error {
 	if multiRunner, ok := runner.(*multiModelRunner); ok {
 		runner = multiRunner.rawRunner
 	}
-	return runner.RunTransaction(ops)
+	return st.traceRunner(runner, "").RunTransaction(ops)
 }
 
 // run is a convenience method delegating to the state's Database.
 func (st *State) run(transactions jujutxn.TransactionSource) error {
 	runner, closer := st.database.TransactionRunner()
 	defer closer()
-	return runner.Run(transactions)
+	return st.traceRunner(runner, st.ModelUUID()).Run(transactions)
 }
 
 // runForModel is a convenience method that delegates to a Database for a different
@@ -65,7 +70,7 @@ func (st *State) runForModel(modelUUID string, transactions jujutxn.TransactionS
 	defer dbcloser()
 	runner, closer := database.TransactionRunner()
 	defer closer()
-	return runner.Run(transactions)
+	return st.traceRunner(runner, modelUUID).Run(transactions)
 }
 
 // ResumeTransactions resumes all pending transactions.
@@ -83,6 +88,66 @@ func (st *State) MaybePruneTransactions() error {
 	return runner.MaybePruneTransactions(2.0)
 }
 
+// traceRunner returns a runner that records the transactions run by
+// the given runner as children of the tracing span carried by the
+// state's context. If there is no such span, the runner is returned
+// unchanged.
+func (st *State) traceRunner(runner jujutxn.Runner, modelUUID string) jujutxn.Runner {
+	if tracing.SpanFromContext(st.ctx) == nil {
+		return runner
+	}
+	return &tracingRunner{
+		Runner:    runner,
+		ctx:       st.ctx,
+		modelUUID: modelUUID,
+	}
+}
+
+// tracingRunner is a jujutxn.Runner that records each transaction it
+// runs as a tracing span.
+type tracingRunner struct {
+	jujutxn.Runner
+	ctx       context.Context
+	modelUUID string
+}
+
+// RunTransaction is part of the jujutxn.Runner interface.
+func (r *tracingRunner) RunTransaction(ops []txn.Op) error {
+	span := r.startSpan()
+	err := r.Runner.RunTransaction(ops)
+	r.finishSpan(span, 1, err)
+	return err
+}
+
+// Run is part of the jujutxn.Runner interface.
+func (r *tracingRunner) Run(transactions jujutxn.TransactionSource) error {
+	span := r.startSpan()
+	var attempts int
+	err := r.Runner.Run(func(attempt int) ([]txn.Op, error) {
+		attempts = attempt + 1
+		return transactions(attempt)
+	})
+	r.finishSpan(span, attempts, err)
+	return err
+}
+
+func (r *tracingRunner) startSpan() *tracing.ActiveSpan {
+	span := tracing.StartChildSpan(r.ctx, "txn")
+	span.SetTag("component", "state")
+	if r.modelUUID != "" {
+		span.SetTag("model", r.modelUUID)
+	}
+	return span
+}
+
+func (r *tracingRunner) finishSpan(span *tracing.ActiveSpan, attempts int, err error) {
+	span.SetTag("attempts", strconv.Itoa(attempts))
+	if err != nil && err != jujutxn.ErrNoOperations {
+		span.SetTag("error", err.Error())
+	}
+	span.Finish()
+}
+
 type multiModelRunner struct {
 	rawRunner jujutxn.Runner
 	schema    collectionSchema
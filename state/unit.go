@@ -1499,10 +1499,12 @@ func (u *Unit) AssignToMachine(m *Machine) (err error) {
 
 // assignToNewMachineOps returns txn.Ops to assign the unit to a machine
 // created according to the supplied params, with the supplied constraints.
+// The new machine's preferred addresses are chosen by the given IP family.
 func (u *Unit) assignToNewMachineOps(
 	template MachineTemplate,
 	parentId string,
 	containerType instance.ContainerType,
+	family network.IPFamily,
 ) (*Machine, []txn.Op, error) {
 
 	if u.Life() != Alive {
@@ -1522,7 +1524,7 @@ func (u *Unit) assignToNewMachineOps(
 	)
 	switch {
 	case parentId == "" && containerType == "":
-		mdoc, ops, err = u.st.addMachineOps(template)
+		mdoc, ops, err = u.st.addMachineOps(template, family)
 	case parentId == "":
 		if containerType == "" {
 			return nil, nil, errors.New("assignToNewMachine called without container type (should never happen)")
@@ -1531,9 +1533,9 @@ func (u *Unit) assignToNewMachineOps(
 		// regardless of its child.
 		parentParams := template
 		parentParams.Jobs = []MachineJob{JobHostUnits}
-		mdoc, ops, err = u.st.addMachineInsideNewMachineOps(template, parentParams, containerType)
+		mdoc, ops, err = u.st.addMachineInsideNewMachineOps(template, parentParams, containerType, family)
 	default:
-		mdoc, ops, err = u.st.addMachineInsideMachineOps(template, parentId, containerType)
+		mdoc, ops, err = u.st.addMachineInsideMachineOps(template, parentId, containerType, family)
 	}
 	if err != nil {
 		return nil, nil, err
@@ -1621,7 +1623,7 @@ func (u *Unit) AssignToNewMachineOrContainer() (err error) {
 	hostCons := *cons
 	noContainer := instance.NONE
 	hostCons.Container = &noContainer
-	query, err := u.findCleanMachineQuery(true, &hostCons)
+	query, err := u.findCleanMachineQuery(true, false, &hostCons)
 	if err != nil {
 		return err
 	}
@@ -1636,6 +1638,10 @@ func (u *Unit) AssignToNewMachineOrContainer() (err error) {
 	} else if err != nil {
 		return err
 	}
+	family, err := u.st.preferredIPFamily()
+	if err != nil {
+		return errors.Trace(err)
+	}
 
 	var m *Machine
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -1653,7 +1659,7 @@ func (u *Unit) AssignToNewMachineOrContainer() (err error) {
 			Jobs:        []MachineJob{JobHostUnits},
 		}
 		var ops []txn.Op
-		m, ops, err = u.assignToNewMachineOps(template, host.Id, *cons.Container)
+		m, ops, err = u.assignToNewMachineOps(template, host.Id, *cons.Container, family)
 		return ops, err
 	}
 	if err := u.st.run(buildTxn); err != nil {
@@ -1676,6 +1682,10 @@ func (u *Unit) AssignToNewMachine() (err error) {
 	if u.doc.Principal != "" {
 		return fmt.Errorf("unit is a subordinate")
 	}
+	family, err := u.st.preferredIPFamily()
+	if err != nil {
+		return errors.Trace(err)
+	}
 	var m *Machine
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		var err error
@@ -1711,7 +1721,7 @@ func (u *Unit) AssignToNewMachine() (err error) {
 		// machine doc that will be added with those operations
 		// (which includes the machine id).
 		var ops []txn.Op
-		m, ops, err = u.assignToNewMachineOps(template, "", containerType)
+		m, ops, err = u.assignToNewMachineOps(template, "", containerType, family)
 		return ops, err
 	}
 	if err := u.st.run(buildTxn); err != nil {
@@ -1913,6 +1923,8 @@ func machineStorageParamsForStorageInstance(
 
 var noCleanMachines = errors.New("all eligible machines in use")
 
+var noPooledMachines = errors.New("no eligible pooled machines available")
+
 // AssignToCleanMachine assigns u to a machine which is marked as clean. A machine
 // is clean if it has never had any principal units assigned to it.
 // If there are no clean machines besides any machine(s) running JobHostEnviron,
@@ -1920,7 +1932,7 @@ var noCleanMachines = errors.New("all eligible machines in use")
 // This method does not take constraints into consideration when choosing a
 // machine (lp:1161919).
 func (u *Unit) AssignToCleanMachine() (m *Machine, err error) {
-	return u.assignToCleanMaybeEmptyMachine(false)
+	return u.assignToCleanMaybeEmptyMachine(false, false)
 }
 
 // AssignToCleanEmptyMachine assigns u to a machine which is marked as clean and is also
@@ -1930,7 +1942,15 @@ func (u *Unit) AssignToCleanMachine() (m *Machine, err error) {
 // This method does not take constraints into consideration when choosing a
 // machine (lp:1161919).
 func (u *Unit) AssignToCleanEmptyMachine() (m *Machine, err error) {
-	return u.assignToCleanMaybeEmptyMachine(true)
+	return u.assignToCleanMaybeEmptyMachine(true, false)
+}
+
+// AssignToPooledMachine assigns u to a clean, empty machine taken from
+// one of the model's machine pools. The machine is removed from its
+// pool so that the pool is topped up again. If no pooled machine
+// satisfies the unit's constraints, an error is returned.
+func (u *Unit) AssignToPooledMachine() (m *Machine, err error) {
+	return u.assignToCleanMaybeEmptyMachine(true, true)
 }
 
 var hasContainerTerm = bson.DocElem{
@@ -1946,8 +1966,9 @@ var hasNoContainersTerm = bson.DocElem{
 	}}
 
 // findCleanMachineQuery returns a Mongo query to find clean (and possibly empty) machines with
-// characteristics matching the specified constraints.
-func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value) (bson.D, error) {
+// characteristics matching the specified constraints. If pooled is true, only machines
+// reserved for a machine pool are matched; otherwise pooled machines are excluded.
+func (u *Unit) findCleanMachineQuery(requireEmpty, pooled bool, cons *constraints.Value) (bson.D, error) {
 	db, closer := u.st.newDB()
 	defer closer()
 	containerRefsCollection, closer := db.GetCollection(containerRefsC)
@@ -1973,6 +1994,7 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 		{"jobs", []MachineJob{JobHostUnits}},
 		{"clean", true},
 		{"machineid", bson.D{{"$nin", machinesWithContainers}}},
+		{"pool", bson.D{{"$exists", pooled}}},
 	}
 	// Add the container filter term if necessary.
 	var containerType instance.ContainerType
@@ -2012,6 +2034,9 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	if cons.Tags != nil && len(*cons.Tags) > 0 {
 		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *cons.Tags}}})
 	}
+	if cons.HasZones() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"availzone", bson.D{{"$in", *cons.Zones}}})
+	}
 	if len(suitableTerms) > 0 {
 		instanceDataCollection, closer := db.GetCollection(instanceDataC)
 		defer closer()
@@ -2030,7 +2055,7 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 
 // assignToCleanMaybeEmptyMachine implements AssignToCleanMachine and AssignToCleanEmptyMachine.
 // A 'machine' may be a machine instance or container depending on the service constraints.
-func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (*Machine, error) {
+func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty, pooled bool) (*Machine, error) {
 	var m *Machine
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		var err error
@@ -2042,7 +2067,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (*Machine, erro
 			}
 		}
 		var ops []txn.Op
-		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty)
+		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty, pooled)
 		return ops, err
 	}
 	if err := u.st.run(buildTxn); err != nil {
@@ -2050,10 +2075,11 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (*Machine, erro
 	}
 	u.doc.MachineId = m.doc.Id
 	m.doc.Clean = false
+	m.doc.Pool = ""
 	return m, nil
 }
 
-func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine, _ []txn.Op, err error) {
+func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty, pooled bool) (_ *Machine, _ []txn.Op, err error) {
 	failure := func(err error) (*Machine, []txn.Op, error) {
 		return nil, nil, err
 	}
@@ -2063,6 +2089,11 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		context += ", empty"
 	}
 	context += " machine"
+	noMachines := noCleanMachines
+	if pooled {
+		context = "pooled machine"
+		noMachines = noPooledMachines
+	}
 
 	if u.doc.Principal != "" {
 		err = fmt.Errorf("unit is a subordinate")
@@ -2084,7 +2115,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 	}
 	if err := validateDynamicStoragePools(u.st, storagePools); err != nil {
 		if errors.IsNotSupported(err) {
-			return failure(noCleanMachines)
+			return failure(noMachines)
 		}
 		assignContextf(&err, u.Name(), context)
 		return failure(err)
@@ -2096,7 +2127,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		assignContextf(&err, u.Name(), context)
 		return failure(err)
 	}
-	query, err := u.findCleanMachineQuery(requireEmpty, cons)
+	query, err := u.findCleanMachineQuery(requireEmpty, pooled, cons)
 	if err != nil {
 		assignContextf(&err, u.Name(), context)
 		return failure(err)
@@ -2172,6 +2203,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 		}
 		ops, err := u.assignToMachineOps(m, true)
 		if err == nil {
+			if pooled {
+				ops = append(ops, claimPooledMachineOps(m)...)
+			}
 			return m, ops, nil
 		}
 		switch errors.Cause(err) {
@@ -2181,7 +2215,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 			return failure(err)
 		}
 	}
-	return failure(noCleanMachines)
+	return failure(noMachines)
 }
 
 // UnassignFromMachine removes the assignment between this unit and the
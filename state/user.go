@@ -99,6 +99,11 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		Id:     nameToLower,
 		Assert: txn.DocMissing,
 		Insert: &user.doc,
+	}, {
+		// Users and groups share a namespace.
+		C:      groupsC,
+		Id:     nameToLower,
+		Assert: txn.DocMissing,
 	}}
 	controllerUserOps := createControllerUserOps(st.ControllerUUID(),
 		names.NewUserTag(name),
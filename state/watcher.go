@@ -1738,6 +1738,19 @@ func (w *machineAddressesWatcher) loop() error {
 	}
 }
 
+// WatchMachinePools returns a NotifyWatcher that notifies when machine
+// pools are added, removed or resized, or when machines join or leave
+// them.
+func (st *State) WatchMachinePools() NotifyWatcher {
+	return newNotifyCollWatcher(st, machinePoolsC, isLocalID(st))
+}
+
+// WatchSubnets returns a NotifyWatcher that notifies when subnets are
+// added or removed, or moved between spaces.
+func (st *State) WatchSubnets() NotifyWatcher {
+	return newNotifyCollWatcher(st, subnetsC, isLocalID(st))
+}
+
 // WatchCleanups starts and returns a CleanupWatcher.
 func (st *State) WatchCleanups() NotifyWatcher {
 	return newNotifyCollWatcher(st, cleanupsC, isLocalID(st))
@@ -2568,6 +2581,259 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchApplicationCharms returns a StringsWatcher that notifies of
+// the names of applications whose charm or life changes, so that the
+// LXD profiles supplied by their charms can be kept up to date. The
+// first event holds the names of all applications in the model.
+func (st *State) WatchApplicationCharms() StringsWatcher {
+	return newApplicationCharmsWatcher(st)
+}
+
+// applicationCharmsWatcher notifies about changes to the charm URLs
+// and lives of applications, ignoring changes to their other fields.
+type applicationCharmsWatcher struct {
+	commonWatcher
+	known map[string]applicationCharmDoc
+	out   chan []string
+}
+
+// applicationCharmDoc holds the fields of an application document
+// watched by an applicationCharmsWatcher.
+type applicationCharmDoc struct {
+	DocID    string     `bson:"_id"`
+	CharmURL *charm.URL `bson:"charmurl"`
+	Life     Life       `bson:"life"`
+}
+
+var applicationCharmFields = bson.D{{"_id", 1}, {"charmurl", 1}, {"life", 1}}
+
+func newApplicationCharmsWatcher(st *State) StringsWatcher {
+	w := &applicationCharmsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		known:         make(map[string]applicationCharmDoc),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *applicationCharmsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *applicationCharmsWatcher) initial() (set.Strings, error) {
+	applications, closer := w.st.getCollection(applicationsC)
+	defer closer()
+
+	names := make(set.Strings)
+	var doc applicationCharmDoc
+	iter := applications.Find(nil).Select(applicationCharmFields).Iter()
+	for iter.Next(&doc) {
+		name := w.st.localID(doc.DocID)
+		w.known[name] = doc
+		names.Add(name)
+	}
+	return names, iter.Close()
+}
+
+func (w *applicationCharmsWatcher) merge(names set.Strings, change watcher.Change) error {
+	name := w.st.localID(change.Id.(string))
+	if change.Revno == -1 {
+		if _, ok := w.known[name]; ok {
+			delete(w.known, name)
+			names.Add(name)
+		}
+		return nil
+	}
+	applications, closer := w.st.getCollection(applicationsC)
+	defer closer()
+
+	var doc applicationCharmDoc
+	err := applications.FindId(change.Id).Select(applicationCharmFields).One(&doc)
+	if err == mgo.ErrNotFound {
+		// The application has been removed since the change; the
+		// removal will be reported separately.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	known, ok := w.known[name]
+	w.known[name] = doc
+	if !ok || !reflect.DeepEqual(known, doc) {
+		names.Add(name)
+	}
+	return nil
+}
+
+func (w *applicationCharmsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(applicationsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(applicationsC, in)
+	names, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if err := w.merge(names, change); err != nil {
+				return errors.Trace(err)
+			}
+			if !names.IsEmpty() {
+				out = w.out
+			}
+		case out <- names.SortedValues():
+			out = nil
+			names = make(set.Strings)
+		}
+	}
+}
+
+// WatchUnitAssignments returns a StringsWatcher that notifies of the
+// ids of machines that have principal units assigned to or removed
+// from them, or whose principal units gain or lose subordinates, so
+// that the LXD profiles supplied by their charms can be kept up to
+// date. The first event holds the ids of all machines with units.
+func (st *State) WatchUnitAssignments() StringsWatcher {
+	return newUnitAssignmentsWatcher(st)
+}
+
+// unitAssignmentsWatcher notifies about changes to the machines and
+// subordinates of principal units, ignoring changes to their other
+// fields.
+type unitAssignmentsWatcher struct {
+	commonWatcher
+	known map[string]unitAssignmentDoc
+	out   chan []string
+}
+
+// unitAssignmentDoc holds the fields of a unit document watched by a
+// unitAssignmentsWatcher.
+type unitAssignmentDoc struct {
+	DocID        string   `bson:"_id"`
+	Principal    string   `bson:"principal"`
+	MachineId    string   `bson:"machineid"`
+	Subordinates []string `bson:"subordinates"`
+}
+
+var unitAssignmentFields = bson.D{{"_id", 1}, {"principal", 1}, {"machineid", 1}, {"subordinates", 1}}
+
+func newUnitAssignmentsWatcher(st *State) StringsWatcher {
+	w := &unitAssignmentsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		known:         make(map[string]unitAssignmentDoc),
+		out:           make(chan []string),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *unitAssignmentsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+func (w *unitAssignmentsWatcher) initial() (set.Strings, error) {
+	units, closer := w.st.getCollection(unitsC)
+	defer closer()
+
+	machineIds := make(set.Strings)
+	var doc unitAssignmentDoc
+	iter := units.Find(bson.D{{"principal", ""}}).Select(unitAssignmentFields).Iter()
+	for iter.Next(&doc) {
+		w.known[w.st.localID(doc.DocID)] = doc
+		if doc.MachineId != "" {
+			machineIds.Add(doc.MachineId)
+		}
+	}
+	return machineIds, iter.Close()
+}
+
+func (w *unitAssignmentsWatcher) merge(machineIds set.Strings, change watcher.Change) error {
+	name := w.st.localID(change.Id.(string))
+	if change.Revno == -1 {
+		if known, ok := w.known[name]; ok {
+			delete(w.known, name)
+			if known.MachineId != "" {
+				machineIds.Add(known.MachineId)
+			}
+		}
+		return nil
+	}
+	units, closer := w.st.getCollection(unitsC)
+	defer closer()
+
+	var doc unitAssignmentDoc
+	err := units.FindId(change.Id).Select(unitAssignmentFields).One(&doc)
+	if err == mgo.ErrNotFound {
+		// The unit has been removed since the change; the removal
+		// will be reported separately.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.Principal != "" {
+		// Subordinates are reported through the subordinates
+		// of their principals.
+		return nil
+	}
+	known, ok := w.known[name]
+	w.known[name] = doc
+	if ok && reflect.DeepEqual(known, doc) {
+		return nil
+	}
+	if ok && known.MachineId != "" {
+		machineIds.Add(known.MachineId)
+	}
+	if doc.MachineId != "" {
+		machineIds.Add(doc.MachineId)
+	}
+	return nil
+}
+
+func (w *unitAssignmentsWatcher) loop() error {
+	in := make(chan watcher.Change)
+	w.watcher.WatchCollectionWithFilter(unitsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(unitsC, in)
+	machineIds, err := w.initial()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if err := w.merge(machineIds, change); err != nil {
+				return errors.Trace(err)
+			}
+			if !machineIds.IsEmpty() {
+				out = w.out
+			}
+		case out <- machineIds.SortedValues():
+			out = nil
+			machineIds = make(set.Strings)
+		}
+	}
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -147,6 +147,11 @@ const (
 	// The unit believes it is correctly offering all the services it has
 	// been asked to offer.
 	Active Status = "active"
+
+	// Unhealthy is set when:
+	// One or more of the health checks declared by the unit's charm
+	// have failed. It is set by the unit agent, never by the charm.
+	Unhealthy Status = "unhealthy"
 )
 
 const (
@@ -262,7 +267,8 @@ func ValidWorkloadStatus(status Status) bool {
 		Waiting,
 		Active,
 		Unknown,
-		Terminated:
+		Terminated,
+		Unhealthy:
 		return true
 	default:
 		return false
@@ -13,9 +13,10 @@ var (
 	errNoMountPoint = errors.New("filesystem mount point not specified")
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
-		LoopProviderType:   &loopProvider{logAndExec},
-		RootfsProviderType: &rootfsProvider{logAndExec},
-		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		HostLoopProviderType: &hostLoopProvider{},
+		LoopProviderType:     &loopProvider{logAndExec},
+		RootfsProviderType:   &rootfsProvider{logAndExec},
+		TmpfsProviderType:    &tmpfsProvider{logAndExec},
 	}
 )
 
@@ -125,6 +125,10 @@ func TmpfsFilesystemSource(storageDir string, run func(string, ...string) (strin
 	}
 }
 
+func HostLoopProvider() storage.Provider {
+	return &hostLoopProvider{}
+}
+
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/storage"
+)
+
+// HostDirAttr is the optional hostloop pool attribute naming the
+// directory on the container host in which the volume images are
+// created. If it is not specified, the images are created alongside
+// the container's own disks.
+const HostDirAttr = "host-dir"
+
+// hostLoopProvider describes block devices that are backed by image
+// files on the host of a KVM container, and attached to the container
+// as virtio disks when it is started. The volumes are created by the
+// container broker, so the provider is not dynamic and has no volume
+// source of its own.
+type hostLoopProvider struct{}
+
+var _ storage.Provider = (*hostLoopProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*hostLoopProvider) ValidateConfig(cfg *storage.Config) error {
+	hostDir, ok := cfg.ValueString(HostDirAttr)
+	if ok && hostDir != "" && !filepath.IsAbs(hostDir) {
+		return errors.Errorf("%s %q must be an absolute path", HostDirAttr, hostDir)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*hostLoopProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volume source for %s", HostLoopProviderType)
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*hostLoopProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*hostLoopProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*hostLoopProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*hostLoopProvider) Dynamic() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*hostLoopProvider) DefaultPools() []*storage.Config {
+	return nil
+}
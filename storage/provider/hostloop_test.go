@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&hostLoopSuite{})
+
+type hostLoopSuite struct {
+	testing.BaseSuite
+}
+
+func (s *hostLoopSuite) TestValidateConfig(c *gc.C) {
+	p := provider.HostLoopProvider()
+	cfg, err := storage.NewConfig("name", provider.HostLoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.HostLoopProviderType, map[string]interface{}{
+		"host-dir": "/srv/images",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.ValidateConfig(cfg), jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.HostLoopProviderType, map[string]interface{}{
+		"host-dir": "images",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `host-dir "images" must be an absolute path`)
+}
+
+func (s *hostLoopSuite) TestNoSources(c *gc.C) {
+	p := provider.HostLoopProvider()
+	cfg, err := storage.NewConfig("name", provider.HostLoopProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *hostLoopSuite) TestSupports(c *gc.C) {
+	p := provider.HostLoopProvider()
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *hostLoopSuite) TestScope(c *gc.C) {
+	p := provider.HostLoopProvider()
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *hostLoopSuite) TestDynamic(c *gc.C) {
+	p := provider.HostLoopProvider()
+	c.Assert(p.Dynamic(), jc.IsFalse)
+}
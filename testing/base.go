@@ -48,6 +48,7 @@ func (s *JujuOSEnvSuite) SetUpTest(c *gc.C) {
 	for _, name := range []string{
 		osenv.JujuXDGDataHomeEnvKey,
 		osenv.JujuModelEnvKey,
+		osenv.JujuAPITokenEnvKey,
 		osenv.JujuLoggingConfigEnvKey,
 		osenv.JujuFeatureFlagEnvKey,
 		osenv.XDGDataHome,
@@ -300,7 +300,7 @@ func verifyDefaultProfileBridgeConfig(client *lxd.Client, networkAPISupported bo
 		 * handle this case and configure one now.
 		 */
 		if networkAPISupported {
-			if err := CreateDefaultBridgeInDefaultProfile(client); err != nil {
+			if err := CreateDefaultBridgeInDefaultProfile(client, network.PreferIPv4); err != nil {
 				return "", errors.Annotate(err, "couldn't create default bridge")
 			}
 
@@ -308,7 +308,7 @@ func verifyDefaultProfileBridgeConfig(client *lxd.Client, networkAPISupported bo
 		}
 		return "", errors.Errorf("unexpected LXD %q profile config without eth0: %+v", defaultProfileName, config)
 	} else if networkAPISupported {
-		if err := checkBridgeConfig(client, eth0[configParentKey]); err != nil {
+		if err := checkBridgeConfig(client, eth0[configParentKey], network.PreferIPv4); err != nil {
 			return "", err
 		}
 	}
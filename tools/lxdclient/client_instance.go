@@ -46,6 +46,7 @@ type rawInstanceClient interface {
 	ContainerState(name string) (*api.ContainerState, error)
 	ContainerDeviceAdd(container, devname, devtype string, props []string) (*api.Response, error)
 	PushFile(container, path string, gid int, uid int, mode string, buf io.ReadSeeker) error
+	UpdateContainerConfig(container string, st api.ContainerPut) error
 }
 
 type instanceClient struct {
@@ -175,6 +176,31 @@ func (client *instanceClient) Status(name string) (string, error) {
 	return info.Status, nil
 }
 
+// InstanceProfiles returns the names of the profiles applied to the
+// given instance.
+func (client *instanceClient) InstanceProfiles(name string) ([]string, error) {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info.Profiles, nil
+}
+
+// SetInstanceProfiles replaces the profiles applied to the given
+// instance with the named ones.
+func (client *instanceClient) SetInstanceProfiles(name string, profiles []string) error {
+	info, err := client.raw.ContainerInfo(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	put := info.Writable()
+	put.Profiles = profiles
+	if err := client.raw.UpdateContainerConfig(name, put); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // Instances sends a request to the API for a list of all instances
 // (in the Client's namespace) for which the name starts with the
 // provided prefix. The result is also limited to those instances with
@@ -48,33 +48,57 @@ type creator interface {
 	ProfileConfig(profile string) (*api.Profile, error)
 }
 
-func checkBridgeConfig(client rawNetworkClient, bridge string) error {
+func checkBridgeConfig(client rawNetworkClient, bridge string, family network.IPFamily) error {
 	n, err := client.NetworkGet(bridge)
 	if err != nil {
 		return err
 	}
+	if !n.Managed {
+		return nil
+	}
 	ipv6AddressConfig := n.Config["ipv6.address"]
-	if n.Managed && ipv6AddressConfig != "none" && ipv6AddressConfig != "" {
-		return errors.Errorf(`juju doesn't support ipv6. Please disable LXD's IPV6:
+	ipv6Enabled := ipv6AddressConfig != "none" && ipv6AddressConfig != ""
+	if ipv6Enabled && family == network.PreferIPv4 {
+		return errors.Errorf(`the model's preferred-ip-family is "ipv4", but LXD's IPV6 is enabled. Please either disable LXD's IPV6:
 
 	$ lxc network set %s ipv6.address none
 
-and rebootstrap`, bridge)
+or set preferred-ip-family to "ipv6" or "dual", and rebootstrap`, bridge)
+	}
+	if !ipv6Enabled && family != network.PreferIPv4 {
+		logger.Warningf(
+			"LXD bridge %q has IPv6 disabled; containers will only get IPv4 addresses despite preferred-ip-family %q",
+			bridge, family,
+		)
 	}
 
 	return nil
 }
 
+// bridgeNetworkConfig returns the LXD network config used to create the
+// default bridge: IPv6 is only enabled when the IP family asks for it.
+func bridgeNetworkConfig(family network.IPFamily) map[string]string {
+	if family == network.PreferIPv4 {
+		return map[string]string{
+			"ipv6.address": "none",
+			"ipv6.nat":     "false",
+		}
+	}
+	return map[string]string{
+		"ipv6.address": "auto",
+		"ipv6.nat":     "true",
+	}
+}
+
 // CreateDefaultBridgeInDefaultProfile creates a default bridge if it doesn't
-// exist and (if necessary) inserts it into the default profile.
-func CreateDefaultBridgeInDefaultProfile(client creator) error {
+// exist and (if necessary) inserts it into the default profile. IPv6 is
+// enabled on a newly created bridge when the given IP family is
+// network.PreferIPv6 or network.DualStack.
+func CreateDefaultBridgeInDefaultProfile(client creator, family network.IPFamily) error {
 	/* create the default bridge if it doesn't exist */
 	n, err := client.NetworkGet(network.DefaultLXDBridge)
 	if err != nil {
-		err := client.NetworkCreate(network.DefaultLXDBridge, map[string]string{
-			"ipv6.address": "none",
-			"ipv6.nat":     "false",
-		})
+		err := client.NetworkCreate(network.DefaultLXDBridge, bridgeNetworkConfig(family))
 		if err != nil {
 			return err
 		}
@@ -84,7 +108,7 @@ func CreateDefaultBridgeInDefaultProfile(client creator) error {
 			return err
 		}
 	} else {
-		if err := checkBridgeConfig(client, network.DefaultLXDBridge); err != nil {
+		if err := checkBridgeConfig(client, network.DefaultLXDBridge, family); err != nil {
 			return err
 		}
 	}
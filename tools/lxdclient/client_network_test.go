@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build go1.3
+
+package lxdclient
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+)
+
+type networkSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&networkSuite{})
+
+type stubNetworkCreator struct {
+	networks map[string]api.Network
+	created  map[string]map[string]string
+}
+
+func newStubNetworkCreator() *stubNetworkCreator {
+	return &stubNetworkCreator{
+		networks: make(map[string]api.Network),
+		created:  make(map[string]map[string]string),
+	}
+}
+
+func (s *stubNetworkCreator) NetworkCreate(name string, config map[string]string) error {
+	s.created[name] = config
+	n := api.Network{Name: name, Type: "bridge", Managed: true}
+	n.Config = config
+	s.networks[name] = n
+	return nil
+}
+
+func (s *stubNetworkCreator) NetworkGet(name string) (api.Network, error) {
+	n, ok := s.networks[name]
+	if !ok {
+		return api.Network{}, errors.NotFoundf("network %q", name)
+	}
+	return n, nil
+}
+
+func (s *stubNetworkCreator) ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error) {
+	return &api.Response{}, nil
+}
+
+func (s *stubNetworkCreator) ProfileConfig(profile string) (*api.Profile, error) {
+	return &api.Profile{}, nil
+}
+
+func (s *networkSuite) TestCreateDefaultBridgeIPv4(c *gc.C) {
+	client := newStubNetworkCreator()
+	err := CreateDefaultBridgeInDefaultProfile(client, network.PreferIPv4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.created[network.DefaultLXDBridge], jc.DeepEquals, map[string]string{
+		"ipv6.address": "none",
+		"ipv6.nat":     "false",
+	})
+}
+
+func (s *networkSuite) TestCreateDefaultBridgeDualStack(c *gc.C) {
+	client := newStubNetworkCreator()
+	err := CreateDefaultBridgeInDefaultProfile(client, network.DualStack)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.created[network.DefaultLXDBridge], jc.DeepEquals, map[string]string{
+		"ipv6.address": "auto",
+		"ipv6.nat":     "true",
+	})
+}
+
+func (s *networkSuite) TestCheckBridgeConfigIPv6Enabled(c *gc.C) {
+	client := newStubNetworkCreator()
+	err := client.NetworkCreate("lxdbr0", map[string]string{"ipv6.address": "auto"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = checkBridgeConfig(client, "lxdbr0", network.PreferIPv4)
+	c.Assert(err, gc.ErrorMatches, `(?s)the model's preferred-ip-family is "ipv4", but LXD's IPV6 is enabled.*`)
+
+	err = checkBridgeConfig(client, "lxdbr0", network.PreferIPv6)
+	c.Assert(err, jc.ErrorIsNil)
+	err = checkBridgeConfig(client, "lxdbr0", network.DualStack)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -6,6 +6,8 @@
 package lxdclient
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/lxc/lxd/shared/api"
 )
@@ -18,6 +20,7 @@ type rawProfileClient interface {
 	ProfileDelete(profile string) error
 	ProfileDeviceAdd(profile, devname, devtype string, props []string) (*api.Response, error)
 	ProfileConfig(profile string) (*api.Profile, error)
+	PutProfile(name string, profile api.ProfilePut) error
 }
 
 type profileClient struct {
@@ -94,3 +97,38 @@ func (p profileClient) GetProfileConfig(profile string) (map[string]string, erro
 func (p profileClient) ProfileConfig(profile string) (*api.Profile, error) {
 	return p.raw.ProfileConfig(profile)
 }
+
+// EnsureProfile creates the named profile if it does not exist, and
+// then replaces its description, config and devices with the given
+// ones. Containers using the profile pick up the changes immediately.
+func (p profileClient) EnsureProfile(name string, profile api.ProfilePut) error {
+	exists, err := p.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !exists {
+		if err := p.raw.ProfileCreate(name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := p.raw.PutProfile(name, profile); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UnusedProfiles returns the names of the profiles with the given
+// prefix that are not used by any container.
+func (p profileClient) UnusedProfiles(prefix string) ([]string, error) {
+	profiles, err := p.raw.ListProfiles()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, profile := range profiles {
+		if strings.HasPrefix(profile.Name, prefix) && len(profile.UsedBy) == 0 {
+			names = append(names, profile.Name)
+		}
+	}
+	return names, nil
+}
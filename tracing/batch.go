@@ -0,0 +1,161 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+)
+
+// BatchExporterConfig holds the configuration for a BatchExporter.
+type BatchExporterConfig struct {
+	// Exporter is the exporter that batches of spans are passed to.
+	Exporter Exporter
+
+	// Clock is used to time the flushing of partial batches.
+	Clock clock.Clock
+
+	// QueueSize is the maximum number of spans waiting to be
+	// exported. Spans exported while the queue is full are dropped.
+	QueueSize int
+
+	// BatchSize is the maximum number of spans passed to Exporter
+	// at once.
+	BatchSize int
+
+	// FlushInterval is the longest time a span waits in an
+	// incomplete batch before the batch is exported.
+	FlushInterval time.Duration
+}
+
+// Validate validates the batch exporter configuration.
+func (config BatchExporterConfig) Validate() error {
+	if config.Exporter == nil {
+		return errors.NotValidf("nil Exporter")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.QueueSize <= 0 {
+		return errors.NotValidf("non-positive QueueSize")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.FlushInterval <= 0 {
+		return errors.NotValidf("non-positive FlushInterval")
+	}
+	return nil
+}
+
+// BatchExporter is an Exporter that queues spans and passes them in
+// batches to another exporter on a background goroutine, so that
+// exporting never delays the operations being traced. When the queue
+// is full, spans are dropped rather than waiting for space.
+//
+// A BatchExporter is a worker; when it is stopped, any queued spans
+// are exported before it exits.
+type BatchExporter struct {
+	tomb    tomb.Tomb
+	config  BatchExporterConfig
+	queue   chan Span
+	dropped int64
+}
+
+// NewBatchExporter returns a new BatchExporter with the given
+// configuration.
+func NewBatchExporter(config BatchExporterConfig) (*BatchExporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	e := &BatchExporter{
+		config: config,
+		queue:  make(chan Span, config.QueueSize),
+	}
+	go func() {
+		defer e.tomb.Done()
+		e.tomb.Kill(e.loop())
+	}()
+	return e, nil
+}
+
+// Export is part of the Exporter interface. It queues the spans
+// for export and never blocks.
+func (e *BatchExporter) Export(spans []Span) error {
+	for _, span := range spans {
+		select {
+		case e.queue <- span:
+		default:
+			atomic.AddInt64(&e.dropped, 1)
+		}
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (e *BatchExporter) Kill() {
+	e.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (e *BatchExporter) Wait() error {
+	return e.tomb.Wait()
+}
+
+func (e *BatchExporter) loop() error {
+	var batch []Span
+	var flush <-chan time.Time
+	for {
+		select {
+		case <-e.tomb.Dying():
+			e.drain(batch)
+			return tomb.ErrDying
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) < e.config.BatchSize {
+				if flush == nil {
+					flush = e.config.Clock.After(e.config.FlushInterval)
+				}
+				continue
+			}
+		case <-flush:
+		}
+		e.export(batch)
+		batch = nil
+		flush = nil
+	}
+}
+
+// drain exports the given batch along with any spans still queued.
+func (e *BatchExporter) drain(batch []Span) {
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= e.config.BatchSize {
+				e.export(batch)
+				batch = nil
+			}
+		default:
+			e.export(batch)
+			return
+		}
+	}
+}
+
+func (e *BatchExporter) export(batch []Span) {
+	if dropped := atomic.SwapInt64(&e.dropped, 0); dropped > 0 {
+		logger.Warningf("dropped %d spans: export queue full", dropped)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	if err := e.config.Exporter.Export(batch); err != nil {
+		logger.Warningf("cannot export %d spans: %v", len(batch), err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tracing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type batchSuite struct {
+	testing.IsolationSuite
+	clock     *testing.Clock
+	exporting chan struct{}
+	batches   chan []tracing.Span
+	config    tracing.BatchExporterConfig
+}
+
+var _ = gc.Suite(&batchSuite{})
+
+type channelExporter struct {
+	exporting chan struct{}
+	batches   chan []tracing.Span
+}
+
+func (e channelExporter) Export(spans []tracing.Span) error {
+	e.exporting <- struct{}{}
+	e.batches <- spans
+	<-e.exporting
+	return nil
+}
+
+func (s *batchSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.exporting = make(chan struct{}, 1)
+	s.batches = make(chan []tracing.Span)
+	s.config = tracing.BatchExporterConfig{
+		Exporter:      channelExporter{s.exporting, s.batches},
+		Clock:         s.clock,
+		QueueSize:     10,
+		BatchSize:     2,
+		FlushInterval: time.Second,
+	}
+}
+
+func (s *batchSuite) nextBatch(c *gc.C) []tracing.Span {
+	select {
+	case batch := <-s.batches:
+		return batch
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for batch")
+	}
+	panic("unreachable")
+}
+
+// waitExporting waits until the exporter is blocked passing a batch
+// to the underlying exporter.
+func (s *batchSuite) waitExporting(c *gc.C) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.exporting) > 0 {
+			return
+		}
+	}
+	c.Fatalf("timed out waiting for export")
+}
+
+func (s *batchSuite) TestValidate(c *gc.C) {
+	s.config.QueueSize = 0
+	_, err := tracing.NewBatchExporter(s.config)
+	c.Assert(err, gc.ErrorMatches, "non-positive QueueSize not valid")
+}
+
+func (s *batchSuite) TestExportsFullBatches(c *gc.C) {
+	exporter, err := tracing.NewBatchExporter(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, exporter)
+
+	err = exporter.Export([]tracing.Span{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []tracing.Span{{Name: "a"}, {Name: "b"}})
+
+	// The incomplete batch is exported after the flush interval.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []tracing.Span{{Name: "c"}})
+}
+
+func (s *batchSuite) TestDropsWhenFull(c *gc.C) {
+	s.config.QueueSize = 1
+	s.config.BatchSize = 1
+	exporter, err := tracing.NewBatchExporter(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, exporter)
+
+	// The export of the first span blocks until it is received, so
+	// the second span fills the queue and the third is dropped.
+	err = exporter.Export([]tracing.Span{{Name: "a"}})
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitExporting(c)
+	err = exporter.Export([]tracing.Span{{Name: "b"}, {Name: "c"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []tracing.Span{{Name: "a"}})
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []tracing.Span{{Name: "b"}})
+	select {
+	case batch := <-s.batches:
+		c.Fatalf("unexpected batch %v", batch)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *batchSuite) TestExportsQueuedSpansOnStop(c *gc.C) {
+	exporter, err := tracing.NewBatchExporter(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = exporter.Export([]tracing.Span{{Name: "a"}})
+	c.Assert(err, jc.ErrorIsNil)
+	exporter.Kill()
+	c.Assert(s.nextBatch(c), jc.DeepEquals, []tracing.Span{{Name: "a"}})
+	workertest.CheckKilled(c, exporter)
+}
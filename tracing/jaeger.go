@@ -0,0 +1,208 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// ServiceName is the name under which spans are exported.
+const ServiceName = "juju-apiserver"
+
+// The jaeger* types describe traces in the JSON format used
+// by the Jaeger query API and UI.
+
+type jaegerTraces struct {
+	Data []jaegerTrace `json:"data"`
+}
+
+type jaegerTrace struct {
+	TraceID   string                   `json:"traceID"`
+	Spans     []jaegerSpan             `json:"spans"`
+	Processes map[string]jaegerProcess `json:"processes"`
+}
+
+type jaegerProcess struct {
+	ServiceName string `json:"serviceName"`
+}
+
+type jaegerSpan struct {
+	TraceID       string            `json:"traceID"`
+	SpanID        string            `json:"spanID"`
+	OperationName string            `json:"operationName"`
+	References    []jaegerReference `json:"references"`
+	StartTime     int64             `json:"startTime"`
+	Duration      int64             `json:"duration"`
+	Tags          []jaegerTag       `json:"tags"`
+	ProcessID     string            `json:"processID"`
+}
+
+type jaegerReference struct {
+	RefType string `json:"refType"`
+	TraceID string `json:"traceID"`
+	SpanID  string `json:"spanID"`
+}
+
+type jaegerTag struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+const jaegerProcessID = "p1"
+
+// marshalJaeger returns the given spans, grouped by trace, in Jaeger
+// JSON format.
+func marshalJaeger(spans []Span) ([]byte, error) {
+	var traces jaegerTraces
+	index := make(map[string]int)
+	for _, span := range spans {
+		i, ok := index[span.TraceID]
+		if !ok {
+			i = len(traces.Data)
+			index[span.TraceID] = i
+			traces.Data = append(traces.Data, jaegerTrace{
+				TraceID: span.TraceID,
+				Processes: map[string]jaegerProcess{
+					jaegerProcessID: {ServiceName: ServiceName},
+				},
+			})
+		}
+		traces.Data[i].Spans = append(traces.Data[i].Spans, newJaegerSpan(span))
+	}
+	return json.Marshal(traces)
+}
+
+func newJaegerSpan(span Span) jaegerSpan {
+	references := []jaegerReference{}
+	if span.ParentID != "" {
+		references = append(references, jaegerReference{
+			RefType: "CHILD_OF",
+			TraceID: span.TraceID,
+			SpanID:  span.ParentID,
+		})
+	}
+	keys := make([]string, 0, len(span.Tags))
+	for key := range span.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]jaegerTag, len(keys))
+	for i, key := range keys {
+		tags[i] = jaegerTag{
+			Key:   key,
+			Type:  "string",
+			Value: span.Tags[key],
+		}
+	}
+	return jaegerSpan{
+		TraceID:       span.TraceID,
+		SpanID:        span.SpanID,
+		OperationName: span.Name,
+		References:    references,
+		StartTime:     span.Start.UnixNano() / 1000,
+		Duration:      int64(span.Duration) / 1000,
+		Tags:          tags,
+		ProcessID:     jaegerProcessID,
+	}
+}
+
+// NewWriterExporter returns an Exporter that writes spans to w in
+// Jaeger JSON format, one line per export.
+func NewWriterExporter(w io.Writer) Exporter {
+	return &writerExporter{w: w}
+}
+
+type writerExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// Export is part of the Exporter interface.
+func (e *writerExporter) Export(spans []Span) error {
+	data, err := marshalJaeger(spans)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.w.Write(append(data, '\n'))
+	return errors.Trace(err)
+}
+
+// NewFileExporter returns an Exporter that appends spans to the file
+// at the given path in Jaeger JSON format, one line per export. The
+// file is opened for each export, so that it may be rotated or
+// removed while the exporter is in use; the exporter should be
+// wrapped in a BatchExporter to avoid doing so for every span.
+func NewFileExporter(path string) Exporter {
+	return &fileExporter{path: path}
+}
+
+type fileExporter struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Export is part of the Exporter interface.
+func (e *fileExporter) Export(spans []Span) error {
+	data, err := marshalJaeger(spans)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	f, err := os.OpenFile(e.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return errors.Trace(err)
+}
+
+// NewCollectorExporter returns an Exporter that posts spans in Jaeger
+// JSON format to the collector at the given URL. Export blocks until
+// the collector has responded, so the exporter should be wrapped in
+// a BatchExporter.
+func NewCollectorExporter(client *http.Client, url string) Exporter {
+	return &collectorExporter{
+		client: client,
+		url:    url,
+	}
+}
+
+type collectorExporter struct {
+	client *http.Client
+	url    string
+}
+
+// Export is part of the Exporter interface.
+func (e *collectorExporter) Export(spans []Span) error {
+	data, err := marshalJaeger(spans)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(e.post(data), "cannot post spans to %s", e.url)
+}
+
+func (e *collectorExporter) post(data []byte) error {
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package tracing records the time spent handling API requests as
+// spans of distributed traces, and exports them for analysis.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"golang.org/x/net/context"
+)
+
+var logger = loggo.GetLogger("juju.tracing")
+
+var validTraceID = regexp.MustCompile("^[0-9a-f]{32}$")
+
+// NewTraceID returns a new random trace ID.
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// NewSpanID returns a new random span ID.
+func NewSpanID() string {
+	return randomHex(8)
+}
+
+// IsValidTraceID reports whether id is a valid trace ID.
+func IsValidTraceID(id string) bool {
+	return validTraceID.MatchString(id)
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		panic(fmt.Sprintf("cannot read random bytes: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Span records an operation that is part of a trace.
+type Span struct {
+	// TraceID holds the ID of the trace that the span is part of.
+	TraceID string
+
+	// SpanID holds the ID of the span.
+	SpanID string
+
+	// ParentID holds the ID of the span's parent span,
+	// or is empty if the span is the root of its trace.
+	ParentID string
+
+	// Name describes the operation recorded by the span.
+	Name string
+
+	// Start holds the time the operation started.
+	Start time.Time
+
+	// Duration holds how long the operation took.
+	Duration time.Duration
+
+	// Tags holds additional information about the operation.
+	Tags map[string]string
+}
+
+// Exporter is the interface used to export finished spans.
+type Exporter interface {
+	// Export exports the given spans.
+	Export(spans []Span) error
+}
+
+// MultiExporter is an Exporter that exports spans to each of the
+// exporters it holds.
+type MultiExporter []Exporter
+
+// Export is part of the Exporter interface.
+func (m MultiExporter) Export(spans []Span) error {
+	var firstErr error
+	for _, e := range m {
+		if err := e.Export(spans); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Tracer records spans and passes them to an exporter when they are
+// finished. Spans are exported on the goroutine that finishes them, so
+// the exporter should not block; see BatchExporter.
+type Tracer struct {
+	clock    clock.Clock
+	exporter Exporter
+}
+
+// NewTracer returns a Tracer that uses the given clock to time spans
+// and exports them to the given exporter.
+func NewTracer(clock clock.Clock, exporter Exporter) *Tracer {
+	return &Tracer{
+		clock:    clock,
+		exporter: exporter,
+	}
+}
+
+// StartSpan starts a new span with the given name. If traceID is not
+// a valid trace ID, the span is the root of a new trace. If parentID
+// is non-empty, the span is a child of the span with that ID.
+func (t *Tracer) StartSpan(traceID, parentID, name string) *ActiveSpan {
+	if !IsValidTraceID(traceID) {
+		traceID = NewTraceID()
+		parentID = ""
+	}
+	return &ActiveSpan{
+		tracer: t,
+		span: Span{
+			TraceID:  traceID,
+			SpanID:   NewSpanID(),
+			ParentID: parentID,
+			Name:     name,
+			Start:    t.clock.Now(),
+			Tags:     make(map[string]string),
+		},
+	}
+}
+
+// ActiveSpan is a span that has been started but not yet finished.
+// All methods may be called on a nil *ActiveSpan, and do nothing, so
+// that code need not check whether the operation is being traced.
+type ActiveSpan struct {
+	tracer *Tracer
+
+	// mu guards the fields below it.
+	mu       sync.Mutex
+	span     Span
+	finished bool
+}
+
+// TraceID returns the ID of the trace that the span is part of.
+func (s *ActiveSpan) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return s.span.TraceID
+}
+
+// SpanID returns the ID of the span.
+func (s *ActiveSpan) SpanID() string {
+	if s == nil {
+		return ""
+	}
+	return s.span.SpanID
+}
+
+// StartChild starts a new span with the given name that is a child
+// of s.
+func (s *ActiveSpan) StartChild(name string) *ActiveSpan {
+	if s == nil {
+		return nil
+	}
+	return s.tracer.StartSpan(s.span.TraceID, s.span.SpanID, name)
+}
+
+// SetTag records additional information about the operation.
+func (s *ActiveSpan) SetTag(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Tags[key] = value
+}
+
+// Finish records the end of the operation and exports the span.
+// Calling Finish more than once has no effect.
+func (s *ActiveSpan) Finish() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.finished {
+		s.mu.Unlock()
+		return
+	}
+	s.finished = true
+	s.span.Duration = s.tracer.clock.Now().Sub(s.span.Start)
+	span := s.span
+	s.mu.Unlock()
+
+	if err := s.tracer.exporter.Export([]Span{span}); err != nil {
+		logger.Warningf("cannot export trace %s: %v", span.TraceID, err)
+	}
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx that carries the given span,
+// so that operations run with the context can record child spans.
+func ContextWithSpan(ctx context.Context, span *ActiveSpan) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil if there is
+// none. ctx may be nil.
+func SpanFromContext(ctx context.Context) *ActiveSpan {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*ActiveSpan)
+	return span
+}
+
+// StartChildSpan starts a span with the given name that is a child of
+// the span carried by ctx. If ctx carries no span, the operation is
+// not being traced and StartChildSpan returns nil.
+func StartChildSpan(ctx context.Context, name string) *ActiveSpan {
+	return SpanFromContext(ctx).StartChild(name)
+}
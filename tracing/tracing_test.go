@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tracing_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/net/context"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/tracing"
+)
+
+type tracingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&tracingSuite{})
+
+func (s *tracingSuite) TestNewTraceID(c *gc.C) {
+	id := tracing.NewTraceID()
+	c.Assert(tracing.IsValidTraceID(id), jc.IsTrue)
+	c.Assert(tracing.NewTraceID(), gc.Not(gc.Equals), id)
+}
+
+func (s *tracingSuite) TestNewSpanID(c *gc.C) {
+	c.Assert(tracing.NewSpanID(), gc.Matches, "[0-9a-f]{16}")
+}
+
+func (s *tracingSuite) TestIsValidTraceID(c *gc.C) {
+	c.Assert(tracing.IsValidTraceID(""), jc.IsFalse)
+	c.Assert(tracing.IsValidTraceID("abc"), jc.IsFalse)
+	c.Assert(tracing.IsValidTraceID("0123456789ABCDEF0123456789abcdef"), jc.IsFalse)
+	c.Assert(tracing.IsValidTraceID("0123456789abcdef0123456789abcdef"), jc.IsTrue)
+}
+
+type recordingExporter struct {
+	spans []tracing.Span
+}
+
+func (e *recordingExporter) Export(spans []tracing.Span) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (s *tracingSuite) TestStartSpan(c *gc.C) {
+	clock := testing.NewClock(time.Unix(1000, 0))
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(clock, &exporter)
+
+	traceID := tracing.NewTraceID()
+	span := tracer.StartSpan(traceID, "0011223344556677", "Client.FullStatus")
+	span.SetTag("model", "deadbeef")
+	clock.Advance(2 * time.Second)
+	c.Assert(exporter.spans, gc.HasLen, 0)
+	span.Finish()
+	span.Finish()
+
+	c.Assert(exporter.spans, jc.DeepEquals, []tracing.Span{{
+		TraceID:  traceID,
+		SpanID:   span.SpanID(),
+		ParentID: "0011223344556677",
+		Name:     "Client.FullStatus",
+		Start:    time.Unix(1000, 0),
+		Duration: 2 * time.Second,
+		Tags:     map[string]string{"model": "deadbeef"},
+	}})
+}
+
+func (s *tracingSuite) TestStartSpanInvalidTraceID(c *gc.C) {
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(testing.NewClock(time.Time{}), &exporter)
+
+	span := tracer.StartSpan("bad", "0011223344556677", "Client.FullStatus")
+	c.Assert(span.TraceID(), gc.Not(gc.Equals), "bad")
+	c.Assert(tracing.IsValidTraceID(span.TraceID()), jc.IsTrue)
+	span.Finish()
+	c.Assert(exporter.spans, gc.HasLen, 1)
+	c.Assert(exporter.spans[0].ParentID, gc.Equals, "")
+}
+
+func (s *tracingSuite) TestWriterExporter(c *gc.C) {
+	var buf bytes.Buffer
+	exporter := tracing.NewWriterExporter(&buf)
+	err := exporter.Export([]tracing.Span{{
+		TraceID:  "0123456789abcdef0123456789abcdef",
+		SpanID:   "0011223344556677",
+		ParentID: "8899aabbccddeeff",
+		Name:     "Client.FullStatus",
+		Start:    time.Unix(1, 0),
+		Duration: 5 * time.Millisecond,
+		Tags:     map[string]string{"method": "FullStatus", "facade": "Client"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out interface{}
+	err = json.Unmarshal(buf.Bytes(), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, map[string]interface{}{
+		"data": []interface{}{map[string]interface{}{
+			"traceID": "0123456789abcdef0123456789abcdef",
+			"spans": []interface{}{map[string]interface{}{
+				"traceID":       "0123456789abcdef0123456789abcdef",
+				"spanID":        "0011223344556677",
+				"operationName": "Client.FullStatus",
+				"references": []interface{}{map[string]interface{}{
+					"refType": "CHILD_OF",
+					"traceID": "0123456789abcdef0123456789abcdef",
+					"spanID":  "8899aabbccddeeff",
+				}},
+				"startTime": float64(1000000),
+				"duration":  float64(5000),
+				"tags": []interface{}{
+					map[string]interface{}{"key": "facade", "type": "string", "value": "Client"},
+					map[string]interface{}{"key": "method", "type": "string", "value": "FullStatus"},
+				},
+				"processID": "p1",
+			}},
+			"processes": map[string]interface{}{
+				"p1": map[string]interface{}{"serviceName": "juju-apiserver"},
+			},
+		}},
+	})
+}
+
+func (s *tracingSuite) TestFileExporter(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "traces.json")
+	exporter := tracing.NewFileExporter(path)
+	for i := 0; i < 2; i++ {
+		err := exporter.Export([]tracing.Span{{
+			TraceID: tracing.NewTraceID(),
+			SpanID:  tracing.NewSpanID(),
+			Name:    "Pinger.Ping",
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bytes.Count(data, []byte("\n")), gc.Equals, 2)
+}
+
+func (s *tracingSuite) TestMultiExporter(c *gc.C) {
+	var e1, e2 recordingExporter
+	exporter := tracing.MultiExporter{&e1, &e2}
+	spans := []tracing.Span{{Name: "Pinger.Ping"}}
+	err := exporter.Export(spans)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(e1.spans, jc.DeepEquals, spans)
+	c.Assert(e2.spans, jc.DeepEquals, spans)
+}
+
+func (s *tracingSuite) TestStartChild(c *gc.C) {
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(testing.NewClock(time.Time{}), &exporter)
+
+	parent := tracer.StartSpan("", "", "Uniter.SetCharmURL")
+	child := parent.StartChild("txn")
+	child.Finish()
+	parent.Finish()
+
+	c.Assert(exporter.spans, gc.HasLen, 2)
+	c.Assert(exporter.spans[0].Name, gc.Equals, "txn")
+	c.Assert(exporter.spans[0].TraceID, gc.Equals, parent.TraceID())
+	c.Assert(exporter.spans[0].ParentID, gc.Equals, parent.SpanID())
+}
+
+func (s *tracingSuite) TestNilSpan(c *gc.C) {
+	var span *tracing.ActiveSpan
+	c.Assert(span.StartChild("txn"), gc.IsNil)
+	c.Assert(span.TraceID(), gc.Equals, "")
+	span.SetTag("model", "deadbeef")
+	span.Finish()
+}
+
+func (s *tracingSuite) TestContextWithSpan(c *gc.C) {
+	var exporter recordingExporter
+	tracer := tracing.NewTracer(testing.NewClock(time.Time{}), &exporter)
+
+	c.Assert(tracing.SpanFromContext(nil), gc.IsNil)
+	ctx := context.Background()
+	c.Assert(tracing.SpanFromContext(ctx), gc.IsNil)
+	c.Assert(tracing.StartChildSpan(ctx, "txn"), gc.IsNil)
+
+	span := tracer.StartSpan("", "", "Uniter.SetCharmURL")
+	ctx = tracing.ContextWithSpan(ctx, span)
+	c.Assert(tracing.SpanFromContext(ctx), gc.Equals, span)
+	child := tracing.StartChildSpan(ctx, "txn")
+	child.Finish()
+	c.Assert(exporter.spans, gc.HasLen, 1)
+	c.Assert(exporter.spans[0].ParentID, gc.Equals, span.SpanID())
+}
@@ -95,12 +95,20 @@ func (pc *ProxyConfig) useProxy(addr string) bool {
 	if hasPort(addr) {
 		addr = addr[:strings.LastIndex(addr, ":")]
 	}
+	addrIP := net.ParseIP(strings.Trim(addr, "[]"))
 
 	for _, p := range strings.Split(pc.noProxy, ",") {
 		p = strings.ToLower(strings.TrimSpace(p))
 		if len(p) == 0 {
 			continue
 		}
+		if _, ipNet, err := net.ParseCIDR(p); err == nil {
+			// no_proxy "10.0.0.0/8" matches "10.1.2.3"
+			if addrIP != nil && ipNet.Contains(addrIP) {
+				return false
+			}
+			continue
+		}
 		if hasPort(p) {
 			p = p[:strings.LastIndex(p, ":")]
 		}
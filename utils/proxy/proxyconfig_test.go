@@ -62,6 +62,20 @@ func (s *Suite) TestGetProxy(c *gc.C) {
 	checkProxy(c, proxy.Settings{Http: "grizzly.bear"}, "veckatimest.com", "http://grizzly.bear")
 }
 
+func (s *Suite) TestGetProxyCIDR(c *gc.C) {
+	settings := proxy.Settings{
+		Http:    "http://http.proxy",
+		NoProxy: "10.0.0.0/8, fd00::/8,bar.com",
+	}
+	checkProxy(c, settings, "http://10.23.45.67:80", "")
+	checkProxy(c, settings, "http://10.0.0.1", "")
+	checkProxy(c, settings, "http://11.0.0.1", "http://http.proxy")
+	checkProxy(c, settings, "http://[fd00::1]:17070", "")
+	checkProxy(c, settings, "http://[fe80::1]:17070", "http://http.proxy")
+	checkProxy(c, settings, "http://10.example.com", "http://http.proxy")
+	checkProxy(c, settings, "http://adz.bar.com", "")
+}
+
 func (s *Suite) TestSetBadUrl(c *gc.C) {
 	pc := proxyconfig.ProxyConfig{}
 	err := pc.Set(proxy.Settings{
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/keyupdater"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
@@ -14,19 +15,28 @@ import (
 	"github.com/juju/juju/worker/dependency"
 )
 
-// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
-type ManifoldConfig engine.AgentAPIManifoldConfig
+// ManifoldConfig defines the names of the manifolds on which a Manifold will
+// depend, and the tracker that records the keys the worker writes.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Tracker       *drift.Tracker
+}
 
 // Manifold returns a dependency manifold that runs a authenticationworker worker,
 // using the resource names defined in the supplied config.
 func Manifold(config ManifoldConfig) dependency.Manifold {
-	typedConfig := engine.AgentAPIManifoldConfig(config)
-
-	return engine.AgentAPIManifold(typedConfig, newWorker)
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.newWorker)
 }
 
-func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
-	w, err := NewWorker(keyupdater.NewState(apiCaller), a.CurrentConfig())
+// newWorker is an engine.AgentAPIStartFunc that draws context from the
+// ManifoldConfig on which it is defined.
+func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	w, err := NewWorker(keyupdater.NewState(apiCaller), a.CurrentConfig(), config.Tracker)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot start ssh auth-keys updater worker")
 	}
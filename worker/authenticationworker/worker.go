@@ -4,6 +4,7 @@
 package authenticationworker
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"gopkg.in/tomb.v1"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api/keyupdater"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
@@ -27,9 +29,10 @@ var SSHUser = "ubuntu"
 var logger = loggo.GetLogger("juju.worker.authenticationworker")
 
 type keyupdaterWorker struct {
-	st   *keyupdater.State
-	tomb tomb.Tomb
-	tag  names.MachineTag
+	st      *keyupdater.State
+	tomb    tomb.Tomb
+	tag     names.MachineTag
+	tracker *drift.Tracker
 	// jujuKeys are the most recently retrieved keys from state.
 	jujuKeys set.Strings
 	// nonJujuKeys are those added externally to auth keys file
@@ -39,8 +42,10 @@ type keyupdaterWorker struct {
 
 // NewWorker returns a worker that keeps track of
 // the machine's authorised ssh keys and ensures the
-// ~/.ssh/authorized_keys file is up to date.
-func NewWorker(st *keyupdater.State, agentConfig agent.Config) (worker.Worker, error) {
+// ~/.ssh/authorized_keys file is up to date. If tracker
+// is not nil, it records the fingerprint of the Juju keys
+// written to the file.
+func NewWorker(st *keyupdater.State, agentConfig agent.Config, tracker *drift.Tracker) (worker.Worker, error) {
 	machineTag, ok := agentConfig.Tag().(names.MachineTag)
 	if !ok {
 		return nil, errors.NotValidf("machine tag %v", agentConfig.Tag())
@@ -50,8 +55,9 @@ func NewWorker(st *keyupdater.State, agentConfig agent.Config) (worker.Worker, e
 	}
 	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
 		Handler: &keyupdaterWorker{
-			st:  st,
-			tag: machineTag,
+			st:      st,
+			tag:     machineTag,
+			tracker: tracker,
 		},
 	})
 	if err != nil {
@@ -112,7 +118,34 @@ func (kw *keyupdaterWorker) writeSSHKeys(jujuKeys []string) error {
 		jujuKeys[i] = ssh.EnsureJujuComment(key)
 	}
 	allKeys = append(allKeys, jujuKeys...)
-	return ssh.ReplaceKeys(SSHUser, allKeys...)
+	if err := ssh.ReplaceKeys(SSHUser, allKeys...); err != nil {
+		return err
+	}
+	if kw.tracker == nil {
+		return nil
+	}
+	return kw.tracker.Record(
+		"authorized-keys", "~"+SSHUser+"/.ssh/authorized_keys", jujuKeysFingerprint,
+	)
+}
+
+// jujuKeysFingerprint returns a fingerprint of the Juju keys in
+// ~/.ssh/authorized_keys. Keys added without the Juju comment
+// prefix are the operator's business, and are not included.
+func jujuKeysFingerprint() (string, error) {
+	sshKeys, err := ssh.ListKeys(SSHUser, ssh.FullKeys)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var jujuKeys []string
+	for _, key := range sshKeys {
+		_, comment, err := ssh.KeyFingerprint(key)
+		if err == nil && strings.HasPrefix(comment, ssh.JujuCommentPrefix) {
+			jujuKeys = append(jujuKeys, key)
+		}
+	}
+	sort.Strings(jujuKeys)
+	return drift.Fingerprint([]byte(strings.Join(jujuKeys, "\n"))), nil
 }
 
 // Handle is defined on the worker.NotifyWatchHandler interface.
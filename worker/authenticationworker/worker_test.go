@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/keyupdater"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -29,6 +30,7 @@ type workerSuite struct {
 	stateMachine  *state.Machine
 	machine       *state.Machine
 	keyupdaterAPI *keyupdater.State
+	tracker       *drift.Tracker
 
 	existingEnvKey string
 	existingKeys   []string
@@ -63,6 +65,7 @@ func (s *workerSuite) SetUpTest(c *gc.C) {
 	c.Assert(apiRoot, gc.NotNil)
 	s.keyupdaterAPI = keyupdater.NewState(apiRoot)
 	c.Assert(s.keyupdaterAPI, gc.NotNil)
+	s.tracker = drift.NewTracker()
 }
 
 func stop(c *gc.C, w worker.Worker) {
@@ -110,7 +113,7 @@ func (s *workerSuite) waitSSHKeys(c *gc.C, expected []string) {
 }
 
 func (s *workerSuite) TestKeyUpdateRetainsExisting(c *gc.C) {
-	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 
@@ -124,7 +127,7 @@ func (s *workerSuite) TestNewKeysInJujuAreSavedOnStartup(c *gc.C) {
 	newKey := sshtesting.ValidKeyThree.Key + " user@host"
 	s.setAuthorisedKeys(c, newKey)
 
-	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 
@@ -133,7 +136,7 @@ func (s *workerSuite) TestNewKeysInJujuAreSavedOnStartup(c *gc.C) {
 }
 
 func (s *workerSuite) TestDeleteKey(c *gc.C) {
-	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 
@@ -149,7 +152,7 @@ func (s *workerSuite) TestDeleteKey(c *gc.C) {
 }
 
 func (s *workerSuite) TestMultipleChanges(c *gc.C) {
-	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey))
@@ -163,7 +166,7 @@ func (s *workerSuite) TestMultipleChanges(c *gc.C) {
 }
 
 func (s *workerSuite) TestWorkerRestart(c *gc.C) {
-	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey))
@@ -175,10 +178,36 @@ func (s *workerSuite) TestWorkerRestart(c *gc.C) {
 	s.setAuthorisedKeys(c, sshtesting.ValidKeyThree.Key+" yetanother@host")
 
 	// Restart the worker and check that the ssh auth keys are as expected.
-	authWorker, err = authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)))
+	authWorker, err = authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
 	c.Assert(err, jc.ErrorIsNil)
 	defer stop(c, authWorker)
 
 	yetAnotherKeyWithCommentPrefix := sshtesting.ValidKeyThree.Key + " Juju:yetanother@host"
 	s.waitSSHKeys(c, append(s.existingKeys, yetAnotherKeyWithCommentPrefix))
 }
+
+func (s *workerSuite) TestRecordsJujuKeys(c *gc.C) {
+	authWorker, err := authenticationworker.NewWorker(s.keyupdaterAPI, agentConfig(c, s.machine.Tag().(names.MachineTag)), s.tracker)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitSSHKeys(c, append(s.existingKeys, s.existingEnvKey))
+	stop(c, authWorker)
+
+	drifted, err := s.tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// Keys added without the Juju comment prefix are not drift.
+	err = ssh.AddKeys(authenticationworker.SSHUser, sshtesting.ValidKeyThree.Key+" user@host")
+	c.Assert(err, jc.ErrorIsNil)
+	drifted, err = s.tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	// Removing a Juju key is.
+	err = ssh.DeleteKeys(authenticationworker.SSHUser, "Juju:firstuser@host")
+	c.Assert(err, jc.ErrorIsNil)
+	drifted, err = s.tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 1)
+	c.Assert(drifted[0].Name, gc.Equals, "authorized-keys")
+}
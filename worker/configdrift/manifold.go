@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package configdrift
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
+	"github.com/juju/juju/api/base"
+	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold
+// will depend, and the configuration of the worker.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Tracker       *drift.Tracker
+	Clock         clock.Clock
+	Interval      time.Duration
+}
+
+// newWorker is an engine.AgentAPIStartFunc that draws context from the
+// ManifoldConfig on which it is defined.
+func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	tag, ok := a.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.NotValidf("machine tag %v", a.CurrentConfig().Tag())
+	}
+	machine, err := apimachiner.NewState(apiCaller).Machine(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := NewWorker(Config{
+		Tracker:   config.Tracker,
+		Clock:     config.Clock,
+		Interval:  config.Interval,
+		SetStatus: machine.SetStatus,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot start configuration drift worker")
+	}
+	return w, nil
+}
+
+// Manifold returns a dependency manifold that runs a configuration
+// drift worker, using the resources named in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	aaConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(aaConfig, config.newWorker)
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package configdrift provides a worker that periodically checks
+// whether the artifacts that Juju has written to a machine have been
+// changed outside of Juju, and reports any such drift in the machine's
+// status.
+package configdrift
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/agent/drift"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.configdrift")
+
+// DriftedMessage is the machine status message reported
+// when one or more artifacts have drifted.
+const DriftedMessage = "drifted"
+
+// Config holds the dependencies and configuration of a Worker.
+type Config struct {
+	// Tracker holds the fingerprints of the artifacts
+	// written by Juju.
+	Tracker *drift.Tracker
+
+	// Clock is used to schedule the checks.
+	Clock clock.Clock
+
+	// Interval is the time between checks.
+	Interval time.Duration
+
+	// SetStatus sets the status of the machine agent.
+	SetStatus func(status.Status, string, map[string]interface{}) error
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	if config.Tracker == nil {
+		return errors.NotValidf("nil Tracker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.SetStatus == nil {
+		return errors.NotValidf("nil SetStatus")
+	}
+	return nil
+}
+
+// Worker periodically checks for configuration drift.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// reported holds the names of the drifted artifacts last
+	// reported, or is empty if no drift was last reported.
+	reported string
+}
+
+// NewWorker returns a new Worker that checks for drift as
+// described by the supplied config.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+		if err := w.check(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (w *Worker) check() error {
+	drifted, err := w.config.Tracker.Check()
+	if err != nil {
+		// An unreadable artifact is reported as drift by the
+		// next successful check, if it persists.
+		logger.Warningf("cannot check for configuration drift: %v", err)
+		return nil
+	}
+	names := make([]string, len(drifted))
+	artifacts := make(map[string]interface{})
+	for i, artifact := range drifted {
+		names[i] = artifact.Name
+		artifacts[artifact.Name] = artifact.Path
+	}
+	reported := strings.Join(names, ",")
+	if reported == w.reported {
+		return nil
+	}
+	if len(drifted) == 0 {
+		logger.Infof("configuration no longer drifted")
+		err = w.config.SetStatus(status.Started, "", nil)
+	} else {
+		logger.Warningf("configuration changed outside of Juju: %s", strings.Join(names, ", "))
+		err = w.config.SetStatus(status.Started, DriftedMessage, map[string]interface{}{
+			drift.StatusDataKey: artifacts,
+		})
+	}
+	if err != nil {
+		return errors.Annotate(err, "setting machine status")
+	}
+	w.reported = reported
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package configdrift_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent/drift"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/configdrift"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock    *testing.Clock
+	file     string
+	tracker  *drift.Tracker
+	statuses chan statusCall
+	config   configdrift.Config
+}
+
+type statusCall struct {
+	status status.Status
+	info   string
+	data   map[string]interface{}
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.file = filepath.Join(c.MkDir(), "juju-proxy")
+	c.Assert(ioutil.WriteFile(s.file, []byte("http_proxy=foo"), 0644), jc.ErrorIsNil)
+	s.tracker = drift.NewTracker()
+	c.Assert(s.tracker.RecordFile("proxy-settings", s.file), jc.ErrorIsNil)
+	s.statuses = make(chan statusCall, 10)
+	s.config = configdrift.Config{
+		Tracker:  s.tracker,
+		Clock:    s.clock,
+		Interval: time.Minute,
+		SetStatus: func(st status.Status, info string, data map[string]interface{}) error {
+			s.statuses <- statusCall{st, info, data}
+			return nil
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Tracker = nil
+	_, err := configdrift.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Tracker not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestReportsDrift(c *gc.C) {
+	w, err := configdrift.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// No drift, nothing reported.
+	s.advance(c)
+	s.assertNoStatus(c)
+
+	// The file is edited outside of Juju.
+	c.Assert(ioutil.WriteFile(s.file, []byte("http_proxy=bar"), 0644), jc.ErrorIsNil)
+	s.advance(c)
+	s.assertStatus(c, statusCall{status.Started, "drifted", map[string]interface{}{
+		"drift": map[string]interface{}{"proxy-settings": s.file},
+	}})
+
+	// Unchanged drift is not reported again.
+	s.advance(c)
+	s.assertNoStatus(c)
+
+	// Juju rewrites the file.
+	c.Assert(ioutil.WriteFile(s.file, []byte("http_proxy=foo"), 0644), jc.ErrorIsNil)
+	s.advance(c)
+	s.assertStatus(c, statusCall{status.Started, "", nil})
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) assertStatus(c *gc.C, expect statusCall) {
+	select {
+	case call := <-s.statuses:
+		c.Assert(call, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status")
+	}
+}
+
+func (s *WorkerSuite) assertNoStatus(c *gc.C) {
+	select {
+	case call := <-s.statuses:
+		c.Fatalf("unexpected status %#v", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
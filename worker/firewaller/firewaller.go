@@ -4,6 +4,7 @@
 package firewaller
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/juju/errors"
@@ -32,6 +33,7 @@ type Firewaller struct {
 	environ              environs.Environ
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.NotifyWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -40,6 +42,9 @@ type Firewaller struct {
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
 	machinePorts         map[names.MachineTag]portRanges
+	// unsupportedProtocols holds, for each protocol the provider
+	// has refused to open ports for, the provider's error.
+	unsupportedProtocols map[string]error
 }
 
 // NewFirewaller returns a new Firewaller or a new FirewallerV0,
@@ -50,14 +55,15 @@ func NewFirewaller(
 	mode string,
 ) (worker.Worker, error) {
 	fw := &Firewaller{
-		st:             st,
-		environ:        env,
-		machineds:      make(map[names.MachineTag]*machineData),
-		unitsChange:    make(chan *unitsChange),
-		unitds:         make(map[names.UnitTag]*unitData),
-		applicationids: make(map[names.ApplicationTag]*serviceData),
-		exposedChange:  make(chan *exposedChange),
-		machinePorts:   make(map[names.MachineTag]portRanges),
+		st:                   st,
+		environ:              env,
+		machineds:            make(map[names.MachineTag]*machineData),
+		unitsChange:          make(chan *unitsChange),
+		unitds:               make(map[names.UnitTag]*unitData),
+		applicationids:       make(map[names.ApplicationTag]*serviceData),
+		exposedChange:        make(chan *exposedChange),
+		machinePorts:         make(map[names.MachineTag]portRanges),
+		unsupportedProtocols: make(map[string]error),
 	}
 
 	switch mode {
@@ -97,6 +103,14 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.subnetsWatcher, err = fw.st.WatchSubnets()
+	if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	}
+	if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Debugf("started watching opened port ranges for the environment")
 	return nil
 }
@@ -145,12 +159,21 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-fw.subnetsWatcher.Changes():
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			// The CIDRs of the spaces that services are exposed to
+			// may have changed.
+			for _, serviced := range fw.applicationids {
+				serviced.subnetsChanged()
+			}
 		case change := <-fw.unitsChange:
 			if err := fw.unitsChanged(change); err != nil {
 				return errors.Trace(err)
 			}
 		case change := <-fw.exposedChange:
-			change.serviced.exposed = change.exposed
+			change.serviced.exposedCIDRs = change.exposedCIDRs
 			unitds := []*unitData{}
 			for _, unitd := range change.serviced.unitds {
 				unitds = append(unitds, unitd)
@@ -278,20 +301,21 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startService creates a new data value for tracking details of the
 // service and starts watching the service for exposure changes.
 func (fw *Firewaller) startService(service *firewaller.Application) error {
-	exposed, err := service.IsExposed()
+	exposedCIDRs, err := service.ExposedSourceCIDRs()
 	if err != nil {
 		return err
 	}
 	serviced := &serviceData{
-		fw:          fw,
-		application: service,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:            fw,
+		application:   service,
+		exposedCIDRs:  exposedCIDRs,
+		unitds:        make(map[names.UnitTag]*unitData),
+		subnetsChange: make(chan struct{}, 1),
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &serviced.catacomb,
 		Work: func() error {
-			return serviced.watchLoop(exposed)
+			return serviced.watchLoop(exposedCIDRs)
 		},
 	})
 	if err != nil {
@@ -322,7 +346,7 @@ func (fw *Firewaller) reconcileGlobal() error {
 	toOpen, toClose := diffRanges(initialPortRanges, want)
 	if len(toOpen) > 0 {
 		logger.Infof("opening global ports %v", toOpen)
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openIngressRules(fw.environ.OpenPorts, toOpen); err != nil {
 			return err
 		}
 	}
@@ -332,6 +356,11 @@ func (fw *Firewaller) reconcileGlobal() error {
 			return err
 		}
 	}
+	for _, machined := range machines {
+		if err := fw.reportUnsupportedRules(machined); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -376,7 +405,10 @@ func (fw *Firewaller) reconcileInstances() error {
 		if len(toOpen) > 0 {
 			logger.Infof("opening instance port ranges %v for %q",
 				toOpen, machined.tag)
-			if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+			open := func(rules []network.IngressRule) error {
+				return instances[0].OpenPorts(machineId, rules)
+			}
+			if err := fw.openIngressRules(open, toOpen); err != nil {
 				// TODO(mue) Add local retry logic.
 				return err
 			}
@@ -389,6 +421,9 @@ func (fw *Firewaller) reconcileInstances() error {
 				return err
 			}
 		}
+		if err := fw.reportUnsupportedRules(machined); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }
@@ -457,21 +492,23 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
-		if !ok {
-			// It is common to receive port change notification before
-			// registering a unit. Skip handling the port change - it will
-			// be handled when the unit is registered.
-			logger.Errorf("failed to lookup %q, skipping port change", unitTag)
-			return nil
-		}
-		ranges, ok := newPortRanges[unitd.tag]
-		if !ok {
-			ranges = make(portRanges)
-			newPortRanges[unitd.tag] = ranges
+	for portRange, unitTags := range ports {
+		for _, unitTag := range unitTags {
+			unitd, ok := machined.unitds[unitTag]
+			if !ok {
+				// It is common to receive port change notification before
+				// registering a unit. Skip handling the port change - it will
+				// be handled when the unit is registered.
+				logger.Errorf("failed to lookup %q, skipping port change", unitTag)
+				return nil
+			}
+			ranges, ok := newPortRanges[unitd.tag]
+			if !ok {
+				ranges = make(portRanges)
+				newPortRanges[unitd.tag] = ranges
+			}
+			ranges[portRange] = true
 		}
-		ranges[portRange] = true
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -536,15 +573,21 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	toOpen, toClose := diffRanges(machined.ingressRules, want)
 	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		err = fw.flushGlobalPorts(toOpen, toClose)
+	} else {
+		err = fw.flushInstancePorts(machined, toOpen, toClose)
+	}
+	if err != nil {
+		return err
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return fw.reportUnsupportedRules(machined)
 }
 
 // gatherIngressRules returns the ingress rules to open and close
-// for the specified machines.
+// for the specified machines. Units sharing a port range, as they may
+// for port-less protocols, yield a single rule for it.
 func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.IngressRule, error) {
-	var want []network.IngressRule
+	rangeCidrs := make(map[network.PortRange]set.Strings)
 	for _, machined := range machines {
 		for unitTag, portRanges := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
@@ -553,26 +596,27 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.serviced.exposed {
-				cidrs.Add("0.0.0.0/0")
-			}
+			// If the unit is exposed, allow access from the CIDRs
+			// its application is exposed to.
+			cidrs := set.NewStrings(unitd.serviced.exposedCIDRs...)
 
 			// Add any ingress rules required by remote relations.
 			fw.updateForRemoteRelationIngress(unitd.serviced.application.Tag(), cidrs)
 			if cidrs.Size() > 0 {
 				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
-					}
-					want = append(want, rule)
+					rangeCidrs[portRange] = cidrs.Union(rangeCidrs[portRange])
 				}
 			}
 		}
 	}
+	var want []network.IngressRule
+	for portRange, cidrs := range rangeCidrs {
+		rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, cidrs.SortedValues()...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		want = append(want, rule)
+	}
 	return want, nil
 }
 
@@ -604,13 +648,14 @@ func (fw *Firewaller) flushGlobalPorts(rawOpen, rawClose []network.IngressRule)
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := fw.environ.OpenPorts(toOpen); err != nil {
+		if err := fw.openIngressRules(fw.environ.OpenPorts, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
 		network.SortIngressRules(toOpen)
 		logger.Infof("opened port ranges %v in environment", toOpen)
 	}
+	toClose = fw.supportedIngressRules(toClose)
 	if len(toClose) > 0 {
 		if err := fw.environ.ClosePorts(toClose); err != nil {
 			// TODO(mue) Add local retry logic.
@@ -649,13 +694,17 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	}
 	// Open and close the ports.
 	if len(toOpen) > 0 {
-		if err := instances[0].OpenPorts(machineId, toOpen); err != nil {
+		open := func(rules []network.IngressRule) error {
+			return instances[0].OpenPorts(machineId, rules)
+		}
+		if err := fw.openIngressRules(open, toOpen); err != nil {
 			// TODO(mue) Add local retry logic.
 			return err
 		}
 		network.SortIngressRules(toOpen)
 		logger.Infof("opened port ranges %v on %q", toOpen, machined.tag)
 	}
+	toClose = fw.supportedIngressRules(toClose)
 	if len(toClose) > 0 {
 		if err := instances[0].ClosePorts(machineId, toClose); err != nil {
 			// TODO(mue) Add local retry logic.
@@ -667,6 +716,94 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// openIngressRules opens the given rules with the open function,
+// skipping those for protocols the provider doesn't support. Providers
+// reject a whole request when any rule is unsupported, so after such a
+// rejection the rules are opened one at a time to learn which protocols
+// are unsupported; those are remembered and not attempted again.
+func (fw *Firewaller) openIngressRules(open func([]network.IngressRule) error, rules []network.IngressRule) error {
+	rules = fw.supportedIngressRules(rules)
+	if len(rules) == 0 {
+		return nil
+	}
+	err := open(rules)
+	if !errors.IsNotSupported(err) {
+		return err
+	}
+	for _, rule := range rules {
+		protocol := strings.ToLower(rule.Protocol)
+		if fw.unsupportedProtocols[protocol] != nil {
+			logger.Warningf("skipping ingress rule %v: %v", rule, fw.unsupportedProtocols[protocol])
+			continue
+		}
+		err := open([]network.IngressRule{rule})
+		if errors.IsNotSupported(err) {
+			logger.Warningf("skipping ingress rule %v: %v", rule, err)
+			fw.unsupportedProtocols[protocol] = err
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// supportedIngressRules returns the given rules except for those whose
+// protocols the provider is known not to support. Such rules are never
+// opened, so they must not be closed either.
+func (fw *Firewaller) supportedIngressRules(rules []network.IngressRule) []network.IngressRule {
+	var supported []network.IngressRule
+	for _, rule := range rules {
+		if err := fw.unsupportedProtocols[strings.ToLower(rule.Protocol)]; err != nil {
+			logger.Debugf("skipping ingress rule %v: %v", rule, err)
+			continue
+		}
+		supported = append(supported, rule)
+	}
+	return supported
+}
+
+// UnsupportedRulesKey is the machine status data key under which the
+// firewaller reports ingress rules the provider doesn't support. The
+// status itself is owned by the machine agent and left alone.
+const UnsupportedRulesKey = "unsupported-ingress-rules"
+
+// reportUnsupportedRules records under UnsupportedRulesKey in the
+// machine's status data why its ingress rules can't all be opened,
+// and removes the key once they can.
+func (fw *Firewaller) reportUnsupportedRules(machined *machineData) error {
+	var message string
+	for _, rule := range machined.ingressRules {
+		if err := fw.unsupportedProtocols[strings.ToLower(rule.Protocol)]; err != nil {
+			message = fmt.Sprintf("cannot open ports: %v", err)
+			break
+		}
+	}
+	if message == machined.unsupportedMessage {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Life() == params.Dead {
+		return nil
+	}
+	var value interface{}
+	if message != "" {
+		value = message
+	}
+	err = m.SetStatusData(map[string]interface{}{UnsupportedRulesKey: value})
+	if err != nil {
+		return errors.Annotatef(err, "cannot set status data of %q", machined.tag)
+	}
+	machined.unsupportedMessage = message
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -764,6 +901,9 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// unsupportedMessage holds the status data value reporting the
+	// machine's unsupported ingress rules, if any.
+	unsupportedMessage string
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -811,10 +951,11 @@ type unitData struct {
 	machined *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific service.
+// exposedChange contains the changed exposed source CIDRs for one
+// specific service.
 type exposedChange struct {
-	serviced *serviceData
-	exposed  bool
+	serviced     *serviceData
+	exposedCIDRs []string
 }
 
 // serviceData holds service details and watches exposure changes.
@@ -822,12 +963,28 @@ type serviceData struct {
 	catacomb    catacomb.Catacomb
 	fw          *Firewaller
 	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	// exposedCIDRs holds the CIDRs that may access the service's
+	// opened ports; it is empty if the service is not exposed.
+	exposedCIDRs []string
+	unitds       map[names.UnitTag]*unitData
+	// subnetsChange is signalled when subnets are added, removed
+	// or moved between spaces.
+	subnetsChange chan struct{}
 }
 
-// watchLoop watches the service's exposed flag for changes.
-func (sd *serviceData) watchLoop(exposed bool) error {
+// subnetsChanged asks the service's watchLoop to check its exposed
+// source CIDRs, which depend on the subnets of the spaces the service
+// is exposed to. It never blocks.
+func (sd *serviceData) subnetsChanged() {
+	select {
+	case sd.subnetsChange <- struct{}{}:
+	default:
+	}
+}
+
+// watchLoop watches the service's exposed source CIDRs for changes,
+// whether to the service itself or to the subnets of its spaces.
+func (sd *serviceData) watchLoop(exposedCIDRs []string) error {
 	serviceWatcher, err := sd.application.Watch()
 	if err != nil {
 		return errors.Trace(err)
@@ -849,24 +1006,33 @@ func (sd *serviceData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := sd.application.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
-				continue
-			}
+		case <-sd.subnetsChange:
+		}
+		change, err := sd.application.ExposedSourceCIDRs()
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if sameCIDRs(change, exposedCIDRs) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case sd.fw.exposedChange <- &exposedChange{sd, change}:
-			case <-sd.catacomb.Dying():
-				return sd.catacomb.ErrDying()
-			}
+		exposedCIDRs = change
+		select {
+		case sd.fw.exposedChange <- &exposedChange{sd, change}:
+		case <-sd.catacomb.Dying():
+			return sd.catacomb.ErrDying()
 		}
 	}
 }
 
+// sameCIDRs returns whether the two lists hold the same CIDRs.
+func sameCIDRs(a, b []string) bool {
+	as, bs := set.NewStrings(a...), set.NewStrings(b...)
+	return as.Difference(bs).IsEmpty() && bs.Difference(as).IsEmpty()
+}
+
 // Kill is part of the worker.Worker interface.
 func (sd *serviceData) Kill() {
 	sd.catacomb.Kill(nil)
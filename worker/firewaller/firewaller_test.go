@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/firewaller"
@@ -113,6 +114,36 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// assertUnsupportedRules waits until the machine's status data reports
+// the given unsupported ingress rules message, or none if it's empty.
+func (s *firewallerBaseSuite) assertUnsupportedRules(c *gc.C, m *state.Machine, message string) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		statusInfo, err := m.Status()
+		c.Assert(err, jc.ErrorIsNil)
+		got, _ := statusInfo.Data[firewaller.UnsupportedRulesKey].(string)
+		if got == message {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", message, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertStatusUnchanged checks that reporting unsupported ingress rules
+// left alone the status set by the machine's other owners.
+func (s *firewallerBaseSuite) assertStatusUnchanged(c *gc.C, m *state.Machine) {
+	statusInfo, err := m.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Started)
+	c.Check(statusInfo.Message, gc.Equals, "drifted")
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	units, err := juju.AddUnits(s.State, app, app.Name(), 1, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -428,6 +459,159 @@ func (s *InstanceModeSuite) TestSetClearExposedService(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestExposeToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err := u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Changing the expose settings changes the source CIDRs.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/8"),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestSharedPortlessProtocol(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app1 := s.AddTestingService(c, "wordpress", s.charm)
+	err := app1.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m := s.addUnit(c, app1)
+	inst := s.startInstance(c, m)
+
+	app2 := s.AddTestingService(c, "mysql", s.charm)
+	err = app2.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToCIDRs: []string{"192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := app2.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Both units open icmp, which yields a single rule.
+	err = u1.OpenPorts("icmp", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.OpenPorts("icmp", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("icmp", network.NoPort, network.NoPort, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Closing it for one unit leaves it open for the other.
+	err = u1.ClosePorts("icmp", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("icmp", network.NoPort, network.NoPort, "192.168.1.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestExposeToSpaceFollowsSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("admin", "", []string{"10.0.0.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		state.WildcardEndpoint: {ExposeToSpaces: []string{"admin"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24"),
+	})
+
+	// Moving a subnet into the space opens the ports to it.
+	_, err = s.State.MoveSubnetsToSpace("admin", []string{"192.168.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "10.0.0.0/24", "192.168.1.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestUnsupportedProtocol(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = m.SetStatus(status.StatusInfo{Status: status.Started, Message: "drifted"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The dummy provider supports icmp but not gre, so the gre rule
+	// is skipped and reported in the machine's status data.
+	err = u.OpenPorts("gre", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPorts("icmp", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("icmp", network.NoPort, network.NoPort, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+	s.assertUnsupportedRules(c, m, `cannot open ports: ingress rules for protocol "gre" not supported`)
+	s.assertStatusUnchanged(c, m)
+
+	// Closing the gre port clears the report.
+	err = u.ClosePorts("gre", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnsupportedRules(c, m, "")
+	s.assertStatusUnchanged(c, m)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("icmp", network.NoPort, network.NoPort, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *InstanceModeSuite) TestRemoveUnit(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -717,6 +901,39 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestUnsupportedProtocol(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingService(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = m.SetStatus(status.StatusInfo{Status: status.Started, Message: "drifted"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.OpenPorts("gre", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+	s.assertUnsupportedRules(c, m, `cannot open ports: ingress rules for protocol "gre" not supported`)
+	s.assertStatusUnchanged(c, m)
+
+	err = u.ClosePorts("gre", network.NoPort, network.NoPort)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnsupportedRules(c, m, "")
+	s.assertStatusUnchanged(c, m)
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedService(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,94 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenanceflag_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+	dt "github.com/juju/juju/worker/dependency/testing"
+	"github.com/juju/juju/worker/maintenanceflag"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func validManifoldConfig() maintenanceflag.ManifoldConfig {
+	return maintenanceflag.ManifoldConfig{
+		APICallerName: "api-caller",
+		NewFacade: func(base.APICaller) (maintenanceflag.Facade, error) {
+			panic("NewFacade")
+		},
+		NewWorker: func(maintenanceflag.Config) (worker.Worker, error) {
+			panic("NewWorker")
+		},
+	}
+}
+
+func (*ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := maintenanceflag.Manifold(validManifoldConfig())
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller"})
+}
+
+func (*ManifoldSuite) TestOutput(c *gc.C) {
+	manifold := maintenanceflag.Manifold(validManifoldConfig())
+	in := &maintenanceflag.Worker{}
+	var out engine.Flag
+	err := manifold.Output(in, &out)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, in)
+}
+
+func (*ManifoldSuite) TestFilterErrChanged(c *gc.C) {
+	manifold := maintenanceflag.Manifold(validManifoldConfig())
+	err := manifold.Filter(maintenanceflag.ErrChanged)
+	c.Check(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (*ManifoldSuite) TestFilterOther(c *gc.C) {
+	manifold := maintenanceflag.Manifold(validManifoldConfig())
+	expect := errors.New("whatever")
+	actual := manifold.Filter(expect)
+	c.Check(actual, gc.Equals, expect)
+}
+
+func (*ManifoldSuite) TestStartMissingAPICallerName(c *gc.C) {
+	config := validManifoldConfig()
+	config.APICallerName = ""
+	manifold := maintenanceflag.Manifold(config)
+	worker, err := manifold.Start(dt.StubContext(nil, nil))
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "empty APICallerName not valid")
+}
+
+func (*ManifoldSuite) TestStartSuccess(c *gc.C) {
+	expectCaller := &struct{ base.APICaller }{}
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": expectCaller,
+	})
+	expectFacade := &struct{ maintenanceflag.Facade }{}
+	expectWorker := &struct{ worker.Worker }{}
+	config := validManifoldConfig()
+	config.NewFacade = func(caller base.APICaller) (maintenanceflag.Facade, error) {
+		c.Check(caller, gc.Equals, expectCaller)
+		return expectFacade, nil
+	}
+	config.NewWorker = func(workerConfig maintenanceflag.Config) (worker.Worker, error) {
+		c.Check(workerConfig.Facade, gc.Equals, expectFacade)
+		return expectWorker, nil
+	}
+	manifold := maintenanceflag.Manifold(config)
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, expectWorker)
+}
@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenanceflag provides a flag worker that is set while
+// the controller is not in maintenance mode, so that workers that
+// change the model can be paused during controller maintenance.
+package maintenanceflag
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// ErrChanged indicates that a Worker has stopped because the
+// controller's maintenance mode has changed.
+var ErrChanged = errors.New("maintenance flag value changed")
+
+// Facade exposes controller functionality required by a Worker.
+type Facade interface {
+	Watch() (watcher.NotifyWatcher, error)
+	Maintenance() (bool, error)
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Facade Facade
+}
+
+// Validate returns an error if the config cannot be expected to
+// drive a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	return nil
+}
+
+// New returns a Worker that tracks whether the controller is in
+// maintenance mode, as exposed by the Facade.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	maintenance, err := config.Facade.Maintenance()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config:      config,
+		maintenance: maintenance,
+	}
+	err = catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Worker implements worker.Worker and util.Flag, and exits
+// with ErrChanged whenever the controller enters or leaves
+// maintenance mode.
+type Worker struct {
+	catacomb    catacomb.Catacomb
+	config      Config
+	maintenance bool
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+// Check is part of the util.Flag interface. It returns true when
+// the controller is not in maintenance mode.
+func (w *Worker) Check() bool {
+	return !w.maintenance
+}
+
+func (w *Worker) loop() error {
+	facade := w.config.Facade
+	watcher, err := facade.Watch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-watcher.Changes():
+			maintenance, err := facade.Maintenance()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if maintenance != w.maintenance {
+				return ErrChanged
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenanceflag_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/maintenanceflag"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (*WorkerSuite) TestValidateNilFacade(c *gc.C) {
+	worker, err := maintenanceflag.New(maintenanceflag.Config{})
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*WorkerSuite) TestMaintenanceErrorOnStartup(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(errors.New("gaah"))
+	facade := newMockFacade(stub)
+	worker, err := maintenanceflag.New(maintenanceflag.Config{Facade: facade})
+	c.Check(worker, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "gaah")
+	stub.CheckCallNames(c, "Maintenance")
+}
+
+func (*WorkerSuite) TestWatchError(c *gc.C) {
+	stub := &testing.Stub{}
+	stub.SetErrors(nil, errors.New("boff"))
+	facade := newMockFacade(stub, false)
+	worker, err := maintenanceflag.New(maintenanceflag.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.ErrorMatches, "boff")
+	stub.CheckCallNames(c, "Maintenance", "Watch")
+}
+
+func (*WorkerSuite) TestInMaintenance(c *gc.C) {
+	stub := &testing.Stub{}
+	facade := newMockFacade(stub, true, true, true, true)
+	worker, err := maintenanceflag.New(maintenanceflag.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsFalse)
+
+	workertest.CheckAlive(c, worker)
+	workertest.CleanKill(c, worker)
+}
+
+func (*WorkerSuite) TestMaintenanceChanged(c *gc.C) {
+	stub := &testing.Stub{}
+	facade := newMockFacade(stub, false, false, true)
+	worker, err := maintenanceflag.New(maintenanceflag.Config{Facade: facade})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(worker.Check(), jc.IsTrue)
+
+	err = workertest.CheckKilled(c, worker)
+	c.Check(err, gc.Equals, maintenanceflag.ErrChanged)
+	stub.CheckCallNames(c, "Maintenance", "Watch", "Maintenance", "Maintenance")
+}
+
+// newMockFacade returns a mock Facade that will add calls to the
+// supplied testing.Stub, and return errors in the sequences it
+// specifies; if any Maintenance call does not return an error, it
+// will return a value consumed from the head of the supplied list.
+func newMockFacade(stub *testing.Stub, maintenance ...bool) *mockFacade {
+	return &mockFacade{
+		stub:        stub,
+		maintenance: maintenance,
+	}
+}
+
+// mockFacade implements maintenanceflag.Facade for use in the tests.
+type mockFacade struct {
+	stub        *testing.Stub
+	maintenance []bool
+}
+
+// Maintenance is part of the maintenanceflag.Facade interface.
+func (mock *mockFacade) Maintenance() (bool, error) {
+	mock.stub.AddCall("Maintenance")
+	if err := mock.stub.NextErr(); err != nil {
+		return false, err
+	}
+	maintenance := mock.maintenance[0]
+	mock.maintenance = mock.maintenance[1:]
+	return maintenance, nil
+}
+
+// Watch is part of the maintenanceflag.Facade interface.
+func (mock *mockFacade) Watch() (watcher.NotifyWatcher, error) {
+	mock.stub.AddCall("Watch")
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return newMockWatcher(), nil
+}
+
+// newMockWatcher returns a watcher.NotifyWatcher that always
+// sends 3 changes and then sits quietly until killed.
+func newMockWatcher() *mockWatcher {
+	const count = 3
+	changes := make(chan struct{}, count)
+	for i := 0; i < count; i++ {
+		changes <- struct{}{}
+	}
+	return &mockWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: changes,
+	}
+}
+
+// mockWatcher implements watcher.NotifyWatcher for use in the tests.
+type mockWatcher struct {
+	worker.Worker
+	changes chan struct{}
+}
+
+// Changes is part of the watcher.NotifyWatcher interface.
+func (mock *mockWatcher) Changes() watcher.NotifyChannel {
+	return mock.changes
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 )
 
 type apiHostPortsSetter interface {
 	SetAPIHostPorts([][]network.HostPort) error
+	ModelConfig() (*config.Config, error)
 }
 
 type publisher struct {
@@ -34,10 +36,15 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 	pub.mu.Lock()
 	defer pub.mu.Unlock()
 
+	cfg, err := pub.st.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read controller model config")
+	}
+	family := cfg.PreferredIPFamily()
 	sortedAPIServers := make([][]network.HostPort, len(apiServers))
 	for i, hostPorts := range apiServers {
 		sortedAPIServers[i] = append([]network.HostPort{}, hostPorts...)
-		network.SortHostPorts(sortedAPIServers[i])
+		network.SortHostPortsForFamily(sortedAPIServers[i], family)
 	}
 	if apiServersEqual(sortedAPIServers, pub.lastAPIServers) {
 		logger.Debugf("API host ports have not changed")
@@ -45,7 +52,7 @@ func (pub *publisher) publishAPIServers(apiServers [][]network.HostPort, instanc
 	}
 
 	// TODO(rog) publish instanceIds in environment storage.
-	err := pub.st.SetAPIHostPorts(sortedAPIServers)
+	err = pub.st.SetAPIHostPorts(sortedAPIServers)
 	if err != nil {
 		return err
 	}
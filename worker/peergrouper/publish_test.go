@@ -7,6 +7,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
@@ -20,6 +21,7 @@ var _ = gc.Suite(&publishSuite{})
 type mockAPIHostPortsSetter struct {
 	calls        int
 	apiHostPorts [][]network.HostPort
+	family       network.IPFamily
 }
 
 func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPort) error {
@@ -28,6 +30,14 @@ func (s *mockAPIHostPortsSetter) SetAPIHostPorts(apiHostPorts [][]network.HostPo
 	return nil
 }
 
+func (s *mockAPIHostPortsSetter) ModelConfig() (*config.Config, error) {
+	attrs := testing.FakeConfig()
+	if s.family != "" {
+		attrs = attrs.Merge(testing.Attrs{config.PreferredIPFamilyKey: string(s.family)})
+	}
+	return config.New(config.NoDefaults, attrs)
+}
+
 func (s *publishSuite) TestPublisherSetsAPIHostPortsOnce(c *gc.C) {
 	var mock mockAPIHostPortsSetter
 	statePublish := newPublisher(&mock)
@@ -73,6 +83,18 @@ func (s *publishSuite) TestPublisherSortsHostPorts(c *gc.C) {
 	check(ipV4First, ipV4First)
 }
 
+func (s *publishSuite) TestPublisherSortsHostPortsForPreferredIPFamily(c *gc.C) {
+	mock := mockAPIHostPortsSetter{family: network.PreferIPv6}
+	statePublish := newPublisher(&mock)
+	err := statePublish.publishAPIServers([][]network.HostPort{
+		network.NewHostPorts(1234, "10.0.0.1", "fc00::1"),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.apiHostPorts, gc.DeepEquals, [][]network.HostPort{
+		network.NewHostPorts(1234, "fc00::1", "10.0.0.1"),
+	})
+}
+
 func (s *publishSuite) TestPublisherRejectsNoServers(c *gc.C) {
 	var mock mockAPIHostPortsSetter
 	statePublish := newPublisher(&mock)
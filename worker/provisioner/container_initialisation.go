@@ -264,6 +264,12 @@ func (cs *ContainerSetup) getContainerArtifacts(
 	if err != nil {
 		return nil, nil, nil, err
 	}
+	// The IP family is used by the initialiser rather than the manager.
+	// Controllers that predate it send none, so fall back to IPv4.
+	family := network.IPFamily(managerConfig.PopValue(container.ConfigIPFamily))
+	if family == "" {
+		family = network.PreferIPv4
+	}
 
 	switch containerType {
 	case instance.KVM:
@@ -304,14 +310,14 @@ func (cs *ContainerSetup) getContainerArtifacts(
 	default:
 		return nil, nil, nil, fmt.Errorf("unknown container type: %v", containerType)
 	}
-	initialiser := getContainerInitialiser(containerType, series)
+	initialiser := getContainerInitialiser(containerType, series, family)
 	return initialiser, broker, toolsFinder, nil
 }
 
 // getContainerInitialiser exists to patch out in tests.
-var getContainerInitialiser = func(ct instance.ContainerType, series string) container.Initialiser {
+var getContainerInitialiser = func(ct instance.ContainerType, series string, family network.IPFamily) container.Initialiser {
 	if ct == instance.LXD {
-		return lxd.NewContainerInitialiser(series)
+		return lxd.NewContainerInitialiser(series, family)
 	}
 	return kvm.NewContainerInitialiser()
 }
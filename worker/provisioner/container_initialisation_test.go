@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/tools"
@@ -159,7 +160,7 @@ func (s *ContainerSetupSuite) assertContainerProvisionerStarted(
 }
 
 func (s *ContainerSetupSuite) TestContainerProvisionerStarted(c *gc.C) {
-	s.PatchValue(provisioner.GetContainerInitialiser, func(instance.ContainerType, string) container.Initialiser {
+	s.PatchValue(provisioner.GetContainerInitialiser, func(instance.ContainerType, string, network.IPFamily) container.Initialiser {
 		return fakeContainerInitialiser{}
 	})
 	// Specifically ignore LXD here, if present in instance.ContainerTypes.
@@ -189,7 +190,7 @@ func (s *ContainerSetupSuite) TestKvmContainerUsesHostArch(c *gc.C) {
 	// KVM should do what it's told, and use the architecture in
 	// constraints.
 	s.PatchValue(&arch.HostArch, func() string { return arch.PPC64EL })
-	s.PatchValue(provisioner.GetContainerInitialiser, func(instance.ContainerType, string) container.Initialiser {
+	s.PatchValue(provisioner.GetContainerInitialiser, func(instance.ContainerType, string, network.IPFamily) container.Initialiser {
 		return fakeContainerInitialiser{}
 	})
 	s.testContainerConstraintsArch(c, instance.KVM, arch.AMD64)
@@ -33,6 +33,7 @@ var (
 	RetryStrategyDelay       = &retryStrategyDelay
 	RetryStrategyCount       = &retryStrategyCount
 	GetObservedNetworkConfig = &getObservedNetworkConfig
+	HostCapacity             = &hostCapacity
 )
 
 var ClassifyMachine = classifyMachine
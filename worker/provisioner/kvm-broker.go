@@ -10,11 +10,13 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/storage"
 )
 
 var kvmLogger = loggo.GetLogger("juju.provisioner.kvm")
@@ -64,6 +66,9 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 	// TODO: refactor common code out of the container brokers.
 	containerMachineID := args.InstanceConfig.MachineId
 	kvmLogger.Infof("starting kvm container for containerMachineID: %s", containerMachineID)
+	if err := validateKVMConstraints(args.Constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	// TODO: Default to using the host network until we can configure.  Yes,
 	// this is using the LxcBridge value, we should put it in the api call for
@@ -138,9 +143,11 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		kvmLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	args.InstanceConfig.CloudInitUserData = config.CloudInitUserData
 
 	storageConfig := &container.StorageConfig{
 		AllowMount: true,
+		Volumes:    args.Volumes,
 	}
 	inst, hardware, err := broker.manager.CreateContainer(
 		args.InstanceConfig, args.Constraints,
@@ -151,13 +158,64 @@ func (broker *kvmBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		return nil, err
 	}
 	kvmLogger.Infof("started kvm container for containerMachineID: %s, %s, %s", containerMachineID, inst.Id(), hardware.String())
+	volumes, volumeAttachments := kvmVolumeResults(args.Volumes, names.NewMachineTag(containerMachineID))
 	return &environs.StartInstanceResult{
-		Instance:    inst,
-		Hardware:    hardware,
-		NetworkInfo: interfaces,
+		Instance:          inst,
+		Hardware:          hardware,
+		NetworkInfo:       interfaces,
+		Volumes:           volumes,
+		VolumeAttachments: volumeAttachments,
 	}, nil
 }
 
+// kvmVolumeResults returns the volumes attached to a KVM container
+// when it was started, and their attachments to the container.
+func kvmVolumeResults(args []storage.VolumeParams, machineTag names.MachineTag) ([]storage.Volume, []storage.VolumeAttachment) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	volumes := make([]storage.Volume, len(args))
+	attachments := make([]storage.VolumeAttachment, len(args))
+	for i, v := range args {
+		volumes[i] = storage.Volume{
+			Tag: v.Tag,
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId: kvm.VolumeSerial(v.Tag),
+				Size:     v.Size,
+			},
+		}
+		attachments[i] = storage.VolumeAttachment{
+			Volume:  v.Tag,
+			Machine: machineTag,
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				DeviceLink: kvm.VolumeDeviceLink(v.Tag),
+			},
+		}
+	}
+	return volumes, attachments
+}
+
+// validateKVMConstraints returns an error if the mem or cores
+// constraints ask for more than the host can provide. The root-disk
+// constraint is not checked, as the container's disk is a sparse
+// image that only grows as it is written to.
+func validateKVMConstraints(cons constraints.Value) error {
+	if !cons.HasMem() && !cons.HasCpuCores() {
+		return nil
+	}
+	memMB, cores, err := hostCapacity()
+	if err != nil {
+		return errors.Annotate(err, "getting host capacity")
+	}
+	if cons.HasMem() && *cons.Mem > memMB {
+		return errors.Errorf("mem constraint %dM exceeds host memory %dM", *cons.Mem, memMB)
+	}
+	if cons.HasCpuCores() && *cons.CpuCores > cores {
+		return errors.Errorf("cores constraint %d exceeds host cores %d", *cons.CpuCores, cores)
+	}
+	return nil
+}
+
 // MaintainInstance ensures the container's host has the required iptables and
 // routing rules to make the container visible to both the host and other
 // machines on the same subnet.
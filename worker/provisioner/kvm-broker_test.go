@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm/mock"
 	kvmtesting "github.com/juju/juju/container/kvm/testing"
@@ -27,6 +28,8 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker/provisioner"
@@ -318,6 +321,73 @@ func (s *kvmBrokerSuite) TestStartInstancePopulatesFallbackNetworkInfo(c *gc.C)
 	}})
 }
 
+func (s *kvmBrokerSuite) TestStartInstanceConstraintsExceedHostCapacity(c *gc.C) {
+	s.PatchValue(provisioner.HostCapacity, func() (uint64, uint64, error) { return 1024, 2, nil })
+	broker, brokerErr := s.newKVMBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	for _, test := range []struct {
+		cons string
+		err  string
+	}{{
+		cons: "mem=2G",
+		err:  "mem constraint 2048M exceeds host memory 1024M",
+	}, {
+		cons: "cores=4",
+		err:  "cores constraint 4 exceeds host cores 2",
+	}} {
+		_, err := broker.StartInstance(environs.StartInstanceParams{
+			Constraints:    constraints.MustParse(test.cons),
+			Tools:          makePossibleTools(),
+			InstanceConfig: makeInstanceConfig(c, s, "1/kvm/0"),
+			StatusCallback: makeNoOpStatusCallback(),
+		})
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *kvmBrokerSuite) TestStartInstanceWithVolumes(c *gc.C) {
+	broker, brokerErr := s.newKVMBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	s.PatchValue(provisioner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return nil, nil
+	})
+	patchResolvConf(s, c)
+
+	machineTag := names.NewMachineTag("1/kvm/0")
+	volumeTag := names.NewVolumeTag("1/kvm/0/0")
+	result, err := broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/kvm/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		Volumes: []storage.VolumeParams{{
+			Tag:      volumeTag,
+			Size:     1024,
+			Provider: provider.HostLoopProviderType,
+			Attachment: &storage.VolumeAttachmentParams{
+				AttachmentParams: storage.AttachmentParams{Machine: machineTag},
+				Volume:           volumeTag,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Volumes, jc.DeepEquals, []storage.Volume{{
+		Tag: volumeTag,
+		VolumeInfo: storage.VolumeInfo{
+			VolumeId: "juju-1-kvm-0-0",
+			Size:     1024,
+		},
+	}})
+	c.Assert(result.VolumeAttachments, jc.DeepEquals, []storage.VolumeAttachment{{
+		Volume:  volumeTag,
+		Machine: machineTag,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/disk/by-id/virtio-juju-1-kvm-0-0",
+		},
+	}})
+}
+
 type kvmProvisionerSuite struct {
 	CommonProvisionerSuite
 	kvmSuite
@@ -4,13 +4,22 @@
 package provisioner
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -67,6 +76,9 @@ type lxdBroker struct {
 
 func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
 	containerMachineID := args.InstanceConfig.MachineId
+	if err := validateLXDConstraints(args.Constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 	bridgeDevice := broker.agentConfig.Value(agent.LxdBridge)
 	if bridgeDevice == "" {
 		bridgeDevice = network.DefaultLXDBridge
@@ -134,6 +146,13 @@ func (broker *lxdBroker) StartInstance(args environs.StartInstanceParams) (*envi
 		lxdLogger.Errorf("failed to populate machine config: %v", err)
 		return nil, err
 	}
+	args.InstanceConfig.CloudInitUserData = config.CloudInitUserData
+
+	profileNames, err := broker.writeCharmProfiles(args.CharmLXDProfiles)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args.InstanceConfig.Profiles = profileNames
 
 	storageConfig := &container.StorageConfig{}
 	inst, hardware, err := broker.manager.CreateContainer(
@@ -161,9 +180,56 @@ func (broker *lxdBroker) StopInstances(ids ...instance.Id) error {
 		}
 		releaseContainerAddresses(broker.api, id, broker.manager.Namespace(), lxdLogger)
 	}
+	if profileManager, ok := broker.manager.(container.LXDProfileManager); ok {
+		if err := profileManager.RemoveUnusedLXDProfiles(); err != nil {
+			lxdLogger.Errorf("cannot remove unused charm profiles: %v", err)
+		}
+	}
 	return nil
 }
 
+// UpdateCharmProfiles replaces the charm profiles applied to the
+// container with the given ones, and removes any charm profiles that
+// are no longer used by a container.
+func (broker *lxdBroker) UpdateCharmProfiles(machineId string, instId instance.Id, profiles map[string]*lxdprofile.Profile) error {
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return nil
+	}
+	profileNames, err := broker.writeCharmProfiles(profiles)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := profileManager.SetContainerLXDProfiles(instId, profileNames); err != nil {
+		return errors.Annotatef(err, "setting profiles of machine %q", machineId)
+	}
+	return errors.Trace(profileManager.RemoveUnusedLXDProfiles())
+}
+
+// writeCharmProfiles creates or updates the given charm profiles, and
+// returns their names in a stable order.
+func (broker *lxdBroker) writeCharmProfiles(profiles map[string]*lxdprofile.Profile) ([]string, error) {
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	profileManager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return nil, errors.NotSupportedf("charm lxd profiles")
+	}
+	var names []string
+	for name, profile := range profiles {
+		if err := profile.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "charm profile %q", name)
+		}
+		if err := profileManager.EnsureLXDProfile(name, profile); err != nil {
+			return nil, errors.Trace(err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // AllInstances only returns running containers.
 func (broker *lxdBroker) AllInstances() (result []instance.Instance, err error) {
 	return broker.manager.ListContainers()
@@ -191,3 +257,56 @@ func (broker *lxdBroker) MaintainInstance(args environs.StartInstanceParams) err
 	)
 	return err
 }
+
+// meminfoPath is the path of the file describing the host's memory.
+var meminfoPath = "/proc/meminfo"
+
+// hostCapacity returns the total memory, in megabytes, and the number
+// of CPU cores of the machine hosting the containers.
+var hostCapacity = func() (memMB, cores uint64, err error) {
+	f, err := os.Open(meminfoPath)
+	if err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		var memKB uint64
+		if _, err := fmt.Sscanf(line, "MemTotal: %d kB", &memKB); err != nil {
+			return 0, 0, errors.Annotatef(err, "parsing %q", line)
+		}
+		return memKB / 1024, uint64(runtime.NumCPU()), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	return 0, 0, errors.NotFoundf("MemTotal in %s", meminfoPath)
+}
+
+// validateLXDConstraints returns an error if the mem, cores or
+// cpu-power constraints ask for more than the host can provide.
+// The root-disk constraint is not checked, as the container's
+// storage need not be on the host's root filesystem.
+func validateLXDConstraints(cons constraints.Value) error {
+	if !cons.HasMem() && !cons.HasCpuCores() && !cons.HasCpuPower() {
+		return nil
+	}
+	memMB, cores, err := hostCapacity()
+	if err != nil {
+		return errors.Annotate(err, "getting host capacity")
+	}
+	if cons.HasMem() && *cons.Mem > memMB {
+		return errors.Errorf("mem constraint %dM exceeds host memory %dM", *cons.Mem, memMB)
+	}
+	if cons.HasCpuCores() && *cons.CpuCores > cores {
+		return errors.Errorf("cores constraint %d exceeds host cores %d", *cons.CpuCores, cores)
+	}
+	if cons.HasCpuPower() && *cons.CpuPower > cores*100 {
+		return errors.Errorf("cpu-power constraint %d exceeds host cpu-power %d", *cons.CpuPower, cores*100)
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -235,6 +236,151 @@ func (s *lxdBrokerSuite) TestStartInstanceNoHostArchTools(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `need tools for arch amd64, only found \[arm64\]`)
 }
 
+func (s *lxdBrokerSuite) startInstanceWithConstraints(c *gc.C, broker environs.InstanceBroker, cons string) error {
+	_, err := broker.StartInstance(environs.StartInstanceParams{
+		Constraints:    constraints.MustParse(cons),
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+	})
+	return err
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceConstraintsExceedHostMemory(c *gc.C) {
+	s.PatchValue(provisioner.HostCapacity, func() (uint64, uint64, error) { return 1024, 4, nil })
+	broker, brokerErr := s.newLXDBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	err := s.startInstanceWithConstraints(c, broker, "mem=2G")
+	c.Assert(err, gc.ErrorMatches, "mem constraint 2048M exceeds host memory 1024M")
+	s.manager.CheckNoCalls(c)
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceConstraintsExceedHostCores(c *gc.C) {
+	s.PatchValue(provisioner.HostCapacity, func() (uint64, uint64, error) { return 4096, 2, nil })
+	broker, brokerErr := s.newLXDBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	err := s.startInstanceWithConstraints(c, broker, "cores=4")
+	c.Assert(err, gc.ErrorMatches, "cores constraint 4 exceeds host cores 2")
+
+	err = s.startInstanceWithConstraints(c, broker, "cpu-power=300")
+	c.Assert(err, gc.ErrorMatches, "cpu-power constraint 300 exceeds host cpu-power 200")
+	s.manager.CheckNoCalls(c)
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceConstraintsWithinHostCapacity(c *gc.C) {
+	s.PatchValue(provisioner.HostCapacity, func() (uint64, uint64, error) { return 4096, 2, nil })
+	broker, brokerErr := s.newLXDBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+
+	s.PatchValue(provisioner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return nil, nil
+	})
+
+	s.startInstanceWithConstraints(c, broker, "mem=2G cores=2 cpu-power=150 root-disk=100G")
+	s.manager.CheckCallNames(c, "CreateContainer")
+	cons := s.manager.Calls()[0].Args[1].(constraints.Value)
+	c.Assert(cons.String(), gc.Equals, "cores=2 cpu-power=150 mem=2048M root-disk=102400M")
+}
+
+func (s *lxdBrokerSuite) newLXDBrokerWithProfiles(c *gc.C) (environs.InstanceBroker, *fakeProfileContainerManager) {
+	manager := &fakeProfileContainerManager{}
+	tag, err := names.ParseMachineTag("machine-1")
+	c.Assert(err, jc.ErrorIsNil)
+	broker, err := provisioner.NewLxdBroker(newFakeBridgerNeverErrors(), tag, s.api, manager, s.agentConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	return broker, manager
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceWithCharmProfiles(c *gc.C) {
+	broker, manager := s.newLXDBrokerWithProfiles(c)
+	s.PatchValue(provisioner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return nil, nil
+	})
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+
+	broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-charm-foo-wordpress": profile,
+			"juju-charm-foo-mysql":     profile,
+		},
+	})
+	manager.CheckCallNames(c, "EnsureLXDProfile", "EnsureLXDProfile", "CreateContainer")
+	instanceConfig := manager.Calls()[2].Args[0].(*instancecfg.InstanceConfig)
+	c.Assert(instanceConfig.Profiles, jc.DeepEquals, []string{"juju-charm-foo-mysql", "juju-charm-foo-wordpress"})
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceRejectsInvalidCharmProfile(c *gc.C) {
+	broker, manager := s.newLXDBrokerWithProfiles(c)
+	s.PatchValue(provisioner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return nil, nil
+	})
+
+	_, err := broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-charm-foo-mysql": {Config: map[string]string{"limits.memory": "1TB"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `charm profile "juju-charm-foo-mysql": lxd profile config key "limits.memory" not valid`)
+	manager.CheckNoCalls(c)
+}
+
+func (s *lxdBrokerSuite) TestStartInstanceCharmProfilesNotSupported(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c, newFakeBridgerNeverErrors())
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	s.PatchValue(provisioner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
+		return nil, nil
+	})
+
+	_, err := broker.StartInstance(environs.StartInstanceParams{
+		Tools:          makePossibleTools(),
+		InstanceConfig: makeInstanceConfig(c, s, "1/lxd/0"),
+		StatusCallback: makeNoOpStatusCallback(),
+		CharmLXDProfiles: map[string]*lxdprofile.Profile{
+			"juju-charm-foo-mysql": {Config: map[string]string{"security.nesting": "true"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "charm lxd profiles not supported")
+	s.manager.CheckNoCalls(c)
+}
+
+func (s *lxdBrokerSuite) TestUpdateCharmProfiles(c *gc.C) {
+	broker, manager := s.newLXDBrokerWithProfiles(c)
+	updater, ok := broker.(interface {
+		UpdateCharmProfiles(string, instance.Id, map[string]*lxdprofile.Profile) error
+	})
+	c.Assert(ok, jc.IsTrue)
+
+	profile := &lxdprofile.Profile{Config: map[string]string{"security.nesting": "true"}}
+	err := updater.UpdateCharmProfiles("1/lxd/0", "juju-06f00d-1-lxd-0", map[string]*lxdprofile.Profile{
+		"juju-charm-foo-mysql": profile,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	manager.CheckCalls(c, []gitjujutesting.StubCall{{
+		FuncName: "EnsureLXDProfile",
+		Args:     []interface{}{"juju-charm-foo-mysql", profile},
+	}, {
+		FuncName: "SetContainerLXDProfiles",
+		Args:     []interface{}{instance.Id("juju-06f00d-1-lxd-0"), []string{"juju-charm-foo-mysql"}},
+	}, {
+		FuncName: "RemoveUnusedLXDProfiles",
+	}})
+}
+
+func (s *lxdBrokerSuite) TestStopInstancesRemovesUnusedCharmProfiles(c *gc.C) {
+	broker, manager := s.newLXDBrokerWithProfiles(c)
+	err := broker.StopInstances("juju-06f00d-1-lxd-0")
+	c.Assert(err, jc.ErrorIsNil)
+	manager.CheckCallNames(c, "DestroyContainer", "RemoveUnusedLXDProfiles")
+}
+
 type fakeContainerManager struct {
 	gitjujutesting.Stub
 }
@@ -270,3 +416,22 @@ func (m *fakeContainerManager) IsInitialized() bool {
 	m.PopNoErr()
 	return true
 }
+
+type fakeProfileContainerManager struct {
+	fakeContainerManager
+}
+
+func (m *fakeProfileContainerManager) EnsureLXDProfile(name string, profile *lxdprofile.Profile) error {
+	m.MethodCall(m, "EnsureLXDProfile", name, profile)
+	return m.NextErr()
+}
+
+func (m *fakeProfileContainerManager) SetContainerLXDProfiles(id instance.Id, names []string) error {
+	m.MethodCall(m, "SetContainerLXDProfiles", id, names)
+	return m.NextErr()
+}
+
+func (m *fakeProfileContainerManager) RemoveUnusedLXDProfiles() error {
+	m.MethodCall(m, "RemoveUnusedLXDProfiles")
+	return m.NextErr()
+}
@@ -38,6 +38,9 @@ type Provisioner interface {
 	worker.Worker
 	getMachineWatcher() (watcher.StringsWatcher, error)
 	getRetryWatcher() (watcher.NotifyWatcher, error)
+	getProfileWatcher() (watcher.StringsWatcher, error)
+	getAssignmentWatcher() (watcher.StringsWatcher, error)
+	getPoolWatcher() (watcher.NotifyWatcher, error)
 }
 
 // environProvisioner represents a running provisioning worker for machine nodes
@@ -133,6 +136,18 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 	if err != nil && !errors.IsNotImplemented(err) {
 		return nil, err
 	}
+	profileWatcher, err := p.getProfileWatcher()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return nil, err
+	}
+	assignmentWatcher, err := p.getAssignmentWatcher()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return nil, err
+	}
+	poolWatcher, err := p.getPoolWatcher()
+	if err != nil && !errors.IsNotImplemented(err) {
+		return nil, err
+	}
 	tag := p.agentConfig.Tag()
 	machineTag, ok := tag.(names.MachineTag)
 	if !ok {
@@ -157,6 +172,9 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		profileWatcher,
+		assignmentWatcher,
+		poolWatcher,
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
@@ -248,6 +266,18 @@ func (p *environProvisioner) getRetryWatcher() (watcher.NotifyWatcher, error) {
 	return p.st.WatchMachineErrorRetry()
 }
 
+func (p *environProvisioner) getProfileWatcher() (watcher.StringsWatcher, error) {
+	return nil, errors.NotImplementedf("getProfileWatcher")
+}
+
+func (p *environProvisioner) getAssignmentWatcher() (watcher.StringsWatcher, error) {
+	return nil, errors.NotImplementedf("getAssignmentWatcher")
+}
+
+func (p *environProvisioner) getPoolWatcher() (watcher.NotifyWatcher, error) {
+	return p.st.WatchMachinePools()
+}
+
 // setConfig updates the environment configuration and notifies
 // the config observer.
 func (p *environProvisioner) setConfig(modelConfig *config.Config) error {
@@ -360,3 +390,28 @@ func (p *containerProvisioner) getMachineWatcher() (watcher.StringsWatcher, erro
 func (p *containerProvisioner) getRetryWatcher() (watcher.NotifyWatcher, error) {
 	return nil, errors.NotImplementedf("getRetryWatcher")
 }
+
+// getProfileWatcher returns a watcher that notifies of the applications
+// whose charm-supplied LXD profiles may need to be refreshed. Only LXD
+// containers have them.
+func (p *containerProvisioner) getProfileWatcher() (watcher.StringsWatcher, error) {
+	if p.containerType != instance.LXD {
+		return nil, errors.NotImplementedf("getProfileWatcher")
+	}
+	return p.st.WatchApplicationCharms()
+}
+
+// getAssignmentWatcher returns a watcher that notifies of the machines
+// whose units, or their subordinates, change, so that their
+// charm-supplied LXD profiles may be refreshed. Only LXD containers
+// have them.
+func (p *containerProvisioner) getAssignmentWatcher() (watcher.StringsWatcher, error) {
+	if p.containerType != instance.LXD {
+		return nil, errors.NotImplementedf("getAssignmentWatcher")
+	}
+	return p.st.WatchUnitAssignments()
+}
+
+func (p *containerProvisioner) getPoolWatcher() (watcher.NotifyWatcher, error) {
+	return nil, errors.NotImplementedf("getPoolWatcher")
+}
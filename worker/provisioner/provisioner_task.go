@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	apiprovisioner "github.com/juju/juju/api/provisioner"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -68,6 +70,9 @@ func NewProvisionerTask(
 	toolsFinder ToolsFinder,
 	machineWatcher watcher.StringsWatcher,
 	retryWatcher watcher.NotifyWatcher,
+	profileWatcher watcher.StringsWatcher,
+	assignmentWatcher watcher.StringsWatcher,
+	poolWatcher watcher.NotifyWatcher,
 	broker environs.InstanceBroker,
 	auth authentication.AuthenticationProvider,
 	imageStream string,
@@ -80,6 +85,21 @@ func NewProvisionerTask(
 		retryChanges = retryWatcher.Changes()
 		workers = append(workers, retryWatcher)
 	}
+	var profileChanges watcher.StringsChannel
+	if profileWatcher != nil {
+		profileChanges = profileWatcher.Changes()
+		workers = append(workers, profileWatcher)
+	}
+	var assignmentChanges watcher.StringsChannel
+	if assignmentWatcher != nil {
+		assignmentChanges = assignmentWatcher.Changes()
+		workers = append(workers, assignmentWatcher)
+	}
+	var poolChanges watcher.NotifyChannel
+	if poolWatcher != nil {
+		poolChanges = poolWatcher.Changes()
+		workers = append(workers, poolWatcher)
+	}
 	task := &provisionerTask{
 		controllerUUID:             controllerUUID,
 		machineTag:                 machineTag,
@@ -87,11 +107,15 @@ func NewProvisionerTask(
 		toolsFinder:                toolsFinder,
 		machineChanges:             machineChanges,
 		retryChanges:               retryChanges,
+		profileChanges:             profileChanges,
+		assignmentChanges:          assignmentChanges,
+		poolChanges:                poolChanges,
 		broker:                     broker,
 		auth:                       auth,
 		harvestMode:                harvestMode,
 		harvestModeChan:            make(chan config.HarvestMode, 1),
 		machines:                   make(map[string]*apiprovisioner.Machine),
+		charmApplications:          make(map[string]set.Strings),
 		imageStream:                imageStream,
 		retryStartInstanceStrategy: retryStartInstanceStrategy,
 	}
@@ -113,6 +137,9 @@ type provisionerTask struct {
 	toolsFinder                ToolsFinder
 	machineChanges             watcher.StringsChannel
 	retryChanges               watcher.NotifyChannel
+	profileChanges             watcher.StringsChannel
+	assignmentChanges          watcher.StringsChannel
+	poolChanges                watcher.NotifyChannel
 	broker                     environs.InstanceBroker
 	catacomb                   catacomb.Catacomb
 	auth                       authentication.AuthenticationProvider
@@ -124,6 +151,9 @@ type provisionerTask struct {
 	instances map[instance.Id]instance.Instance
 	// machine id -> machine
 	machines map[string]*apiprovisioner.Machine
+	// machine id -> names of the applications whose charms may
+	// supply LXD profiles to the machine, as last fetched
+	charmApplications map[string]set.Strings
 }
 
 // Kill implements worker.Worker.Kill.
@@ -179,6 +209,21 @@ func (task *provisionerTask) loop() error {
 			if err := task.processMachinesWithTransientErrors(); err != nil {
 				return errors.Annotate(err, "failed to process machines with transient errors")
 			}
+		case applications, ok := <-task.profileChanges:
+			if !ok {
+				return errors.New("application charm watcher closed channel")
+			}
+			task.updateCharmProfiles(applications)
+		case machineIds, ok := <-task.assignmentChanges:
+			if !ok {
+				return errors.New("unit assignment watcher closed channel")
+			}
+			task.updateMachineCharmProfiles(machineIds)
+		case _, ok := <-task.poolChanges:
+			if !ok {
+				return errors.New("machine pool watcher closed channel")
+			}
+			task.topUpMachinePools()
 		}
 	}
 }
@@ -280,6 +325,7 @@ func (task *provisionerTask) processMachines(ids []string) error {
 			logger.Errorf("failed to remove dead machine %q", machine)
 		}
 		delete(task.machines, machine.Id())
+		delete(task.charmApplications, machine.Id())
 	}
 
 	// Any machines that require maintenance get pinged
@@ -320,6 +366,7 @@ func (task *provisionerTask) populateMachineMaps(ids []string) error {
 		case params.IsCodeNotFoundOrCodeUnauthorized(err):
 			logger.Debugf("machine %q not found in state", id)
 			delete(task.machines, id)
+			delete(task.charmApplications, id)
 		case err == nil:
 			task.machines[id] = machine
 		default:
@@ -633,10 +680,116 @@ func constructStartInstanceParams(
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
+		CharmLXDProfiles:  charmLXDProfiles(provisioningInfo.CharmLXDProfiles),
 		StatusCallback:    machine.SetInstanceStatus,
 	}, nil
 }
 
+func charmLXDProfiles(in map[string]params.CharmLXDProfile) map[string]*lxdprofile.Profile {
+	if len(in) == 0 {
+		return nil
+	}
+	profiles := make(map[string]*lxdprofile.Profile, len(in))
+	for name, profile := range in {
+		profiles[name] = &lxdprofile.Profile{
+			Description: profile.Description,
+			Config:      profile.Config,
+			Devices:     profile.Devices,
+		}
+	}
+	return profiles
+}
+
+// charmProfileUpdater is implemented by brokers that apply the LXD
+// profiles supplied by charms to the instances they start.
+type charmProfileUpdater interface {
+	// UpdateCharmProfiles replaces the charm profiles applied to the
+	// instance of the given machine with the given profiles.
+	UpdateCharmProfiles(machineId string, instId instance.Id, profiles map[string]*lxdprofile.Profile) error
+}
+
+// updateCharmProfiles brings the charm profiles applied to the
+// provisioned machines hosting any of the given applications up to
+// date, so that upgraded charms and removed applications are reflected
+// in running containers. Machines whose applications are not yet known
+// are always updated.
+func (task *provisionerTask) updateCharmProfiles(applications []string) {
+	updater, ok := task.broker.(charmProfileUpdater)
+	if !ok {
+		return
+	}
+	changed := set.NewStrings(applications...)
+	for _, machine := range task.machines {
+		known, ok := task.charmApplications[machine.Id()]
+		if ok && known.Intersection(changed).IsEmpty() {
+			continue
+		}
+		task.updateCharmProfile(updater, machine)
+	}
+}
+
+// updateMachineCharmProfiles brings the charm profiles applied to the
+// given provisioned machines up to date, so that units assigned to
+// them, and subordinates added to or removed from those units, are
+// reflected in running containers.
+func (task *provisionerTask) updateMachineCharmProfiles(machineIds []string) {
+	updater, ok := task.broker.(charmProfileUpdater)
+	if !ok {
+		return
+	}
+	for _, id := range machineIds {
+		if machine, ok := task.machines[id]; ok {
+			task.updateCharmProfile(updater, machine)
+		}
+	}
+}
+
+// updateCharmProfile replaces the charm profiles applied to the given
+// machine, if it is provisioned, with those of the charms it hosts.
+func (task *provisionerTask) updateCharmProfile(updater charmProfileUpdater, machine *apiprovisioner.Machine) {
+	instId, err := machine.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		return
+	} else if err != nil {
+		logger.Errorf("cannot get instance id of machine %q: %v", machine, err)
+		return
+	}
+	provisioningInfo, err := machine.ProvisioningInfo()
+	if err != nil {
+		logger.Errorf("cannot get provisioning info of machine %q: %v", machine, err)
+		return
+	}
+	task.charmApplications[machine.Id()] = set.NewStrings(provisioningInfo.CharmApplications...)
+	profiles := charmLXDProfiles(provisioningInfo.CharmLXDProfiles)
+	if err := updater.UpdateCharmProfiles(machine.Id(), instId, profiles); err != nil {
+		logger.Errorf("cannot update lxd profiles of machine %q: %v", machine, err)
+	}
+}
+
+// machinePoolFiller is implemented by machine getters that can add
+// machines to the model's machine pools.
+type machinePoolFiller interface {
+	TopUpMachinePools() ([]string, error)
+}
+
+// topUpMachinePools adds machines to any machine pool that holds fewer
+// machines than its size. The new machines are started when they
+// are reported by the machine watcher.
+func (task *provisionerTask) topUpMachinePools() {
+	filler, ok := task.machineGetter.(machinePoolFiller)
+	if !ok {
+		return
+	}
+	ids, err := filler.TopUpMachinePools()
+	if err != nil {
+		logger.Errorf("cannot top up machine pools: %v", err)
+		return
+	}
+	if len(ids) > 0 {
+		logger.Infof("added machines %v to machine pools", ids)
+	}
+}
+
 func (task *provisionerTask) maintainMachines(machines []*apiprovisioner.Machine) error {
 	for _, m := range machines {
 		logger.Infof("maintainMachines: %v", m)
@@ -663,6 +816,7 @@ func (task *provisionerTask) startMachines(machines []*apiprovisioner.Machine) e
 		if err != nil {
 			return task.setErrorStatus("fetching provisioning info for machine %q: %v", m, err)
 		}
+		task.charmApplications[m.Id()] = set.NewStrings(pInfo.CharmApplications...)
 
 		instanceCfg, err := task.constructInstanceConfig(m, task.auth, pInfo)
 		if err != nil {
@@ -25,6 +25,7 @@ import (
 	apiserverprovisioner "github.com/juju/juju/apiserver/provisioner"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/controller/authentication"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/filestorage"
@@ -45,8 +46,10 @@ import (
 	coretesting "github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/provisioner"
+	"github.com/juju/juju/worker/workertest"
 )
 
 type CommonProvisionerSuite struct {
@@ -449,6 +452,26 @@ func (s *ProvisionerSuite) TestSimple(c *gc.C) {
 	s.waitForRemovalMark(c, m)
 }
 
+func (s *ProvisionerSuite) TestMachinePoolIsToppedUp(c *gc.C) {
+	p := s.newEnvironProvisioner(c)
+	defer stop(c, p)
+
+	// Adding a pool causes a machine to be added to it and provisioned.
+	pool, err := s.BackingState.AddMachinePool(1, series.LatestLts(), s.defaultConstraints)
+	c.Assert(err, jc.ErrorIsNil)
+	var machines []*state.Machine
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.BackingState.StartSync()
+		machines, err = pool.Machines()
+		c.Assert(err, jc.ErrorIsNil)
+		if len(machines) > 0 {
+			break
+		}
+	}
+	c.Assert(machines, gc.HasLen, 1)
+	s.checkStartInstance(c, machines[0])
+}
+
 func (s *ProvisionerSuite) TestConstraints(c *gc.C) {
 	// Create a machine with non-standard constraints.
 	m, err := s.addMachine()
@@ -1130,6 +1153,18 @@ func (s *ProvisionerSuite) newProvisionerTask(
 	machineGetter provisioner.MachineGetter,
 	toolsFinder provisioner.ToolsFinder,
 ) provisioner.ProvisionerTask {
+	return s.newProvisionerTaskWithProfileWatchers(c, harvestingMethod, broker, machineGetter, toolsFinder, nil, nil)
+}
+
+func (s *ProvisionerSuite) newProvisionerTaskWithProfileWatchers(
+	c *gc.C,
+	harvestingMethod config.HarvestMode,
+	broker environs.InstanceBroker,
+	machineGetter provisioner.MachineGetter,
+	toolsFinder provisioner.ToolsFinder,
+	profileWatcher watcher.StringsWatcher,
+	assignmentWatcher watcher.StringsWatcher,
+) provisioner.ProvisionerTask {
 
 	machineWatcher, err := s.provisioner.WatchModelMachines()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1148,6 +1183,9 @@ func (s *ProvisionerSuite) newProvisionerTask(
 		toolsFinder,
 		machineWatcher,
 		retryWatcher,
+		profileWatcher,
+		assignmentWatcher,
+		nil,
 		broker,
 		auth,
 		imagemetadata.ReleasedStream,
@@ -1344,6 +1382,119 @@ func (b *mockBroker) StartInstance(args environs.StartInstanceParams) (*environs
 	return nil, fmt.Errorf("error: some error")
 }
 
+func (s *ProvisionerSuite) TestUpdateCharmProfilesOnlyAffectedMachines(c *gc.C) {
+	// A machine provisioned before the task started hosts
+	// applications the task does not know about yet.
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	broker := &profileBroker{Environ: s.Environ, updated: make(chan string, 10)}
+	profileWatcher := &mockStringsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan []string),
+	}
+	task := s.newProvisionerTaskWithProfileWatchers(
+		c, config.HarvestDestroyed, broker, s.provisioner, mockToolsFinder{}, profileWatcher, nil,
+	)
+	defer stop(c, task)
+
+	// The task learns the applications of the machines it starts.
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m1)
+
+	// Only the machine with unknown applications is updated, and
+	// then its applications are known too.
+	profileWatcher.sendChange(c, "mysql")
+	broker.checkUpdated(c, m0.Id())
+	profileWatcher.sendChange(c, "mysql")
+	broker.checkUpdated(c)
+}
+
+func (s *ProvisionerSuite) TestUpdateCharmProfilesOnUnitAssignment(c *gc.C) {
+	m0, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	broker := &profileBroker{Environ: s.Environ, updated: make(chan string, 10)}
+	assignmentWatcher := &mockStringsWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: make(chan []string),
+	}
+	task := s.newProvisionerTaskWithProfileWatchers(
+		c, config.HarvestDestroyed, broker, s.provisioner, mockToolsFinder{}, nil, assignmentWatcher,
+	)
+	defer stop(c, task)
+
+	// Wait for the task to know about the machines.
+	m1, err := s.addMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStartInstance(c, m1)
+
+	// The profiles of provisioned machines whose units change are
+	// updated, whatever applications they were known to host.
+	assignmentWatcher.sendChange(c, m0.Id())
+	broker.checkUpdated(c, m0.Id())
+	assignmentWatcher.sendChange(c, m0.Id(), m1.Id())
+	broker.checkUpdated(c, m0.Id(), m1.Id())
+
+	// Machines the task does not know about are ignored.
+	assignmentWatcher.sendChange(c, "42")
+	broker.checkUpdated(c)
+}
+
+// profileBroker is an InstanceBroker that records the machines whose
+// charm profiles are updated.
+type profileBroker struct {
+	environs.Environ
+	updated chan string
+}
+
+func (b *profileBroker) UpdateCharmProfiles(machineId string, instId instance.Id, profiles map[string]*lxdprofile.Profile) error {
+	b.updated <- machineId
+	return nil
+}
+
+// checkUpdated checks that the profiles of exactly the given machines
+// are updated.
+func (b *profileBroker) checkUpdated(c *gc.C, expect ...string) {
+	var updated []string
+	for range expect {
+		select {
+		case id := <-b.updated:
+			updated = append(updated, id)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for profile update")
+		}
+	}
+	c.Check(updated, jc.SameContents, expect)
+	select {
+	case id := <-b.updated:
+		c.Fatalf("unexpected profile update for machine %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockStringsWatcher struct {
+	worker.Worker
+	changes chan []string
+}
+
+func (w *mockStringsWatcher) Changes() watcher.StringsChannel {
+	return w.changes
+}
+
+func (w *mockStringsWatcher) sendChange(c *gc.C, values ...string) {
+	select {
+	case w.changes <- values:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
 type mockToolsFinder struct {
 }
 
@@ -0,0 +1,9 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxyupdater
+
+var (
+	AptSourcesFile = &aptSourcesFile
+	AptSourcesDir  = &aptSourcesDir
+)
@@ -8,6 +8,7 @@ import (
 	"github.com/juju/utils/proxy"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/worker"
@@ -21,6 +22,7 @@ type ManifoldConfig struct {
 	WorkerFunc      func(Config) (worker.Worker, error)
 	ExternalUpdate  func(proxy.Settings) error
 	InProcessUpdate func(proxy.Settings) error
+	Tracker         *drift.Tracker
 }
 
 // Manifold returns a dependency manifold that runs a proxy updater worker,
@@ -59,6 +61,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				API:             proxyAPI,
 				ExternalUpdate:  config.ExternalUpdate,
 				InProcessUpdate: config.InProcessUpdate,
+				Tracker:         config.Tracker,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
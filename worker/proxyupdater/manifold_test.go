@@ -12,6 +12,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/agent/drift"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/dependency"
@@ -48,6 +49,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		},
 		ExternalUpdate:  MakeUpdateFunc("external"),
 		InProcessUpdate: MakeUpdateFunc("in-process"),
+		Tracker:         drift.NewTracker(),
 	}
 }
 
@@ -126,6 +128,7 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	// return.
 	c.Check(dummy.config.ExternalUpdate(proxy.Settings{}), gc.ErrorMatches, "external")
 	c.Check(dummy.config.InProcessUpdate(proxy.Settings{}), gc.ErrorMatches, "in-process")
+	c.Check(dummy.config.Tracker, gc.Equals, s.config.Tracker)
 }
 
 type dummyAgent struct {
@@ -18,6 +18,8 @@ import (
 	proxyutils "github.com/juju/utils/proxy"
 	"github.com/juju/utils/series"
 
+	"github.com/juju/juju/agent/drift"
+	"github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 )
@@ -33,12 +35,25 @@ type Config struct {
 	API             API
 	ExternalUpdate  func(proxyutils.Settings) error
 	InProcessUpdate func(proxyutils.Settings) error
+
+	// Tracker, if not nil, records the fingerprints of the proxy
+	// and apt files written by the worker, and tracks the machine's
+	// apt sources, so that changes made to them outside of Juju are
+	// detected.
+	Tracker *drift.Tracker
 }
 
+var (
+	// aptSourcesFile and aptSourcesDir hold the machine's apt
+	// sources, which are set up when the machine is provisioned.
+	aptSourcesFile = "/etc/apt/sources.list"
+	aptSourcesDir  = "/etc/apt/sources.list.d"
+)
+
 // API is an interface that is provided to New
 // which can be used to fetch the API host ports
 type API interface {
-	ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error)
+	ProxyConfig() (proxyupdater.ProxyConfiguration, error)
 	WatchForProxyConfigAndAPIHostPortChanges() (watcher.NotifyWatcher, error)
 }
 
@@ -48,8 +63,9 @@ type API interface {
 // changes are apt proxy configuration and the juju proxies stored in the juju
 // proxy file.
 type proxyWorker struct {
-	aptProxy proxyutils.Settings
-	proxy    proxyutils.Settings
+	aptProxy   proxyutils.Settings
+	proxy      proxyutils.Settings
+	agentProxy proxyutils.Settings
 
 	// The whole point of the first value is to make sure that the the files
 	// are written out the first time through, even if they are the same as
@@ -104,8 +120,30 @@ func (w *proxyWorker) writeEnvironmentFile() error {
 	}
 	if result.Code != 0 {
 		logger.Errorf("failed writing new proxy values: \n%s\n%s", result.Stdout, result.Stderr)
+		return nil
 	}
-	return nil
+	return w.recordFile("proxy-settings", filePath)
+}
+
+// recordFile records the fingerprint of the named file, which the
+// worker has just written, if the worker has a tracker.
+func (w *proxyWorker) recordFile(name, path string) error {
+	if w.config.Tracker == nil {
+		return nil
+	}
+	return w.config.Tracker.RecordFile(name, path)
+}
+
+// trackAptSources starts checking the machine's apt sources for
+// changes, if the worker has a tracker. Juju does not write them
+// after the machine is provisioned, so the sources seen when they
+// are first tracked are expected from then on.
+func (w *proxyWorker) trackAptSources() error {
+	if w.config.Tracker == nil {
+		return nil
+	}
+	fingerprint := drift.GlobFingerprinter(aptSourcesFile, path.Join(aptSourcesDir, "*.list"))
+	return w.config.Tracker.Track("apt-sources", aptSourcesFile, fingerprint)
 }
 
 func (w *proxyWorker) writeEnvironmentToRegistry() error {
@@ -146,20 +184,33 @@ func (w *proxyWorker) writeEnvironment() error {
 	}
 }
 
-func (w *proxyWorker) handleProxyValues(proxySettings proxyutils.Settings) {
-	proxySettings.SetEnvironmentValues()
-	if err := w.config.InProcessUpdate(proxySettings); err != nil {
+// handleProxyValues writes the legacy proxy settings to the machine wide
+// environment, and applies the agent proxy settings to this process.
+// The controller merges the juju-* proxy settings with the legacy ones
+// to give the agent proxy settings; controllers that don't send them
+// leave the agent using the legacy settings.
+func (w *proxyWorker) handleProxyValues(legacyProxySettings, jujuProxySettings proxyutils.Settings) {
+	agentProxySettings := jujuProxySettings
+	if agentProxySettings == (proxyutils.Settings{}) {
+		agentProxySettings = legacyProxySettings
+	}
+	agentProxySettings.SetEnvironmentValues()
+	if err := w.config.InProcessUpdate(agentProxySettings); err != nil {
 		logger.Errorf("error updating in-process proxy settings: %v", err)
 	}
-	if proxySettings != w.proxy || w.first {
-		logger.Debugf("new proxy settings %#v", proxySettings)
-		w.proxy = proxySettings
+	if legacyProxySettings != w.proxy || w.first {
+		logger.Debugf("new proxy settings %#v", legacyProxySettings)
+		w.proxy = legacyProxySettings
 		if err := w.writeEnvironment(); err != nil {
 			// It isn't really fatal, but we should record it.
 			logger.Errorf("error writing proxy environment file: %v", err)
 		}
+	}
+	if agentProxySettings != w.agentProxy || w.first {
+		logger.Debugf("new agent proxy settings %#v", agentProxySettings)
+		w.agentProxy = agentProxySettings
 		if externalFunc := w.config.ExternalUpdate; externalFunc != nil {
-			if err := externalFunc(proxySettings); err != nil {
+			if err := externalFunc(agentProxySettings); err != nil {
 				// It isn't really fatal, but we should record it.
 				logger.Errorf("%v", err)
 			}
@@ -192,19 +243,21 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxyutils.Settings) erro
 		if err != nil {
 			// It isn't really fatal, but we should record it.
 			logger.Errorf("error writing apt proxy config file: %v", err)
+		} else if err := w.recordFile("apt-proxy-settings", config.AptProxyConfigFile); err != nil {
+			logger.Errorf("error recording apt proxy config file: %v", err)
 		}
 	}
 	return nil
 }
 
 func (w *proxyWorker) onChange() error {
-	proxySettings, APTProxySettings, err := w.config.API.ProxyConfig()
+	config, err := w.config.API.ProxyConfig()
 	if err != nil {
 		return err
 	}
 
-	w.handleProxyValues(proxySettings)
-	return w.handleAptProxyValues(APTProxySettings)
+	w.handleProxyValues(config.LegacyProxy, config.JujuProxy)
+	return w.handleAptProxyValues(config.APTProxy)
 }
 
 // SetUp is defined on the worker.NotifyWatchHandler interface.
@@ -215,6 +268,10 @@ func (w *proxyWorker) SetUp() (watcher.NotifyWatcher, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := w.trackAptSources(); err != nil {
+		// It isn't really fatal, but we should record it.
+		logger.Errorf("error tracking apt sources: %v", err)
+	}
 	w.first = false
 	return w.config.API.WatchForProxyConfigAndAPIHostPortChanges()
 }
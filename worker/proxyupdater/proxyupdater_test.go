@@ -21,6 +21,8 @@ import (
 	"github.com/juju/utils/series"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/agent/drift"
+	apiproxyupdater "github.com/juju/juju/api/proxyupdater"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
@@ -53,10 +55,11 @@ func (w notAWatcher) Changes() watcher.NotifyChannel {
 }
 
 type fakeAPI struct {
-	Proxy    proxyutils.Settings
-	APTProxy proxyutils.Settings
-	Err      error
-	Watcher  *notAWatcher
+	Proxy     proxyutils.Settings
+	JujuProxy proxyutils.Settings
+	APTProxy  proxyutils.Settings
+	Err       error
+	Watcher   *notAWatcher
 }
 
 func NewFakeAPI() *fakeAPI {
@@ -64,9 +67,12 @@ func NewFakeAPI() *fakeAPI {
 	return f
 }
 
-func (api fakeAPI) ProxyConfig() (proxyutils.Settings, proxyutils.Settings, error) {
-	return api.Proxy, api.APTProxy, api.Err
-
+func (api fakeAPI) ProxyConfig() (apiproxyupdater.ProxyConfiguration, error) {
+	return apiproxyupdater.ProxyConfiguration{
+		LegacyProxy: api.Proxy,
+		JujuProxy:   api.JujuProxy,
+		APTProxy:    api.APTProxy,
+	}, api.Err
 }
 
 func (api fakeAPI) WatchForProxyConfigAndAPIHostPortChanges() (watcher.NotifyWatcher, error) {
@@ -245,6 +251,52 @@ func (s *ProxyUpdaterSuite) TestEnvironmentVariables(c *gc.C) {
 	assertEnv("no_proxy", proxySettings.NoProxy)
 }
 
+func (s *ProxyUpdaterSuite) TestJujuProxySettingsOnlyForAgent(c *gc.C) {
+	proxySettings, _ := s.updateConfig(c)
+	s.api.JujuProxy = proxy.Settings{
+		Http:    "juju http proxy",
+		Https:   "juju https proxy",
+		NoProxy: "localhost,10.0.0.0/8",
+	}
+
+	var externalSettings proxy.Settings
+	updated := make(chan struct{})
+	s.config.ExternalUpdate = func(values proxy.Settings) error {
+		externalSettings = values
+		close(updated)
+		return nil
+	}
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	// The agent process uses the agent proxy settings, while the
+	// machine wide proxy file contains the legacy settings.
+	agentSettings := s.api.JujuProxy
+	s.waitProxySettings(c, agentSettings)
+	s.waitForFile(c, s.proxyFile, proxySettings.AsScriptEnvironment()+"\n")
+
+	select {
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("function not called")
+	case <-updated:
+	}
+	c.Assert(externalSettings, jc.DeepEquals, agentSettings)
+}
+
+func (s *ProxyUpdaterSuite) TestLegacyProxySettingsWithoutJujuProxySettings(c *gc.C) {
+	proxySettings, _ := s.updateConfig(c)
+	s.api.JujuProxy = proxy.Settings{}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	// Controllers that don't send agent proxy settings leave the
+	// agent using the legacy settings.
+	s.waitProxySettings(c, proxySettings)
+}
+
 func (s *ProxyUpdaterSuite) TestExternalFuncCalled(c *gc.C) {
 	proxySettings, _ := s.updateConfig(c)
 
@@ -302,3 +354,40 @@ func (s *ProxyUpdaterSuite) TestErrorSettingInProcessLogs(c *gc.C) {
 	}
 	c.Assert(foundMessage, jc.IsTrue)
 }
+
+func (s *ProxyUpdaterSuite) TestTracksWrittenFilesAndAptSources(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Proxy settings are written to the registry on windows")
+	}
+	sourcesDir := c.MkDir()
+	sourcesFile := path.Join(sourcesDir, "sources.list")
+	c.Assert(ioutil.WriteFile(sourcesFile, []byte("deb http://archive"), 0644), jc.ErrorIsNil)
+	s.PatchValue(proxyupdater.AptSourcesFile, sourcesFile)
+	s.PatchValue(proxyupdater.AptSourcesDir, path.Join(sourcesDir, "sources.list.d"))
+
+	tracker := drift.NewTracker()
+	s.config.Tracker = tracker
+	proxySettings, aptProxySettings := s.updateConfig(c)
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitProxySettings(c, proxySettings)
+	paccmder, err := commands.NewPackageCommander(series.MustHostSeries())
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForFile(c, pacconfig.AptProxyConfigFile, paccmder.ProxyConfigContents(aptProxySettings)+"\n")
+	workertest.CleanKill(c, updater)
+
+	drifted, err := tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, gc.HasLen, 0)
+
+	c.Assert(ioutil.WriteFile(s.proxyFile, []byte("http_proxy=elsewhere"), 0644), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(pacconfig.AptProxyConfigFile, nil, 0644), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(sourcesFile, []byte("deb http://mirror"), 0644), jc.ErrorIsNil)
+	drifted, err = tracker.Check()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drifted, jc.DeepEquals, []drift.Artifact{
+		{Name: "apt-proxy-settings", Path: pacconfig.AptProxyConfigFile},
+		{Name: "apt-sources", Path: sourcesFile},
+		{Name: "proxy-settings", Path: s.proxyFile},
+	})
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrade
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/worker"
+)
+
+// Facade exposes the controller capability required by the worker.
+type Facade interface {
+
+	// AdvanceRollingUpgrades causes the running rolling upgrades in
+	// the model to release their next batch of units, if the previous
+	// batch has completed, or to pause, if any released unit is in
+	// error.
+	AdvanceRollingUpgrades() error
+}
+
+// Config defines the operation of a rolling upgrade worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time.
+	Clock clock.Clock
+
+	// Period is the time between checks of rolling upgrade progress.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that calls AdvanceRollingUpgrades on the
+// configured Facade, once when started and subsequently every Period.
+// All progress is recorded by the controller, so a restarted worker
+// simply carries on from where the previous one stopped.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &rollingUpgradeWorker{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type rollingUpgradeWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *rollingUpgradeWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			if err := w.config.Facade.AdvanceRollingUpgrades(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *rollingUpgradeWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *rollingUpgradeWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrade_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/rollingupgrade"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	facade := newMockFacade()
+	clock := testing.NewClock(coretesting.ZeroTime())
+	for i, test := range []struct {
+		config rollingupgrade.Config
+		err    string
+	}{{
+		config: rollingupgrade.Config{Clock: clock, Period: time.Second},
+		err:    "nil Facade not valid",
+	}, {
+		config: rollingupgrade.Config{Facade: facade, Period: time.Second},
+		err:    "nil Clock not valid",
+	}, {
+		config: rollingupgrade.Config{Facade: facade, Clock: clock},
+		err:    "non-positive Period not valid",
+	}} {
+		c.Logf("test %d", i)
+		w, err := rollingupgrade.NewWorker(test.config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestAdvancesPeriodically(c *gc.C) {
+	facade := newMockFacade()
+	clock := testing.NewClock(coretesting.ZeroTime())
+	w, err := rollingupgrade.NewWorker(rollingupgrade.Config{
+		Facade: facade,
+		Clock:  clock,
+		Period: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(w)
+
+	waitCall(c, facade)
+	if err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 1); err != nil {
+		c.Fatal(err)
+	}
+	waitCall(c, facade)
+	c.Assert(worker.Stop(w), jc.ErrorIsNil)
+	facade.stub.CheckCallNames(c, "AdvanceRollingUpgrades", "AdvanceRollingUpgrades")
+}
+
+func (s *WorkerSuite) TestAdvanceError(c *gc.C) {
+	facade := newMockFacade()
+	facade.stub.SetErrors(errors.New("cannot advance"))
+	w, err := rollingupgrade.NewWorker(rollingupgrade.Config{
+		Facade: facade,
+		Clock:  testing.NewClock(coretesting.ZeroTime()),
+		Period: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	waitCall(c, facade)
+	c.Check(w.Wait(), gc.ErrorMatches, "cannot advance")
+}
+
+func waitCall(c *gc.C, facade mockFacade) {
+	select {
+	case <-facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out")
+	}
+}
+
+type mockFacade struct {
+	stub  *testing.Stub
+	calls chan struct{}
+}
+
+func newMockFacade() mockFacade {
+	return mockFacade{
+		stub:  &testing.Stub{},
+		calls: make(chan struct{}, 100),
+	}
+}
+
+func (mock mockFacade) AdvanceRollingUpgrades() error {
+	mock.stub.AddCall("AdvanceRollingUpgrades")
+	mock.calls <- struct{}{}
+	return mock.stub.NextErr()
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/status"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+// workloadHealth flips the unit's workload status to unhealthy when
+// the charm's health checks fail, and restores the status the charm
+// last set when they pass again.
+type workloadHealth struct {
+	unit *uniter.Unit
+
+	// saved holds the workload status as it was before the
+	// workload was reported unhealthy.
+	saved *params.StatusResult
+}
+
+func (h *workloadHealth) setUnhealthy(message string) error {
+	if h.saved == nil {
+		current, err := h.unit.UnitStatus()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if current.Status != string(status.Unhealthy) {
+			h.saved = &current
+		}
+	}
+	return h.unit.SetUnitStatus(status.Unhealthy, message, nil)
+}
+
+func (h *workloadHealth) setHealthy() error {
+	saved := h.saved
+	h.saved = nil
+	current, err := h.unit.UnitStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != string(status.Unhealthy) {
+		// The charm has set the status itself since the
+		// checks failed, most likely in response to the
+		// health-check-failed hook; leave it alone.
+		return nil
+	}
+	if saved == nil {
+		return h.unit.SetUnitStatus(status.Unknown, "", nil)
+	}
+	return h.unit.SetUnitStatus(status.Status(saved.Status), saved.Info, saved.Data)
+}
+
+// startHealthChecker starts a worker that runs the health checks
+// declared by the deployed charm, sending the names of failed checks
+// on the given channel.
+func (u *Uniter) startHealthChecker(failed chan<- string) error {
+	health := &workloadHealth{unit: u.unit}
+	charmDir := u.paths.State.CharmDir
+	checker, err := healthcheck.NewChecker(healthcheck.Config{
+		Clock: u.clock,
+		ReadChecks: func() ([]healthcheck.Check, error) {
+			return healthcheck.ReadChecks(charmDir)
+		},
+		Probe:        healthcheck.NewProbe(charmDir, u.clock),
+		SetUnhealthy: health.setUnhealthy,
+		SetHealthy:   health.setHealthy,
+		Failed:       failed,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(u.catacomb.Add(checker))
+}
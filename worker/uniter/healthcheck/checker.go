@@ -0,0 +1,257 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.healthcheck")
+
+// ReloadInterval is the longest time the checker waits before
+// reading the declared checks again, so that checks added or
+// removed by a charm upgrade are noticed.
+const ReloadInterval = time.Minute
+
+// Config holds the dependencies and configuration of a Checker.
+type Config struct {
+	// Clock is used to schedule the checks.
+	Clock clock.Clock
+
+	// ReadChecks returns the health checks currently declared
+	// by the deployed charm.
+	ReadChecks func() ([]Check, error)
+
+	// Probe runs a single health check, returning an error if
+	// the check does not pass.
+	Probe func(Check) error
+
+	// SetUnhealthy is called with a message describing the
+	// failed checks whenever the set of failed checks changes
+	// and is not empty.
+	SetUnhealthy func(message string) error
+
+	// SetHealthy is called when all previously failed checks
+	// have passed again.
+	SetHealthy func() error
+
+	// Failed receives the name of each check when it reaches
+	// its failure threshold.
+	Failed chan<- string
+}
+
+// Validate returns an error if the config is not valid.
+func (config Config) Validate() error {
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.ReadChecks == nil {
+		return errors.NotValidf("nil ReadChecks")
+	}
+	if config.Probe == nil {
+		return errors.NotValidf("nil Probe")
+	}
+	if config.SetUnhealthy == nil {
+		return errors.NotValidf("nil SetUnhealthy")
+	}
+	if config.SetHealthy == nil {
+		return errors.NotValidf("nil SetHealthy")
+	}
+	if config.Failed == nil {
+		return errors.NotValidf("nil Failed")
+	}
+	return nil
+}
+
+// Checker is a worker that periodically runs the workload
+// health checks declared by a charm.
+type Checker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// next records when each check is next due.
+	next map[string]time.Time
+
+	// failures records the number of consecutive failures
+	// of each check.
+	failures map[string]int
+
+	// failed records the error of each check that has reached
+	// its failure threshold.
+	failed map[string]string
+
+	// reported holds the names of the failed checks last
+	// reported with SetUnhealthy, or is empty if the workload
+	// was last reported healthy.
+	reported string
+}
+
+// NewChecker returns a new Checker that runs the health checks
+// as described by the given config.
+func NewChecker(config Config) (*Checker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Checker{
+		config:   config,
+		next:     make(map[string]time.Time),
+		failures: make(map[string]int),
+		failed:   make(map[string]string),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Checker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Checker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Checker) loop() error {
+	var checks []Check
+	for {
+		newChecks, err := w.config.ReadChecks()
+		if err != nil {
+			// Keep running the previous checks; the charm
+			// may be in the middle of being upgraded.
+			logger.Warningf("cannot read health checks: %v", err)
+		} else {
+			checks = newChecks
+			w.forgetRemoved(checks)
+		}
+
+		wait := ReloadInterval
+		for _, check := range checks {
+			now := w.config.Clock.Now()
+			due, ok := w.next[check.Name]
+			if !ok || !now.Before(due) {
+				if err := w.run(check); err != nil {
+					return errors.Trace(err)
+				}
+				now = w.config.Clock.Now()
+				due = now.Add(check.Interval)
+				w.next[check.Name] = due
+			}
+			if d := due.Sub(now); d < wait {
+				wait = d
+			}
+		}
+		if err := w.updateStatus(); err != nil {
+			return errors.Trace(err)
+		}
+
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(wait):
+		}
+	}
+}
+
+// run runs the given check, recording the result and notifying
+// the Failed channel if the check reaches its failure threshold.
+func (w *Checker) run(check Check) error {
+	err := w.config.Probe(check)
+	if err == nil {
+		if _, ok := w.failed[check.Name]; ok {
+			logger.Infof("health check %q passed", check.Name)
+		}
+		delete(w.failures, check.Name)
+		delete(w.failed, check.Name)
+		return nil
+	}
+	w.failures[check.Name]++
+	logger.Debugf("health check %q failed (%d/%d): %v",
+		check.Name, w.failures[check.Name], check.Threshold, err,
+	)
+	if w.failures[check.Name] < check.Threshold {
+		return nil
+	}
+	_, alreadyFailed := w.failed[check.Name]
+	w.failed[check.Name] = err.Error()
+	if alreadyFailed {
+		return nil
+	}
+	logger.Warningf("health check %q failed: %v", check.Name, err)
+	select {
+	case <-w.catacomb.Dying():
+		return w.catacomb.ErrDying()
+	case w.config.Failed <- check.Name:
+	}
+	return nil
+}
+
+// forgetRemoved discards the state of any checks that are
+// no longer declared.
+func (w *Checker) forgetRemoved(checks []Check) {
+	current := make(map[string]bool)
+	for _, check := range checks {
+		current[check.Name] = true
+	}
+	for name := range w.next {
+		if !current[name] {
+			delete(w.next, name)
+			delete(w.failures, name)
+			delete(w.failed, name)
+		}
+	}
+}
+
+// updateStatus reports the workload as unhealthy if any checks
+// have failed, or healthy if all previously failed checks have
+// since passed.
+func (w *Checker) updateStatus() error {
+	if len(w.failed) == 0 {
+		if w.reported == "" {
+			return nil
+		}
+		if err := w.config.SetHealthy(); err != nil {
+			return errors.Annotate(err, "setting workload healthy")
+		}
+		w.reported = ""
+		return nil
+	}
+	names := make([]string, 0, len(w.failed))
+	for name := range w.failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reported := strings.Join(names, ",")
+	if reported == w.reported {
+		// Only report changes to the set of failed checks,
+		// so as not to flood the status history.
+		return nil
+	}
+	var message string
+	if len(names) == 1 {
+		message = fmt.Sprintf("health check %q failed: %s", names[0], w.failed[names[0]])
+	} else {
+		message = fmt.Sprintf("health checks failed: %s", strings.Join(names, ", "))
+	}
+	if err := w.config.SetUnhealthy(message); err != nil {
+		return errors.Annotate(err, "setting workload unhealthy")
+	}
+	w.reported = reported
+	return nil
+}
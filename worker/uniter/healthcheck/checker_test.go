@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type CheckerSuite struct {
+	testing.IsolationSuite
+
+	clock    *testing.Clock
+	results  chan error
+	statuses chan string
+	failed   chan string
+	config   healthcheck.Config
+}
+
+var _ = gc.Suite(&CheckerSuite{})
+
+func (s *CheckerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.results = make(chan error, 10)
+	s.statuses = make(chan string, 10)
+	s.failed = make(chan string)
+	s.config = healthcheck.Config{
+		Clock: s.clock,
+		ReadChecks: func() ([]healthcheck.Check, error) {
+			return []healthcheck.Check{{
+				Name:      "web",
+				HTTP:      "http://localhost/",
+				Interval:  10 * time.Second,
+				Timeout:   time.Second,
+				Threshold: 2,
+			}}, nil
+		},
+		Probe: func(healthcheck.Check) error {
+			select {
+			case err := <-s.results:
+				return err
+			case <-time.After(coretesting.LongWait):
+				return errors.New("no result")
+			}
+		},
+		SetUnhealthy: func(message string) error {
+			s.statuses <- "unhealthy: " + message
+			return nil
+		},
+		SetHealthy: func() error {
+			s.statuses <- "healthy"
+			return nil
+		},
+		Failed: s.failed,
+	}
+}
+
+func (s *CheckerSuite) TestValidate(c *gc.C) {
+	s.config.Probe = nil
+	_, err := healthcheck.NewChecker(s.config)
+	c.Assert(err, gc.ErrorMatches, "nil Probe not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CheckerSuite) TestFailureThresholdAndRecovery(c *gc.C) {
+	s.results <- errors.New("connection refused")
+	checker, err := healthcheck.NewChecker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, checker)
+
+	// The first failure is below the threshold.
+	s.assertNoStatus(c)
+
+	// The second failure reaches the threshold.
+	s.results <- errors.New("connection refused")
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case name := <-s.failed:
+		c.Assert(name, gc.Equals, "web")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for failed check")
+	}
+	s.assertStatus(c, `unhealthy: health check "web" failed: connection refused`)
+
+	// Repeated failures are not reported again.
+	s.results <- errors.New("connection refused")
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoStatus(c)
+
+	// A pass restores the workload status.
+	s.results <- nil
+	err = s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertStatus(c, "healthy")
+}
+
+func (s *CheckerSuite) assertStatus(c *gc.C, expect string) {
+	select {
+	case status := <-s.statuses:
+		c.Assert(status, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status %q", expect)
+	}
+}
+
+func (s *CheckerSuite) assertNoStatus(c *gc.C) {
+	select {
+	case status := <-s.statuses:
+		c.Fatalf("unexpected status %q", status)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
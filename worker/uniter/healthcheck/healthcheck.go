@@ -0,0 +1,185 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck implements the workload health checks that a
+// charm may declare in the health-checks section of its metadata.
+//
+// For example:
+//
+//     health-checks:
+//       web:
+//         http: http://localhost:8080/health
+//         interval: 30s
+//         timeout: 5s
+//         threshold: 3
+//       database:
+//         tcp: localhost:5432
+//       worker:
+//         exec: pgrep -f my-worker
+//
+// Exactly one of http, tcp or exec must be specified for each check.
+package healthcheck
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	goyaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultInterval is the time between runs of a health
+	// check if none is specified.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultTimeout is the time a health check may take
+	// before it is considered to have failed, if none is
+	// specified.
+	DefaultTimeout = 10 * time.Second
+
+	// DefaultThreshold is the number of consecutive failures
+	// after which a health check is considered to have failed,
+	// if none is specified.
+	DefaultThreshold = 3
+)
+
+// Check describes a single workload health check.
+type Check struct {
+	// Name is the name of the check, as declared in the
+	// charm metadata.
+	Name string
+
+	// HTTP, if set, holds the URL that is fetched with a GET
+	// request. Any response status other than 2xx or 3xx is a
+	// failure.
+	HTTP string
+
+	// TCP, if set, holds the host:port address that is
+	// connected to.
+	TCP string
+
+	// Exec, if set, holds a command that is run in the charm
+	// directory. A non-zero exit code is a failure.
+	Exec string
+
+	// Interval is the time between runs of the check.
+	Interval time.Duration
+
+	// Timeout is the time that a single run of the check
+	// may take before it is considered to have failed.
+	Timeout time.Duration
+
+	// Threshold is the number of consecutive failures after
+	// which the check is considered to have failed.
+	Threshold int
+}
+
+// Validate returns an error if the check is not valid.
+func (c Check) Validate() error {
+	var kinds int
+	for _, v := range []string{c.HTTP, c.TCP, c.Exec} {
+		if v != "" {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.NotValidf("health check %q without exactly one of http, tcp or exec", c.Name)
+	}
+	if c.HTTP != "" {
+		u, err := url.Parse(c.HTTP)
+		if err != nil {
+			return errors.NotValidf("health check %q http URL %q", c.Name, c.HTTP)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("health check %q http URL scheme %q", c.Name, u.Scheme)
+		}
+	}
+	if c.Interval <= 0 {
+		return errors.NotValidf("health check %q interval %v", c.Name, c.Interval)
+	}
+	if c.Timeout <= 0 {
+		return errors.NotValidf("health check %q timeout %v", c.Name, c.Timeout)
+	}
+	if c.Threshold <= 0 {
+		return errors.NotValidf("health check %q threshold %d", c.Name, c.Threshold)
+	}
+	return nil
+}
+
+type checkDoc struct {
+	HTTP      string `yaml:"http"`
+	TCP       string `yaml:"tcp"`
+	Exec      string `yaml:"exec"`
+	Interval  string `yaml:"interval"`
+	Timeout   string `yaml:"timeout"`
+	Threshold int    `yaml:"threshold"`
+}
+
+type metadataDoc struct {
+	HealthChecks map[string]checkDoc `yaml:"health-checks"`
+}
+
+// ParseChecks parses the health-checks section of the given
+// charm metadata, returning the checks sorted by name.
+func ParseChecks(metadata []byte) ([]Check, error) {
+	var doc metadataDoc
+	if err := goyaml.Unmarshal(metadata, &doc); err != nil {
+		return nil, errors.Annotate(err, "parsing charm metadata")
+	}
+	checks := make([]Check, 0, len(doc.HealthChecks))
+	for name, cdoc := range doc.HealthChecks {
+		check := Check{
+			Name:      name,
+			HTTP:      cdoc.HTTP,
+			TCP:       cdoc.TCP,
+			Exec:      cdoc.Exec,
+			Interval:  DefaultInterval,
+			Timeout:   DefaultTimeout,
+			Threshold: DefaultThreshold,
+		}
+		var err error
+		if cdoc.Interval != "" {
+			if check.Interval, err = time.ParseDuration(cdoc.Interval); err != nil {
+				return nil, errors.Annotatef(err, "health check %q interval", name)
+			}
+		}
+		if cdoc.Timeout != "" {
+			if check.Timeout, err = time.ParseDuration(cdoc.Timeout); err != nil {
+				return nil, errors.Annotatef(err, "health check %q timeout", name)
+			}
+		}
+		if cdoc.Threshold != 0 {
+			check.Threshold = cdoc.Threshold
+		}
+		if err := check.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		checks = append(checks, check)
+	}
+	sort.Sort(byName(checks))
+	return checks, nil
+}
+
+// ReadChecks reads the health checks declared in the metadata of
+// the charm deployed in charmDir. If no charm is deployed, no checks
+// are returned.
+func ReadChecks(charmDir string) ([]Check, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, "metadata.yaml"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseChecks(data)
+}
+
+type byName []Check
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
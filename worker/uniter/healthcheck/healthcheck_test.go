@@ -0,0 +1,112 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type HealthCheckSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HealthCheckSuite{})
+
+func (s *HealthCheckSuite) TestParseChecks(c *gc.C) {
+	checks, err := healthcheck.ParseChecks([]byte(`
+name: wordpress
+summary: blog
+health-checks:
+  web:
+    http: http://localhost:8080/health
+    interval: 5s
+    timeout: 2s
+    threshold: 1
+  database:
+    tcp: localhost:5432
+  worker:
+    exec: pgrep -f my-worker
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []healthcheck.Check{{
+		Name:      "database",
+		TCP:       "localhost:5432",
+		Interval:  healthcheck.DefaultInterval,
+		Timeout:   healthcheck.DefaultTimeout,
+		Threshold: healthcheck.DefaultThreshold,
+	}, {
+		Name:      "web",
+		HTTP:      "http://localhost:8080/health",
+		Interval:  5 * time.Second,
+		Timeout:   2 * time.Second,
+		Threshold: 1,
+	}, {
+		Name:      "worker",
+		Exec:      "pgrep -f my-worker",
+		Interval:  healthcheck.DefaultInterval,
+		Timeout:   healthcheck.DefaultTimeout,
+		Threshold: healthcheck.DefaultThreshold,
+	}})
+}
+
+func (s *HealthCheckSuite) TestParseChecksNone(c *gc.C) {
+	checks, err := healthcheck.ParseChecks([]byte("name: wordpress\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *HealthCheckSuite) TestParseChecksInvalid(c *gc.C) {
+	for i, test := range []struct {
+		metadata string
+		err      string
+	}{{
+		metadata: "health-checks: {web: {}}",
+		err:      `health check "web" without exactly one of http, tcp or exec not valid`,
+	}, {
+		metadata: "health-checks: {web: {tcp: a:1, exec: b}}",
+		err:      `health check "web" without exactly one of http, tcp or exec not valid`,
+	}, {
+		metadata: "health-checks: {web: {http: 'ftp://x'}}",
+		err:      `health check "web" http URL scheme "ftp" not valid`,
+	}, {
+		metadata: "health-checks: {web: {tcp: a:1, interval: soon}}",
+		err:      `health check "web" interval: time: invalid duration .*`,
+	}, {
+		metadata: "health-checks: {web: {tcp: a:1, timeout: -1s}}",
+		err:      `health check "web" timeout -1s not valid`,
+	}, {
+		metadata: "health-checks: {web: {tcp: a:1, threshold: -1}}",
+		err:      `health check "web" threshold -1 not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.metadata)
+		_, err := healthcheck.ParseChecks([]byte(test.metadata))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HealthCheckSuite) TestReadChecks(c *gc.C) {
+	dir := c.MkDir()
+	checks, err := healthcheck.ReadChecks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(`
+health-checks:
+  web:
+    tcp: localhost:80
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	checks, err = healthcheck.ReadChecks(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Assert(checks[0].Name, gc.Equals, "web")
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/juju/utils/exec"
+)
+
+// NewProbe returns a function that runs a health check, returning
+// an error describing the failure if the check does not pass.
+// Exec checks are run in the given charm directory.
+func NewProbe(charmDir string, clock clock.Clock) func(Check) error {
+	return func(check Check) error {
+		switch {
+		case check.HTTP != "":
+			return probeHTTP(check)
+		case check.TCP != "":
+			return probeTCP(check)
+		case check.Exec != "":
+			return probeExec(check, charmDir, clock)
+		}
+		return errors.NotValidf("health check %q", check.Name)
+	}
+}
+
+func probeHTTP(check Check) error {
+	client := &http.Client{Timeout: check.Timeout}
+	resp, err := client.Get(check.HTTP)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.Errorf("GET %s returned %q", check.HTTP, resp.Status)
+	}
+	return nil
+}
+
+func probeTCP(check Check) error {
+	conn, err := net.DialTimeout("tcp", check.TCP, check.Timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+func probeExec(check Check, charmDir string, clock clock.Clock) error {
+	cmd := exec.RunParams{
+		Commands:   check.Exec,
+		WorkingDir: charmDir,
+		Clock:      clock,
+	}
+	if err := cmd.Run(); err != nil {
+		return errors.Trace(err)
+	}
+
+	cancel := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-clock.After(check.Timeout):
+			close(cancel)
+		case <-done:
+		}
+	}()
+
+	result, err := cmd.WaitWithCancel(cancel)
+	if err == exec.ErrCancelled {
+		return errors.Errorf("%q timed out after %v", check.Exec, check.Timeout)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		output := strings.TrimSpace(string(result.Stderr))
+		if output == "" {
+			output = strings.TrimSpace(string(result.Stdout))
+		}
+		if output != "" {
+			return errors.Errorf("%q exited with code %d: %s", check.Exec, result.Code, output)
+		}
+		return errors.Errorf("%q exited with code %d", check.Exec, result.Code)
+	}
+	return nil
+}
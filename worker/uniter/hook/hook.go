@@ -16,6 +16,7 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	HealthCheckFailed     hooks.Kind = "health-check-failed"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -37,6 +38,10 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// HealthCheck is the name of the failed health check. It is only
+	// set when Kind is HealthCheckFailed.
+	HealthCheck string `yaml:"health-check,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -60,6 +65,11 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case HealthCheckFailed:
+		if hi.HealthCheck == "" {
+			return fmt.Errorf("%q hook requires a health check", hi.Kind)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
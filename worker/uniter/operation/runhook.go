@@ -40,6 +40,8 @@ func (rh *runHook) String() string {
 		}
 	case rh.info.Kind.IsStorage():
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.Kind == hook.HealthCheckFailed:
+		suffix = fmt.Sprintf(" (%s)", rh.info.HealthCheck)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
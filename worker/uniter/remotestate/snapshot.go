@@ -61,6 +61,15 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// HealthCheckFailedVersion increments each time a charm
+	// health check fails, and a health-check-failed hook is
+	// supposed to run.
+	HealthCheckFailedVersion int
+
+	// FailedHealthCheck is the name of the health check that
+	// most recently failed.
+	FailedHealthCheck string
+
 	// Actions is the list of pending actions to
 	// be peformed by this unit.
 	Actions []string
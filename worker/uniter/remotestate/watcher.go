@@ -35,6 +35,7 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	healthCheckFailedChannel  <-chan string
 
 	catacomb catacomb.Catacomb
 
@@ -52,6 +53,10 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// HealthCheckFailedChannel, if non-nil, receives the names
+	// of charm health checks that have failed.
+	HealthCheckFailedChannel <-chan string
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
@@ -67,6 +72,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		healthCheckFailedChannel:  config.HealthCheckFailedChannel,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -435,6 +441,15 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			if err := w.retryHookTimerTriggered(); err != nil {
 				return err
 			}
+
+		case name, ok := <-w.healthCheckFailedChannel:
+			if !ok {
+				return errors.New("healthCheckFailedChannel closed")
+			}
+			logger.Debugf("health check %q failed", name)
+			if err := w.healthCheckFailed(name); err != nil {
+				return err
+			}
 		}
 
 		// Something changed.
@@ -466,6 +481,15 @@ func (w *RemoteStateWatcher) retryHookTimerTriggered() error {
 	return nil
 }
 
+// healthCheckFailed is called when a charm health check fails.
+func (w *RemoteStateWatcher) healthCheckFailed(name string) error {
+	w.mu.Lock()
+	w.current.HealthCheckFailedVersion++
+	w.current.FailedHealthCheck = name
+	w.mu.Unlock()
+	return nil
+}
+
 // unitChanged responds to changes in the unit.
 func (w *RemoteStateWatcher) unitChanged() error {
 	if err := w.unit.Refresh(); err != nil {
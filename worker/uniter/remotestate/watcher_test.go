@@ -21,10 +21,11 @@ import (
 type WatcherSuite struct {
 	coretesting.BaseSuite
 
-	st         *mockState
-	leadership *mockLeadershipTracker
-	watcher    *remotestate.RemoteStateWatcher
-	clock      *testing.Clock
+	st                *mockState
+	leadership        *mockLeadershipTracker
+	watcher           *remotestate.RemoteStateWatcher
+	healthCheckFailed chan string
+	clock             *testing.Clock
 }
 
 // Duration is arbitrary, we'll trigger the ticker
@@ -71,11 +72,13 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		return s.clock.After(statusTickDuration)
 	}
 
+	s.healthCheckFailed = make(chan string)
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
-		State:               s.st,
-		LeadershipTracker:   s.leadership,
-		UnitTag:             s.st.unit.tag,
-		UpdateStatusChannel: statusTicker,
+		State:                    s.st,
+		LeadershipTracker:        s.leadership,
+		UnitTag:                  s.st.unit.tag,
+		UpdateStatusChannel:      statusTicker,
+		HealthCheckFailedChannel: s.healthCheckFailed,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -548,6 +551,22 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestHealthCheckFailed(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	initial := s.watcher.Snapshot()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	select {
+	case s.healthCheckFailed <- "web":
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending health check failure")
+	}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	snapshot := s.watcher.Snapshot()
+	c.Assert(snapshot.HealthCheckFailedVersion, gc.Equals, initial.HealthCheckFailedVersion+1)
+	c.Assert(snapshot.FailedHealthCheck, gc.Equals, "web")
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
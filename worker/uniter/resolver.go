@@ -269,6 +269,13 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	if localState.HealthCheckFailedVersion != remoteState.HealthCheckFailedVersion {
+		return opFactory.NewRunHook(hook.Info{
+			Kind:        hook.HealthCheckFailed,
+			HealthCheck: remoteState.FailedHealthCheck,
+		})
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -77,6 +77,11 @@ type LocalState struct {
 	// for which an update-status hook has been committed.
 	UpdateStatusVersion int
 
+	// HealthCheckFailedVersion is the version of health check failures
+	// from remotestate.Snapshot for which a health-check-failed hook
+	// has been committed.
+	HealthCheckFailedVersion int
+
 	// RetryHookVersion is the version of hook-retries from
 	// remotestate.Snapshot for which a hook has been retried.
 	RetryHookVersion int
@@ -120,6 +120,11 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.HealthCheckFailed:
+		v := s.RemoteState.HealthCheckFailedVersion
+		op = onCommitWrapper{op, func() {
+			s.LocalState.HealthCheckFailedVersion = v
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
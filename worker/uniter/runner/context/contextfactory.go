@@ -213,6 +213,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.HealthCheckFailed {
+		ctx.healthCheck = hookInfo.HealthCheck
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -168,6 +168,10 @@ func ContextMachineTag(hctx *HookContext) names.MachineTag {
 	return hctx.assignedMachineTag
 }
 
+func ContextHealthCheck(hctx *HookContext) string {
+	return hctx.healthCheck
+}
+
 func UpdateCachedSettings(cf0 ContextFactory, relId int, unitName string, settings params.Settings) {
 	cf := cf0.(*contextFactory)
 	members := cf.relationCaches[relId].members
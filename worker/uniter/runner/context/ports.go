@@ -85,12 +85,12 @@ func tryOpenPorts(
 				portRange,
 			)
 		}
+		if portRange == newRange && relUnitTag == unitTag {
+			// The same unit trying to open the same range is just
+			// ignored.
+			return nil
+		}
 		if newRange.ConflictsWith(portRange) {
-			if portRange == newRange && relUnitTag == unitTag {
-				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
-			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
 				newRange, unitTag.Id(), portRange, relUnitTag.Id(),
@@ -151,7 +151,10 @@ func tryClosePorts(
 	if !found {
 		// Trying to close a range which is not open is ignored.
 		return nil
-	} else if relUnit.Unit != unitTag.String() {
+	} else if relUnit.Unit != unitTag.String() && !newRange.IsPortless() {
+		// Port-less ranges can be opened by several units, so only
+		// one of them is known here; closing one the unit hasn't
+		// opened is ignored by the controller.
 		relUnitTag, err := names.ParseUnitTag(relUnit.Unit)
 		if err != nil {
 			return errors.Annotatef(
@@ -56,7 +56,21 @@ func (s *PortsSuite) TestValidatePortRange(c *gc.C) {
 		about:     "invalid protocol - 1-65535/foo",
 		proto:     "foo",
 		ports:     []int{1, 65535},
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp", "icmp", "gre", "esp" or "ah"`,
+	}, {
+		about:     "invalid icmp range - 1-2/icmp",
+		proto:     "icmp",
+		ports:     []int{1, 2},
+		expectErr: `invalid port range 1-2/icmp, protocol "icmp" does not use ports`,
+	}, {
+		about: "valid icmp range",
+		proto: "ICMP",
+		ports: []int{-1, -1},
+		portRange: network.PortRange{
+			FromPort: -1,
+			ToPort:   -1,
+			Protocol: "icmp",
+		},
 	}, {
 		about: "valid range - 100-200/udp",
 		proto: "UDP",
@@ -164,7 +178,7 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 	}, {
 		about:     "invalid protocol - 10-20/foo",
 		proto:     "foo",
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp", "icmp", "gre", "esp" or "ah"`,
 	}, {
 		about:         "open a new range (no machine ports yet)",
 		expectPending: makePendingPorts("tcp", 10, 20, true),
@@ -188,6 +202,12 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
+	}, {
+		about:         "open a port-less range opened by another unit",
+		proto:         "icmp",
+		ports:         []int{-1, -1},
+		machinePorts:  makeMachinePorts("u/1", "icmp", -1, -1),
+		expectPending: makePendingPorts("icmp", -1, -1, true),
 	}, {
 		about:         "open a range conflicting with the same unit (ignored)",
 		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
@@ -226,7 +246,7 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 	}, {
 		about:     "invalid protocol - 10-20/foo",
 		proto:     "foo",
-		expectErr: `invalid protocol "foo", expected "tcp" or "udp"`,
+		expectErr: `invalid protocol "foo", expected "tcp", "udp", "icmp", "gre", "esp" or "ah"`,
 	}, {
 		about:         "close a new range (no machine ports yet; ignored)",
 		expectPending: map[context.PortRange]context.PortRangeInfo{},
@@ -250,6 +270,12 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:         "close a port-less range also opened by another unit",
+		proto:         "icmp",
+		ports:         []int{-1, -1},
+		machinePorts:  makeMachinePorts("u/1", "icmp", -1, -1),
+		expectPending: makePendingPorts("icmp", -1, -1, false),
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
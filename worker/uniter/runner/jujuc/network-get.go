@@ -16,8 +16,9 @@ type NetworkGetCommand struct {
 	cmd.CommandBase
 	ctx Context
 
-	bindingName    string
-	primaryAddress bool
+	bindingName      string
+	primaryAddress   bool
+	ingressAddresses bool
 
 	out cmd.Output
 }
@@ -29,11 +30,14 @@ func NewNetworkGetCommand(ctx Context) (cmd.Command, error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> --primary-address"
+	args := "<binding-name> (--primary-address | --ingress-addresses)"
 	doc := `
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. One of
+--primary-address or --ingress-addresses is required. --primary-address
+returns the IP address the local unit should advertise as its endpoint to
+its peers. --ingress-addresses returns all the addresses the local unit can
+be reached on for the binding, those of the model's preferred-ip-family
+first; in a dual-stack model this includes an address of each IP family.
 `
 	return &cmd.Info{
 		Name:    "network-get",
@@ -47,6 +51,7 @@ the IP address the local unit should advertise as its endpoint to its peers.
 func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.primaryAddress, "primary-address", false, "get the primary address for the binding")
+	f.BoolVar(&c.ingressAddresses, "ingress-addresses", false, "get the ingress addresses for the binding")
 }
 
 // Init is part of the cmd.Command interface.
@@ -60,8 +65,8 @@ func (c *NetworkGetCommand) Init(args []string) error {
 		return fmt.Errorf("no binding name specified")
 	}
 
-	if !c.primaryAddress {
-		return fmt.Errorf("--primary-address is currently required")
+	if c.primaryAddress == c.ingressAddresses {
+		return fmt.Errorf("exactly one of --primary-address or --ingress-addresses is required")
 	}
 
 	return cmd.CheckEmpty(args[1:])
@@ -80,5 +85,9 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 		return c.out.Write(ctx, netConfig[0].Address)
 	}
 
-	return nil // never reached as --primary-address is required.
+	addresses := make([]string, len(netConfig))
+	for i, config := range netConfig {
+		addresses[i] = config.Address
+	}
+	return c.out.Write(ctx, addresses)
 }
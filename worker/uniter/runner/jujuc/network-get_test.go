@@ -65,7 +65,12 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		summary: "binding name given, no --primary-address given",
 		code:    2,
 		args:    []string{"foo"},
-		out:     `--primary-address is currently required`,
+		out:     `exactly one of --primary-address or --ingress-addresses is required`,
+	}, {
+		summary: "both --primary-address and --ingress-addresses given",
+		code:    2,
+		args:    []string{"foo", "--primary-address", "--ingress-addresses"},
+		out:     `exactly one of --primary-address or --ingress-addresses is required`,
 	}, {
 		summary: "unknown binding given, with --primary-address",
 		args:    []string{"unknown", "--primary-address"},
@@ -88,6 +93,14 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 		summary: "implicitly bound binding name given with --primary-address",
 		args:    []string{"known-unbound", "--primary-address"},
 		out:     "10.33.1.8", // preferred private address used for unspecified bindings.
+	}, {
+		summary: "explicitly bound, extra-binding name given with --ingress-addresses",
+		args:    []string{"known-extra", "--ingress-addresses"},
+		out:     "10.20.1.42\nfc00::1/64",
+	}, {
+		summary: "explicitly bound, extra-binding name given with --ingress-addresses as json",
+		args:    []string{"known-extra", "--ingress-addresses", "--format", "json"},
+		out:     `["10.20.1.42","fc00::1/64"]`,
 	}} {
 		c.Logf("test %d: %s", i, t.summary)
 		com := s.createCommand(c)
@@ -112,7 +125,7 @@ func (s *NetworkGetSuite) TestNetworkGet(c *gc.C) {
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
 	var helpTemplate = `
-Usage: network-get [options] <binding-name> --primary-address
+Usage: network-get [options] <binding-name> (--primary-address | --ingress-addresses)
 
 Summary:
 get network config
@@ -120,15 +133,20 @@ get network config
 Options:
 --format  (= smart)
     Specify output format (json|smart|yaml)
+--ingress-addresses  (= false)
+    get the ingress addresses for the binding
 -o, --output (= "")
     Specify an output file
 --primary-address  (= false)
     get the primary address for the binding
 
 Details:
-network-get returns the network config for a given binding name. The only
-supported flag for now is --primary-address, which is required and returns
-the IP address the local unit should advertise as its endpoint to its peers.
+network-get returns the network config for a given binding name. One of
+--primary-address or --ingress-addresses is required. --primary-address
+returns the IP address the local unit should advertise as its endpoint to
+its peers. --ingress-addresses returns all the addresses the local unit can
+be reached on for the binding, those of the model's preferred-ip-family
+first; in a dual-stack model this includes an address of each IP family.
 `[1:]
 
 	com := s.createCommand(c)
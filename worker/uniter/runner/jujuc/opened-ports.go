@@ -20,8 +20,9 @@ func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
 }
 
 func (c *OpenedPortsCommand) Info() *cmd.Info {
-	doc := `Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").`
+	doc := `Each list entry has format <port>/<protocol> (e.g. "80/tcp"),
+<from>-<to>/<protocol> (e.g. "8080-8088/udp") or <protocol> for
+protocols that do not use ports (e.g. "icmp").`
 	return &cmd.Info{
 		Name:    "opened-ports",
 		Purpose: "lists all ports or ranges opened by the unit",
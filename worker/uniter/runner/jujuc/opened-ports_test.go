@@ -78,8 +78,9 @@ Summary:
 lists all ports or ranges opened by the unit
 
 Details:
-Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").
+Each list entry has format <port>/<protocol> (e.g. "80/tcp"),
+<from>-<to>/<protocol> (e.g. "8080-8088/udp") or <protocol> for
+protocols that do not use ports (e.g. "icmp").
 `[1:])
 }
 
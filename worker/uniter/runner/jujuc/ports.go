@@ -12,10 +12,12 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/network"
 )
 
 const (
-	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>]"
+	portFormat = "<port>[/<protocol>] or <from>-<to>[/<protocol>] or <protocol>"
 
 	portExp  = "(?:[0-9]+)"
 	protoExp = "(?:[a-z0-9]+)"
@@ -32,9 +34,18 @@ func (p port) validate() error {
 	if p.number < 1 || p.number > 65535 {
 		return errors.Errorf(`port must be in the range [1, 65535]; got "%v"`, p.number)
 	}
-	proto := strings.ToLower(p.protocol)
+	return validatePortProtocol(p.protocol)
+}
+
+// validatePortProtocol returns an error unless the given protocol's
+// traffic is addressed to ports.
+func validatePortProtocol(protocol string) error {
+	proto := strings.ToLower(protocol)
+	if network.IsPortlessProtocol(proto) {
+		return errors.Errorf(`protocol %q does not use ports; expected %q on its own`, proto, proto)
+	}
 	if proto != "tcp" && proto != "udp" {
-		return errors.Errorf(`protocol must be "tcp" or "udp"; got %q`, p.protocol)
+		return errors.Errorf(`protocol must be "tcp", "udp", "icmp", "gre", "esp" or "ah"; got %q`, protocol)
 	}
 	return nil
 }
@@ -60,15 +71,14 @@ func (pr portRange) validate() error {
 	if pr.toPort < 1 || pr.toPort > 65535 {
 		return errors.Errorf(`toPort must be in the range [1, 65535]; got "%v"`, pr.toPort)
 	}
-	proto := strings.ToLower(pr.protocol)
-	if proto != "tcp" && proto != "udp" {
-		return errors.Errorf(`protocol must be "tcp" or "udp"; got %q`, pr.protocol)
-	}
-	return nil
+	return validatePortProtocol(pr.protocol)
 }
 
 func parseArguments(args []string) (portRange, error) {
 	arg := strings.ToLower(args[0])
+	if network.IsPortlessProtocol(arg) {
+		return portRange{network.NoPort, network.NoPort, arg}, nil
+	}
 	if !validPortOrRange.MatchString(arg) {
 		return portRange{}, errors.Errorf("expected %s; got %q", portFormat, args[0])
 	}
@@ -148,7 +158,10 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the service is exposed.",
+	Doc: `
+The port range will only be open while the service is exposed.
+The traffic of a protocol that does not use ports, such as icmp,
+gre, esp or ah, is opened by giving the protocol on its own.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
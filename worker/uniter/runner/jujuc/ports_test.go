@@ -37,12 +37,17 @@ var portsTests = []struct {
 	{[]string{"close-port", "443/udp"}, makeRanges("99/tcp")},
 	{[]string{"open-port", "123/udp"}, makeRanges("99/tcp", "123/udp")},
 	{[]string{"close-port", "9999/UDP"}, makeRanges("99/tcp", "123/udp")},
+	{[]string{"open-port", "icmp"}, makeRanges("99/tcp", "123/udp", "icmp")},
+	{[]string{"open-port", "GRE"}, makeRanges("99/tcp", "123/udp", "icmp", "gre")},
+	{[]string{"close-port", "icmp"}, makeRanges("99/tcp", "123/udp", "gre")},
 }
 
 func makeRanges(stringRanges ...string) []network.PortRange {
 	var results []network.PortRange
 	for _, s := range stringRanges {
-		if strings.Contains(s, "-") {
+		if network.IsPortlessProtocol(s) {
+			results = append(results, network.NewPortlessRange(s))
+		} else if strings.Contains(s, "-") {
 			parts := strings.Split(s, "-")
 			fromPort, _ := strconv.Atoi(parts[0])
 			parts = strings.Split(parts[1], "/")
@@ -89,15 +94,18 @@ var badPortsTests = []struct {
 	{nil, "no port or range specified"},
 	{[]string{"0"}, `port must be in the range \[1, 65535\]; got "0"`},
 	{[]string{"65536"}, `port must be in the range \[1, 65535\]; got "65536"`},
-	{[]string{"two"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\]; got "two"`},
-	{[]string{"80/http"}, `protocol must be "tcp" or "udp"; got "http"`},
-	{[]string{"blah/blah/blah"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\]; got "blah/blah/blah"`},
+	{[]string{"two"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\] or <protocol>; got "two"`},
+	{[]string{"80/http"}, `protocol must be "tcp", "udp", "icmp", "gre", "esp" or "ah"; got "http"`},
+	{[]string{"blah/blah/blah"}, `expected <port>\[/<protocol>\] or <from>-<to>\[/<protocol>\] or <protocol>; got "blah/blah/blah"`},
 	{[]string{"123", "haha"}, `unrecognized args: \["haha"\]`},
 	{[]string{"1-0"}, `invalid port range 1-0/tcp; expected fromPort <= toPort`},
 	{[]string{"-42"}, `flag provided but not defined: -4`},
 	{[]string{"99999/UDP"}, `port must be in the range \[1, 65535\]; got "99999"`},
-	{[]string{"9999/foo"}, `protocol must be "tcp" or "udp"; got "foo"`},
-	{[]string{"80-90/http"}, `protocol must be "tcp" or "udp"; got "http"`},
+	{[]string{"9999/foo"}, `protocol must be "tcp", "udp", "icmp", "gre", "esp" or "ah"; got "foo"`},
+	{[]string{"80-90/http"}, `protocol must be "tcp", "udp", "icmp", "gre", "esp" or "ah"; got "http"`},
+	{[]string{"80/icmp"}, `protocol "icmp" does not use ports; expected "icmp" on its own`},
+	{[]string{"80-90/esp"}, `protocol "esp" does not use ports; expected "esp" on its own`},
+	{[]string{"icmp", "80"}, `unrecognized args: \["80"\]`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
 }
 
@@ -119,19 +127,21 @@ func (s *PortsSuite) TestHelp(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	flags := testing.NewFlagSet()
 	c.Assert(string(open.Info().Help(flags)), gc.Equals, `
-Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
+Usage: open-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or <protocol>
 
 Summary:
 register a port or range to open
 
 Details:
 The port range will only be open while the service is exposed.
+The traffic of a protocol that does not use ports, such as icmp,
+gre, esp or ah, is opened by giving the protocol on its own.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(string(close.Info().Help(flags)), gc.Equals, `
-Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>]
+Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or <protocol>
 
 Summary:
 ensure a port or range is always closed
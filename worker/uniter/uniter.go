@@ -203,6 +203,12 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		watcherMu sync.Mutex
 	)
 
+	// The health checker is started once the charm has been
+	// started, and reports failed checks to the remote state
+	// watcher so that the health-check-failed hook is run.
+	healthCheckFailed := make(chan string)
+	healthCheckerStarted := false
+
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
 	retryHookChan := make(chan struct{}, 1)
 	// TODO(katco): 2016-08-09: This type is deprecated: lp:1611427
@@ -245,6 +251,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+
+				HealthCheckFailedChannel: healthCheckFailed,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -264,6 +272,12 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
+		if opState.Started && !healthCheckerStarted {
+			if err := u.startHealthChecker(healthCheckFailed); err != nil {
+				return errors.Annotate(err, "starting health checker")
+			}
+			healthCheckerStarted = true
+		}
 		return setAgentStatus(u, status.Idle, "", nil)
 	}
 